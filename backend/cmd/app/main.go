@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -50,7 +52,8 @@ func main() {
 	// Set the combined logger (tint + New Relic) as default
 	slog.SetDefault(tintLogger)
 
-	ctx := context.Background()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	// Set up database connection pool
 	poolConfig, err := pgxpool.ParseConfig(config.DbConnectionString)
@@ -73,20 +76,47 @@ func main() {
 
 	queries := db.New(pool)
 
-	// Initialize repositories and services
-	apiServer := httpserver.NewAPIServer(
+	activityService, err := service.NewActivityServiceFromConfig(ctx, config, pool, queries)
+	if err != nil {
+		slog.Error("Failed to create the activity service", "error", err)
+		os.Exit(1)
+	}
+
+	apiServer, err := httpserver.NewAPIServer(
 		httpserver.WithConfig(config),
+		httpserver.WithDbPool(pool),
 		httpserver.WithDbQueries(queries),
+		httpserver.WithActivityService(activityService),
 	)
+	if err != nil {
+		slog.Error("Failed to create the HTTP server", "error", err)
+		os.Exit(1)
+	}
 
 	go apiServer.Run()
 
-	activityRepo := repositories.NewActivityRepository(queries)
-	recordRepo := repositories.NewRecordRepository(queries)
-	activityService := service.NewActivityService(activityRepo, recordRepo)
+	ingestionWorkers, err := service.ParseIngestionWorkers(config.IngestionWorkers)
+	if err != nil {
+		slog.Error("Invalid ingestion worker count", "error", err)
+		os.Exit(1)
+	}
+
+	ingestionService := service.NewIngestionService(
+		repositories.NewIngestionJobRepository(queries),
+		activityService,
+		service.WithIngestionWorkers(ingestionWorkers),
+	)
+
+	// Uploads are queued and ingested in the background by the worker pool.
+	// On shutdown the jobs in progress are handed back to the queue.
+	ingestionDone := make(chan struct{})
+	go func() {
+		defer close(ingestionDone)
+		ingestionService.Run(ctx)
+	}()
 
 	// Initialize RPC server
-	server := rpcserver.NewServer(config, activityService, newRelicApp)
+	server := rpcserver.NewServer(config, activityService, ingestionService, newRelicApp)
 
 	// Start the server
 	slog.Info("Starting RPC server...")
@@ -94,4 +124,7 @@ func main() {
 		slog.Error("RPC server exited with error", "error", err)
 		os.Exit(1)
 	}
+
+	<-ingestionDone
+	slog.Info("Shut down")
 }
@@ -0,0 +1,76 @@
+// Command importer watches folders for activity files, e.g. a NAS share synced
+// from a head unit, and imports them for the user each folder belongs to.
+// Imported files are moved into the folder's done subfolder, rejected ones into
+// failed, and every outcome is appended to the folder's import.log.
+//
+//	IMPORT_FOLDERS=/nas/alice=04961e85-8280-4fb3-80d4-a5072bcec9b1 go run ./cmd/importer
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lmittmann/tint"
+
+	"github.com/notaduck/backend/internal/config"
+	"github.com/notaduck/backend/internal/db"
+	service "github.com/notaduck/backend/internal/services"
+)
+
+func main() {
+	debounce := flag.Duration("debounce", service.DefaultImportDebounce, "how long a file must stay unchanged before it is imported")
+	flag.Parse()
+
+	slog.SetDefault(slog.New(tint.NewHandler(os.Stderr, &tint.Options{
+		Level:      slog.LevelInfo,
+		TimeFormat: time.Kitchen,
+	})))
+
+	config := config.NewConfig()
+
+	folders, err := service.ParseImportFolders(config.ImportFolders)
+	if err != nil {
+		slog.Error("Invalid import folders", "error", err)
+		os.Exit(1)
+	}
+
+	// Under docker or systemd the importer is stopped with SIGTERM; a file
+	// being imported then stays in place for the next run.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	poolConfig, err := pgxpool.ParseConfig(config.DbConnectionString)
+	if err != nil {
+		slog.Error("Failed to parse database configuration", "error", err)
+		os.Exit(1)
+	}
+	poolConfig.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeSimpleProtocol
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		slog.Error("Failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+	defer pool.Close()
+
+	queries := db.New(pool)
+
+	activityService, err := service.NewActivityServiceFromConfig(ctx, config, pool, queries)
+	if err != nil {
+		slog.Error("Failed to create the activity service", "error", err)
+		os.Exit(1)
+	}
+
+	importer := service.NewFolderImporter(activityService, folders, service.WithImportDebounce(*debounce))
+	if err := importer.Run(ctx); err != nil {
+		slog.Error("Folder import failed", "error", err)
+		os.Exit(1)
+	}
+}
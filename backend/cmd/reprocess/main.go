@@ -0,0 +1,97 @@
+// Command reprocess derives the data of existing activities again from their
+// stored original files, e.g. after a metric calculation was fixed. Fields
+// edited by the user, such as the activity name and ride type, are kept.
+//
+//	go run ./cmd/reprocess -activity 42
+//	go run ./cmd/reprocess -user 04961e85-8280-4fb3-80d4-a5072bcec9b1
+//	go run ./cmd/reprocess -all
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lmittmann/tint"
+
+	"github.com/notaduck/backend/internal/config"
+	"github.com/notaduck/backend/internal/db"
+	service "github.com/notaduck/backend/internal/services"
+)
+
+func main() {
+	activityID := flag.Int("activity", 0, "reprocess the activity with this id")
+	userID := flag.String("user", "", "reprocess every activity of the user with this id")
+	all := flag.Bool("all", false, "reprocess every activity of every user")
+	flag.Parse()
+
+	scopes := 0
+	for _, set := range []bool{*activityID != 0, *userID != "", *all} {
+		if set {
+			scopes++
+		}
+	}
+	if scopes != 1 {
+		fmt.Fprintln(os.Stderr, "exactly one of -activity, -user or -all is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	slog.SetDefault(slog.New(tint.NewHandler(os.Stderr, &tint.Options{
+		Level:      slog.LevelInfo,
+		TimeFormat: time.Kitchen,
+	})))
+
+	config := config.NewConfig()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	poolConfig, err := pgxpool.ParseConfig(config.DbConnectionString)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to parse database configuration: %v\n", err)
+		os.Exit(1)
+	}
+	poolConfig.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeSimpleProtocol
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		slog.Error("Failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+	defer pool.Close()
+
+	queries := db.New(pool)
+
+	activityService, err := service.NewActivityServiceFromConfig(ctx, config, pool, queries)
+	if err != nil {
+		slog.Error("Failed to create the activity service", "error", err)
+		os.Exit(1)
+	}
+
+	filter := service.ReprocessFilter{ActivityID: int32(*activityID), UserID: *userID}
+
+	result, err := activityService.ReprocessActivities(ctx, filter, func(progress service.ReprocessProgress) error {
+		status := "ok"
+		if progress.Result.Error != "" {
+			status = "failed: " + progress.Result.Error
+		}
+		fmt.Printf("[%d/%d] activity %d (%s): %s\n", progress.Processed, progress.Total, progress.Result.ActivityID, progress.Result.Filename, status)
+		return nil
+	})
+	if err != nil {
+		slog.Error("Reprocessing failed", "error", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("reprocessed %d activities, %d failed\n", len(result.Files)-result.Failed(), result.Failed())
+	if result.Failed() > 0 {
+		os.Exit(1)
+	}
+}
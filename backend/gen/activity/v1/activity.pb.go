@@ -23,6 +23,123 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// UploadFailureReason classifies why a file could not be ingested.
+type UploadFailureReason int32
+
+const (
+	UploadFailureReason_UPLOAD_FAILURE_REASON_UNSPECIFIED        UploadFailureReason = 0
+	UploadFailureReason_UPLOAD_FAILURE_REASON_UNSUPPORTED_FORMAT UploadFailureReason = 1
+	UploadFailureReason_UPLOAD_FAILURE_REASON_CORRUPT_FILE       UploadFailureReason = 2
+	UploadFailureReason_UPLOAD_FAILURE_REASON_NO_RECORDS         UploadFailureReason = 3 // The file holds no data records
+	UploadFailureReason_UPLOAD_FAILURE_REASON_DUPLICATE          UploadFailureReason = 4
+	UploadFailureReason_UPLOAD_FAILURE_REASON_EMPTY_FILE         UploadFailureReason = 5
+	UploadFailureReason_UPLOAD_FAILURE_REASON_INTERNAL           UploadFailureReason = 6
+)
+
+// Enum value maps for UploadFailureReason.
+var (
+	UploadFailureReason_name = map[int32]string{
+		0: "UPLOAD_FAILURE_REASON_UNSPECIFIED",
+		1: "UPLOAD_FAILURE_REASON_UNSUPPORTED_FORMAT",
+		2: "UPLOAD_FAILURE_REASON_CORRUPT_FILE",
+		3: "UPLOAD_FAILURE_REASON_NO_RECORDS",
+		4: "UPLOAD_FAILURE_REASON_DUPLICATE",
+		5: "UPLOAD_FAILURE_REASON_EMPTY_FILE",
+		6: "UPLOAD_FAILURE_REASON_INTERNAL",
+	}
+	UploadFailureReason_value = map[string]int32{
+		"UPLOAD_FAILURE_REASON_UNSPECIFIED":        0,
+		"UPLOAD_FAILURE_REASON_UNSUPPORTED_FORMAT": 1,
+		"UPLOAD_FAILURE_REASON_CORRUPT_FILE":       2,
+		"UPLOAD_FAILURE_REASON_NO_RECORDS":         3,
+		"UPLOAD_FAILURE_REASON_DUPLICATE":          4,
+		"UPLOAD_FAILURE_REASON_EMPTY_FILE":         5,
+		"UPLOAD_FAILURE_REASON_INTERNAL":           6,
+	}
+)
+
+func (x UploadFailureReason) Enum() *UploadFailureReason {
+	p := new(UploadFailureReason)
+	*p = x
+	return p
+}
+
+func (x UploadFailureReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UploadFailureReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_activity_v1_activity_proto_enumTypes[0].Descriptor()
+}
+
+func (UploadFailureReason) Type() protoreflect.EnumType {
+	return &file_activity_v1_activity_proto_enumTypes[0]
+}
+
+func (x UploadFailureReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UploadFailureReason.Descriptor instead.
+func (UploadFailureReason) EnumDescriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{0}
+}
+
+type IngestionJobStatus int32
+
+const (
+	IngestionJobStatus_INGESTION_JOB_STATUS_UNSPECIFIED IngestionJobStatus = 0
+	IngestionJobStatus_INGESTION_JOB_STATUS_QUEUED      IngestionJobStatus = 1
+	IngestionJobStatus_INGESTION_JOB_STATUS_RUNNING     IngestionJobStatus = 2
+	IngestionJobStatus_INGESTION_JOB_STATUS_DONE        IngestionJobStatus = 3
+	IngestionJobStatus_INGESTION_JOB_STATUS_FAILED      IngestionJobStatus = 4
+)
+
+// Enum value maps for IngestionJobStatus.
+var (
+	IngestionJobStatus_name = map[int32]string{
+		0: "INGESTION_JOB_STATUS_UNSPECIFIED",
+		1: "INGESTION_JOB_STATUS_QUEUED",
+		2: "INGESTION_JOB_STATUS_RUNNING",
+		3: "INGESTION_JOB_STATUS_DONE",
+		4: "INGESTION_JOB_STATUS_FAILED",
+	}
+	IngestionJobStatus_value = map[string]int32{
+		"INGESTION_JOB_STATUS_UNSPECIFIED": 0,
+		"INGESTION_JOB_STATUS_QUEUED":      1,
+		"INGESTION_JOB_STATUS_RUNNING":     2,
+		"INGESTION_JOB_STATUS_DONE":        3,
+		"INGESTION_JOB_STATUS_FAILED":      4,
+	}
+)
+
+func (x IngestionJobStatus) Enum() *IngestionJobStatus {
+	p := new(IngestionJobStatus)
+	*p = x
+	return p
+}
+
+func (x IngestionJobStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IngestionJobStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_activity_v1_activity_proto_enumTypes[1].Descriptor()
+}
+
+func (IngestionJobStatus) Type() protoreflect.EnumType {
+	return &file_activity_v1_activity_proto_enumTypes[1]
+}
+
+func (x IngestionJobStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IngestionJobStatus.Descriptor instead.
+func (IngestionJobStatus) EnumDescriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{1}
+}
+
 // Point represents a coordinate point.
 type Point struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -80,12 +197,13 @@ func (x *Point) GetY() float64 {
 type Record struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Coordinates   *Point                 `protobuf:"bytes,2,opt,name=coordinates,proto3" json:"coordinates,omitempty"`
+	Coordinates   *Point                 `protobuf:"bytes,2,opt,name=coordinates,proto3" json:"coordinates,omitempty"` // Unset for records without a GPS position
 	Speed         float64                `protobuf:"fixed64,3,opt,name=speed,proto3" json:"speed,omitempty"`
 	TimeStamp     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time_stamp,json=timeStamp,proto3" json:"time_stamp,omitempty"`
 	Distance      int32                  `protobuf:"varint,5,opt,name=distance,proto3" json:"distance,omitempty"`
 	HeartRate     int32                  `protobuf:"varint,6,opt,name=heart_rate,json=heartRate,proto3" json:"heart_rate,omitempty"`
 	Cadence       int32                  `protobuf:"varint,7,opt,name=cadence,proto3" json:"cadence,omitempty"`
+	Power         int32                  `protobuf:"varint,8,opt,name=power,proto3" json:"power,omitempty"` // Watts, 0 when not recorded
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -169,23 +287,69 @@ func (x *Record) GetCadence() int32 {
 	return 0
 }
 
+func (x *Record) GetPower() int32 {
+	if x != nil {
+		return x.Power
+	}
+	return 0
+}
+
 // Activity represents the detailed information of a single activity.
 type GetActivityResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	Distance      float64                `protobuf:"fixed64,3,opt,name=distance,proto3" json:"distance,omitempty"`
-	ActivityName  string                 `protobuf:"bytes,4,opt,name=activity_name,json=activityName,proto3" json:"activity_name,omitempty"`
-	AvgSpeed      float64                `protobuf:"fixed64,5,opt,name=avg_speed,json=avgSpeed,proto3" json:"avg_speed,omitempty"`
-	MaxSpeed      float64                `protobuf:"fixed64,6,opt,name=max_speed,json=maxSpeed,proto3" json:"max_speed,omitempty"`
-	ElapsedTime   string                 `protobuf:"bytes,7,opt,name=elapsed_time,json=elapsedTime,proto3" json:"elapsed_time,omitempty"`
-	TotalTime     string                 `protobuf:"bytes,8,opt,name=total_time,json=totalTime,proto3" json:"total_time,omitempty"`
-	Records       []*Record              `protobuf:"bytes,9,rep,name=records,proto3" json:"records,omitempty"`
-	AvgHeartRate  float64                `protobuf:"fixed64,10,opt,name=avg_heart_rate,json=avgHeartRate,proto3" json:"avg_heart_rate,omitempty"`
-	MaxHeartRate  float64                `protobuf:"fixed64,11,opt,name=max_heart_rate,json=maxHeartRate,proto3" json:"max_heart_rate,omitempty"`
-	AvgCadence    float64                `protobuf:"fixed64,12,opt,name=avg_cadence,json=avgCadence,proto3" json:"avg_cadence,omitempty"`
-	MaxCadence    float64                `protobuf:"fixed64,13,opt,name=max_cadence,json=maxCadence,proto3" json:"max_cadence,omitempty"`
-	RideType      string                 `protobuf:"bytes,14,opt,name=ride_type,json=rideType,proto3" json:"ride_type,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt    string                 `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Distance     float64                `protobuf:"fixed64,3,opt,name=distance,proto3" json:"distance,omitempty"`
+	ActivityName string                 `protobuf:"bytes,4,opt,name=activity_name,json=activityName,proto3" json:"activity_name,omitempty"`
+	AvgSpeed     float64                `protobuf:"fixed64,5,opt,name=avg_speed,json=avgSpeed,proto3" json:"avg_speed,omitempty"`
+	MaxSpeed     float64                `protobuf:"fixed64,6,opt,name=max_speed,json=maxSpeed,proto3" json:"max_speed,omitempty"`
+	ElapsedTime  string                 `protobuf:"bytes,7,opt,name=elapsed_time,json=elapsedTime,proto3" json:"elapsed_time,omitempty"`
+	TotalTime    string                 `protobuf:"bytes,8,opt,name=total_time,json=totalTime,proto3" json:"total_time,omitempty"`
+	Records      []*Record              `protobuf:"bytes,9,rep,name=records,proto3" json:"records,omitempty"`
+	AvgHeartRate float64                `protobuf:"fixed64,10,opt,name=avg_heart_rate,json=avgHeartRate,proto3" json:"avg_heart_rate,omitempty"`
+	MaxHeartRate float64                `protobuf:"fixed64,11,opt,name=max_heart_rate,json=maxHeartRate,proto3" json:"max_heart_rate,omitempty"`
+	AvgCadence   float64                `protobuf:"fixed64,12,opt,name=avg_cadence,json=avgCadence,proto3" json:"avg_cadence,omitempty"`
+	MaxCadence   float64                `protobuf:"fixed64,13,opt,name=max_cadence,json=maxCadence,proto3" json:"max_cadence,omitempty"`
+	RideType     string                 `protobuf:"bytes,14,opt,name=ride_type,json=rideType,proto3" json:"ride_type,omitempty"`
+	DuplicateOf  int32                  `protobuf:"varint,15,opt,name=duplicate_of,json=duplicateOf,proto3" json:"duplicate_of,omitempty"` // Set when imported as a likely duplicate of that activity
+	Description  string                 `protobuf:"bytes,16,opt,name=description,proto3" json:"description,omitempty"`
+	Sessions     []*ActivitySession     `protobuf:"bytes,17,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	Laps         []*Lap                 `protobuf:"bytes,18,rep,name=laps,proto3" json:"laps,omitempty"`
+	// Power metrics in watts, unset for rides without power data. Intensity
+	// factor and TSS are rated against the FTP set when the ride was ingested.
+	AvgPower            *wrapperspb.Int32Value  `protobuf:"bytes,19,opt,name=avg_power,json=avgPower,proto3" json:"avg_power,omitempty"`
+	MaxPower            *wrapperspb.Int32Value  `protobuf:"bytes,20,opt,name=max_power,json=maxPower,proto3" json:"max_power,omitempty"`
+	NormalizedPower     *wrapperspb.Int32Value  `protobuf:"bytes,21,opt,name=normalized_power,json=normalizedPower,proto3" json:"normalized_power,omitempty"`
+	VariabilityIndex    *wrapperspb.DoubleValue `protobuf:"bytes,22,opt,name=variability_index,json=variabilityIndex,proto3" json:"variability_index,omitempty"`
+	IntensityFactor     *wrapperspb.DoubleValue `protobuf:"bytes,23,opt,name=intensity_factor,json=intensityFactor,proto3" json:"intensity_factor,omitempty"`
+	TrainingStressScore *wrapperspb.DoubleValue `protobuf:"bytes,24,opt,name=training_stress_score,json=trainingStressScore,proto3" json:"training_stress_score,omitempty"`
+	Ftp                 *wrapperspb.Int32Value  `protobuf:"bytes,25,opt,name=ftp,proto3" json:"ftp,omitempty"`
+	Indoor              bool                    `protobuf:"varint,26,opt,name=indoor,proto3" json:"indoor,omitempty"` // Recorded without GPS, e.g. on a trainer
+	Devices             []*ActivityDevice       `protobuf:"bytes,27,rep,name=devices,proto3" json:"devices,omitempty"`
+	MovingTime          string                  `protobuf:"bytes,28,opt,name=moving_time,json=movingTime,proto3" json:"moving_time,omitempty"` // Excludes the pauses below
+	Pauses              []*ActivityPause        `protobuf:"bytes,29,rep,name=pauses,proto3" json:"pauses,omitempty"`
+	RideTypeDetected    bool                    `protobuf:"varint,30,opt,name=ride_type_detected,json=rideTypeDetected,proto3" json:"ride_type_detected,omitempty"` // False once the user has chosen the ride type
+	// Elevation in metres; unset for rides without altitude data.
+	ElevationGain *wrapperspb.DoubleValue `protobuf:"bytes,31,opt,name=elevation_gain,json=elevationGain,proto3" json:"elevation_gain,omitempty"`
+	ElevationLoss *wrapperspb.DoubleValue `protobuf:"bytes,32,opt,name=elevation_loss,json=elevationLoss,proto3" json:"elevation_loss,omitempty"`
+	MinAltitude   *wrapperspb.DoubleValue `protobuf:"bytes,33,opt,name=min_altitude,json=minAltitude,proto3" json:"min_altitude,omitempty"`
+	MaxAltitude   *wrapperspb.DoubleValue `protobuf:"bytes,34,opt,name=max_altitude,json=maxAltitude,proto3" json:"max_altitude,omitempty"`
+	// Unset for rides without heart rate data or ingested before the user
+	// configured heart rate zones.
+	HeartRateZones *HeartRateZones `protobuf:"bytes,35,opt,name=heart_rate_zones,json=heartRateZones,proto3" json:"heart_rate_zones,omitempty"`
+	// Mean-maximal power by duration, empty for rides without power data.
+	PowerCurve []*PowerCurvePoint `protobuf:"bytes,36,rep,name=power_curve,json=powerCurve,proto3" json:"power_curve,omitempty"`
+	// The fastest effort over each distance the ride covers, flagged when it
+	// was a personal record.
+	BestEfforts []*BestEffort `protobuf:"bytes,37,rep,name=best_efforts,json=bestEfforts,proto3" json:"best_efforts,omitempty"`
+	// GPS spikes moved or dropped by the track cleaning at ingestion.
+	CorrectedPoints int32 `protobuf:"varint,38,opt,name=corrected_points,json=correctedPoints,proto3" json:"corrected_points,omitempty"`
+	// The route simplified to the requested tolerance, and the same in the
+	// encoded polyline format with five decimals.
+	Route        []*Point `protobuf:"bytes,39,rep,name=route,proto3" json:"route,omitempty"`
+	EncodedRoute string   `protobuf:"bytes,40,opt,name=encoded_route,json=encodedRoute,proto3" json:"encoded_route,omitempty"`
+	// Missing GPS fixes interpolated by the track cleaning at ingestion.
+	FilledPoints  int32 `protobuf:"varint,41,opt,name=filled_points,json=filledPoints,proto3" json:"filled_points,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -318,213 +482,222 @@ func (x *GetActivityResponse) GetRideType() string {
 	return ""
 }
 
-// ActivitySummary provides a summarized view of an activity.
-type ActivitySummary struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	Distance      float64                `protobuf:"fixed64,3,opt,name=distance,proto3" json:"distance,omitempty"`
-	ActivityName  string                 `protobuf:"bytes,4,opt,name=activity_name,json=activityName,proto3" json:"activity_name,omitempty"`
-	AvgSpeed      float64                `protobuf:"fixed64,5,opt,name=avg_speed,json=avgSpeed,proto3" json:"avg_speed,omitempty"`
-	MaxSpeed      float64                `protobuf:"fixed64,6,opt,name=max_speed,json=maxSpeed,proto3" json:"max_speed,omitempty"`
-	ElapsedTime   string                 `protobuf:"bytes,7,opt,name=elapsed_time,json=elapsedTime,proto3" json:"elapsed_time,omitempty"`
-	TotalTime     string                 `protobuf:"bytes,8,opt,name=total_time,json=totalTime,proto3" json:"total_time,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *GetActivityResponse) GetDuplicateOf() int32 {
+	if x != nil {
+		return x.DuplicateOf
+	}
+	return 0
 }
 
-func (x *ActivitySummary) Reset() {
-	*x = ActivitySummary{}
-	mi := &file_activity_v1_activity_proto_msgTypes[3]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *GetActivityResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
 }
 
-func (x *ActivitySummary) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *GetActivityResponse) GetSessions() []*ActivitySession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
 }
 
-func (*ActivitySummary) ProtoMessage() {}
+func (x *GetActivityResponse) GetLaps() []*Lap {
+	if x != nil {
+		return x.Laps
+	}
+	return nil
+}
 
-func (x *ActivitySummary) ProtoReflect() protoreflect.Message {
-	mi := &file_activity_v1_activity_proto_msgTypes[3]
+func (x *GetActivityResponse) GetAvgPower() *wrapperspb.Int32Value {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.AvgPower
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use ActivitySummary.ProtoReflect.Descriptor instead.
-func (*ActivitySummary) Descriptor() ([]byte, []int) {
-	return file_activity_v1_activity_proto_rawDescGZIP(), []int{3}
+func (x *GetActivityResponse) GetMaxPower() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.MaxPower
+	}
+	return nil
 }
 
-func (x *ActivitySummary) GetId() int32 {
+func (x *GetActivityResponse) GetNormalizedPower() *wrapperspb.Int32Value {
 	if x != nil {
-		return x.Id
+		return x.NormalizedPower
 	}
-	return 0
+	return nil
 }
 
-func (x *ActivitySummary) GetCreatedAt() *timestamppb.Timestamp {
+func (x *GetActivityResponse) GetVariabilityIndex() *wrapperspb.DoubleValue {
 	if x != nil {
-		return x.CreatedAt
+		return x.VariabilityIndex
 	}
 	return nil
 }
 
-func (x *ActivitySummary) GetDistance() float64 {
+func (x *GetActivityResponse) GetIntensityFactor() *wrapperspb.DoubleValue {
 	if x != nil {
-		return x.Distance
+		return x.IntensityFactor
 	}
-	return 0
+	return nil
 }
 
-func (x *ActivitySummary) GetActivityName() string {
+func (x *GetActivityResponse) GetTrainingStressScore() *wrapperspb.DoubleValue {
 	if x != nil {
-		return x.ActivityName
+		return x.TrainingStressScore
 	}
-	return ""
+	return nil
 }
 
-func (x *ActivitySummary) GetAvgSpeed() float64 {
+func (x *GetActivityResponse) GetFtp() *wrapperspb.Int32Value {
 	if x != nil {
-		return x.AvgSpeed
+		return x.Ftp
 	}
-	return 0
+	return nil
 }
 
-func (x *ActivitySummary) GetMaxSpeed() float64 {
+func (x *GetActivityResponse) GetIndoor() bool {
 	if x != nil {
-		return x.MaxSpeed
+		return x.Indoor
 	}
-	return 0
+	return false
 }
 
-func (x *ActivitySummary) GetElapsedTime() string {
+func (x *GetActivityResponse) GetDevices() []*ActivityDevice {
 	if x != nil {
-		return x.ElapsedTime
+		return x.Devices
 	}
-	return ""
+	return nil
 }
 
-func (x *ActivitySummary) GetTotalTime() string {
+func (x *GetActivityResponse) GetMovingTime() string {
 	if x != nil {
-		return x.TotalTime
+		return x.MovingTime
 	}
 	return ""
 }
 
-// Request message for streaming uploads
-type UploadActivitiesRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Payload:
-	//
-	//	*UploadActivitiesRequest_FileChunk
-	//	*UploadActivitiesRequest_Metadata
-	Payload       isUploadActivitiesRequest_Payload `protobuf_oneof:"payload"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *GetActivityResponse) GetPauses() []*ActivityPause {
+	if x != nil {
+		return x.Pauses
+	}
+	return nil
 }
 
-func (x *UploadActivitiesRequest) Reset() {
-	*x = UploadActivitiesRequest{}
-	mi := &file_activity_v1_activity_proto_msgTypes[4]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *GetActivityResponse) GetRideTypeDetected() bool {
+	if x != nil {
+		return x.RideTypeDetected
+	}
+	return false
 }
 
-func (x *UploadActivitiesRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *GetActivityResponse) GetElevationGain() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.ElevationGain
+	}
+	return nil
 }
 
-func (*UploadActivitiesRequest) ProtoMessage() {}
-
-func (x *UploadActivitiesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_activity_v1_activity_proto_msgTypes[4]
+func (x *GetActivityResponse) GetElevationLoss() *wrapperspb.DoubleValue {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.ElevationLoss
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use UploadActivitiesRequest.ProtoReflect.Descriptor instead.
-func (*UploadActivitiesRequest) Descriptor() ([]byte, []int) {
-	return file_activity_v1_activity_proto_rawDescGZIP(), []int{4}
+func (x *GetActivityResponse) GetMinAltitude() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.MinAltitude
+	}
+	return nil
 }
 
-func (x *UploadActivitiesRequest) GetPayload() isUploadActivitiesRequest_Payload {
+func (x *GetActivityResponse) GetMaxAltitude() *wrapperspb.DoubleValue {
 	if x != nil {
-		return x.Payload
+		return x.MaxAltitude
 	}
 	return nil
 }
 
-func (x *UploadActivitiesRequest) GetFileChunk() []byte {
+func (x *GetActivityResponse) GetHeartRateZones() *HeartRateZones {
 	if x != nil {
-		if x, ok := x.Payload.(*UploadActivitiesRequest_FileChunk); ok {
-			return x.FileChunk
-		}
+		return x.HeartRateZones
 	}
 	return nil
 }
 
-func (x *UploadActivitiesRequest) GetMetadata() string {
+func (x *GetActivityResponse) GetPowerCurve() []*PowerCurvePoint {
 	if x != nil {
-		if x, ok := x.Payload.(*UploadActivitiesRequest_Metadata); ok {
-			return x.Metadata
-		}
+		return x.PowerCurve
 	}
-	return ""
+	return nil
 }
 
-type isUploadActivitiesRequest_Payload interface {
-	isUploadActivitiesRequest_Payload()
+func (x *GetActivityResponse) GetBestEfforts() []*BestEffort {
+	if x != nil {
+		return x.BestEfforts
+	}
+	return nil
 }
 
-type UploadActivitiesRequest_FileChunk struct {
-	FileChunk []byte `protobuf:"bytes,1,opt,name=file_chunk,json=fileChunk,proto3,oneof"` // Chunk of file data
+func (x *GetActivityResponse) GetCorrectedPoints() int32 {
+	if x != nil {
+		return x.CorrectedPoints
+	}
+	return 0
 }
 
-type UploadActivitiesRequest_Metadata struct {
-	Metadata string `protobuf:"bytes,2,opt,name=metadata,proto3,oneof"` // Optional metadata (e.g., user ID, filename)
+func (x *GetActivityResponse) GetRoute() []*Point {
+	if x != nil {
+		return x.Route
+	}
+	return nil
 }
 
-func (*UploadActivitiesRequest_FileChunk) isUploadActivitiesRequest_Payload() {}
+func (x *GetActivityResponse) GetEncodedRoute() string {
+	if x != nil {
+		return x.EncodedRoute
+	}
+	return ""
+}
 
-func (*UploadActivitiesRequest_Metadata) isUploadActivitiesRequest_Payload() {}
+func (x *GetActivityResponse) GetFilledPoints() int32 {
+	if x != nil {
+		return x.FilledPoints
+	}
+	return 0
+}
 
-// Response message after upload
-type UploadActivitiesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"` // Status of the upload (e.g., "success" or error message)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// BestEffort is the fastest stretch of a ride over a distance, timed in
+// elapsed seconds.
+type BestEffort struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Distance       int32                  `protobuf:"varint,1,opt,name=distance,proto3" json:"distance,omitempty"` // Metres
+	Seconds        int32                  `protobuf:"varint,2,opt,name=seconds,proto3" json:"seconds,omitempty"`
+	StartedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	PersonalRecord bool                   `protobuf:"varint,4,opt,name=personal_record,json=personalRecord,proto3" json:"personal_record,omitempty"` // Faster than any earlier ride when ingested
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *UploadActivitiesResponse) Reset() {
-	*x = UploadActivitiesResponse{}
-	mi := &file_activity_v1_activity_proto_msgTypes[5]
+func (x *BestEffort) Reset() {
+	*x = BestEffort{}
+	mi := &file_activity_v1_activity_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadActivitiesResponse) String() string {
+func (x *BestEffort) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadActivitiesResponse) ProtoMessage() {}
+func (*BestEffort) ProtoMessage() {}
 
-func (x *UploadActivitiesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_activity_v1_activity_proto_msgTypes[5]
+func (x *BestEffort) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -535,43 +708,66 @@ func (x *UploadActivitiesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadActivitiesResponse.ProtoReflect.Descriptor instead.
-func (*UploadActivitiesResponse) Descriptor() ([]byte, []int) {
-	return file_activity_v1_activity_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use BestEffort.ProtoReflect.Descriptor instead.
+func (*BestEffort) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *UploadActivitiesResponse) GetStatus() string {
+func (x *BestEffort) GetDistance() int32 {
 	if x != nil {
-		return x.Status
+		return x.Distance
 	}
-	return ""
+	return 0
 }
 
-type UploadActivitiesUnaryFile struct {
+func (x *BestEffort) GetSeconds() int32 {
+	if x != nil {
+		return x.Seconds
+	}
+	return 0
+}
+
+func (x *BestEffort) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *BestEffort) GetPersonalRecord() bool {
+	if x != nil {
+		return x.PersonalRecord
+	}
+	return false
+}
+
+// PowerCurvePoint is the best average power held for a duration. On an
+// envelope curve it also names the ride that set it.
+type PowerCurvePoint struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
-	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
-	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
-	LastModified  int64                  `protobuf:"varint,4,opt,name=last_modified,json=lastModified,proto3" json:"last_modified,omitempty"` // Unix milliseconds
+	Duration      int32                  `protobuf:"varint,1,opt,name=duration,proto3" json:"duration,omitempty"` // Seconds
+	Watts         int32                  `protobuf:"varint,2,opt,name=watts,proto3" json:"watts,omitempty"`
+	ActivityId    int32                  `protobuf:"varint,3,opt,name=activity_id,json=activityId,proto3" json:"activity_id,omitempty"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=date,proto3" json:"date,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UploadActivitiesUnaryFile) Reset() {
-	*x = UploadActivitiesUnaryFile{}
-	mi := &file_activity_v1_activity_proto_msgTypes[6]
+func (x *PowerCurvePoint) Reset() {
+	*x = PowerCurvePoint{}
+	mi := &file_activity_v1_activity_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadActivitiesUnaryFile) String() string {
+func (x *PowerCurvePoint) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadActivitiesUnaryFile) ProtoMessage() {}
+func (*PowerCurvePoint) ProtoMessage() {}
 
-func (x *UploadActivitiesUnaryFile) ProtoReflect() protoreflect.Message {
-	mi := &file_activity_v1_activity_proto_msgTypes[6]
+func (x *PowerCurvePoint) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -582,61 +778,2466 @@ func (x *UploadActivitiesUnaryFile) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadActivitiesUnaryFile.ProtoReflect.Descriptor instead.
-func (*UploadActivitiesUnaryFile) Descriptor() ([]byte, []int) {
-	return file_activity_v1_activity_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use PowerCurvePoint.ProtoReflect.Descriptor instead.
+func (*PowerCurvePoint) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *UploadActivitiesUnaryFile) GetData() []byte {
+func (x *PowerCurvePoint) GetDuration() int32 {
 	if x != nil {
-		return x.Data
+		return x.Duration
 	}
-	return nil
+	return 0
+}
+
+func (x *PowerCurvePoint) GetWatts() int32 {
+	if x != nil {
+		return x.Watts
+	}
+	return 0
+}
+
+func (x *PowerCurvePoint) GetActivityId() int32 {
+	if x != nil {
+		return x.ActivityId
+	}
+	return 0
+}
+
+func (x *PowerCurvePoint) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+// HeartRateZones is the time an activity spent in each heart rate zone, with
+// the zone bounds in effect when it was ingested.
+type HeartRateZones struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Zones         []*HeartRateZone       `protobuf:"bytes,1,rep,name=zones,proto3" json:"zones,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartRateZones) Reset() {
+	*x = HeartRateZones{}
+	mi := &file_activity_v1_activity_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartRateZones) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartRateZones) ProtoMessage() {}
+
+func (x *HeartRateZones) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartRateZones.ProtoReflect.Descriptor instead.
+func (*HeartRateZones) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *HeartRateZones) GetZones() []*HeartRateZone {
+	if x != nil {
+		return x.Zones
+	}
+	return nil
+}
+
+type HeartRateZone struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Zone          int32                  `protobuf:"varint,1,opt,name=zone,proto3" json:"zone,omitempty"` // Numbered from 1
+	MinHeartRate  int32                  `protobuf:"varint,2,opt,name=min_heart_rate,json=minHeartRate,proto3" json:"min_heart_rate,omitempty"`
+	MaxHeartRate  *wrapperspb.Int32Value `protobuf:"bytes,3,opt,name=max_heart_rate,json=maxHeartRate,proto3" json:"max_heart_rate,omitempty"` // Unset for the top zone
+	Seconds       int32                  `protobuf:"varint,4,opt,name=seconds,proto3" json:"seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartRateZone) Reset() {
+	*x = HeartRateZone{}
+	mi := &file_activity_v1_activity_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartRateZone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartRateZone) ProtoMessage() {}
+
+func (x *HeartRateZone) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartRateZone.ProtoReflect.Descriptor instead.
+func (*HeartRateZone) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *HeartRateZone) GetZone() int32 {
+	if x != nil {
+		return x.Zone
+	}
+	return 0
+}
+
+func (x *HeartRateZone) GetMinHeartRate() int32 {
+	if x != nil {
+		return x.MinHeartRate
+	}
+	return 0
+}
+
+func (x *HeartRateZone) GetMaxHeartRate() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.MaxHeartRate
+	}
+	return nil
+}
+
+func (x *HeartRateZone) GetSeconds() int32 {
+	if x != nil {
+		return x.Seconds
+	}
+	return 0
+}
+
+// ActivityPause is a period in which the rider was stopped. The source is
+// "timer" for pauses of the device timer and "auto" for stops detected from
+// the recorded speed.
+type ActivityPause struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	EndedAt       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	ElapsedTime   string                 `protobuf:"bytes,3,opt,name=elapsed_time,json=elapsedTime,proto3" json:"elapsed_time,omitempty"`
+	Source        string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActivityPause) Reset() {
+	*x = ActivityPause{}
+	mi := &file_activity_v1_activity_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActivityPause) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivityPause) ProtoMessage() {}
+
+func (x *ActivityPause) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivityPause.ProtoReflect.Descriptor instead.
+func (*ActivityPause) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ActivityPause) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *ActivityPause) GetEndedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndedAt
+	}
+	return nil
+}
+
+func (x *ActivityPause) GetElapsedTime() string {
+	if x != nil {
+		return x.ElapsedTime
+	}
+	return ""
+}
+
+func (x *ActivityPause) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+// ActivityDevice is the head unit (creator) or a sensor that recorded an
+// activity. Names are snake_case FIT names, e.g. garmin, edge530, bike_power;
+// unknown values are empty.
+type ActivityDevice struct {
+	state           protoimpl.MessageState  `protogen:"open.v1"`
+	Index           int32                   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Creator         bool                    `protobuf:"varint,2,opt,name=creator,proto3" json:"creator,omitempty"`
+	Manufacturer    string                  `protobuf:"bytes,3,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"`
+	Product         *wrapperspb.Int32Value  `protobuf:"bytes,4,opt,name=product,proto3" json:"product,omitempty"`
+	ProductName     string                  `protobuf:"bytes,5,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	SerialNumber    *wrapperspb.Int64Value  `protobuf:"bytes,6,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	SoftwareVersion string                  `protobuf:"bytes,7,opt,name=software_version,json=softwareVersion,proto3" json:"software_version,omitempty"`
+	HardwareVersion *wrapperspb.Int32Value  `protobuf:"bytes,8,opt,name=hardware_version,json=hardwareVersion,proto3" json:"hardware_version,omitempty"`
+	DeviceType      string                  `protobuf:"bytes,9,opt,name=device_type,json=deviceType,proto3" json:"device_type,omitempty"`
+	SourceType      string                  `protobuf:"bytes,10,opt,name=source_type,json=sourceType,proto3" json:"source_type,omitempty"` // e.g. antplus, bluetooth_low_energy, local
+	AntDeviceNumber *wrapperspb.Int32Value  `protobuf:"bytes,11,opt,name=ant_device_number,json=antDeviceNumber,proto3" json:"ant_device_number,omitempty"`
+	BatteryVoltage  *wrapperspb.DoubleValue `protobuf:"bytes,12,opt,name=battery_voltage,json=batteryVoltage,proto3" json:"battery_voltage,omitempty"`
+	BatteryStatus   string                  `protobuf:"bytes,13,opt,name=battery_status,json=batteryStatus,proto3" json:"battery_status,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ActivityDevice) Reset() {
+	*x = ActivityDevice{}
+	mi := &file_activity_v1_activity_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActivityDevice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivityDevice) ProtoMessage() {}
+
+func (x *ActivityDevice) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivityDevice.ProtoReflect.Descriptor instead.
+func (*ActivityDevice) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ActivityDevice) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ActivityDevice) GetCreator() bool {
+	if x != nil {
+		return x.Creator
+	}
+	return false
+}
+
+func (x *ActivityDevice) GetManufacturer() string {
+	if x != nil {
+		return x.Manufacturer
+	}
+	return ""
+}
+
+func (x *ActivityDevice) GetProduct() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *ActivityDevice) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *ActivityDevice) GetSerialNumber() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return nil
+}
+
+func (x *ActivityDevice) GetSoftwareVersion() string {
+	if x != nil {
+		return x.SoftwareVersion
+	}
+	return ""
+}
+
+func (x *ActivityDevice) GetHardwareVersion() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.HardwareVersion
+	}
+	return nil
+}
+
+func (x *ActivityDevice) GetDeviceType() string {
+	if x != nil {
+		return x.DeviceType
+	}
+	return ""
+}
+
+func (x *ActivityDevice) GetSourceType() string {
+	if x != nil {
+		return x.SourceType
+	}
+	return ""
+}
+
+func (x *ActivityDevice) GetAntDeviceNumber() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.AntDeviceNumber
+	}
+	return nil
+}
+
+func (x *ActivityDevice) GetBatteryVoltage() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.BatteryVoltage
+	}
+	return nil
+}
+
+func (x *ActivityDevice) GetBatteryStatus() string {
+	if x != nil {
+		return x.BatteryStatus
+	}
+	return ""
+}
+
+// ActivitySession is one FIT session of an activity, e.g. a leg of a
+// multi-sport file.
+type ActivitySession struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Sport         string                 `protobuf:"bytes,2,opt,name=sport,proto3" json:"sport,omitempty"`
+	SubSport      string                 `protobuf:"bytes,3,opt,name=sub_sport,json=subSport,proto3" json:"sub_sport,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	ElapsedTime   string                 `protobuf:"bytes,6,opt,name=elapsed_time,json=elapsedTime,proto3" json:"elapsed_time,omitempty"`
+	TimerTime     string                 `protobuf:"bytes,7,opt,name=timer_time,json=timerTime,proto3" json:"timer_time,omitempty"`
+	Distance      float64                `protobuf:"fixed64,8,opt,name=distance,proto3" json:"distance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActivitySession) Reset() {
+	*x = ActivitySession{}
+	mi := &file_activity_v1_activity_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActivitySession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivitySession) ProtoMessage() {}
+
+func (x *ActivitySession) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivitySession.ProtoReflect.Descriptor instead.
+func (*ActivitySession) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ActivitySession) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ActivitySession) GetSport() string {
+	if x != nil {
+		return x.Sport
+	}
+	return ""
+}
+
+func (x *ActivitySession) GetSubSport() string {
+	if x != nil {
+		return x.SubSport
+	}
+	return ""
+}
+
+func (x *ActivitySession) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ActivitySession) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ActivitySession) GetElapsedTime() string {
+	if x != nil {
+		return x.ElapsedTime
+	}
+	return ""
+}
+
+func (x *ActivitySession) GetTimerTime() string {
+	if x != nil {
+		return x.TimerTime
+	}
+	return ""
+}
+
+func (x *ActivitySession) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+// Lap is a manual or automatic lap recorded by the device. Heart rate and
+// power are 0 when the device did not record them.
+type Lap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Trigger       string                 `protobuf:"bytes,2,opt,name=trigger,proto3" json:"trigger,omitempty"` // e.g. manual, distance, time, session_end
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	ElapsedTime   string                 `protobuf:"bytes,5,opt,name=elapsed_time,json=elapsedTime,proto3" json:"elapsed_time,omitempty"`
+	TimerTime     string                 `protobuf:"bytes,6,opt,name=timer_time,json=timerTime,proto3" json:"timer_time,omitempty"`
+	Distance      float64                `protobuf:"fixed64,7,opt,name=distance,proto3" json:"distance,omitempty"`
+	AvgSpeed      float64                `protobuf:"fixed64,8,opt,name=avg_speed,json=avgSpeed,proto3" json:"avg_speed,omitempty"`
+	MaxSpeed      float64                `protobuf:"fixed64,9,opt,name=max_speed,json=maxSpeed,proto3" json:"max_speed,omitempty"`
+	AvgHeartRate  int32                  `protobuf:"varint,10,opt,name=avg_heart_rate,json=avgHeartRate,proto3" json:"avg_heart_rate,omitempty"`
+	MaxHeartRate  int32                  `protobuf:"varint,11,opt,name=max_heart_rate,json=maxHeartRate,proto3" json:"max_heart_rate,omitempty"`
+	AvgPower      int32                  `protobuf:"varint,12,opt,name=avg_power,json=avgPower,proto3" json:"avg_power,omitempty"`
+	MaxPower      int32                  `protobuf:"varint,13,opt,name=max_power,json=maxPower,proto3" json:"max_power,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Lap) Reset() {
+	*x = Lap{}
+	mi := &file_activity_v1_activity_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Lap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lap) ProtoMessage() {}
+
+func (x *Lap) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lap.ProtoReflect.Descriptor instead.
+func (*Lap) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Lap) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Lap) GetTrigger() string {
+	if x != nil {
+		return x.Trigger
+	}
+	return ""
+}
+
+func (x *Lap) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *Lap) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *Lap) GetElapsedTime() string {
+	if x != nil {
+		return x.ElapsedTime
+	}
+	return ""
+}
+
+func (x *Lap) GetTimerTime() string {
+	if x != nil {
+		return x.TimerTime
+	}
+	return ""
+}
+
+func (x *Lap) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *Lap) GetAvgSpeed() float64 {
+	if x != nil {
+		return x.AvgSpeed
+	}
+	return 0
+}
+
+func (x *Lap) GetMaxSpeed() float64 {
+	if x != nil {
+		return x.MaxSpeed
+	}
+	return 0
+}
+
+func (x *Lap) GetAvgHeartRate() int32 {
+	if x != nil {
+		return x.AvgHeartRate
+	}
+	return 0
+}
+
+func (x *Lap) GetMaxHeartRate() int32 {
+	if x != nil {
+		return x.MaxHeartRate
+	}
+	return 0
+}
+
+func (x *Lap) GetAvgPower() int32 {
+	if x != nil {
+		return x.AvgPower
+	}
+	return 0
+}
+
+func (x *Lap) GetMaxPower() int32 {
+	if x != nil {
+		return x.MaxPower
+	}
+	return 0
+}
+
+// ActivitySummary provides a summarized view of an activity.
+type ActivitySummary struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Id            int32                   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp  `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Distance      float64                 `protobuf:"fixed64,3,opt,name=distance,proto3" json:"distance,omitempty"`
+	ActivityName  string                  `protobuf:"bytes,4,opt,name=activity_name,json=activityName,proto3" json:"activity_name,omitempty"`
+	AvgSpeed      float64                 `protobuf:"fixed64,5,opt,name=avg_speed,json=avgSpeed,proto3" json:"avg_speed,omitempty"`
+	MaxSpeed      float64                 `protobuf:"fixed64,6,opt,name=max_speed,json=maxSpeed,proto3" json:"max_speed,omitempty"`
+	ElapsedTime   string                  `protobuf:"bytes,7,opt,name=elapsed_time,json=elapsedTime,proto3" json:"elapsed_time,omitempty"`
+	TotalTime     string                  `protobuf:"bytes,8,opt,name=total_time,json=totalTime,proto3" json:"total_time,omitempty"`
+	Indoor        bool                    `protobuf:"varint,9,opt,name=indoor,proto3" json:"indoor,omitempty"`
+	MovingTime    string                  `protobuf:"bytes,10,opt,name=moving_time,json=movingTime,proto3" json:"moving_time,omitempty"`
+	ElevationGain *wrapperspb.DoubleValue `protobuf:"bytes,11,opt,name=elevation_gain,json=elevationGain,proto3" json:"elevation_gain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActivitySummary) Reset() {
+	*x = ActivitySummary{}
+	mi := &file_activity_v1_activity_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActivitySummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivitySummary) ProtoMessage() {}
+
+func (x *ActivitySummary) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivitySummary.ProtoReflect.Descriptor instead.
+func (*ActivitySummary) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ActivitySummary) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ActivitySummary) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ActivitySummary) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *ActivitySummary) GetActivityName() string {
+	if x != nil {
+		return x.ActivityName
+	}
+	return ""
+}
+
+func (x *ActivitySummary) GetAvgSpeed() float64 {
+	if x != nil {
+		return x.AvgSpeed
+	}
+	return 0
+}
+
+func (x *ActivitySummary) GetMaxSpeed() float64 {
+	if x != nil {
+		return x.MaxSpeed
+	}
+	return 0
+}
+
+func (x *ActivitySummary) GetElapsedTime() string {
+	if x != nil {
+		return x.ElapsedTime
+	}
+	return ""
+}
+
+func (x *ActivitySummary) GetTotalTime() string {
+	if x != nil {
+		return x.TotalTime
+	}
+	return ""
+}
+
+func (x *ActivitySummary) GetIndoor() bool {
+	if x != nil {
+		return x.Indoor
+	}
+	return false
+}
+
+func (x *ActivitySummary) GetMovingTime() string {
+	if x != nil {
+		return x.MovingTime
+	}
+	return ""
+}
+
+func (x *ActivitySummary) GetElevationGain() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.ElevationGain
+	}
+	return nil
+}
+
+// Request message for streaming uploads.
+//
+// A stream carries one or more files. Every file starts with a file_header
+// message followed by the file_chunk messages holding its bytes; the next
+// file_header (or the end of the stream) completes the current file.
+type UploadActivitiesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*UploadActivitiesRequest_FileChunk
+	//	*UploadActivitiesRequest_Metadata
+	//	*UploadActivitiesRequest_FileHeader
+	Payload       isUploadActivitiesRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadActivitiesRequest) Reset() {
+	*x = UploadActivitiesRequest{}
+	mi := &file_activity_v1_activity_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadActivitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadActivitiesRequest) ProtoMessage() {}
+
+func (x *UploadActivitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadActivitiesRequest.ProtoReflect.Descriptor instead.
+func (*UploadActivitiesRequest) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UploadActivitiesRequest) GetPayload() isUploadActivitiesRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *UploadActivitiesRequest) GetFileChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadActivitiesRequest_FileChunk); ok {
+			return x.FileChunk
+		}
+	}
+	return nil
+}
+
+func (x *UploadActivitiesRequest) GetMetadata() string {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadActivitiesRequest_Metadata); ok {
+			return x.Metadata
+		}
+	}
+	return ""
+}
+
+func (x *UploadActivitiesRequest) GetFileHeader() *UploadFileHeader {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadActivitiesRequest_FileHeader); ok {
+			return x.FileHeader
+		}
+	}
+	return nil
+}
+
+type isUploadActivitiesRequest_Payload interface {
+	isUploadActivitiesRequest_Payload()
+}
+
+type UploadActivitiesRequest_FileChunk struct {
+	FileChunk []byte `protobuf:"bytes,1,opt,name=file_chunk,json=fileChunk,proto3,oneof"` // Chunk of file data
+}
+
+type UploadActivitiesRequest_Metadata struct {
+	Metadata string `protobuf:"bytes,2,opt,name=metadata,proto3,oneof"` // Optional metadata (e.g., user ID, filename)
+}
+
+type UploadActivitiesRequest_FileHeader struct {
+	FileHeader *UploadFileHeader `protobuf:"bytes,3,opt,name=file_header,json=fileHeader,proto3,oneof"` // Starts a new file in the stream
+}
+
+func (*UploadActivitiesRequest_FileChunk) isUploadActivitiesRequest_Payload() {}
+
+func (*UploadActivitiesRequest_Metadata) isUploadActivitiesRequest_Payload() {}
+
+func (*UploadActivitiesRequest_FileHeader) isUploadActivitiesRequest_Payload() {}
+
+// UploadFileHeader describes the file whose chunks follow it in the stream.
+type UploadFileHeader struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"` // Size in bytes, used to validate the received data
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Sha256        string                 `protobuf:"bytes,4,opt,name=sha256,proto3" json:"sha256,omitempty"`                                  // Optional hex encoded SHA-256 checksum of the file
+	LastModified  int64                  `protobuf:"varint,5,opt,name=last_modified,json=lastModified,proto3" json:"last_modified,omitempty"` // Unix milliseconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadFileHeader) Reset() {
+	*x = UploadFileHeader{}
+	mi := &file_activity_v1_activity_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadFileHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadFileHeader) ProtoMessage() {}
+
+func (x *UploadFileHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadFileHeader.ProtoReflect.Descriptor instead.
+func (*UploadFileHeader) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UploadFileHeader) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *UploadFileHeader) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UploadFileHeader) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *UploadFileHeader) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *UploadFileHeader) GetLastModified() int64 {
+	if x != nil {
+		return x.LastModified
+	}
+	return 0
+}
+
+// UploadFileResult reports the outcome for a single uploaded file.
+type UploadFileResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	ActivityId    int32                  `protobuf:"varint,2,opt,name=activity_id,json=activityId,proto3" json:"activity_id,omitempty"` // Set when the activity was created
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`                              // Set when the file could not be ingested
+	FailureReason UploadFailureReason    `protobuf:"varint,4,opt,name=failure_reason,json=failureReason,proto3,enum=activity.v1.UploadFailureReason" json:"failure_reason,omitempty"`
+	Skipped       bool                   `protobuf:"varint,5,opt,name=skipped,proto3" json:"skipped,omitempty"` // Duplicate that was not imported, activity_id is the existing activity
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadFileResult) Reset() {
+	*x = UploadFileResult{}
+	mi := &file_activity_v1_activity_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadFileResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadFileResult) ProtoMessage() {}
+
+func (x *UploadFileResult) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadFileResult.ProtoReflect.Descriptor instead.
+func (*UploadFileResult) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UploadFileResult) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *UploadFileResult) GetActivityId() int32 {
+	if x != nil {
+		return x.ActivityId
+	}
+	return 0
+}
+
+func (x *UploadFileResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *UploadFileResult) GetFailureReason() UploadFailureReason {
+	if x != nil {
+		return x.FailureReason
+	}
+	return UploadFailureReason_UPLOAD_FAILURE_REASON_UNSPECIFIED
+}
+
+func (x *UploadFileResult) GetSkipped() bool {
+	if x != nil {
+		return x.Skipped
+	}
+	return false
+}
+
+// Response message after upload
+//
+// Uploads are ingested asynchronously: every accepted file is queued as a job
+// whose progress is reported by GetIngestionJobs. Results only hold the files
+// rejected before they could be queued, e.g. incomplete streamed files.
+type UploadActivitiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`                                      // "queued" when files were queued, otherwise "failed"
+	ActivityIds   []int32                `protobuf:"varint,2,rep,packed,name=activity_ids,json=activityIds,proto3" json:"activity_ids,omitempty"` // IDs of the activities that were created
+	Results       []*UploadFileResult    `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`                                    // Per-file outcome in upload order
+	Jobs          []*IngestionJob        `protobuf:"bytes,4,rep,name=jobs,proto3" json:"jobs,omitempty"`                                          // Jobs queued for the accepted files
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadActivitiesResponse) Reset() {
+	*x = UploadActivitiesResponse{}
+	mi := &file_activity_v1_activity_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadActivitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadActivitiesResponse) ProtoMessage() {}
+
+func (x *UploadActivitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadActivitiesResponse.ProtoReflect.Descriptor instead.
+func (*UploadActivitiesResponse) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UploadActivitiesResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UploadActivitiesResponse) GetActivityIds() []int32 {
+	if x != nil {
+		return x.ActivityIds
+	}
+	return nil
+}
+
+func (x *UploadActivitiesResponse) GetResults() []*UploadFileResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *UploadActivitiesResponse) GetJobs() []*IngestionJob {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+// IngestionJob tracks an uploaded file through asynchronous ingestion. Once
+// done or failed it holds the same outcome as an UploadFileResult.
+type IngestionJob struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	Status        IngestionJobStatus     `protobuf:"varint,3,opt,name=status,proto3,enum=activity.v1.IngestionJobStatus" json:"status,omitempty"`
+	ActivityId    int32                  `protobuf:"varint,4,opt,name=activity_id,json=activityId,proto3" json:"activity_id,omitempty"` // Created activity, or the existing one for skipped duplicates
+	Skipped       bool                   `protobuf:"varint,5,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	FailureReason UploadFailureReason    `protobuf:"varint,6,opt,name=failure_reason,json=failureReason,proto3,enum=activity.v1.UploadFailureReason" json:"failure_reason,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestionJob) Reset() {
+	*x = IngestionJob{}
+	mi := &file_activity_v1_activity_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestionJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestionJob) ProtoMessage() {}
+
+func (x *IngestionJob) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestionJob.ProtoReflect.Descriptor instead.
+func (*IngestionJob) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *IngestionJob) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *IngestionJob) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *IngestionJob) GetStatus() IngestionJobStatus {
+	if x != nil {
+		return x.Status
+	}
+	return IngestionJobStatus_INGESTION_JOB_STATUS_UNSPECIFIED
+}
+
+func (x *IngestionJob) GetActivityId() int32 {
+	if x != nil {
+		return x.ActivityId
+	}
+	return 0
+}
+
+func (x *IngestionJob) GetSkipped() bool {
+	if x != nil {
+		return x.Skipped
+	}
+	return false
+}
+
+func (x *IngestionJob) GetFailureReason() UploadFailureReason {
+	if x != nil {
+		return x.FailureReason
+	}
+	return UploadFailureReason_UPLOAD_FAILURE_REASON_UNSPECIFIED
+}
+
+func (x *IngestionJob) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *IngestionJob) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *IngestionJob) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *IngestionJob) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+type GetIngestionJobsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobIds        []int64                `protobuf:"varint,1,rep,packed,name=job_ids,json=jobIds,proto3" json:"job_ids,omitempty"` // Empty lists the most recent jobs
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIngestionJobsRequest) Reset() {
+	*x = GetIngestionJobsRequest{}
+	mi := &file_activity_v1_activity_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIngestionJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIngestionJobsRequest) ProtoMessage() {}
+
+func (x *GetIngestionJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIngestionJobsRequest.ProtoReflect.Descriptor instead.
+func (*GetIngestionJobsRequest) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetIngestionJobsRequest) GetJobIds() []int64 {
+	if x != nil {
+		return x.JobIds
+	}
+	return nil
+}
+
+type GetIngestionJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*IngestionJob        `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIngestionJobsResponse) Reset() {
+	*x = GetIngestionJobsResponse{}
+	mi := &file_activity_v1_activity_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIngestionJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIngestionJobsResponse) ProtoMessage() {}
+
+func (x *GetIngestionJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIngestionJobsResponse.ProtoReflect.Descriptor instead.
+func (*GetIngestionJobsResponse) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetIngestionJobsResponse) GetJobs() []*IngestionJob {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+type UploadActivitiesUnaryFile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	LastModified  int64                  `protobuf:"varint,4,opt,name=last_modified,json=lastModified,proto3" json:"last_modified,omitempty"` // Unix milliseconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadActivitiesUnaryFile) Reset() {
+	*x = UploadActivitiesUnaryFile{}
+	mi := &file_activity_v1_activity_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadActivitiesUnaryFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadActivitiesUnaryFile) ProtoMessage() {}
+
+func (x *UploadActivitiesUnaryFile) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadActivitiesUnaryFile.ProtoReflect.Descriptor instead.
+func (*UploadActivitiesUnaryFile) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UploadActivitiesUnaryFile) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UploadActivitiesUnaryFile) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *UploadActivitiesUnaryFile) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *UploadActivitiesUnaryFile) GetLastModified() int64 {
+	if x != nil {
+		return x.LastModified
+	}
+	return 0
+}
+
+type UploadActivitiesUnaryRequest struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Files         []*UploadActivitiesUnaryFile `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadActivitiesUnaryRequest) Reset() {
+	*x = UploadActivitiesUnaryRequest{}
+	mi := &file_activity_v1_activity_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadActivitiesUnaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadActivitiesUnaryRequest) ProtoMessage() {}
+
+func (x *UploadActivitiesUnaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadActivitiesUnaryRequest.ProtoReflect.Descriptor instead.
+func (*UploadActivitiesUnaryRequest) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UploadActivitiesUnaryRequest) GetFiles() []*UploadActivitiesUnaryFile {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+// UploadArchiveChunkRequest appends a chunk to an export archive staged for
+// ImportArchive. Exports are often several GB, far over the message size
+// limits, so they are sent in chunks of a few MB.
+type UploadArchiveChunkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"` // Empty on the first chunk, which starts a new upload
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`                    // Position of the chunk in the archive
+	Chunk         []byte                 `protobuf:"bytes,3,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadArchiveChunkRequest) Reset() {
+	*x = UploadArchiveChunkRequest{}
+	mi := &file_activity_v1_activity_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadArchiveChunkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadArchiveChunkRequest) ProtoMessage() {}
+
+func (x *UploadArchiveChunkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadArchiveChunkRequest.ProtoReflect.Descriptor instead.
+func (*UploadArchiveChunkRequest) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UploadArchiveChunkRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UploadArchiveChunkRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *UploadArchiveChunkRequest) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+type UploadArchiveChunkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"` // Bytes staged so far
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadArchiveChunkResponse) Reset() {
+	*x = UploadArchiveChunkResponse{}
+	mi := &file_activity_v1_activity_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadArchiveChunkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadArchiveChunkResponse) ProtoMessage() {}
+
+func (x *UploadArchiveChunkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadArchiveChunkResponse.ProtoReflect.Descriptor instead.
+func (*UploadArchiveChunkResponse) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UploadArchiveChunkResponse) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UploadArchiveChunkResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// ImportArchiveRequest refers to a Strava or Garmin account export ZIP staged
+// with UploadArchiveChunk. The staged archive is removed once imported.
+type ImportArchiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	UploadId      string                 `protobuf:"bytes,3,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportArchiveRequest) Reset() {
+	*x = ImportArchiveRequest{}
+	mi := &file_activity_v1_activity_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportArchiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportArchiveRequest) ProtoMessage() {}
+
+func (x *ImportArchiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportArchiveRequest.ProtoReflect.Descriptor instead.
+func (*ImportArchiveRequest) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ImportArchiveRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *ImportArchiveRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+// ImportArchiveProgress is streamed after each activity file of the archive.
+type ImportArchiveProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Processed     int32                  `protobuf:"varint,1,opt,name=processed,proto3" json:"processed,omitempty"` // Files handled so far, including this one
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`         // Activity files found in the archive
+	Result        *UploadFileResult      `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportArchiveProgress) Reset() {
+	*x = ImportArchiveProgress{}
+	mi := &file_activity_v1_activity_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportArchiveProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportArchiveProgress) ProtoMessage() {}
+
+func (x *ImportArchiveProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportArchiveProgress.ProtoReflect.Descriptor instead.
+func (*ImportArchiveProgress) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ImportArchiveProgress) GetProcessed() int32 {
+	if x != nil {
+		return x.Processed
+	}
+	return 0
+}
+
+func (x *ImportArchiveProgress) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ImportArchiveProgress) GetResult() *UploadFileResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+// Selects the activities to reprocess from their stored originals. Exactly
+// one of activity_id, user_id or all must be set.
+type ReprocessActivitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActivityId    int32                  `protobuf:"varint,1,opt,name=activity_id,json=activityId,proto3" json:"activity_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	All           bool                   `protobuf:"varint,3,opt,name=all,proto3" json:"all,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReprocessActivitiesRequest) Reset() {
+	*x = ReprocessActivitiesRequest{}
+	mi := &file_activity_v1_activity_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReprocessActivitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReprocessActivitiesRequest) ProtoMessage() {}
+
+func (x *ReprocessActivitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReprocessActivitiesRequest.ProtoReflect.Descriptor instead.
+func (*ReprocessActivitiesRequest) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ReprocessActivitiesRequest) GetActivityId() int32 {
+	if x != nil {
+		return x.ActivityId
+	}
+	return 0
+}
+
+func (x *ReprocessActivitiesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReprocessActivitiesRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+// Reported after each reprocessed activity
+type ReprocessActivitiesProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Processed     int32                  `protobuf:"varint,1,opt,name=processed,proto3" json:"processed,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Result        *UploadFileResult      `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReprocessActivitiesProgress) Reset() {
+	*x = ReprocessActivitiesProgress{}
+	mi := &file_activity_v1_activity_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReprocessActivitiesProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReprocessActivitiesProgress) ProtoMessage() {}
+
+func (x *ReprocessActivitiesProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReprocessActivitiesProgress.ProtoReflect.Descriptor instead.
+func (*ReprocessActivitiesProgress) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ReprocessActivitiesProgress) GetProcessed() int32 {
+	if x != nil {
+		return x.Processed
+	}
+	return 0
+}
+
+func (x *ReprocessActivitiesProgress) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ReprocessActivitiesProgress) GetResult() *UploadFileResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+// GetActivitiesResponse contains a list of activity summaries.
+type GetActivitiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Activities    []*ActivitySummary     `protobuf:"bytes,1,rep,name=activities,proto3" json:"activities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActivitiesResponse) Reset() {
+	*x = GetActivitiesResponse{}
+	mi := &file_activity_v1_activity_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActivitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActivitiesResponse) ProtoMessage() {}
+
+func (x *GetActivitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActivitiesResponse.ProtoReflect.Descriptor instead.
+func (*GetActivitiesResponse) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetActivitiesResponse) GetActivities() []*ActivitySummary {
+	if x != nil {
+		return x.Activities
+	}
+	return nil
+}
+
+// GetActivitiesRequest is an empty request message for fetching all activities.
+// If you prefer to keep using the standard Empty message, you can alias it, but
+// defining it explicitly is clearer.
+type GetActivitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActivitiesRequest) Reset() {
+	*x = GetActivitiesRequest{}
+	mi := &file_activity_v1_activity_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActivitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActivitiesRequest) ProtoMessage() {}
+
+func (x *GetActivitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActivitiesRequest.ProtoReflect.Descriptor instead.
+func (*GetActivitiesRequest) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{28}
+}
+
+// GetActivityRequest specifies the ID of the activity to retrieve and,
+// optionally, the resolution to return it at.
+type GetActivityRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ActivityId int32                  `protobuf:"varint,1,opt,name=activity_id,json=activityId,proto3" json:"activity_id,omitempty"`
+	// Furthest in metres the simplified route may stray from the recorded
+	// positions; 0 leaves route and encoded_route unset.
+	Tolerance float64 `protobuf:"fixed64,2,opt,name=tolerance,proto3" json:"tolerance,omitempty"`
+	// Number of records to average the streams down to; 0 returns every record.
+	MaxPoints     int32 `protobuf:"varint,3,opt,name=max_points,json=maxPoints,proto3" json:"max_points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActivityRequest) Reset() {
+	*x = GetActivityRequest{}
+	mi := &file_activity_v1_activity_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActivityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActivityRequest) ProtoMessage() {}
+
+func (x *GetActivityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActivityRequest.ProtoReflect.Descriptor instead.
+func (*GetActivityRequest) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetActivityRequest) GetActivityId() int32 {
+	if x != nil {
+		return x.ActivityId
+	}
+	return 0
+}
+
+func (x *GetActivityRequest) GetTolerance() float64 {
+	if x != nil {
+		return x.Tolerance
+	}
+	return 0
+}
+
+func (x *GetActivityRequest) GetMaxPoints() int32 {
+	if x != nil {
+		return x.MaxPoints
+	}
+	return 0
+}
+
+type GetActivityLapsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActivityId    int32                  `protobuf:"varint,1,opt,name=activity_id,json=activityId,proto3" json:"activity_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActivityLapsRequest) Reset() {
+	*x = GetActivityLapsRequest{}
+	mi := &file_activity_v1_activity_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActivityLapsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActivityLapsRequest) ProtoMessage() {}
+
+func (x *GetActivityLapsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActivityLapsRequest.ProtoReflect.Descriptor instead.
+func (*GetActivityLapsRequest) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetActivityLapsRequest) GetActivityId() int32 {
+	if x != nil {
+		return x.ActivityId
+	}
+	return 0
+}
+
+type GetActivityLapsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Laps          []*Lap                 `protobuf:"bytes,1,rep,name=laps,proto3" json:"laps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActivityLapsResponse) Reset() {
+	*x = GetActivityLapsResponse{}
+	mi := &file_activity_v1_activity_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActivityLapsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActivityLapsResponse) ProtoMessage() {}
+
+func (x *GetActivityLapsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActivityLapsResponse.ProtoReflect.Descriptor instead.
+func (*GetActivityLapsResponse) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetActivityLapsResponse) GetLaps() []*Lap {
+	if x != nil {
+		return x.Laps
+	}
+	return nil
+}
+
+type GetOriginalFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActivityId    int32                  `protobuf:"varint,1,opt,name=activity_id,json=activityId,proto3" json:"activity_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOriginalFileRequest) Reset() {
+	*x = GetOriginalFileRequest{}
+	mi := &file_activity_v1_activity_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOriginalFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOriginalFileRequest) ProtoMessage() {}
+
+func (x *GetOriginalFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOriginalFileRequest.ProtoReflect.Descriptor instead.
+func (*GetOriginalFileRequest) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetOriginalFileRequest) GetActivityId() int32 {
+	if x != nil {
+		return x.ActivityId
+	}
+	return 0
+}
+
+// The file an activity was created from, as it was uploaded
+type GetOriginalFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOriginalFileResponse) Reset() {
+	*x = GetOriginalFileResponse{}
+	mi := &file_activity_v1_activity_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOriginalFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOriginalFileResponse) ProtoMessage() {}
+
+func (x *GetOriginalFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOriginalFileResponse.ProtoReflect.Descriptor instead.
+func (*GetOriginalFileResponse) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetOriginalFileResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *GetOriginalFileResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *GetOriginalFileResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type UpdateActivityRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	ActivityId    int32                   `protobuf:"varint,1,opt,name=activity_id,json=activityId,proto3" json:"activity_id,omitempty"`
+	ActivityName  *wrapperspb.StringValue `protobuf:"bytes,2,opt,name=activity_name,json=activityName,proto3" json:"activity_name,omitempty"`
+	RideType      *wrapperspb.StringValue `protobuf:"bytes,3,opt,name=ride_type,json=rideType,proto3" json:"ride_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateActivityRequest) Reset() {
+	*x = UpdateActivityRequest{}
+	mi := &file_activity_v1_activity_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateActivityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateActivityRequest) ProtoMessage() {}
+
+func (x *UpdateActivityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateActivityRequest.ProtoReflect.Descriptor instead.
+func (*UpdateActivityRequest) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UpdateActivityRequest) GetActivityId() int32 {
+	if x != nil {
+		return x.ActivityId
+	}
+	return 0
+}
+
+func (x *UpdateActivityRequest) GetActivityName() *wrapperspb.StringValue {
+	if x != nil {
+		return x.ActivityName
+	}
+	return nil
+}
+
+func (x *UpdateActivityRequest) GetRideType() *wrapperspb.StringValue {
+	if x != nil {
+		return x.RideType
+	}
+	return nil
+}
+
+type DeleteActivityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActivityId    int32                  `protobuf:"varint,1,opt,name=activity_id,json=activityId,proto3" json:"activity_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteActivityRequest) Reset() {
+	*x = DeleteActivityRequest{}
+	mi := &file_activity_v1_activity_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteActivityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteActivityRequest) ProtoMessage() {}
+
+func (x *DeleteActivityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteActivityRequest.ProtoReflect.Descriptor instead.
+func (*DeleteActivityRequest) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DeleteActivityRequest) GetActivityId() int32 {
+	if x != nil {
+		return x.ActivityId
+	}
+	return 0
+}
+
+type DeleteActivityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteActivityResponse) Reset() {
+	*x = DeleteActivityResponse{}
+	mi := &file_activity_v1_activity_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteActivityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteActivityResponse) ProtoMessage() {}
+
+func (x *DeleteActivityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteActivityResponse.ProtoReflect.Descriptor instead.
+func (*DeleteActivityResponse) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{36}
+}
+
+type GetUserSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserSettingsRequest) Reset() {
+	*x = GetUserSettingsRequest{}
+	mi := &file_activity_v1_activity_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserSettingsRequest) ProtoMessage() {}
+
+func (x *GetUserSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserSettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetUserSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{37}
+}
+
+// UserSettings holds the rider's training parameters.
+type UserSettings struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Ftp                *wrapperspb.Int32Value `protobuf:"bytes,1,opt,name=ftp,proto3" json:"ftp,omitempty"` // Functional threshold power in watts
+	MaxHeartRate       *wrapperspb.Int32Value `protobuf:"bytes,2,opt,name=max_heart_rate,json=maxHeartRate,proto3" json:"max_heart_rate,omitempty"`
+	ThresholdHeartRate *wrapperspb.Int32Value `protobuf:"bytes,3,opt,name=threshold_heart_rate,json=thresholdHeartRate,proto3" json:"threshold_heart_rate,omitempty"` // Lactate threshold heart rate
+	// How heart rate zones are derived: "max_hr", "lthr" or "custom". Time in
+	// zone is not computed while it is empty.
+	HeartRateZoneMethod string `protobuf:"bytes,4,opt,name=heart_rate_zone_method,json=heartRateZoneMethod,proto3" json:"heart_rate_zone_method,omitempty"`
+	// Custom bounds in bpm between consecutive zones, ascending.
+	HeartRateZoneBounds []int32 `protobuf:"varint,5,rep,packed,name=heart_rate_zone_bounds,json=heartRateZoneBounds,proto3" json:"heart_rate_zone_bounds,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *UserSettings) Reset() {
+	*x = UserSettings{}
+	mi := &file_activity_v1_activity_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserSettings) ProtoMessage() {}
+
+func (x *UserSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserSettings.ProtoReflect.Descriptor instead.
+func (*UserSettings) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *UserSettings) GetFtp() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.Ftp
+	}
+	return nil
+}
+
+func (x *UserSettings) GetMaxHeartRate() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.MaxHeartRate
+	}
+	return nil
+}
+
+func (x *UserSettings) GetThresholdHeartRate() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.ThresholdHeartRate
+	}
+	return nil
+}
+
+func (x *UserSettings) GetHeartRateZoneMethod() string {
+	if x != nil {
+		return x.HeartRateZoneMethod
+	}
+	return ""
+}
+
+func (x *UserSettings) GetHeartRateZoneBounds() []int32 {
+	if x != nil {
+		return x.HeartRateZoneBounds
+	}
+	return nil
+}
+
+type UpdateUserSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *UserSettings          `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserSettingsRequest) Reset() {
+	*x = UpdateUserSettingsRequest{}
+	mi := &file_activity_v1_activity_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserSettingsRequest) ProtoMessage() {}
+
+func (x *UpdateUserSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *UploadActivitiesUnaryFile) GetFilename() string {
+// Deprecated: Use UpdateUserSettingsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *UpdateUserSettingsRequest) GetSettings() *UserSettings {
 	if x != nil {
-		return x.Filename
+		return x.Settings
 	}
-	return ""
+	return nil
 }
 
-func (x *UploadActivitiesUnaryFile) GetContentType() string {
+// GetPowerCurveRequest selects the rides of the envelope curve. An unset from
+// includes all rides, an unset to ends the range now and an empty ride_type
+// includes every ride type.
+type GetPowerCurveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	RideType      string                 `protobuf:"bytes,3,opt,name=ride_type,json=rideType,proto3" json:"ride_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPowerCurveRequest) Reset() {
+	*x = GetPowerCurveRequest{}
+	mi := &file_activity_v1_activity_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPowerCurveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPowerCurveRequest) ProtoMessage() {}
+
+func (x *GetPowerCurveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[40]
 	if x != nil {
-		return x.ContentType
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *UploadActivitiesUnaryFile) GetLastModified() int64 {
+// Deprecated: Use GetPowerCurveRequest.ProtoReflect.Descriptor instead.
+func (*GetPowerCurveRequest) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetPowerCurveRequest) GetFrom() *timestamppb.Timestamp {
 	if x != nil {
-		return x.LastModified
+		return x.From
 	}
-	return 0
+	return nil
 }
 
-type UploadActivitiesUnaryRequest struct {
-	state         protoimpl.MessageState       `protogen:"open.v1"`
-	Files         []*UploadActivitiesUnaryFile `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+func (x *GetPowerCurveRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetPowerCurveRequest) GetRideType() string {
+	if x != nil {
+		return x.RideType
+	}
+	return ""
+}
+
+// GetPowerCurveResponse holds the best power for each duration over the
+// selected rides, ordered by duration.
+type GetPowerCurveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Points        []*PowerCurvePoint     `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UploadActivitiesUnaryRequest) Reset() {
-	*x = UploadActivitiesUnaryRequest{}
-	mi := &file_activity_v1_activity_proto_msgTypes[7]
+func (x *GetPowerCurveResponse) Reset() {
+	*x = GetPowerCurveResponse{}
+	mi := &file_activity_v1_activity_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UploadActivitiesUnaryRequest) String() string {
+func (x *GetPowerCurveResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadActivitiesUnaryRequest) ProtoMessage() {}
+func (*GetPowerCurveResponse) ProtoMessage() {}
 
-func (x *UploadActivitiesUnaryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_activity_v1_activity_proto_msgTypes[7]
+func (x *GetPowerCurveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -647,41 +3248,39 @@ func (x *UploadActivitiesUnaryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadActivitiesUnaryRequest.ProtoReflect.Descriptor instead.
-func (*UploadActivitiesUnaryRequest) Descriptor() ([]byte, []int) {
-	return file_activity_v1_activity_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use GetPowerCurveResponse.ProtoReflect.Descriptor instead.
+func (*GetPowerCurveResponse) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *UploadActivitiesUnaryRequest) GetFiles() []*UploadActivitiesUnaryFile {
+func (x *GetPowerCurveResponse) GetPoints() []*PowerCurvePoint {
 	if x != nil {
-		return x.Files
+		return x.Points
 	}
 	return nil
 }
 
-// GetActivitiesResponse contains a list of activity summaries.
-type GetActivitiesResponse struct {
+type GetPersonalRecordsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Activities    []*ActivitySummary     `protobuf:"bytes,1,rep,name=activities,proto3" json:"activities,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetActivitiesResponse) Reset() {
-	*x = GetActivitiesResponse{}
-	mi := &file_activity_v1_activity_proto_msgTypes[8]
+func (x *GetPersonalRecordsRequest) Reset() {
+	*x = GetPersonalRecordsRequest{}
+	mi := &file_activity_v1_activity_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetActivitiesResponse) String() string {
+func (x *GetPersonalRecordsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetActivitiesResponse) ProtoMessage() {}
+func (*GetPersonalRecordsRequest) ProtoMessage() {}
 
-func (x *GetActivitiesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_activity_v1_activity_proto_msgTypes[8]
+func (x *GetPersonalRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -692,42 +3291,120 @@ func (x *GetActivitiesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetActivitiesResponse.ProtoReflect.Descriptor instead.
-func (*GetActivitiesResponse) Descriptor() ([]byte, []int) {
-	return file_activity_v1_activity_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use GetPersonalRecordsRequest.ProtoReflect.Descriptor instead.
+func (*GetPersonalRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *GetActivitiesResponse) GetActivities() []*ActivitySummary {
+// PersonalRecord is the user's fastest effort over a distance.
+type PersonalRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Distance      int32                  `protobuf:"varint,1,opt,name=distance,proto3" json:"distance,omitempty"` // Metres
+	Seconds       int32                  `protobuf:"varint,2,opt,name=seconds,proto3" json:"seconds,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	ActivityId    int32                  `protobuf:"varint,4,opt,name=activity_id,json=activityId,proto3" json:"activity_id,omitempty"`
+	ActivityName  string                 `protobuf:"bytes,5,opt,name=activity_name,json=activityName,proto3" json:"activity_name,omitempty"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=date,proto3" json:"date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PersonalRecord) Reset() {
+	*x = PersonalRecord{}
+	mi := &file_activity_v1_activity_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PersonalRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PersonalRecord) ProtoMessage() {}
+
+func (x *PersonalRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[43]
 	if x != nil {
-		return x.Activities
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PersonalRecord.ProtoReflect.Descriptor instead.
+func (*PersonalRecord) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *PersonalRecord) GetDistance() int32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *PersonalRecord) GetSeconds() int32 {
+	if x != nil {
+		return x.Seconds
+	}
+	return 0
+}
+
+func (x *PersonalRecord) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
 	}
 	return nil
 }
 
-// GetActivitiesRequest is an empty request message for fetching all activities.
-// If you prefer to keep using the standard Empty message, you can alias it, but
-// defining it explicitly is clearer.
-type GetActivitiesRequest struct {
+func (x *PersonalRecord) GetActivityId() int32 {
+	if x != nil {
+		return x.ActivityId
+	}
+	return 0
+}
+
+func (x *PersonalRecord) GetActivityName() string {
+	if x != nil {
+		return x.ActivityName
+	}
+	return ""
+}
+
+func (x *PersonalRecord) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+// GetPersonalRecordsResponse holds a record for each distance the user has
+// covered, ordered by distance.
+type GetPersonalRecordsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Records       []*PersonalRecord      `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetActivitiesRequest) Reset() {
-	*x = GetActivitiesRequest{}
-	mi := &file_activity_v1_activity_proto_msgTypes[9]
+func (x *GetPersonalRecordsResponse) Reset() {
+	*x = GetPersonalRecordsResponse{}
+	mi := &file_activity_v1_activity_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetActivitiesRequest) String() string {
+func (x *GetPersonalRecordsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetActivitiesRequest) ProtoMessage() {}
+func (*GetPersonalRecordsResponse) ProtoMessage() {}
 
-func (x *GetActivitiesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_activity_v1_activity_proto_msgTypes[9]
+func (x *GetPersonalRecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -738,34 +3415,43 @@ func (x *GetActivitiesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetActivitiesRequest.ProtoReflect.Descriptor instead.
-func (*GetActivitiesRequest) Descriptor() ([]byte, []int) {
-	return file_activity_v1_activity_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use GetPersonalRecordsResponse.ProtoReflect.Descriptor instead.
+func (*GetPersonalRecordsResponse) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{44}
 }
 
-// GetActivityRequest specifies the ID of the activity to retrieve.
-type GetActivityRequest struct {
+func (x *GetPersonalRecordsResponse) GetRecords() []*PersonalRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+// GetTrainingLoadRequest selects the UTC days of the series. An unset to is
+// today and an unset from the year up to to.
+type GetTrainingLoadRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ActivityId    int32                  `protobuf:"varint,1,opt,name=activity_id,json=activityId,proto3" json:"activity_id,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetActivityRequest) Reset() {
-	*x = GetActivityRequest{}
-	mi := &file_activity_v1_activity_proto_msgTypes[10]
+func (x *GetTrainingLoadRequest) Reset() {
+	*x = GetTrainingLoadRequest{}
+	mi := &file_activity_v1_activity_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetActivityRequest) String() string {
+func (x *GetTrainingLoadRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetActivityRequest) ProtoMessage() {}
+func (*GetTrainingLoadRequest) ProtoMessage() {}
 
-func (x *GetActivityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_activity_v1_activity_proto_msgTypes[10]
+func (x *GetTrainingLoadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -776,42 +3462,53 @@ func (x *GetActivityRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetActivityRequest.ProtoReflect.Descriptor instead.
-func (*GetActivityRequest) Descriptor() ([]byte, []int) {
-	return file_activity_v1_activity_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use GetTrainingLoadRequest.ProtoReflect.Descriptor instead.
+func (*GetTrainingLoadRequest) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *GetActivityRequest) GetActivityId() int32 {
+func (x *GetTrainingLoadRequest) GetFrom() *timestamppb.Timestamp {
 	if x != nil {
-		return x.ActivityId
+		return x.From
 	}
-	return 0
+	return nil
 }
 
-type UpdateActivityRequest struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	ActivityId    int32                   `protobuf:"varint,1,opt,name=activity_id,json=activityId,proto3" json:"activity_id,omitempty"`
-	ActivityName  *wrapperspb.StringValue `protobuf:"bytes,2,opt,name=activity_name,json=activityName,proto3" json:"activity_name,omitempty"`
-	RideType      *wrapperspb.StringValue `protobuf:"bytes,3,opt,name=ride_type,json=rideType,proto3" json:"ride_type,omitempty"`
+func (x *GetTrainingLoadRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+// TrainingLoadDay is the training load of a UTC day. Load is the summed TSS
+// of the day's rides, or TRIMP for rides without power.
+type TrainingLoadDay struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Load          float64                `protobuf:"fixed64,2,opt,name=load,proto3" json:"load,omitempty"`
+	Ctl           float64                `protobuf:"fixed64,3,opt,name=ctl,proto3" json:"ctl,omitempty"` // Chronic training load (fitness), 42 day average
+	Atl           float64                `protobuf:"fixed64,4,opt,name=atl,proto3" json:"atl,omitempty"` // Acute training load (fatigue), 7 day average
+	Tsb           float64                `protobuf:"fixed64,5,opt,name=tsb,proto3" json:"tsb,omitempty"` // Training stress balance (form), CTL - ATL of the day before
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateActivityRequest) Reset() {
-	*x = UpdateActivityRequest{}
-	mi := &file_activity_v1_activity_proto_msgTypes[11]
+func (x *TrainingLoadDay) Reset() {
+	*x = TrainingLoadDay{}
+	mi := &file_activity_v1_activity_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateActivityRequest) String() string {
+func (x *TrainingLoadDay) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateActivityRequest) ProtoMessage() {}
+func (*TrainingLoadDay) ProtoMessage() {}
 
-func (x *UpdateActivityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_activity_v1_activity_proto_msgTypes[11]
+func (x *TrainingLoadDay) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -822,28 +3519,87 @@ func (x *UpdateActivityRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateActivityRequest.ProtoReflect.Descriptor instead.
-func (*UpdateActivityRequest) Descriptor() ([]byte, []int) {
-	return file_activity_v1_activity_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use TrainingLoadDay.ProtoReflect.Descriptor instead.
+func (*TrainingLoadDay) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *UpdateActivityRequest) GetActivityId() int32 {
+func (x *TrainingLoadDay) GetDate() *timestamppb.Timestamp {
 	if x != nil {
-		return x.ActivityId
+		return x.Date
+	}
+	return nil
+}
+
+func (x *TrainingLoadDay) GetLoad() float64 {
+	if x != nil {
+		return x.Load
 	}
 	return 0
 }
 
-func (x *UpdateActivityRequest) GetActivityName() *wrapperspb.StringValue {
+func (x *TrainingLoadDay) GetCtl() float64 {
 	if x != nil {
-		return x.ActivityName
+		return x.Ctl
 	}
-	return nil
+	return 0
 }
 
-func (x *UpdateActivityRequest) GetRideType() *wrapperspb.StringValue {
+func (x *TrainingLoadDay) GetAtl() float64 {
 	if x != nil {
-		return x.RideType
+		return x.Atl
+	}
+	return 0
+}
+
+func (x *TrainingLoadDay) GetTsb() float64 {
+	if x != nil {
+		return x.Tsb
+	}
+	return 0
+}
+
+// GetTrainingLoadResponse holds a day for every day of the range, in order.
+type GetTrainingLoadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Days          []*TrainingLoadDay     `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrainingLoadResponse) Reset() {
+	*x = GetTrainingLoadResponse{}
+	mi := &file_activity_v1_activity_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrainingLoadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrainingLoadResponse) ProtoMessage() {}
+
+func (x *GetTrainingLoadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_activity_v1_activity_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrainingLoadResponse.ProtoReflect.Descriptor instead.
+func (*GetTrainingLoadResponse) Descriptor() ([]byte, []int) {
+	return file_activity_v1_activity_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetTrainingLoadResponse) GetDays() []*TrainingLoadDay {
+	if x != nil {
+		return x.Days
 	}
 	return nil
 }
@@ -855,7 +3611,7 @@ const file_activity_v1_activity_proto_rawDesc = "" +
 	"\x1aactivity/v1/activity.proto\x12\vactivity.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/wrappers.proto\"#\n" +
 	"\x05Point\x12\f\n" +
 	"\x01x\x18\x01 \x01(\x01R\x01x\x12\f\n" +
-	"\x01y\x18\x02 \x01(\x01R\x01y\"\xf4\x01\n" +
+	"\x01y\x18\x02 \x01(\x01R\x01y\"\x8a\x02\n" +
 	"\x06Record\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x124\n" +
 	"\vcoordinates\x18\x02 \x01(\v2\x12.activity.v1.PointR\vcoordinates\x12\x14\n" +
@@ -865,7 +3621,8 @@ const file_activity_v1_activity_proto_rawDesc = "" +
 	"\bdistance\x18\x05 \x01(\x05R\bdistance\x12\x1d\n" +
 	"\n" +
 	"heart_rate\x18\x06 \x01(\x05R\theartRate\x12\x18\n" +
-	"\acadence\x18\a \x01(\x05R\acadence\"\xdb\x03\n" +
+	"\acadence\x18\a \x01(\x05R\acadence\x12\x14\n" +
+	"\x05power\x18\b \x01(\x05R\x05power\"\x90\x0f\n" +
 	"\x13GetActivityResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1d\n" +
 	"\n" +
@@ -885,7 +3642,107 @@ const file_activity_v1_activity_proto_rawDesc = "" +
 	"avgCadence\x12\x1f\n" +
 	"\vmax_cadence\x18\r \x01(\x01R\n" +
 	"maxCadence\x12\x1b\n" +
-	"\tride_type\x18\x0e \x01(\tR\brideType\"\x99\x02\n" +
+	"\tride_type\x18\x0e \x01(\tR\brideType\x12!\n" +
+	"\fduplicate_of\x18\x0f \x01(\x05R\vduplicateOf\x12 \n" +
+	"\vdescription\x18\x10 \x01(\tR\vdescription\x128\n" +
+	"\bsessions\x18\x11 \x03(\v2\x1c.activity.v1.ActivitySessionR\bsessions\x12$\n" +
+	"\x04laps\x18\x12 \x03(\v2\x10.activity.v1.LapR\x04laps\x128\n" +
+	"\tavg_power\x18\x13 \x01(\v2\x1b.google.protobuf.Int32ValueR\bavgPower\x128\n" +
+	"\tmax_power\x18\x14 \x01(\v2\x1b.google.protobuf.Int32ValueR\bmaxPower\x12F\n" +
+	"\x10normalized_power\x18\x15 \x01(\v2\x1b.google.protobuf.Int32ValueR\x0fnormalizedPower\x12I\n" +
+	"\x11variability_index\x18\x16 \x01(\v2\x1c.google.protobuf.DoubleValueR\x10variabilityIndex\x12G\n" +
+	"\x10intensity_factor\x18\x17 \x01(\v2\x1c.google.protobuf.DoubleValueR\x0fintensityFactor\x12P\n" +
+	"\x15training_stress_score\x18\x18 \x01(\v2\x1c.google.protobuf.DoubleValueR\x13trainingStressScore\x12-\n" +
+	"\x03ftp\x18\x19 \x01(\v2\x1b.google.protobuf.Int32ValueR\x03ftp\x12\x16\n" +
+	"\x06indoor\x18\x1a \x01(\bR\x06indoor\x125\n" +
+	"\adevices\x18\x1b \x03(\v2\x1b.activity.v1.ActivityDeviceR\adevices\x12\x1f\n" +
+	"\vmoving_time\x18\x1c \x01(\tR\n" +
+	"movingTime\x122\n" +
+	"\x06pauses\x18\x1d \x03(\v2\x1a.activity.v1.ActivityPauseR\x06pauses\x12,\n" +
+	"\x12ride_type_detected\x18\x1e \x01(\bR\x10rideTypeDetected\x12C\n" +
+	"\x0eelevation_gain\x18\x1f \x01(\v2\x1c.google.protobuf.DoubleValueR\relevationGain\x12C\n" +
+	"\x0eelevation_loss\x18  \x01(\v2\x1c.google.protobuf.DoubleValueR\relevationLoss\x12?\n" +
+	"\fmin_altitude\x18! \x01(\v2\x1c.google.protobuf.DoubleValueR\vminAltitude\x12?\n" +
+	"\fmax_altitude\x18\" \x01(\v2\x1c.google.protobuf.DoubleValueR\vmaxAltitude\x12E\n" +
+	"\x10heart_rate_zones\x18# \x01(\v2\x1b.activity.v1.HeartRateZonesR\x0eheartRateZones\x12=\n" +
+	"\vpower_curve\x18$ \x03(\v2\x1c.activity.v1.PowerCurvePointR\n" +
+	"powerCurve\x12:\n" +
+	"\fbest_efforts\x18% \x03(\v2\x17.activity.v1.BestEffortR\vbestEfforts\x12)\n" +
+	"\x10corrected_points\x18& \x01(\x05R\x0fcorrectedPoints\x12(\n" +
+	"\x05route\x18' \x03(\v2\x12.activity.v1.PointR\x05route\x12#\n" +
+	"\rencoded_route\x18( \x01(\tR\fencodedRoute\x12#\n" +
+	"\rfilled_points\x18) \x01(\x05R\ffilledPoints\"\xa6\x01\n" +
+	"\n" +
+	"BestEffort\x12\x1a\n" +
+	"\bdistance\x18\x01 \x01(\x05R\bdistance\x12\x18\n" +
+	"\aseconds\x18\x02 \x01(\x05R\aseconds\x129\n" +
+	"\n" +
+	"started_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12'\n" +
+	"\x0fpersonal_record\x18\x04 \x01(\bR\x0epersonalRecord\"\x94\x01\n" +
+	"\x0fPowerCurvePoint\x12\x1a\n" +
+	"\bduration\x18\x01 \x01(\x05R\bduration\x12\x14\n" +
+	"\x05watts\x18\x02 \x01(\x05R\x05watts\x12\x1f\n" +
+	"\vactivity_id\x18\x03 \x01(\x05R\n" +
+	"activityId\x12.\n" +
+	"\x04date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\"B\n" +
+	"\x0eHeartRateZones\x120\n" +
+	"\x05zones\x18\x01 \x03(\v2\x1a.activity.v1.HeartRateZoneR\x05zones\"\xa6\x01\n" +
+	"\rHeartRateZone\x12\x12\n" +
+	"\x04zone\x18\x01 \x01(\x05R\x04zone\x12$\n" +
+	"\x0emin_heart_rate\x18\x02 \x01(\x05R\fminHeartRate\x12A\n" +
+	"\x0emax_heart_rate\x18\x03 \x01(\v2\x1b.google.protobuf.Int32ValueR\fmaxHeartRate\x12\x18\n" +
+	"\aseconds\x18\x04 \x01(\x05R\aseconds\"\xbc\x01\n" +
+	"\rActivityPause\x129\n" +
+	"\n" +
+	"started_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x125\n" +
+	"\bended_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendedAt\x12!\n" +
+	"\felapsed_time\x18\x03 \x01(\tR\velapsedTime\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\"\xec\x04\n" +
+	"\x0eActivityDevice\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x18\n" +
+	"\acreator\x18\x02 \x01(\bR\acreator\x12\"\n" +
+	"\fmanufacturer\x18\x03 \x01(\tR\fmanufacturer\x125\n" +
+	"\aproduct\x18\x04 \x01(\v2\x1b.google.protobuf.Int32ValueR\aproduct\x12!\n" +
+	"\fproduct_name\x18\x05 \x01(\tR\vproductName\x12@\n" +
+	"\rserial_number\x18\x06 \x01(\v2\x1b.google.protobuf.Int64ValueR\fserialNumber\x12)\n" +
+	"\x10software_version\x18\a \x01(\tR\x0fsoftwareVersion\x12F\n" +
+	"\x10hardware_version\x18\b \x01(\v2\x1b.google.protobuf.Int32ValueR\x0fhardwareVersion\x12\x1f\n" +
+	"\vdevice_type\x18\t \x01(\tR\n" +
+	"deviceType\x12\x1f\n" +
+	"\vsource_type\x18\n" +
+	" \x01(\tR\n" +
+	"sourceType\x12G\n" +
+	"\x11ant_device_number\x18\v \x01(\v2\x1b.google.protobuf.Int32ValueR\x0fantDeviceNumber\x12E\n" +
+	"\x0fbattery_voltage\x18\f \x01(\v2\x1c.google.protobuf.DoubleValueR\x0ebatteryVoltage\x12%\n" +
+	"\x0ebattery_status\x18\r \x01(\tR\rbatteryStatus\"\xaa\x02\n" +
+	"\x0fActivitySession\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x14\n" +
+	"\x05sport\x18\x02 \x01(\tR\x05sport\x12\x1b\n" +
+	"\tsub_sport\x18\x03 \x01(\tR\bsubSport\x129\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12!\n" +
+	"\felapsed_time\x18\x06 \x01(\tR\velapsedTime\x12\x1d\n" +
+	"\n" +
+	"timer_time\x18\a \x01(\tR\ttimerTime\x12\x1a\n" +
+	"\bdistance\x18\b \x01(\x01R\bdistance\"\xc5\x03\n" +
+	"\x03Lap\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x18\n" +
+	"\atrigger\x18\x02 \x01(\tR\atrigger\x129\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12!\n" +
+	"\felapsed_time\x18\x05 \x01(\tR\velapsedTime\x12\x1d\n" +
+	"\n" +
+	"timer_time\x18\x06 \x01(\tR\ttimerTime\x12\x1a\n" +
+	"\bdistance\x18\a \x01(\x01R\bdistance\x12\x1b\n" +
+	"\tavg_speed\x18\b \x01(\x01R\bavgSpeed\x12\x1b\n" +
+	"\tmax_speed\x18\t \x01(\x01R\bmaxSpeed\x12$\n" +
+	"\x0eavg_heart_rate\x18\n" +
+	" \x01(\x05R\favgHeartRate\x12$\n" +
+	"\x0emax_heart_rate\x18\v \x01(\x05R\fmaxHeartRate\x12\x1b\n" +
+	"\tavg_power\x18\f \x01(\x05R\bavgPower\x12\x1b\n" +
+	"\tmax_power\x18\r \x01(\x05R\bmaxPower\"\x97\x03\n" +
 	"\x0fActivitySummary\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x129\n" +
 	"\n" +
@@ -896,40 +3753,189 @@ const file_activity_v1_activity_proto_rawDesc = "" +
 	"\tmax_speed\x18\x06 \x01(\x01R\bmaxSpeed\x12!\n" +
 	"\felapsed_time\x18\a \x01(\tR\velapsedTime\x12\x1d\n" +
 	"\n" +
-	"total_time\x18\b \x01(\tR\ttotalTime\"c\n" +
+	"total_time\x18\b \x01(\tR\ttotalTime\x12\x16\n" +
+	"\x06indoor\x18\t \x01(\bR\x06indoor\x12\x1f\n" +
+	"\vmoving_time\x18\n" +
+	" \x01(\tR\n" +
+	"movingTime\x12C\n" +
+	"\x0eelevation_gain\x18\v \x01(\v2\x1c.google.protobuf.DoubleValueR\relevationGain\"\xa5\x01\n" +
 	"\x17UploadActivitiesRequest\x12\x1f\n" +
 	"\n" +
 	"file_chunk\x18\x01 \x01(\fH\x00R\tfileChunk\x12\x1c\n" +
-	"\bmetadata\x18\x02 \x01(\tH\x00R\bmetadataB\t\n" +
-	"\apayload\"2\n" +
+	"\bmetadata\x18\x02 \x01(\tH\x00R\bmetadata\x12@\n" +
+	"\vfile_header\x18\x03 \x01(\v2\x1d.activity.v1.UploadFileHeaderH\x00R\n" +
+	"fileHeaderB\t\n" +
+	"\apayload\"\xa2\x01\n" +
+	"\x10UploadFileHeader\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x16\n" +
+	"\x06sha256\x18\x04 \x01(\tR\x06sha256\x12#\n" +
+	"\rlast_modified\x18\x05 \x01(\x03R\flastModified\"\xc8\x01\n" +
+	"\x10UploadFileResult\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x1f\n" +
+	"\vactivity_id\x18\x02 \x01(\x05R\n" +
+	"activityId\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12G\n" +
+	"\x0efailure_reason\x18\x04 \x01(\x0e2 .activity.v1.UploadFailureReasonR\rfailureReason\x12\x18\n" +
+	"\askipped\x18\x05 \x01(\bR\askipped\"\xbd\x01\n" +
 	"\x18UploadActivitiesResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\"\x93\x01\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12!\n" +
+	"\factivity_ids\x18\x02 \x03(\x05R\vactivityIds\x127\n" +
+	"\aresults\x18\x03 \x03(\v2\x1d.activity.v1.UploadFileResultR\aresults\x12-\n" +
+	"\x04jobs\x18\x04 \x03(\v2\x19.activity.v1.IngestionJobR\x04jobs\"\xc0\x03\n" +
+	"\fIngestionJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x127\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1f.activity.v1.IngestionJobStatusR\x06status\x12\x1f\n" +
+	"\vactivity_id\x18\x04 \x01(\x05R\n" +
+	"activityId\x12\x18\n" +
+	"\askipped\x18\x05 \x01(\bR\askipped\x12G\n" +
+	"\x0efailure_reason\x18\x06 \x01(\x0e2 .activity.v1.UploadFailureReasonR\rfailureReason\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"started_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\"2\n" +
+	"\x17GetIngestionJobsRequest\x12\x17\n" +
+	"\ajob_ids\x18\x01 \x03(\x03R\x06jobIds\"I\n" +
+	"\x18GetIngestionJobsResponse\x12-\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x19.activity.v1.IngestionJobR\x04jobs\"\x93\x01\n" +
 	"\x19UploadActivitiesUnaryFile\x12\x12\n" +
 	"\x04data\x18\x01 \x01(\fR\x04data\x12\x1a\n" +
 	"\bfilename\x18\x02 \x01(\tR\bfilename\x12!\n" +
 	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12#\n" +
 	"\rlast_modified\x18\x04 \x01(\x03R\flastModified\"\\\n" +
 	"\x1cUploadActivitiesUnaryRequest\x12<\n" +
-	"\x05files\x18\x01 \x03(\v2&.activity.v1.UploadActivitiesUnaryFileR\x05files\"U\n" +
+	"\x05files\x18\x01 \x03(\v2&.activity.v1.UploadActivitiesUnaryFileR\x05files\"f\n" +
+	"\x19UploadArchiveChunkRequest\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x05chunk\x18\x03 \x01(\fR\x05chunk\"M\n" +
+	"\x1aUploadArchiveChunkResponse\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\"^\n" +
+	"\x14ImportArchiveRequest\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x1b\n" +
+	"\tupload_id\x18\x03 \x01(\tR\buploadIdJ\x04\b\x02\x10\x03R\aarchive\"\x82\x01\n" +
+	"\x15ImportArchiveProgress\x12\x1c\n" +
+	"\tprocessed\x18\x01 \x01(\x05R\tprocessed\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x125\n" +
+	"\x06result\x18\x03 \x01(\v2\x1d.activity.v1.UploadFileResultR\x06result\"h\n" +
+	"\x1aReprocessActivitiesRequest\x12\x1f\n" +
+	"\vactivity_id\x18\x01 \x01(\x05R\n" +
+	"activityId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03all\x18\x03 \x01(\bR\x03all\"\x88\x01\n" +
+	"\x1bReprocessActivitiesProgress\x12\x1c\n" +
+	"\tprocessed\x18\x01 \x01(\x05R\tprocessed\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x125\n" +
+	"\x06result\x18\x03 \x01(\v2\x1d.activity.v1.UploadFileResultR\x06result\"U\n" +
 	"\x15GetActivitiesResponse\x12<\n" +
 	"\n" +
 	"activities\x18\x01 \x03(\v2\x1c.activity.v1.ActivitySummaryR\n" +
 	"activities\"\x16\n" +
-	"\x14GetActivitiesRequest\"5\n" +
+	"\x14GetActivitiesRequest\"r\n" +
 	"\x12GetActivityRequest\x12\x1f\n" +
 	"\vactivity_id\x18\x01 \x01(\x05R\n" +
-	"activityId\"\xb6\x01\n" +
+	"activityId\x12\x1c\n" +
+	"\ttolerance\x18\x02 \x01(\x01R\ttolerance\x12\x1d\n" +
+	"\n" +
+	"max_points\x18\x03 \x01(\x05R\tmaxPoints\"9\n" +
+	"\x16GetActivityLapsRequest\x12\x1f\n" +
+	"\vactivity_id\x18\x01 \x01(\x05R\n" +
+	"activityId\"?\n" +
+	"\x17GetActivityLapsResponse\x12$\n" +
+	"\x04laps\x18\x01 \x03(\v2\x10.activity.v1.LapR\x04laps\"9\n" +
+	"\x16GetOriginalFileRequest\x12\x1f\n" +
+	"\vactivity_id\x18\x01 \x01(\x05R\n" +
+	"activityId\"l\n" +
+	"\x17GetOriginalFileResponse\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"\xb6\x01\n" +
 	"\x15UpdateActivityRequest\x12\x1f\n" +
 	"\vactivity_id\x18\x01 \x01(\x05R\n" +
 	"activityId\x12A\n" +
 	"\ractivity_name\x18\x02 \x01(\v2\x1c.google.protobuf.StringValueR\factivityName\x129\n" +
-	"\tride_type\x18\x03 \x01(\v2\x1c.google.protobuf.StringValueR\brideType2\xe7\x03\n" +
+	"\tride_type\x18\x03 \x01(\v2\x1c.google.protobuf.StringValueR\brideType\"8\n" +
+	"\x15DeleteActivityRequest\x12\x1f\n" +
+	"\vactivity_id\x18\x01 \x01(\x05R\n" +
+	"activityId\"\x18\n" +
+	"\x16DeleteActivityResponse\"\x18\n" +
+	"\x16GetUserSettingsRequest\"\xb9\x02\n" +
+	"\fUserSettings\x12-\n" +
+	"\x03ftp\x18\x01 \x01(\v2\x1b.google.protobuf.Int32ValueR\x03ftp\x12A\n" +
+	"\x0emax_heart_rate\x18\x02 \x01(\v2\x1b.google.protobuf.Int32ValueR\fmaxHeartRate\x12M\n" +
+	"\x14threshold_heart_rate\x18\x03 \x01(\v2\x1b.google.protobuf.Int32ValueR\x12thresholdHeartRate\x123\n" +
+	"\x16heart_rate_zone_method\x18\x04 \x01(\tR\x13heartRateZoneMethod\x123\n" +
+	"\x16heart_rate_zone_bounds\x18\x05 \x03(\x05R\x13heartRateZoneBounds\"R\n" +
+	"\x19UpdateUserSettingsRequest\x125\n" +
+	"\bsettings\x18\x01 \x01(\v2\x19.activity.v1.UserSettingsR\bsettings\"\x8f\x01\n" +
+	"\x14GetPowerCurveRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x1b\n" +
+	"\tride_type\x18\x03 \x01(\tR\brideType\"M\n" +
+	"\x15GetPowerCurveResponse\x124\n" +
+	"\x06points\x18\x01 \x03(\v2\x1c.activity.v1.PowerCurvePointR\x06points\"\x1b\n" +
+	"\x19GetPersonalRecordsRequest\"\xf7\x01\n" +
+	"\x0ePersonalRecord\x12\x1a\n" +
+	"\bdistance\x18\x01 \x01(\x05R\bdistance\x12\x18\n" +
+	"\aseconds\x18\x02 \x01(\x05R\aseconds\x129\n" +
+	"\n" +
+	"started_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12\x1f\n" +
+	"\vactivity_id\x18\x04 \x01(\x05R\n" +
+	"activityId\x12#\n" +
+	"\ractivity_name\x18\x05 \x01(\tR\factivityName\x12.\n" +
+	"\x04date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\"S\n" +
+	"\x1aGetPersonalRecordsResponse\x125\n" +
+	"\arecords\x18\x01 \x03(\v2\x1b.activity.v1.PersonalRecordR\arecords\"t\n" +
+	"\x16GetTrainingLoadRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"\x8b\x01\n" +
+	"\x0fTrainingLoadDay\x12.\n" +
+	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x12\n" +
+	"\x04load\x18\x02 \x01(\x01R\x04load\x12\x10\n" +
+	"\x03ctl\x18\x03 \x01(\x01R\x03ctl\x12\x10\n" +
+	"\x03atl\x18\x04 \x01(\x01R\x03atl\x12\x10\n" +
+	"\x03tsb\x18\x05 \x01(\x01R\x03tsb\"K\n" +
+	"\x17GetTrainingLoadResponse\x120\n" +
+	"\x04days\x18\x01 \x03(\v2\x1c.activity.v1.TrainingLoadDayR\x04days*\xa7\x02\n" +
+	"\x13UploadFailureReason\x12%\n" +
+	"!UPLOAD_FAILURE_REASON_UNSPECIFIED\x10\x00\x12,\n" +
+	"(UPLOAD_FAILURE_REASON_UNSUPPORTED_FORMAT\x10\x01\x12&\n" +
+	"\"UPLOAD_FAILURE_REASON_CORRUPT_FILE\x10\x02\x12$\n" +
+	" UPLOAD_FAILURE_REASON_NO_RECORDS\x10\x03\x12#\n" +
+	"\x1fUPLOAD_FAILURE_REASON_DUPLICATE\x10\x04\x12$\n" +
+	" UPLOAD_FAILURE_REASON_EMPTY_FILE\x10\x05\x12\"\n" +
+	"\x1eUPLOAD_FAILURE_REASON_INTERNAL\x10\x06*\xbd\x01\n" +
+	"\x12IngestionJobStatus\x12$\n" +
+	" INGESTION_JOB_STATUS_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bINGESTION_JOB_STATUS_QUEUED\x10\x01\x12 \n" +
+	"\x1cINGESTION_JOB_STATUS_RUNNING\x10\x02\x12\x1d\n" +
+	"\x19INGESTION_JOB_STATUS_DONE\x10\x03\x12\x1f\n" +
+	"\x1bINGESTION_JOB_STATUS_FAILED\x10\x042\xe9\f\n" +
 	"\x0fActivityService\x12X\n" +
 	"\rGetActivities\x12!.activity.v1.GetActivitiesRequest\x1a\".activity.v1.GetActivitiesResponse\"\x00\x12R\n" +
-	"\vGetActivity\x12\x1f.activity.v1.GetActivityRequest\x1a .activity.v1.GetActivityResponse\"\x00\x12X\n" +
-	"\x0eUpdateActivity\x12\".activity.v1.UpdateActivityRequest\x1a .activity.v1.GetActivityResponse\"\x00\x12a\n" +
+	"\vGetActivity\x12\x1f.activity.v1.GetActivityRequest\x1a .activity.v1.GetActivityResponse\"\x00\x12^\n" +
+	"\x0fGetActivityLaps\x12#.activity.v1.GetActivityLapsRequest\x1a$.activity.v1.GetActivityLapsResponse\"\x00\x12X\n" +
+	"\x0eUpdateActivity\x12\".activity.v1.UpdateActivityRequest\x1a .activity.v1.GetActivityResponse\"\x00\x12[\n" +
+	"\x0eDeleteActivity\x12\".activity.v1.DeleteActivityRequest\x1a#.activity.v1.DeleteActivityResponse\"\x00\x12^\n" +
+	"\x0fGetOriginalFile\x12#.activity.v1.GetOriginalFileRequest\x1a$.activity.v1.GetOriginalFileResponse\"\x00\x12a\n" +
 	"\x10UploadActivities\x12$.activity.v1.UploadActivitiesRequest\x1a%.activity.v1.UploadActivitiesResponse(\x01\x12i\n" +
-	"\x15UploadActivitiesUnary\x12).activity.v1.UploadActivitiesUnaryRequest\x1a%.activity.v1.UploadActivitiesResponseB8Z6github.com/notaduck/backend/gen/activity/v1;activityv1b\x06proto3"
+	"\x15UploadActivitiesUnary\x12).activity.v1.UploadActivitiesUnaryRequest\x1a%.activity.v1.UploadActivitiesResponse\x12g\n" +
+	"\x12UploadArchiveChunk\x12&.activity.v1.UploadArchiveChunkRequest\x1a'.activity.v1.UploadArchiveChunkResponse\"\x00\x12X\n" +
+	"\rImportArchive\x12!.activity.v1.ImportArchiveRequest\x1a\".activity.v1.ImportArchiveProgress0\x01\x12j\n" +
+	"\x13ReprocessActivities\x12'.activity.v1.ReprocessActivitiesRequest\x1a(.activity.v1.ReprocessActivitiesProgress0\x01\x12a\n" +
+	"\x10GetIngestionJobs\x12$.activity.v1.GetIngestionJobsRequest\x1a%.activity.v1.GetIngestionJobsResponse\"\x00\x12S\n" +
+	"\x0fGetUserSettings\x12#.activity.v1.GetUserSettingsRequest\x1a\x19.activity.v1.UserSettings\"\x00\x12Y\n" +
+	"\x12UpdateUserSettings\x12&.activity.v1.UpdateUserSettingsRequest\x1a\x19.activity.v1.UserSettings\"\x00\x12X\n" +
+	"\rGetPowerCurve\x12!.activity.v1.GetPowerCurveRequest\x1a\".activity.v1.GetPowerCurveResponse\"\x00\x12g\n" +
+	"\x12GetPersonalRecords\x12&.activity.v1.GetPersonalRecordsRequest\x1a'.activity.v1.GetPersonalRecordsResponse\"\x00\x12^\n" +
+	"\x0fGetTrainingLoad\x12#.activity.v1.GetTrainingLoadRequest\x1a$.activity.v1.GetTrainingLoadResponse\"\x00B8Z6github.com/notaduck/backend/gen/activity/v1;activityv1b\x06proto3"
 
 var (
 	file_activity_v1_activity_proto_rawDescOnce sync.Once
@@ -943,47 +3949,175 @@ func file_activity_v1_activity_proto_rawDescGZIP() []byte {
 	return file_activity_v1_activity_proto_rawDescData
 }
 
-var file_activity_v1_activity_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_activity_v1_activity_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_activity_v1_activity_proto_msgTypes = make([]protoimpl.MessageInfo, 48)
 var file_activity_v1_activity_proto_goTypes = []any{
-	(*Point)(nil),                        // 0: activity.v1.Point
-	(*Record)(nil),                       // 1: activity.v1.Record
-	(*GetActivityResponse)(nil),          // 2: activity.v1.GetActivityResponse
-	(*ActivitySummary)(nil),              // 3: activity.v1.ActivitySummary
-	(*UploadActivitiesRequest)(nil),      // 4: activity.v1.UploadActivitiesRequest
-	(*UploadActivitiesResponse)(nil),     // 5: activity.v1.UploadActivitiesResponse
-	(*UploadActivitiesUnaryFile)(nil),    // 6: activity.v1.UploadActivitiesUnaryFile
-	(*UploadActivitiesUnaryRequest)(nil), // 7: activity.v1.UploadActivitiesUnaryRequest
-	(*GetActivitiesResponse)(nil),        // 8: activity.v1.GetActivitiesResponse
-	(*GetActivitiesRequest)(nil),         // 9: activity.v1.GetActivitiesRequest
-	(*GetActivityRequest)(nil),           // 10: activity.v1.GetActivityRequest
-	(*UpdateActivityRequest)(nil),        // 11: activity.v1.UpdateActivityRequest
-	(*timestamppb.Timestamp)(nil),        // 12: google.protobuf.Timestamp
-	(*wrapperspb.StringValue)(nil),       // 13: google.protobuf.StringValue
+	(UploadFailureReason)(0),             // 0: activity.v1.UploadFailureReason
+	(IngestionJobStatus)(0),              // 1: activity.v1.IngestionJobStatus
+	(*Point)(nil),                        // 2: activity.v1.Point
+	(*Record)(nil),                       // 3: activity.v1.Record
+	(*GetActivityResponse)(nil),          // 4: activity.v1.GetActivityResponse
+	(*BestEffort)(nil),                   // 5: activity.v1.BestEffort
+	(*PowerCurvePoint)(nil),              // 6: activity.v1.PowerCurvePoint
+	(*HeartRateZones)(nil),               // 7: activity.v1.HeartRateZones
+	(*HeartRateZone)(nil),                // 8: activity.v1.HeartRateZone
+	(*ActivityPause)(nil),                // 9: activity.v1.ActivityPause
+	(*ActivityDevice)(nil),               // 10: activity.v1.ActivityDevice
+	(*ActivitySession)(nil),              // 11: activity.v1.ActivitySession
+	(*Lap)(nil),                          // 12: activity.v1.Lap
+	(*ActivitySummary)(nil),              // 13: activity.v1.ActivitySummary
+	(*UploadActivitiesRequest)(nil),      // 14: activity.v1.UploadActivitiesRequest
+	(*UploadFileHeader)(nil),             // 15: activity.v1.UploadFileHeader
+	(*UploadFileResult)(nil),             // 16: activity.v1.UploadFileResult
+	(*UploadActivitiesResponse)(nil),     // 17: activity.v1.UploadActivitiesResponse
+	(*IngestionJob)(nil),                 // 18: activity.v1.IngestionJob
+	(*GetIngestionJobsRequest)(nil),      // 19: activity.v1.GetIngestionJobsRequest
+	(*GetIngestionJobsResponse)(nil),     // 20: activity.v1.GetIngestionJobsResponse
+	(*UploadActivitiesUnaryFile)(nil),    // 21: activity.v1.UploadActivitiesUnaryFile
+	(*UploadActivitiesUnaryRequest)(nil), // 22: activity.v1.UploadActivitiesUnaryRequest
+	(*UploadArchiveChunkRequest)(nil),    // 23: activity.v1.UploadArchiveChunkRequest
+	(*UploadArchiveChunkResponse)(nil),   // 24: activity.v1.UploadArchiveChunkResponse
+	(*ImportArchiveRequest)(nil),         // 25: activity.v1.ImportArchiveRequest
+	(*ImportArchiveProgress)(nil),        // 26: activity.v1.ImportArchiveProgress
+	(*ReprocessActivitiesRequest)(nil),   // 27: activity.v1.ReprocessActivitiesRequest
+	(*ReprocessActivitiesProgress)(nil),  // 28: activity.v1.ReprocessActivitiesProgress
+	(*GetActivitiesResponse)(nil),        // 29: activity.v1.GetActivitiesResponse
+	(*GetActivitiesRequest)(nil),         // 30: activity.v1.GetActivitiesRequest
+	(*GetActivityRequest)(nil),           // 31: activity.v1.GetActivityRequest
+	(*GetActivityLapsRequest)(nil),       // 32: activity.v1.GetActivityLapsRequest
+	(*GetActivityLapsResponse)(nil),      // 33: activity.v1.GetActivityLapsResponse
+	(*GetOriginalFileRequest)(nil),       // 34: activity.v1.GetOriginalFileRequest
+	(*GetOriginalFileResponse)(nil),      // 35: activity.v1.GetOriginalFileResponse
+	(*UpdateActivityRequest)(nil),        // 36: activity.v1.UpdateActivityRequest
+	(*DeleteActivityRequest)(nil),        // 37: activity.v1.DeleteActivityRequest
+	(*DeleteActivityResponse)(nil),       // 38: activity.v1.DeleteActivityResponse
+	(*GetUserSettingsRequest)(nil),       // 39: activity.v1.GetUserSettingsRequest
+	(*UserSettings)(nil),                 // 40: activity.v1.UserSettings
+	(*UpdateUserSettingsRequest)(nil),    // 41: activity.v1.UpdateUserSettingsRequest
+	(*GetPowerCurveRequest)(nil),         // 42: activity.v1.GetPowerCurveRequest
+	(*GetPowerCurveResponse)(nil),        // 43: activity.v1.GetPowerCurveResponse
+	(*GetPersonalRecordsRequest)(nil),    // 44: activity.v1.GetPersonalRecordsRequest
+	(*PersonalRecord)(nil),               // 45: activity.v1.PersonalRecord
+	(*GetPersonalRecordsResponse)(nil),   // 46: activity.v1.GetPersonalRecordsResponse
+	(*GetTrainingLoadRequest)(nil),       // 47: activity.v1.GetTrainingLoadRequest
+	(*TrainingLoadDay)(nil),              // 48: activity.v1.TrainingLoadDay
+	(*GetTrainingLoadResponse)(nil),      // 49: activity.v1.GetTrainingLoadResponse
+	(*timestamppb.Timestamp)(nil),        // 50: google.protobuf.Timestamp
+	(*wrapperspb.Int32Value)(nil),        // 51: google.protobuf.Int32Value
+	(*wrapperspb.DoubleValue)(nil),       // 52: google.protobuf.DoubleValue
+	(*wrapperspb.Int64Value)(nil),        // 53: google.protobuf.Int64Value
+	(*wrapperspb.StringValue)(nil),       // 54: google.protobuf.StringValue
 }
 var file_activity_v1_activity_proto_depIdxs = []int32{
-	0,  // 0: activity.v1.Record.coordinates:type_name -> activity.v1.Point
-	12, // 1: activity.v1.Record.time_stamp:type_name -> google.protobuf.Timestamp
-	1,  // 2: activity.v1.GetActivityResponse.records:type_name -> activity.v1.Record
-	12, // 3: activity.v1.ActivitySummary.created_at:type_name -> google.protobuf.Timestamp
-	6,  // 4: activity.v1.UploadActivitiesUnaryRequest.files:type_name -> activity.v1.UploadActivitiesUnaryFile
-	3,  // 5: activity.v1.GetActivitiesResponse.activities:type_name -> activity.v1.ActivitySummary
-	13, // 6: activity.v1.UpdateActivityRequest.activity_name:type_name -> google.protobuf.StringValue
-	13, // 7: activity.v1.UpdateActivityRequest.ride_type:type_name -> google.protobuf.StringValue
-	9,  // 8: activity.v1.ActivityService.GetActivities:input_type -> activity.v1.GetActivitiesRequest
-	10, // 9: activity.v1.ActivityService.GetActivity:input_type -> activity.v1.GetActivityRequest
-	11, // 10: activity.v1.ActivityService.UpdateActivity:input_type -> activity.v1.UpdateActivityRequest
-	4,  // 11: activity.v1.ActivityService.UploadActivities:input_type -> activity.v1.UploadActivitiesRequest
-	7,  // 12: activity.v1.ActivityService.UploadActivitiesUnary:input_type -> activity.v1.UploadActivitiesUnaryRequest
-	8,  // 13: activity.v1.ActivityService.GetActivities:output_type -> activity.v1.GetActivitiesResponse
-	2,  // 14: activity.v1.ActivityService.GetActivity:output_type -> activity.v1.GetActivityResponse
-	2,  // 15: activity.v1.ActivityService.UpdateActivity:output_type -> activity.v1.GetActivityResponse
-	5,  // 16: activity.v1.ActivityService.UploadActivities:output_type -> activity.v1.UploadActivitiesResponse
-	5,  // 17: activity.v1.ActivityService.UploadActivitiesUnary:output_type -> activity.v1.UploadActivitiesResponse
-	13, // [13:18] is the sub-list for method output_type
-	8,  // [8:13] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	2,  // 0: activity.v1.Record.coordinates:type_name -> activity.v1.Point
+	50, // 1: activity.v1.Record.time_stamp:type_name -> google.protobuf.Timestamp
+	3,  // 2: activity.v1.GetActivityResponse.records:type_name -> activity.v1.Record
+	11, // 3: activity.v1.GetActivityResponse.sessions:type_name -> activity.v1.ActivitySession
+	12, // 4: activity.v1.GetActivityResponse.laps:type_name -> activity.v1.Lap
+	51, // 5: activity.v1.GetActivityResponse.avg_power:type_name -> google.protobuf.Int32Value
+	51, // 6: activity.v1.GetActivityResponse.max_power:type_name -> google.protobuf.Int32Value
+	51, // 7: activity.v1.GetActivityResponse.normalized_power:type_name -> google.protobuf.Int32Value
+	52, // 8: activity.v1.GetActivityResponse.variability_index:type_name -> google.protobuf.DoubleValue
+	52, // 9: activity.v1.GetActivityResponse.intensity_factor:type_name -> google.protobuf.DoubleValue
+	52, // 10: activity.v1.GetActivityResponse.training_stress_score:type_name -> google.protobuf.DoubleValue
+	51, // 11: activity.v1.GetActivityResponse.ftp:type_name -> google.protobuf.Int32Value
+	10, // 12: activity.v1.GetActivityResponse.devices:type_name -> activity.v1.ActivityDevice
+	9,  // 13: activity.v1.GetActivityResponse.pauses:type_name -> activity.v1.ActivityPause
+	52, // 14: activity.v1.GetActivityResponse.elevation_gain:type_name -> google.protobuf.DoubleValue
+	52, // 15: activity.v1.GetActivityResponse.elevation_loss:type_name -> google.protobuf.DoubleValue
+	52, // 16: activity.v1.GetActivityResponse.min_altitude:type_name -> google.protobuf.DoubleValue
+	52, // 17: activity.v1.GetActivityResponse.max_altitude:type_name -> google.protobuf.DoubleValue
+	7,  // 18: activity.v1.GetActivityResponse.heart_rate_zones:type_name -> activity.v1.HeartRateZones
+	6,  // 19: activity.v1.GetActivityResponse.power_curve:type_name -> activity.v1.PowerCurvePoint
+	5,  // 20: activity.v1.GetActivityResponse.best_efforts:type_name -> activity.v1.BestEffort
+	2,  // 21: activity.v1.GetActivityResponse.route:type_name -> activity.v1.Point
+	50, // 22: activity.v1.BestEffort.started_at:type_name -> google.protobuf.Timestamp
+	50, // 23: activity.v1.PowerCurvePoint.date:type_name -> google.protobuf.Timestamp
+	8,  // 24: activity.v1.HeartRateZones.zones:type_name -> activity.v1.HeartRateZone
+	51, // 25: activity.v1.HeartRateZone.max_heart_rate:type_name -> google.protobuf.Int32Value
+	50, // 26: activity.v1.ActivityPause.started_at:type_name -> google.protobuf.Timestamp
+	50, // 27: activity.v1.ActivityPause.ended_at:type_name -> google.protobuf.Timestamp
+	51, // 28: activity.v1.ActivityDevice.product:type_name -> google.protobuf.Int32Value
+	53, // 29: activity.v1.ActivityDevice.serial_number:type_name -> google.protobuf.Int64Value
+	51, // 30: activity.v1.ActivityDevice.hardware_version:type_name -> google.protobuf.Int32Value
+	51, // 31: activity.v1.ActivityDevice.ant_device_number:type_name -> google.protobuf.Int32Value
+	52, // 32: activity.v1.ActivityDevice.battery_voltage:type_name -> google.protobuf.DoubleValue
+	50, // 33: activity.v1.ActivitySession.start_time:type_name -> google.protobuf.Timestamp
+	50, // 34: activity.v1.ActivitySession.end_time:type_name -> google.protobuf.Timestamp
+	50, // 35: activity.v1.Lap.start_time:type_name -> google.protobuf.Timestamp
+	50, // 36: activity.v1.Lap.end_time:type_name -> google.protobuf.Timestamp
+	50, // 37: activity.v1.ActivitySummary.created_at:type_name -> google.protobuf.Timestamp
+	52, // 38: activity.v1.ActivitySummary.elevation_gain:type_name -> google.protobuf.DoubleValue
+	15, // 39: activity.v1.UploadActivitiesRequest.file_header:type_name -> activity.v1.UploadFileHeader
+	0,  // 40: activity.v1.UploadFileResult.failure_reason:type_name -> activity.v1.UploadFailureReason
+	16, // 41: activity.v1.UploadActivitiesResponse.results:type_name -> activity.v1.UploadFileResult
+	18, // 42: activity.v1.UploadActivitiesResponse.jobs:type_name -> activity.v1.IngestionJob
+	1,  // 43: activity.v1.IngestionJob.status:type_name -> activity.v1.IngestionJobStatus
+	0,  // 44: activity.v1.IngestionJob.failure_reason:type_name -> activity.v1.UploadFailureReason
+	50, // 45: activity.v1.IngestionJob.created_at:type_name -> google.protobuf.Timestamp
+	50, // 46: activity.v1.IngestionJob.started_at:type_name -> google.protobuf.Timestamp
+	50, // 47: activity.v1.IngestionJob.finished_at:type_name -> google.protobuf.Timestamp
+	18, // 48: activity.v1.GetIngestionJobsResponse.jobs:type_name -> activity.v1.IngestionJob
+	21, // 49: activity.v1.UploadActivitiesUnaryRequest.files:type_name -> activity.v1.UploadActivitiesUnaryFile
+	16, // 50: activity.v1.ImportArchiveProgress.result:type_name -> activity.v1.UploadFileResult
+	16, // 51: activity.v1.ReprocessActivitiesProgress.result:type_name -> activity.v1.UploadFileResult
+	13, // 52: activity.v1.GetActivitiesResponse.activities:type_name -> activity.v1.ActivitySummary
+	12, // 53: activity.v1.GetActivityLapsResponse.laps:type_name -> activity.v1.Lap
+	54, // 54: activity.v1.UpdateActivityRequest.activity_name:type_name -> google.protobuf.StringValue
+	54, // 55: activity.v1.UpdateActivityRequest.ride_type:type_name -> google.protobuf.StringValue
+	51, // 56: activity.v1.UserSettings.ftp:type_name -> google.protobuf.Int32Value
+	51, // 57: activity.v1.UserSettings.max_heart_rate:type_name -> google.protobuf.Int32Value
+	51, // 58: activity.v1.UserSettings.threshold_heart_rate:type_name -> google.protobuf.Int32Value
+	40, // 59: activity.v1.UpdateUserSettingsRequest.settings:type_name -> activity.v1.UserSettings
+	50, // 60: activity.v1.GetPowerCurveRequest.from:type_name -> google.protobuf.Timestamp
+	50, // 61: activity.v1.GetPowerCurveRequest.to:type_name -> google.protobuf.Timestamp
+	6,  // 62: activity.v1.GetPowerCurveResponse.points:type_name -> activity.v1.PowerCurvePoint
+	50, // 63: activity.v1.PersonalRecord.started_at:type_name -> google.protobuf.Timestamp
+	50, // 64: activity.v1.PersonalRecord.date:type_name -> google.protobuf.Timestamp
+	45, // 65: activity.v1.GetPersonalRecordsResponse.records:type_name -> activity.v1.PersonalRecord
+	50, // 66: activity.v1.GetTrainingLoadRequest.from:type_name -> google.protobuf.Timestamp
+	50, // 67: activity.v1.GetTrainingLoadRequest.to:type_name -> google.protobuf.Timestamp
+	50, // 68: activity.v1.TrainingLoadDay.date:type_name -> google.protobuf.Timestamp
+	48, // 69: activity.v1.GetTrainingLoadResponse.days:type_name -> activity.v1.TrainingLoadDay
+	30, // 70: activity.v1.ActivityService.GetActivities:input_type -> activity.v1.GetActivitiesRequest
+	31, // 71: activity.v1.ActivityService.GetActivity:input_type -> activity.v1.GetActivityRequest
+	32, // 72: activity.v1.ActivityService.GetActivityLaps:input_type -> activity.v1.GetActivityLapsRequest
+	36, // 73: activity.v1.ActivityService.UpdateActivity:input_type -> activity.v1.UpdateActivityRequest
+	37, // 74: activity.v1.ActivityService.DeleteActivity:input_type -> activity.v1.DeleteActivityRequest
+	34, // 75: activity.v1.ActivityService.GetOriginalFile:input_type -> activity.v1.GetOriginalFileRequest
+	14, // 76: activity.v1.ActivityService.UploadActivities:input_type -> activity.v1.UploadActivitiesRequest
+	22, // 77: activity.v1.ActivityService.UploadActivitiesUnary:input_type -> activity.v1.UploadActivitiesUnaryRequest
+	23, // 78: activity.v1.ActivityService.UploadArchiveChunk:input_type -> activity.v1.UploadArchiveChunkRequest
+	25, // 79: activity.v1.ActivityService.ImportArchive:input_type -> activity.v1.ImportArchiveRequest
+	27, // 80: activity.v1.ActivityService.ReprocessActivities:input_type -> activity.v1.ReprocessActivitiesRequest
+	19, // 81: activity.v1.ActivityService.GetIngestionJobs:input_type -> activity.v1.GetIngestionJobsRequest
+	39, // 82: activity.v1.ActivityService.GetUserSettings:input_type -> activity.v1.GetUserSettingsRequest
+	41, // 83: activity.v1.ActivityService.UpdateUserSettings:input_type -> activity.v1.UpdateUserSettingsRequest
+	42, // 84: activity.v1.ActivityService.GetPowerCurve:input_type -> activity.v1.GetPowerCurveRequest
+	44, // 85: activity.v1.ActivityService.GetPersonalRecords:input_type -> activity.v1.GetPersonalRecordsRequest
+	47, // 86: activity.v1.ActivityService.GetTrainingLoad:input_type -> activity.v1.GetTrainingLoadRequest
+	29, // 87: activity.v1.ActivityService.GetActivities:output_type -> activity.v1.GetActivitiesResponse
+	4,  // 88: activity.v1.ActivityService.GetActivity:output_type -> activity.v1.GetActivityResponse
+	33, // 89: activity.v1.ActivityService.GetActivityLaps:output_type -> activity.v1.GetActivityLapsResponse
+	4,  // 90: activity.v1.ActivityService.UpdateActivity:output_type -> activity.v1.GetActivityResponse
+	38, // 91: activity.v1.ActivityService.DeleteActivity:output_type -> activity.v1.DeleteActivityResponse
+	35, // 92: activity.v1.ActivityService.GetOriginalFile:output_type -> activity.v1.GetOriginalFileResponse
+	17, // 93: activity.v1.ActivityService.UploadActivities:output_type -> activity.v1.UploadActivitiesResponse
+	17, // 94: activity.v1.ActivityService.UploadActivitiesUnary:output_type -> activity.v1.UploadActivitiesResponse
+	24, // 95: activity.v1.ActivityService.UploadArchiveChunk:output_type -> activity.v1.UploadArchiveChunkResponse
+	26, // 96: activity.v1.ActivityService.ImportArchive:output_type -> activity.v1.ImportArchiveProgress
+	28, // 97: activity.v1.ActivityService.ReprocessActivities:output_type -> activity.v1.ReprocessActivitiesProgress
+	20, // 98: activity.v1.ActivityService.GetIngestionJobs:output_type -> activity.v1.GetIngestionJobsResponse
+	40, // 99: activity.v1.ActivityService.GetUserSettings:output_type -> activity.v1.UserSettings
+	40, // 100: activity.v1.ActivityService.UpdateUserSettings:output_type -> activity.v1.UserSettings
+	43, // 101: activity.v1.ActivityService.GetPowerCurve:output_type -> activity.v1.GetPowerCurveResponse
+	46, // 102: activity.v1.ActivityService.GetPersonalRecords:output_type -> activity.v1.GetPersonalRecordsResponse
+	49, // 103: activity.v1.ActivityService.GetTrainingLoad:output_type -> activity.v1.GetTrainingLoadResponse
+	87, // [87:104] is the sub-list for method output_type
+	70, // [70:87] is the sub-list for method input_type
+	70, // [70:70] is the sub-list for extension type_name
+	70, // [70:70] is the sub-list for extension extendee
+	0,  // [0:70] is the sub-list for field type_name
 }
 
 func init() { file_activity_v1_activity_proto_init() }
@@ -991,22 +4125,24 @@ func file_activity_v1_activity_proto_init() {
 	if File_activity_v1_activity_proto != nil {
 		return
 	}
-	file_activity_v1_activity_proto_msgTypes[4].OneofWrappers = []any{
+	file_activity_v1_activity_proto_msgTypes[12].OneofWrappers = []any{
 		(*UploadActivitiesRequest_FileChunk)(nil),
 		(*UploadActivitiesRequest_Metadata)(nil),
+		(*UploadActivitiesRequest_FileHeader)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_activity_v1_activity_proto_rawDesc), len(file_activity_v1_activity_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   12,
+			NumEnums:      2,
+			NumMessages:   48,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_activity_v1_activity_proto_goTypes,
 		DependencyIndexes: file_activity_v1_activity_proto_depIdxs,
+		EnumInfos:         file_activity_v1_activity_proto_enumTypes,
 		MessageInfos:      file_activity_v1_activity_proto_msgTypes,
 	}.Build()
 	File_activity_v1_activity_proto = out.File
@@ -39,15 +39,51 @@ const (
 	// ActivityServiceGetActivityProcedure is the fully-qualified name of the ActivityService's
 	// GetActivity RPC.
 	ActivityServiceGetActivityProcedure = "/activity.v1.ActivityService/GetActivity"
+	// ActivityServiceGetActivityLapsProcedure is the fully-qualified name of the ActivityService's
+	// GetActivityLaps RPC.
+	ActivityServiceGetActivityLapsProcedure = "/activity.v1.ActivityService/GetActivityLaps"
 	// ActivityServiceUpdateActivityProcedure is the fully-qualified name of the ActivityService's
 	// UpdateActivity RPC.
 	ActivityServiceUpdateActivityProcedure = "/activity.v1.ActivityService/UpdateActivity"
+	// ActivityServiceDeleteActivityProcedure is the fully-qualified name of the ActivityService's
+	// DeleteActivity RPC.
+	ActivityServiceDeleteActivityProcedure = "/activity.v1.ActivityService/DeleteActivity"
+	// ActivityServiceGetOriginalFileProcedure is the fully-qualified name of the ActivityService's
+	// GetOriginalFile RPC.
+	ActivityServiceGetOriginalFileProcedure = "/activity.v1.ActivityService/GetOriginalFile"
 	// ActivityServiceUploadActivitiesProcedure is the fully-qualified name of the ActivityService's
 	// UploadActivities RPC.
 	ActivityServiceUploadActivitiesProcedure = "/activity.v1.ActivityService/UploadActivities"
 	// ActivityServiceUploadActivitiesUnaryProcedure is the fully-qualified name of the
 	// ActivityService's UploadActivitiesUnary RPC.
 	ActivityServiceUploadActivitiesUnaryProcedure = "/activity.v1.ActivityService/UploadActivitiesUnary"
+	// ActivityServiceUploadArchiveChunkProcedure is the fully-qualified name of the ActivityService's
+	// UploadArchiveChunk RPC.
+	ActivityServiceUploadArchiveChunkProcedure = "/activity.v1.ActivityService/UploadArchiveChunk"
+	// ActivityServiceImportArchiveProcedure is the fully-qualified name of the ActivityService's
+	// ImportArchive RPC.
+	ActivityServiceImportArchiveProcedure = "/activity.v1.ActivityService/ImportArchive"
+	// ActivityServiceReprocessActivitiesProcedure is the fully-qualified name of the ActivityService's
+	// ReprocessActivities RPC.
+	ActivityServiceReprocessActivitiesProcedure = "/activity.v1.ActivityService/ReprocessActivities"
+	// ActivityServiceGetIngestionJobsProcedure is the fully-qualified name of the ActivityService's
+	// GetIngestionJobs RPC.
+	ActivityServiceGetIngestionJobsProcedure = "/activity.v1.ActivityService/GetIngestionJobs"
+	// ActivityServiceGetUserSettingsProcedure is the fully-qualified name of the ActivityService's
+	// GetUserSettings RPC.
+	ActivityServiceGetUserSettingsProcedure = "/activity.v1.ActivityService/GetUserSettings"
+	// ActivityServiceUpdateUserSettingsProcedure is the fully-qualified name of the ActivityService's
+	// UpdateUserSettings RPC.
+	ActivityServiceUpdateUserSettingsProcedure = "/activity.v1.ActivityService/UpdateUserSettings"
+	// ActivityServiceGetPowerCurveProcedure is the fully-qualified name of the ActivityService's
+	// GetPowerCurve RPC.
+	ActivityServiceGetPowerCurveProcedure = "/activity.v1.ActivityService/GetPowerCurve"
+	// ActivityServiceGetPersonalRecordsProcedure is the fully-qualified name of the ActivityService's
+	// GetPersonalRecords RPC.
+	ActivityServiceGetPersonalRecordsProcedure = "/activity.v1.ActivityService/GetPersonalRecords"
+	// ActivityServiceGetTrainingLoadProcedure is the fully-qualified name of the ActivityService's
+	// GetTrainingLoad RPC.
+	ActivityServiceGetTrainingLoadProcedure = "/activity.v1.ActivityService/GetTrainingLoad"
 )
 
 // ActivityServiceClient is a client for the activity.v1.ActivityService service.
@@ -56,11 +92,33 @@ type ActivityServiceClient interface {
 	GetActivities(context.Context, *connect.Request[v1.GetActivitiesRequest]) (*connect.Response[v1.GetActivitiesResponse], error)
 	// Fetch a single activity by ID with records.
 	GetActivity(context.Context, *connect.Request[v1.GetActivityRequest]) (*connect.Response[v1.GetActivityResponse], error)
+	// Fetch the laps of an activity.
+	GetActivityLaps(context.Context, *connect.Request[v1.GetActivityLapsRequest]) (*connect.Response[v1.GetActivityLapsResponse], error)
 	UpdateActivity(context.Context, *connect.Request[v1.UpdateActivityRequest]) (*connect.Response[v1.GetActivityResponse], error)
+	// Delete an activity, refreshing the training load and personal records
+	DeleteActivity(context.Context, *connect.Request[v1.DeleteActivityRequest]) (*connect.Response[v1.DeleteActivityResponse], error)
+	// Download the original uploaded file of an activity
+	GetOriginalFile(context.Context, *connect.Request[v1.GetOriginalFileRequest]) (*connect.Response[v1.GetOriginalFileResponse], error)
 	// Upload multiple fit files
 	UploadActivities(context.Context) *connect.ClientStreamForClient[v1.UploadActivitiesRequest, v1.UploadActivitiesResponse]
 	// Upload fit files using a unary request (for clients without streaming support)
 	UploadActivitiesUnary(context.Context, *connect.Request[v1.UploadActivitiesUnaryRequest]) (*connect.Response[v1.UploadActivitiesResponse], error)
+	// Stage a chunk of an export archive for ImportArchive
+	UploadArchiveChunk(context.Context, *connect.Request[v1.UploadArchiveChunkRequest]) (*connect.Response[v1.UploadArchiveChunkResponse], error)
+	// Import a staged Strava or Garmin export archive, streaming per-file progress
+	ImportArchive(context.Context, *connect.Request[v1.ImportArchiveRequest]) (*connect.ServerStreamForClient[v1.ImportArchiveProgress], error)
+	// Admin only: derive activity data again from the stored original files
+	ReprocessActivities(context.Context, *connect.Request[v1.ReprocessActivitiesRequest]) (*connect.ServerStreamForClient[v1.ReprocessActivitiesProgress], error)
+	GetIngestionJobs(context.Context, *connect.Request[v1.GetIngestionJobsRequest]) (*connect.Response[v1.GetIngestionJobsResponse], error)
+	GetUserSettings(context.Context, *connect.Request[v1.GetUserSettingsRequest]) (*connect.Response[v1.UserSettings], error)
+	UpdateUserSettings(context.Context, *connect.Request[v1.UpdateUserSettingsRequest]) (*connect.Response[v1.UserSettings], error)
+	// Fetch the user's best power for each duration over a date range
+	GetPowerCurve(context.Context, *connect.Request[v1.GetPowerCurveRequest]) (*connect.Response[v1.GetPowerCurveResponse], error)
+	// Fetch the user's fastest effort over each best effort distance
+	GetPersonalRecords(context.Context, *connect.Request[v1.GetPersonalRecordsRequest]) (*connect.Response[v1.GetPersonalRecordsResponse], error)
+	// Fetch the user's daily fitness, fatigue and form for a performance
+	// management chart
+	GetTrainingLoad(context.Context, *connect.Request[v1.GetTrainingLoadRequest]) (*connect.Response[v1.GetTrainingLoadResponse], error)
 }
 
 // NewActivityServiceClient constructs a client for the activity.v1.ActivityService service. By
@@ -86,12 +144,30 @@ func NewActivityServiceClient(httpClient connect.HTTPClient, baseURL string, opt
 			connect.WithSchema(activityServiceMethods.ByName("GetActivity")),
 			connect.WithClientOptions(opts...),
 		),
+		getActivityLaps: connect.NewClient[v1.GetActivityLapsRequest, v1.GetActivityLapsResponse](
+			httpClient,
+			baseURL+ActivityServiceGetActivityLapsProcedure,
+			connect.WithSchema(activityServiceMethods.ByName("GetActivityLaps")),
+			connect.WithClientOptions(opts...),
+		),
 		updateActivity: connect.NewClient[v1.UpdateActivityRequest, v1.GetActivityResponse](
 			httpClient,
 			baseURL+ActivityServiceUpdateActivityProcedure,
 			connect.WithSchema(activityServiceMethods.ByName("UpdateActivity")),
 			connect.WithClientOptions(opts...),
 		),
+		deleteActivity: connect.NewClient[v1.DeleteActivityRequest, v1.DeleteActivityResponse](
+			httpClient,
+			baseURL+ActivityServiceDeleteActivityProcedure,
+			connect.WithSchema(activityServiceMethods.ByName("DeleteActivity")),
+			connect.WithClientOptions(opts...),
+		),
+		getOriginalFile: connect.NewClient[v1.GetOriginalFileRequest, v1.GetOriginalFileResponse](
+			httpClient,
+			baseURL+ActivityServiceGetOriginalFileProcedure,
+			connect.WithSchema(activityServiceMethods.ByName("GetOriginalFile")),
+			connect.WithClientOptions(opts...),
+		),
 		uploadActivities: connect.NewClient[v1.UploadActivitiesRequest, v1.UploadActivitiesResponse](
 			httpClient,
 			baseURL+ActivityServiceUploadActivitiesProcedure,
@@ -104,6 +180,60 @@ func NewActivityServiceClient(httpClient connect.HTTPClient, baseURL string, opt
 			connect.WithSchema(activityServiceMethods.ByName("UploadActivitiesUnary")),
 			connect.WithClientOptions(opts...),
 		),
+		uploadArchiveChunk: connect.NewClient[v1.UploadArchiveChunkRequest, v1.UploadArchiveChunkResponse](
+			httpClient,
+			baseURL+ActivityServiceUploadArchiveChunkProcedure,
+			connect.WithSchema(activityServiceMethods.ByName("UploadArchiveChunk")),
+			connect.WithClientOptions(opts...),
+		),
+		importArchive: connect.NewClient[v1.ImportArchiveRequest, v1.ImportArchiveProgress](
+			httpClient,
+			baseURL+ActivityServiceImportArchiveProcedure,
+			connect.WithSchema(activityServiceMethods.ByName("ImportArchive")),
+			connect.WithClientOptions(opts...),
+		),
+		reprocessActivities: connect.NewClient[v1.ReprocessActivitiesRequest, v1.ReprocessActivitiesProgress](
+			httpClient,
+			baseURL+ActivityServiceReprocessActivitiesProcedure,
+			connect.WithSchema(activityServiceMethods.ByName("ReprocessActivities")),
+			connect.WithClientOptions(opts...),
+		),
+		getIngestionJobs: connect.NewClient[v1.GetIngestionJobsRequest, v1.GetIngestionJobsResponse](
+			httpClient,
+			baseURL+ActivityServiceGetIngestionJobsProcedure,
+			connect.WithSchema(activityServiceMethods.ByName("GetIngestionJobs")),
+			connect.WithClientOptions(opts...),
+		),
+		getUserSettings: connect.NewClient[v1.GetUserSettingsRequest, v1.UserSettings](
+			httpClient,
+			baseURL+ActivityServiceGetUserSettingsProcedure,
+			connect.WithSchema(activityServiceMethods.ByName("GetUserSettings")),
+			connect.WithClientOptions(opts...),
+		),
+		updateUserSettings: connect.NewClient[v1.UpdateUserSettingsRequest, v1.UserSettings](
+			httpClient,
+			baseURL+ActivityServiceUpdateUserSettingsProcedure,
+			connect.WithSchema(activityServiceMethods.ByName("UpdateUserSettings")),
+			connect.WithClientOptions(opts...),
+		),
+		getPowerCurve: connect.NewClient[v1.GetPowerCurveRequest, v1.GetPowerCurveResponse](
+			httpClient,
+			baseURL+ActivityServiceGetPowerCurveProcedure,
+			connect.WithSchema(activityServiceMethods.ByName("GetPowerCurve")),
+			connect.WithClientOptions(opts...),
+		),
+		getPersonalRecords: connect.NewClient[v1.GetPersonalRecordsRequest, v1.GetPersonalRecordsResponse](
+			httpClient,
+			baseURL+ActivityServiceGetPersonalRecordsProcedure,
+			connect.WithSchema(activityServiceMethods.ByName("GetPersonalRecords")),
+			connect.WithClientOptions(opts...),
+		),
+		getTrainingLoad: connect.NewClient[v1.GetTrainingLoadRequest, v1.GetTrainingLoadResponse](
+			httpClient,
+			baseURL+ActivityServiceGetTrainingLoadProcedure,
+			connect.WithSchema(activityServiceMethods.ByName("GetTrainingLoad")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -111,9 +241,21 @@ func NewActivityServiceClient(httpClient connect.HTTPClient, baseURL string, opt
 type activityServiceClient struct {
 	getActivities         *connect.Client[v1.GetActivitiesRequest, v1.GetActivitiesResponse]
 	getActivity           *connect.Client[v1.GetActivityRequest, v1.GetActivityResponse]
+	getActivityLaps       *connect.Client[v1.GetActivityLapsRequest, v1.GetActivityLapsResponse]
 	updateActivity        *connect.Client[v1.UpdateActivityRequest, v1.GetActivityResponse]
+	deleteActivity        *connect.Client[v1.DeleteActivityRequest, v1.DeleteActivityResponse]
+	getOriginalFile       *connect.Client[v1.GetOriginalFileRequest, v1.GetOriginalFileResponse]
 	uploadActivities      *connect.Client[v1.UploadActivitiesRequest, v1.UploadActivitiesResponse]
 	uploadActivitiesUnary *connect.Client[v1.UploadActivitiesUnaryRequest, v1.UploadActivitiesResponse]
+	uploadArchiveChunk    *connect.Client[v1.UploadArchiveChunkRequest, v1.UploadArchiveChunkResponse]
+	importArchive         *connect.Client[v1.ImportArchiveRequest, v1.ImportArchiveProgress]
+	reprocessActivities   *connect.Client[v1.ReprocessActivitiesRequest, v1.ReprocessActivitiesProgress]
+	getIngestionJobs      *connect.Client[v1.GetIngestionJobsRequest, v1.GetIngestionJobsResponse]
+	getUserSettings       *connect.Client[v1.GetUserSettingsRequest, v1.UserSettings]
+	updateUserSettings    *connect.Client[v1.UpdateUserSettingsRequest, v1.UserSettings]
+	getPowerCurve         *connect.Client[v1.GetPowerCurveRequest, v1.GetPowerCurveResponse]
+	getPersonalRecords    *connect.Client[v1.GetPersonalRecordsRequest, v1.GetPersonalRecordsResponse]
+	getTrainingLoad       *connect.Client[v1.GetTrainingLoadRequest, v1.GetTrainingLoadResponse]
 }
 
 // GetActivities calls activity.v1.ActivityService.GetActivities.
@@ -126,11 +268,26 @@ func (c *activityServiceClient) GetActivity(ctx context.Context, req *connect.Re
 	return c.getActivity.CallUnary(ctx, req)
 }
 
+// GetActivityLaps calls activity.v1.ActivityService.GetActivityLaps.
+func (c *activityServiceClient) GetActivityLaps(ctx context.Context, req *connect.Request[v1.GetActivityLapsRequest]) (*connect.Response[v1.GetActivityLapsResponse], error) {
+	return c.getActivityLaps.CallUnary(ctx, req)
+}
+
 // UpdateActivity calls activity.v1.ActivityService.UpdateActivity.
 func (c *activityServiceClient) UpdateActivity(ctx context.Context, req *connect.Request[v1.UpdateActivityRequest]) (*connect.Response[v1.GetActivityResponse], error) {
 	return c.updateActivity.CallUnary(ctx, req)
 }
 
+// DeleteActivity calls activity.v1.ActivityService.DeleteActivity.
+func (c *activityServiceClient) DeleteActivity(ctx context.Context, req *connect.Request[v1.DeleteActivityRequest]) (*connect.Response[v1.DeleteActivityResponse], error) {
+	return c.deleteActivity.CallUnary(ctx, req)
+}
+
+// GetOriginalFile calls activity.v1.ActivityService.GetOriginalFile.
+func (c *activityServiceClient) GetOriginalFile(ctx context.Context, req *connect.Request[v1.GetOriginalFileRequest]) (*connect.Response[v1.GetOriginalFileResponse], error) {
+	return c.getOriginalFile.CallUnary(ctx, req)
+}
+
 // UploadActivities calls activity.v1.ActivityService.UploadActivities.
 func (c *activityServiceClient) UploadActivities(ctx context.Context) *connect.ClientStreamForClient[v1.UploadActivitiesRequest, v1.UploadActivitiesResponse] {
 	return c.uploadActivities.CallClientStream(ctx)
@@ -141,17 +298,84 @@ func (c *activityServiceClient) UploadActivitiesUnary(ctx context.Context, req *
 	return c.uploadActivitiesUnary.CallUnary(ctx, req)
 }
 
+// UploadArchiveChunk calls activity.v1.ActivityService.UploadArchiveChunk.
+func (c *activityServiceClient) UploadArchiveChunk(ctx context.Context, req *connect.Request[v1.UploadArchiveChunkRequest]) (*connect.Response[v1.UploadArchiveChunkResponse], error) {
+	return c.uploadArchiveChunk.CallUnary(ctx, req)
+}
+
+// ImportArchive calls activity.v1.ActivityService.ImportArchive.
+func (c *activityServiceClient) ImportArchive(ctx context.Context, req *connect.Request[v1.ImportArchiveRequest]) (*connect.ServerStreamForClient[v1.ImportArchiveProgress], error) {
+	return c.importArchive.CallServerStream(ctx, req)
+}
+
+// ReprocessActivities calls activity.v1.ActivityService.ReprocessActivities.
+func (c *activityServiceClient) ReprocessActivities(ctx context.Context, req *connect.Request[v1.ReprocessActivitiesRequest]) (*connect.ServerStreamForClient[v1.ReprocessActivitiesProgress], error) {
+	return c.reprocessActivities.CallServerStream(ctx, req)
+}
+
+// GetIngestionJobs calls activity.v1.ActivityService.GetIngestionJobs.
+func (c *activityServiceClient) GetIngestionJobs(ctx context.Context, req *connect.Request[v1.GetIngestionJobsRequest]) (*connect.Response[v1.GetIngestionJobsResponse], error) {
+	return c.getIngestionJobs.CallUnary(ctx, req)
+}
+
+// GetUserSettings calls activity.v1.ActivityService.GetUserSettings.
+func (c *activityServiceClient) GetUserSettings(ctx context.Context, req *connect.Request[v1.GetUserSettingsRequest]) (*connect.Response[v1.UserSettings], error) {
+	return c.getUserSettings.CallUnary(ctx, req)
+}
+
+// UpdateUserSettings calls activity.v1.ActivityService.UpdateUserSettings.
+func (c *activityServiceClient) UpdateUserSettings(ctx context.Context, req *connect.Request[v1.UpdateUserSettingsRequest]) (*connect.Response[v1.UserSettings], error) {
+	return c.updateUserSettings.CallUnary(ctx, req)
+}
+
+// GetPowerCurve calls activity.v1.ActivityService.GetPowerCurve.
+func (c *activityServiceClient) GetPowerCurve(ctx context.Context, req *connect.Request[v1.GetPowerCurveRequest]) (*connect.Response[v1.GetPowerCurveResponse], error) {
+	return c.getPowerCurve.CallUnary(ctx, req)
+}
+
+// GetPersonalRecords calls activity.v1.ActivityService.GetPersonalRecords.
+func (c *activityServiceClient) GetPersonalRecords(ctx context.Context, req *connect.Request[v1.GetPersonalRecordsRequest]) (*connect.Response[v1.GetPersonalRecordsResponse], error) {
+	return c.getPersonalRecords.CallUnary(ctx, req)
+}
+
+// GetTrainingLoad calls activity.v1.ActivityService.GetTrainingLoad.
+func (c *activityServiceClient) GetTrainingLoad(ctx context.Context, req *connect.Request[v1.GetTrainingLoadRequest]) (*connect.Response[v1.GetTrainingLoadResponse], error) {
+	return c.getTrainingLoad.CallUnary(ctx, req)
+}
+
 // ActivityServiceHandler is an implementation of the activity.v1.ActivityService service.
 type ActivityServiceHandler interface {
 	// Fetch all activities without records.
 	GetActivities(context.Context, *connect.Request[v1.GetActivitiesRequest]) (*connect.Response[v1.GetActivitiesResponse], error)
 	// Fetch a single activity by ID with records.
 	GetActivity(context.Context, *connect.Request[v1.GetActivityRequest]) (*connect.Response[v1.GetActivityResponse], error)
+	// Fetch the laps of an activity.
+	GetActivityLaps(context.Context, *connect.Request[v1.GetActivityLapsRequest]) (*connect.Response[v1.GetActivityLapsResponse], error)
 	UpdateActivity(context.Context, *connect.Request[v1.UpdateActivityRequest]) (*connect.Response[v1.GetActivityResponse], error)
+	// Delete an activity, refreshing the training load and personal records
+	DeleteActivity(context.Context, *connect.Request[v1.DeleteActivityRequest]) (*connect.Response[v1.DeleteActivityResponse], error)
+	// Download the original uploaded file of an activity
+	GetOriginalFile(context.Context, *connect.Request[v1.GetOriginalFileRequest]) (*connect.Response[v1.GetOriginalFileResponse], error)
 	// Upload multiple fit files
 	UploadActivities(context.Context, *connect.ClientStream[v1.UploadActivitiesRequest]) (*connect.Response[v1.UploadActivitiesResponse], error)
 	// Upload fit files using a unary request (for clients without streaming support)
 	UploadActivitiesUnary(context.Context, *connect.Request[v1.UploadActivitiesUnaryRequest]) (*connect.Response[v1.UploadActivitiesResponse], error)
+	// Stage a chunk of an export archive for ImportArchive
+	UploadArchiveChunk(context.Context, *connect.Request[v1.UploadArchiveChunkRequest]) (*connect.Response[v1.UploadArchiveChunkResponse], error)
+	// Import a staged Strava or Garmin export archive, streaming per-file progress
+	ImportArchive(context.Context, *connect.Request[v1.ImportArchiveRequest], *connect.ServerStream[v1.ImportArchiveProgress]) error
+	// Admin only: derive activity data again from the stored original files
+	ReprocessActivities(context.Context, *connect.Request[v1.ReprocessActivitiesRequest], *connect.ServerStream[v1.ReprocessActivitiesProgress]) error
+	GetIngestionJobs(context.Context, *connect.Request[v1.GetIngestionJobsRequest]) (*connect.Response[v1.GetIngestionJobsResponse], error)
+	GetUserSettings(context.Context, *connect.Request[v1.GetUserSettingsRequest]) (*connect.Response[v1.UserSettings], error)
+	UpdateUserSettings(context.Context, *connect.Request[v1.UpdateUserSettingsRequest]) (*connect.Response[v1.UserSettings], error)
+	// Fetch the user's best power for each duration over a date range
+	GetPowerCurve(context.Context, *connect.Request[v1.GetPowerCurveRequest]) (*connect.Response[v1.GetPowerCurveResponse], error)
+	// Fetch the user's fastest effort over each best effort distance
+	GetPersonalRecords(context.Context, *connect.Request[v1.GetPersonalRecordsRequest]) (*connect.Response[v1.GetPersonalRecordsResponse], error)
+	// Fetch the user's daily fitness, fatigue and form for a performance
+	// management chart
+	GetTrainingLoad(context.Context, *connect.Request[v1.GetTrainingLoadRequest]) (*connect.Response[v1.GetTrainingLoadResponse], error)
 }
 
 // NewActivityServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -173,12 +397,30 @@ func NewActivityServiceHandler(svc ActivityServiceHandler, opts ...connect.Handl
 		connect.WithSchema(activityServiceMethods.ByName("GetActivity")),
 		connect.WithHandlerOptions(opts...),
 	)
+	activityServiceGetActivityLapsHandler := connect.NewUnaryHandler(
+		ActivityServiceGetActivityLapsProcedure,
+		svc.GetActivityLaps,
+		connect.WithSchema(activityServiceMethods.ByName("GetActivityLaps")),
+		connect.WithHandlerOptions(opts...),
+	)
 	activityServiceUpdateActivityHandler := connect.NewUnaryHandler(
 		ActivityServiceUpdateActivityProcedure,
 		svc.UpdateActivity,
 		connect.WithSchema(activityServiceMethods.ByName("UpdateActivity")),
 		connect.WithHandlerOptions(opts...),
 	)
+	activityServiceDeleteActivityHandler := connect.NewUnaryHandler(
+		ActivityServiceDeleteActivityProcedure,
+		svc.DeleteActivity,
+		connect.WithSchema(activityServiceMethods.ByName("DeleteActivity")),
+		connect.WithHandlerOptions(opts...),
+	)
+	activityServiceGetOriginalFileHandler := connect.NewUnaryHandler(
+		ActivityServiceGetOriginalFileProcedure,
+		svc.GetOriginalFile,
+		connect.WithSchema(activityServiceMethods.ByName("GetOriginalFile")),
+		connect.WithHandlerOptions(opts...),
+	)
 	activityServiceUploadActivitiesHandler := connect.NewClientStreamHandler(
 		ActivityServiceUploadActivitiesProcedure,
 		svc.UploadActivities,
@@ -191,18 +433,96 @@ func NewActivityServiceHandler(svc ActivityServiceHandler, opts ...connect.Handl
 		connect.WithSchema(activityServiceMethods.ByName("UploadActivitiesUnary")),
 		connect.WithHandlerOptions(opts...),
 	)
+	activityServiceUploadArchiveChunkHandler := connect.NewUnaryHandler(
+		ActivityServiceUploadArchiveChunkProcedure,
+		svc.UploadArchiveChunk,
+		connect.WithSchema(activityServiceMethods.ByName("UploadArchiveChunk")),
+		connect.WithHandlerOptions(opts...),
+	)
+	activityServiceImportArchiveHandler := connect.NewServerStreamHandler(
+		ActivityServiceImportArchiveProcedure,
+		svc.ImportArchive,
+		connect.WithSchema(activityServiceMethods.ByName("ImportArchive")),
+		connect.WithHandlerOptions(opts...),
+	)
+	activityServiceReprocessActivitiesHandler := connect.NewServerStreamHandler(
+		ActivityServiceReprocessActivitiesProcedure,
+		svc.ReprocessActivities,
+		connect.WithSchema(activityServiceMethods.ByName("ReprocessActivities")),
+		connect.WithHandlerOptions(opts...),
+	)
+	activityServiceGetIngestionJobsHandler := connect.NewUnaryHandler(
+		ActivityServiceGetIngestionJobsProcedure,
+		svc.GetIngestionJobs,
+		connect.WithSchema(activityServiceMethods.ByName("GetIngestionJobs")),
+		connect.WithHandlerOptions(opts...),
+	)
+	activityServiceGetUserSettingsHandler := connect.NewUnaryHandler(
+		ActivityServiceGetUserSettingsProcedure,
+		svc.GetUserSettings,
+		connect.WithSchema(activityServiceMethods.ByName("GetUserSettings")),
+		connect.WithHandlerOptions(opts...),
+	)
+	activityServiceUpdateUserSettingsHandler := connect.NewUnaryHandler(
+		ActivityServiceUpdateUserSettingsProcedure,
+		svc.UpdateUserSettings,
+		connect.WithSchema(activityServiceMethods.ByName("UpdateUserSettings")),
+		connect.WithHandlerOptions(opts...),
+	)
+	activityServiceGetPowerCurveHandler := connect.NewUnaryHandler(
+		ActivityServiceGetPowerCurveProcedure,
+		svc.GetPowerCurve,
+		connect.WithSchema(activityServiceMethods.ByName("GetPowerCurve")),
+		connect.WithHandlerOptions(opts...),
+	)
+	activityServiceGetPersonalRecordsHandler := connect.NewUnaryHandler(
+		ActivityServiceGetPersonalRecordsProcedure,
+		svc.GetPersonalRecords,
+		connect.WithSchema(activityServiceMethods.ByName("GetPersonalRecords")),
+		connect.WithHandlerOptions(opts...),
+	)
+	activityServiceGetTrainingLoadHandler := connect.NewUnaryHandler(
+		ActivityServiceGetTrainingLoadProcedure,
+		svc.GetTrainingLoad,
+		connect.WithSchema(activityServiceMethods.ByName("GetTrainingLoad")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/activity.v1.ActivityService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case ActivityServiceGetActivitiesProcedure:
 			activityServiceGetActivitiesHandler.ServeHTTP(w, r)
 		case ActivityServiceGetActivityProcedure:
 			activityServiceGetActivityHandler.ServeHTTP(w, r)
+		case ActivityServiceGetActivityLapsProcedure:
+			activityServiceGetActivityLapsHandler.ServeHTTP(w, r)
 		case ActivityServiceUpdateActivityProcedure:
 			activityServiceUpdateActivityHandler.ServeHTTP(w, r)
+		case ActivityServiceDeleteActivityProcedure:
+			activityServiceDeleteActivityHandler.ServeHTTP(w, r)
+		case ActivityServiceGetOriginalFileProcedure:
+			activityServiceGetOriginalFileHandler.ServeHTTP(w, r)
 		case ActivityServiceUploadActivitiesProcedure:
 			activityServiceUploadActivitiesHandler.ServeHTTP(w, r)
 		case ActivityServiceUploadActivitiesUnaryProcedure:
 			activityServiceUploadActivitiesUnaryHandler.ServeHTTP(w, r)
+		case ActivityServiceUploadArchiveChunkProcedure:
+			activityServiceUploadArchiveChunkHandler.ServeHTTP(w, r)
+		case ActivityServiceImportArchiveProcedure:
+			activityServiceImportArchiveHandler.ServeHTTP(w, r)
+		case ActivityServiceReprocessActivitiesProcedure:
+			activityServiceReprocessActivitiesHandler.ServeHTTP(w, r)
+		case ActivityServiceGetIngestionJobsProcedure:
+			activityServiceGetIngestionJobsHandler.ServeHTTP(w, r)
+		case ActivityServiceGetUserSettingsProcedure:
+			activityServiceGetUserSettingsHandler.ServeHTTP(w, r)
+		case ActivityServiceUpdateUserSettingsProcedure:
+			activityServiceUpdateUserSettingsHandler.ServeHTTP(w, r)
+		case ActivityServiceGetPowerCurveProcedure:
+			activityServiceGetPowerCurveHandler.ServeHTTP(w, r)
+		case ActivityServiceGetPersonalRecordsProcedure:
+			activityServiceGetPersonalRecordsHandler.ServeHTTP(w, r)
+		case ActivityServiceGetTrainingLoadProcedure:
+			activityServiceGetTrainingLoadHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -220,10 +540,22 @@ func (UnimplementedActivityServiceHandler) GetActivity(context.Context, *connect
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("activity.v1.ActivityService.GetActivity is not implemented"))
 }
 
+func (UnimplementedActivityServiceHandler) GetActivityLaps(context.Context, *connect.Request[v1.GetActivityLapsRequest]) (*connect.Response[v1.GetActivityLapsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("activity.v1.ActivityService.GetActivityLaps is not implemented"))
+}
+
 func (UnimplementedActivityServiceHandler) UpdateActivity(context.Context, *connect.Request[v1.UpdateActivityRequest]) (*connect.Response[v1.GetActivityResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("activity.v1.ActivityService.UpdateActivity is not implemented"))
 }
 
+func (UnimplementedActivityServiceHandler) DeleteActivity(context.Context, *connect.Request[v1.DeleteActivityRequest]) (*connect.Response[v1.DeleteActivityResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("activity.v1.ActivityService.DeleteActivity is not implemented"))
+}
+
+func (UnimplementedActivityServiceHandler) GetOriginalFile(context.Context, *connect.Request[v1.GetOriginalFileRequest]) (*connect.Response[v1.GetOriginalFileResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("activity.v1.ActivityService.GetOriginalFile is not implemented"))
+}
+
 func (UnimplementedActivityServiceHandler) UploadActivities(context.Context, *connect.ClientStream[v1.UploadActivitiesRequest]) (*connect.Response[v1.UploadActivitiesResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("activity.v1.ActivityService.UploadActivities is not implemented"))
 }
@@ -231,3 +563,39 @@ func (UnimplementedActivityServiceHandler) UploadActivities(context.Context, *co
 func (UnimplementedActivityServiceHandler) UploadActivitiesUnary(context.Context, *connect.Request[v1.UploadActivitiesUnaryRequest]) (*connect.Response[v1.UploadActivitiesResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("activity.v1.ActivityService.UploadActivitiesUnary is not implemented"))
 }
+
+func (UnimplementedActivityServiceHandler) UploadArchiveChunk(context.Context, *connect.Request[v1.UploadArchiveChunkRequest]) (*connect.Response[v1.UploadArchiveChunkResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("activity.v1.ActivityService.UploadArchiveChunk is not implemented"))
+}
+
+func (UnimplementedActivityServiceHandler) ImportArchive(context.Context, *connect.Request[v1.ImportArchiveRequest], *connect.ServerStream[v1.ImportArchiveProgress]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("activity.v1.ActivityService.ImportArchive is not implemented"))
+}
+
+func (UnimplementedActivityServiceHandler) ReprocessActivities(context.Context, *connect.Request[v1.ReprocessActivitiesRequest], *connect.ServerStream[v1.ReprocessActivitiesProgress]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("activity.v1.ActivityService.ReprocessActivities is not implemented"))
+}
+
+func (UnimplementedActivityServiceHandler) GetIngestionJobs(context.Context, *connect.Request[v1.GetIngestionJobsRequest]) (*connect.Response[v1.GetIngestionJobsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("activity.v1.ActivityService.GetIngestionJobs is not implemented"))
+}
+
+func (UnimplementedActivityServiceHandler) GetUserSettings(context.Context, *connect.Request[v1.GetUserSettingsRequest]) (*connect.Response[v1.UserSettings], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("activity.v1.ActivityService.GetUserSettings is not implemented"))
+}
+
+func (UnimplementedActivityServiceHandler) UpdateUserSettings(context.Context, *connect.Request[v1.UpdateUserSettingsRequest]) (*connect.Response[v1.UserSettings], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("activity.v1.ActivityService.UpdateUserSettings is not implemented"))
+}
+
+func (UnimplementedActivityServiceHandler) GetPowerCurve(context.Context, *connect.Request[v1.GetPowerCurveRequest]) (*connect.Response[v1.GetPowerCurveResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("activity.v1.ActivityService.GetPowerCurve is not implemented"))
+}
+
+func (UnimplementedActivityServiceHandler) GetPersonalRecords(context.Context, *connect.Request[v1.GetPersonalRecordsRequest]) (*connect.Response[v1.GetPersonalRecordsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("activity.v1.ActivityService.GetPersonalRecords is not implemented"))
+}
+
+func (UnimplementedActivityServiceHandler) GetTrainingLoad(context.Context, *connect.Request[v1.GetTrainingLoadRequest]) (*connect.Response[v1.GetTrainingLoadResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("activity.v1.ActivityService.GetTrainingLoad is not implemented"))
+}
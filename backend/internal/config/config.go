@@ -15,6 +15,28 @@ type Config struct {
 	SupabaseJwtSecret  string `mapstructure:"SUPABASE_JWT_SECRET"`
 	NewRelicAppName    string `mapstructure:"NEW_RELIC_APP_NAME"`
 	NewRelicLicense    string `mapstructure:"NEW_RELIC_LICENSE"`
+	// DuplicatePolicy is one of reject, skip (default) or flag.
+	DuplicatePolicy string `mapstructure:"DUPLICATE_POLICY"`
+	// AutoPauseSpeed is the speed in km/h below which a rider counts as
+	// stopped in files without timer events; 0 disables the detection.
+	AutoPauseSpeed string `mapstructure:"AUTO_PAUSE_SPEED"`
+	// IngestionWorkers is the number of uploaded files ingested concurrently.
+	IngestionWorkers string `mapstructure:"INGESTION_WORKERS"`
+	// FileStore is where uploaded originals are kept: local (default) or s3.
+	FileStore     string `mapstructure:"FILE_STORE"`
+	FileStorePath string `mapstructure:"FILE_STORE_PATH"`
+	S3Endpoint    string `mapstructure:"S3_ENDPOINT"`
+	S3Bucket      string `mapstructure:"S3_BUCKET"`
+	S3AccessKey   string `mapstructure:"S3_ACCESS_KEY"`
+	S3SecretKey   string `mapstructure:"S3_SECRET_KEY"`
+	S3Region      string `mapstructure:"S3_REGION"`
+	S3UseSSL      string `mapstructure:"S3_USE_SSL"`
+	// AdminUserIDs is a comma separated list of the users allowed to call
+	// admin RPCs such as ReprocessActivities.
+	AdminUserIDs string `mapstructure:"ADMIN_USER_IDS"`
+	// ImportFolders maps the folders watched by cmd/importer onto users as a
+	// comma separated list of folder=user-id pairs.
+	ImportFolders string `mapstructure:"IMPORT_FOLDERS"`
 }
 
 func NewConfig() *Config {
@@ -32,6 +54,19 @@ func NewConfig() *Config {
 		config.SupabaseJwtSecret = os.Getenv("SUPABASE_JWT_SECRET")
 		config.NewRelicAppName = os.Getenv("NEW_RELIC_APP_NAME")
 		config.NewRelicLicense = os.Getenv("NEW_RELIC_LICENSE")
+		config.DuplicatePolicy = os.Getenv("DUPLICATE_POLICY")
+		config.AutoPauseSpeed = os.Getenv("AUTO_PAUSE_SPEED")
+		config.IngestionWorkers = os.Getenv("INGESTION_WORKERS")
+		config.FileStore = os.Getenv("FILE_STORE")
+		config.FileStorePath = os.Getenv("FILE_STORE_PATH")
+		config.S3Endpoint = os.Getenv("S3_ENDPOINT")
+		config.S3Bucket = os.Getenv("S3_BUCKET")
+		config.S3AccessKey = os.Getenv("S3_ACCESS_KEY")
+		config.S3SecretKey = os.Getenv("S3_SECRET_KEY")
+		config.S3Region = os.Getenv("S3_REGION")
+		config.S3UseSSL = os.Getenv("S3_USE_SSL")
+		config.AdminUserIDs = os.Getenv("ADMIN_USER_IDS")
+		config.ImportFolders = os.Getenv("IMPORT_FOLDERS")
 	} else {
 		// In development, read from the .env file
 		viper.SetConfigFile(".env")
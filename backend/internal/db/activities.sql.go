@@ -23,8 +23,31 @@ INSERT INTO activities (
     ride_type,
     elapsed_time,
     total_time,
-    date_of_activity
-
+    date_of_activity,
+    content_hash,
+    device_fingerprint,
+    started_at,
+    ended_at,
+    start_position,
+    duplicate_of,
+    description,
+    avg_power,
+    max_power,
+    normalized_power,
+    variability_index,
+    intensity_factor,
+    training_stress_score,
+    ftp,
+    indoor,
+    moving_time,
+    ride_type_source,
+    elevation_gain,
+    elevation_loss,
+    min_altitude,
+    max_altitude,
+    trimp,
+    corrected_points,
+    filled_points
 ) VALUES (
     $1, 
     $2,
@@ -34,21 +57,69 @@ INSERT INTO activities (
     $6,
     $7,
     $8,
-    $9
+    $9,
+    $10,
+    $11,
+    $12,
+    $13,
+    $14,
+    $15,
+    $16,
+    $17,
+    $18,
+    $19,
+    $20,
+    $21,
+    $22,
+    $23,
+    $24,
+    $25,
+    $26,
+    $27,
+    $28,
+    $29,
+    $30,
+    $31,
+    $32,
+    $33
 )
 RETURNING id
 `
 
 type CreateActivityParams struct {
-	UserID         string             `json:"userId"`
-	Distance       decimal.Decimal    `json:"distance"`
-	ActivityName   string             `json:"activityName"`
-	AvgSpeed       decimal.Decimal    `json:"avgSpeed"`
-	MaxSpeed       decimal.Decimal    `json:"maxSpeed"`
-	RideType       string             `json:"rideType"`
-	ElapsedTime    time.Duration      `json:"elapsedTime"`
-	TotalTime      time.Duration      `json:"totalTime"`
-	DateOfActivity pgtype.Timestamptz `json:"dateOfActivity"`
+	UserID              string             `json:"userId"`
+	Distance            decimal.Decimal    `json:"distance"`
+	ActivityName        string             `json:"activityName"`
+	AvgSpeed            decimal.Decimal    `json:"avgSpeed"`
+	MaxSpeed            decimal.Decimal    `json:"maxSpeed"`
+	RideType            string             `json:"rideType"`
+	ElapsedTime         time.Duration      `json:"elapsedTime"`
+	TotalTime           time.Duration      `json:"totalTime"`
+	DateOfActivity      pgtype.Timestamptz `json:"dateOfActivity"`
+	ContentHash         pgtype.Text        `json:"contentHash"`
+	DeviceFingerprint   pgtype.Text        `json:"deviceFingerprint"`
+	StartedAt           pgtype.Timestamptz `json:"startedAt"`
+	EndedAt             pgtype.Timestamptz `json:"endedAt"`
+	StartPosition       pgtype.Point       `json:"startPosition"`
+	DuplicateOf         pgtype.Int4        `json:"duplicateOf"`
+	Description         string             `json:"description"`
+	AvgPower            pgtype.Int4        `json:"avgPower"`
+	MaxPower            pgtype.Int4        `json:"maxPower"`
+	NormalizedPower     pgtype.Int4        `json:"normalizedPower"`
+	VariabilityIndex    pgtype.Float8      `json:"variabilityIndex"`
+	IntensityFactor     pgtype.Float8      `json:"intensityFactor"`
+	TrainingStressScore pgtype.Float8      `json:"trainingStressScore"`
+	Ftp                 pgtype.Int4        `json:"ftp"`
+	Indoor              bool               `json:"indoor"`
+	MovingTime          time.Duration      `json:"movingTime"`
+	RideTypeSource      string             `json:"rideTypeSource"`
+	ElevationGain       pgtype.Float8      `json:"elevationGain"`
+	ElevationLoss       pgtype.Float8      `json:"elevationLoss"`
+	MinAltitude         pgtype.Float8      `json:"minAltitude"`
+	MaxAltitude         pgtype.Float8      `json:"maxAltitude"`
+	Trimp               pgtype.Float8      `json:"trimp"`
+	CorrectedPoints     int32              `json:"correctedPoints"`
+	FilledPoints        int32              `json:"filledPoints"`
 }
 
 func (q *Queries) CreateActivity(ctx context.Context, arg CreateActivityParams) (int32, error) {
@@ -62,22 +133,98 @@ func (q *Queries) CreateActivity(ctx context.Context, arg CreateActivityParams)
 		arg.ElapsedTime,
 		arg.TotalTime,
 		arg.DateOfActivity,
+		arg.ContentHash,
+		arg.DeviceFingerprint,
+		arg.StartedAt,
+		arg.EndedAt,
+		arg.StartPosition,
+		arg.DuplicateOf,
+		arg.Description,
+		arg.AvgPower,
+		arg.MaxPower,
+		arg.NormalizedPower,
+		arg.VariabilityIndex,
+		arg.IntensityFactor,
+		arg.TrainingStressScore,
+		arg.Ftp,
+		arg.Indoor,
+		arg.MovingTime,
+		arg.RideTypeSource,
+		arg.ElevationGain,
+		arg.ElevationLoss,
+		arg.MinAltitude,
+		arg.MaxAltitude,
+		arg.Trimp,
+		arg.CorrectedPoints,
+		arg.FilledPoints,
 	)
 	var id int32
 	err := row.Scan(&id)
 	return id, err
 }
 
+const deleteActivity = `-- name: DeleteActivity :one
+DELETE FROM activities
+WHERE id = $1
+    AND user_id = $2
+RETURNING date_of_activity
+`
+
+type DeleteActivityParams struct {
+	ID     int32  `json:"id"`
+	UserID string `json:"userId"`
+}
+
+// Deletes the activity with its child rows and returns when it started, from
+// which the training load and personal records are refreshed.
+func (q *Queries) DeleteActivity(ctx context.Context, arg DeleteActivityParams) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, deleteActivity, arg.ID, arg.UserID)
+	var date_of_activity pgtype.Timestamptz
+	err := row.Scan(&date_of_activity)
+	return date_of_activity, err
+}
+
+const findDuplicateActivity = `-- name: FindDuplicateActivity :one
+SELECT id
+FROM activities
+WHERE user_id = $1
+    AND (
+        content_hash = $2
+        OR ($3::text <> '' AND device_fingerprint = $3::text)
+    )
+ORDER BY id
+LIMIT 1
+`
+
+type FindDuplicateActivityParams struct {
+	UserID            string      `json:"userId"`
+	ContentHash       pgtype.Text `json:"contentHash"`
+	DeviceFingerprint string      `json:"deviceFingerprint"`
+}
+
+// Exact duplicates share the file content or come from the same device
+// recording started at the same time.
+func (q *Queries) FindDuplicateActivity(ctx context.Context, arg FindDuplicateActivityParams) (int32, error) {
+	row := q.db.QueryRow(ctx, findDuplicateActivity, arg.UserID, arg.ContentHash, arg.DeviceFingerprint)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
 const getActivities = `-- name: GetActivities :many
 SELECT 
     id,
     activity_name,
     distance,
     ride_type,
+    indoor,
+    elevation_gain,
     elapsed_time::interval AS elapsed_time,
     total_time::interval AS total_time,
+    moving_time,
     TO_CHAR(elapsed_time::time, 'HH24:MI:SS') AS elapsed_time_char,
-    TO_CHAR(total_time::time, 'HH24:MI:SS') AS total_time_char
+    TO_CHAR(total_time::time, 'HH24:MI:SS') AS total_time_char,
+    TO_CHAR(moving_time::time, 'HH24:MI:SS') AS moving_time_char
 FROM activities
 WHERE user_id = $1
 ORDER BY date_of_activity DESC
@@ -88,10 +235,14 @@ type GetActivitiesRow struct {
 	ActivityName    string          `json:"activityName"`
 	Distance        decimal.Decimal `json:"distance"`
 	RideType        string          `json:"rideType"`
+	Indoor          bool            `json:"indoor"`
+	ElevationGain   pgtype.Float8   `json:"elevationGain"`
 	ElapsedTime     time.Duration   `json:"elapsedTime"`
 	TotalTime       time.Duration   `json:"totalTime"`
+	MovingTime      time.Duration   `json:"movingTime"`
 	ElapsedTimeChar string          `json:"elapsedTimeChar"`
 	TotalTimeChar   string          `json:"totalTimeChar"`
+	MovingTimeChar  string          `json:"movingTimeChar"`
 }
 
 func (q *Queries) GetActivities(ctx context.Context, userID string) ([]GetActivitiesRow, error) {
@@ -108,10 +259,14 @@ func (q *Queries) GetActivities(ctx context.Context, userID string) ([]GetActivi
 			&i.ActivityName,
 			&i.Distance,
 			&i.RideType,
+			&i.Indoor,
+			&i.ElevationGain,
 			&i.ElapsedTime,
 			&i.TotalTime,
+			&i.MovingTime,
 			&i.ElapsedTimeChar,
 			&i.TotalTimeChar,
+			&i.MovingTimeChar,
 		); err != nil {
 			return nil, err
 		}
@@ -215,18 +370,28 @@ SELECT
             NULLIF(SUM(CASE WHEN DATE_TRUNC('week', created_at) = DATE_TRUNC('week', CURRENT_DATE - INTERVAL '1 WEEK') THEN distance ELSE 0 END), 0) * 100, 
             0
         ), 2
-    ) AS percentage_change_week
+    ) AS percentage_change_week,
+
+    -- Elevation gain totals
+    ROUND(SUM(CASE WHEN TO_CHAR(created_at, 'YYYY-MM') = TO_CHAR(CURRENT_DATE, 'YYYY-MM') THEN COALESCE(elevation_gain, 0) ELSE 0 END)::numeric, 0) AS elevation_gain_for_current_month,
+    ROUND(SUM(CASE WHEN TO_CHAR(created_at, 'YYYY-MM') = TO_CHAR(CURRENT_DATE - INTERVAL '1 MONTH', 'YYYY-MM') THEN COALESCE(elevation_gain, 0) ELSE 0 END)::numeric, 0) AS elevation_gain_for_last_month,
+    ROUND(SUM(CASE WHEN DATE_TRUNC('week', created_at) = DATE_TRUNC('week', CURRENT_DATE) THEN COALESCE(elevation_gain, 0) ELSE 0 END)::numeric, 0) AS elevation_gain_for_current_week,
+    ROUND(SUM(CASE WHEN DATE_TRUNC('week', created_at) = DATE_TRUNC('week', CURRENT_DATE - INTERVAL '1 WEEK') THEN COALESCE(elevation_gain, 0) ELSE 0 END)::numeric, 0) AS elevation_gain_for_last_week
 FROM activities
 WHERE user_id = $1
 `
 
 type GetActivityStatsRow struct {
-	TotalForCurrentMonth  pgtype.Numeric `json:"totalForCurrentMonth"`
-	TotalForLastMonth     pgtype.Numeric `json:"totalForLastMonth"`
-	TotalForCurrentWeek   pgtype.Numeric `json:"totalForCurrentWeek"`
-	TotalForLastWeek      pgtype.Numeric `json:"totalForLastWeek"`
-	PercentageChangeMonth pgtype.Numeric `json:"percentageChangeMonth"`
-	PercentageChangeWeek  pgtype.Numeric `json:"percentageChangeWeek"`
+	TotalForCurrentMonth         pgtype.Numeric `json:"totalForCurrentMonth"`
+	TotalForLastMonth            pgtype.Numeric `json:"totalForLastMonth"`
+	TotalForCurrentWeek          pgtype.Numeric `json:"totalForCurrentWeek"`
+	TotalForLastWeek             pgtype.Numeric `json:"totalForLastWeek"`
+	PercentageChangeMonth        pgtype.Numeric `json:"percentageChangeMonth"`
+	PercentageChangeWeek         pgtype.Numeric `json:"percentageChangeWeek"`
+	ElevationGainForCurrentMonth pgtype.Numeric `json:"elevationGainForCurrentMonth"`
+	ElevationGainForLastMonth    pgtype.Numeric `json:"elevationGainForLastMonth"`
+	ElevationGainForCurrentWeek  pgtype.Numeric `json:"elevationGainForCurrentWeek"`
+	ElevationGainForLastWeek     pgtype.Numeric `json:"elevationGainForLastWeek"`
 }
 
 func (q *Queries) GetActivityStats(ctx context.Context, userID string) (GetActivityStatsRow, error) {
@@ -239,6 +404,10 @@ func (q *Queries) GetActivityStats(ctx context.Context, userID string) (GetActiv
 		&i.TotalForLastWeek,
 		&i.PercentageChangeMonth,
 		&i.PercentageChangeWeek,
+		&i.ElevationGainForCurrentMonth,
+		&i.ElevationGainForLastMonth,
+		&i.ElevationGainForCurrentWeek,
+		&i.ElevationGainForLastWeek,
 	)
 	return i, err
 }
@@ -257,13 +426,37 @@ SELECT
     total_time,
     elapsed_time_char,
     total_time_char,
+    moving_time,
+    moving_time_char,
+    duplicate_of,
+    description,
+    avg_power,
+    max_power,
+    normalized_power,
+    variability_index,
+    intensity_factor,
+    training_stress_score,
+    ftp,
+    indoor,
+    ride_type_source,
+    elevation_gain,
+    elevation_loss,
+    min_altitude,
+    max_altitude,
+    corrected_points,
+    filled_points,
     records
 FROM activity_with_records_view
-WHERE id = $1
+WHERE id = $1 AND user_id = $2
 `
 
-func (q *Queries) GetActivityWithRecordsView(ctx context.Context, id int32) (ActivityWithRecordsView, error) {
-	row := q.db.QueryRow(ctx, getActivityWithRecordsView, id)
+type GetActivityWithRecordsViewParams struct {
+	ID     int32  `json:"id"`
+	UserID string `json:"userId"`
+}
+
+func (q *Queries) GetActivityWithRecordsView(ctx context.Context, arg GetActivityWithRecordsViewParams) (ActivityWithRecordsView, error) {
+	row := q.db.QueryRow(ctx, getActivityWithRecordsView, arg.ID, arg.UserID)
 	var i ActivityWithRecordsView
 	err := row.Scan(
 		&i.ID,
@@ -278,23 +471,88 @@ func (q *Queries) GetActivityWithRecordsView(ctx context.Context, id int32) (Act
 		&i.TotalTime,
 		&i.ElapsedTimeChar,
 		&i.TotalTimeChar,
+		&i.MovingTime,
+		&i.MovingTimeChar,
+		&i.DuplicateOf,
+		&i.Description,
+		&i.AvgPower,
+		&i.MaxPower,
+		&i.NormalizedPower,
+		&i.VariabilityIndex,
+		&i.IntensityFactor,
+		&i.TrainingStressScore,
+		&i.Ftp,
+		&i.Indoor,
+		&i.RideTypeSource,
+		&i.ElevationGain,
+		&i.ElevationLoss,
+		&i.MinAltitude,
+		&i.MaxAltitude,
+		&i.CorrectedPoints,
+		&i.FilledPoints,
 		&i.Records,
 	)
 	return i, err
 }
 
+const getOverlappingActivities = `-- name: GetOverlappingActivities :many
+SELECT
+    id,
+    start_position
+FROM activities
+WHERE user_id = $1
+    AND started_at < $2
+    AND ended_at > $3
+ORDER BY started_at
+`
+
+type GetOverlappingActivitiesParams struct {
+	UserID    string             `json:"userId"`
+	EndedAt   pgtype.Timestamptz `json:"endedAt"`
+	StartedAt pgtype.Timestamptz `json:"startedAt"`
+}
+
+type GetOverlappingActivitiesRow struct {
+	ID            int32        `json:"id"`
+	StartPosition pgtype.Point `json:"startPosition"`
+}
+
+func (q *Queries) GetOverlappingActivities(ctx context.Context, arg GetOverlappingActivitiesParams) ([]GetOverlappingActivitiesRow, error) {
+	rows, err := q.db.Query(ctx, getOverlappingActivities, arg.UserID, arg.EndedAt, arg.StartedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetOverlappingActivitiesRow
+	for rows.Next() {
+		var i GetOverlappingActivitiesRow
+		if err := rows.Scan(&i.ID, &i.StartPosition); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateActivity = `-- name: UpdateActivity :one
 WITH updated_activity AS (
     UPDATE activities 
     SET 
         activity_name = COALESCE($1, activity_name),
-        ride_type = COALESCE($2, ride_type)
+        ride_type = COALESCE($2, ride_type),
+        ride_type_source = CASE
+            WHEN $2::text IS NULL THEN ride_type_source
+            ELSE 'user'
+        END
     WHERE 
         activities.id = $3 
         AND activities.user_id = $4
     RETURNING activities.id
 )
-SELECT id, created_at, user_id, distance, activity_name, avg_speed, max_speed, ride_type, elapsed_time, total_time, elapsed_time_char, total_time_char, records
+SELECT id, created_at, user_id, distance, activity_name, avg_speed, max_speed, ride_type, elapsed_time, total_time, elapsed_time_char, total_time_char, moving_time, moving_time_char, duplicate_of, description, avg_power, max_power, normalized_power, variability_index, intensity_factor, training_stress_score, ftp, indoor, ride_type_source, elevation_gain, elevation_loss, min_altitude, max_altitude, corrected_points, filled_points, records
 FROM activity_with_records_view awrv
 WHERE awrv.id = (SELECT updated_activity.id FROM updated_activity)
 `
@@ -327,6 +585,25 @@ func (q *Queries) UpdateActivity(ctx context.Context, arg UpdateActivityParams)
 		&i.TotalTime,
 		&i.ElapsedTimeChar,
 		&i.TotalTimeChar,
+		&i.MovingTime,
+		&i.MovingTimeChar,
+		&i.DuplicateOf,
+		&i.Description,
+		&i.AvgPower,
+		&i.MaxPower,
+		&i.NormalizedPower,
+		&i.VariabilityIndex,
+		&i.IntensityFactor,
+		&i.TrainingStressScore,
+		&i.Ftp,
+		&i.Indoor,
+		&i.RideTypeSource,
+		&i.ElevationGain,
+		&i.ElevationLoss,
+		&i.MinAltitude,
+		&i.MaxAltitude,
+		&i.CorrectedPoints,
+		&i.FilledPoints,
 		&i.Records,
 	)
 	return i, err
@@ -0,0 +1,191 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: best_efforts.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type CreateActivityBestEffortsParams struct {
+	ActivityID     int32              `json:"activityId"`
+	Distance       int32              `json:"distance"`
+	Seconds        int32              `json:"seconds"`
+	StartedAt      pgtype.Timestamptz `json:"startedAt"`
+	PersonalRecord bool               `json:"personalRecord"`
+}
+
+const getActivityBestEfforts = `-- name: GetActivityBestEfforts :many
+SELECT
+    distance,
+    seconds,
+    started_at,
+    personal_record
+FROM activity_best_efforts
+WHERE activity_id = $1
+ORDER BY distance
+`
+
+type GetActivityBestEffortsRow struct {
+	Distance       int32              `json:"distance"`
+	Seconds        int32              `json:"seconds"`
+	StartedAt      pgtype.Timestamptz `json:"startedAt"`
+	PersonalRecord bool               `json:"personalRecord"`
+}
+
+func (q *Queries) GetActivityBestEfforts(ctx context.Context, activityID int32) ([]GetActivityBestEffortsRow, error) {
+	rows, err := q.db.Query(ctx, getActivityBestEfforts, activityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetActivityBestEffortsRow
+	for rows.Next() {
+		var i GetActivityBestEffortsRow
+		if err := rows.Scan(
+			&i.Distance,
+			&i.Seconds,
+			&i.StartedAt,
+			&i.PersonalRecord,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPersonalRecords = `-- name: GetPersonalRecords :many
+SELECT DISTINCT ON (e.distance)
+    e.distance,
+    e.seconds,
+    e.started_at,
+    a.id AS activity_id,
+    a.activity_name,
+    a.date_of_activity
+FROM activity_best_efforts e
+JOIN activities a ON a.id = e.activity_id
+WHERE a.user_id = $1
+ORDER BY e.distance, e.seconds, a.date_of_activity
+`
+
+type GetPersonalRecordsRow struct {
+	Distance       int32              `json:"distance"`
+	Seconds        int32              `json:"seconds"`
+	StartedAt      pgtype.Timestamptz `json:"startedAt"`
+	ActivityID     int32              `json:"activityId"`
+	ActivityName   string             `json:"activityName"`
+	DateOfActivity pgtype.Timestamptz `json:"dateOfActivity"`
+}
+
+// The user's fastest effort over each distance with the ride that holds it.
+// Ties go to the earliest ride.
+func (q *Queries) GetPersonalRecords(ctx context.Context, userID string) ([]GetPersonalRecordsRow, error) {
+	rows, err := q.db.Query(ctx, getPersonalRecords, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPersonalRecordsRow
+	for rows.Next() {
+		var i GetPersonalRecordsRow
+		if err := rows.Scan(
+			&i.Distance,
+			&i.Seconds,
+			&i.StartedAt,
+			&i.ActivityID,
+			&i.ActivityName,
+			&i.DateOfActivity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPreviousBestEfforts = `-- name: GetPreviousBestEfforts :many
+SELECT
+    e.distance,
+    MIN(e.seconds)::integer AS seconds
+FROM activity_best_efforts e
+JOIN activities a ON a.id = e.activity_id
+WHERE a.user_id = $1
+    AND a.id <> $2
+    AND a.date_of_activity < $3
+GROUP BY e.distance
+`
+
+type GetPreviousBestEffortsParams struct {
+	UserID     string             `json:"userId"`
+	ActivityID int32              `json:"activityId"`
+	Before     pgtype.Timestamptz `json:"before"`
+}
+
+type GetPreviousBestEffortsRow struct {
+	Distance int32 `json:"distance"`
+	Seconds  int32 `json:"seconds"`
+}
+
+// The user's fastest time over each distance in the rides before the given
+// date, leaving out the activity itself.
+func (q *Queries) GetPreviousBestEfforts(ctx context.Context, arg GetPreviousBestEffortsParams) ([]GetPreviousBestEffortsRow, error) {
+	rows, err := q.db.Query(ctx, getPreviousBestEfforts, arg.UserID, arg.ActivityID, arg.Before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPreviousBestEffortsRow
+	for rows.Next() {
+		var i GetPreviousBestEffortsRow
+		if err := rows.Scan(&i.Distance, &i.Seconds); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const refreshPersonalRecords = `-- name: RefreshPersonalRecords :exec
+UPDATE activity_best_efforts e
+SET personal_record = NOT EXISTS (
+    SELECT 1
+    FROM activity_best_efforts earlier
+    JOIN activities ea ON ea.id = earlier.activity_id
+    WHERE ea.user_id = a.user_id
+        AND ea.id <> a.id
+        AND ea.date_of_activity < a.date_of_activity
+        AND earlier.distance = e.distance
+        AND earlier.seconds <= e.seconds
+)
+FROM activities a
+WHERE a.id = e.activity_id
+    AND a.user_id = $1
+    AND a.date_of_activity > $2
+`
+
+type RefreshPersonalRecordsParams struct {
+	UserID string             `json:"userId"`
+	After  pgtype.Timestamptz `json:"after"`
+}
+
+// Flags the best efforts of the user's rides after the given date again, so
+// they follow a ride whose efforts were added or changed before them. An
+// effort is a record when no earlier ride was as fast over the distance.
+func (q *Queries) RefreshPersonalRecords(ctx context.Context, arg RefreshPersonalRecordsParams) error {
+	_, err := q.db.Exec(ctx, refreshPersonalRecords, arg.UserID, arg.After)
+	return err
+}
@@ -9,6 +9,243 @@ import (
 	"context"
 )
 
+// iteratorForCreateActivityBestEfforts implements pgx.CopyFromSource.
+type iteratorForCreateActivityBestEfforts struct {
+	rows                 []CreateActivityBestEffortsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForCreateActivityBestEfforts) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForCreateActivityBestEfforts) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].ActivityID,
+		r.rows[0].Distance,
+		r.rows[0].Seconds,
+		r.rows[0].StartedAt,
+		r.rows[0].PersonalRecord,
+	}, nil
+}
+
+func (r iteratorForCreateActivityBestEfforts) Err() error {
+	return nil
+}
+
+func (q *Queries) CreateActivityBestEfforts(ctx context.Context, arg []CreateActivityBestEffortsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"activity_best_efforts"}, []string{"activity_id", "distance", "seconds", "started_at", "personal_record"}, &iteratorForCreateActivityBestEfforts{rows: arg})
+}
+
+// iteratorForCreateActivityDevices implements pgx.CopyFromSource.
+type iteratorForCreateActivityDevices struct {
+	rows                 []CreateActivityDevicesParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForCreateActivityDevices) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForCreateActivityDevices) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].ActivityID,
+		r.rows[0].DeviceIndex,
+		r.rows[0].Creator,
+		r.rows[0].Manufacturer,
+		r.rows[0].Product,
+		r.rows[0].ProductName,
+		r.rows[0].SerialNumber,
+		r.rows[0].SoftwareVersion,
+		r.rows[0].HardwareVersion,
+		r.rows[0].DeviceType,
+		r.rows[0].SourceType,
+		r.rows[0].AntDeviceNumber,
+		r.rows[0].BatteryVoltage,
+		r.rows[0].BatteryStatus,
+	}, nil
+}
+
+func (r iteratorForCreateActivityDevices) Err() error {
+	return nil
+}
+
+func (q *Queries) CreateActivityDevices(ctx context.Context, arg []CreateActivityDevicesParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"activity_devices"}, []string{"activity_id", "device_index", "creator", "manufacturer", "product", "product_name", "serial_number", "software_version", "hardware_version", "device_type", "source_type", "ant_device_number", "battery_voltage", "battery_status"}, &iteratorForCreateActivityDevices{rows: arg})
+}
+
+// iteratorForCreateActivityHeartRateZones implements pgx.CopyFromSource.
+type iteratorForCreateActivityHeartRateZones struct {
+	rows                 []CreateActivityHeartRateZonesParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForCreateActivityHeartRateZones) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForCreateActivityHeartRateZones) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].ActivityID,
+		r.rows[0].Zone,
+		r.rows[0].MinHeartRate,
+		r.rows[0].MaxHeartRate,
+		r.rows[0].Seconds,
+	}, nil
+}
+
+func (r iteratorForCreateActivityHeartRateZones) Err() error {
+	return nil
+}
+
+func (q *Queries) CreateActivityHeartRateZones(ctx context.Context, arg []CreateActivityHeartRateZonesParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"activity_heart_rate_zones"}, []string{"activity_id", "zone", "min_heart_rate", "max_heart_rate", "seconds"}, &iteratorForCreateActivityHeartRateZones{rows: arg})
+}
+
+// iteratorForCreateActivityPauses implements pgx.CopyFromSource.
+type iteratorForCreateActivityPauses struct {
+	rows                 []CreateActivityPausesParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForCreateActivityPauses) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForCreateActivityPauses) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].ActivityID,
+		r.rows[0].StartedAt,
+		r.rows[0].EndedAt,
+		r.rows[0].Source,
+	}, nil
+}
+
+func (r iteratorForCreateActivityPauses) Err() error {
+	return nil
+}
+
+func (q *Queries) CreateActivityPauses(ctx context.Context, arg []CreateActivityPausesParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"activity_pauses"}, []string{"activity_id", "started_at", "ended_at", "source"}, &iteratorForCreateActivityPauses{rows: arg})
+}
+
+// iteratorForCreateActivitySessions implements pgx.CopyFromSource.
+type iteratorForCreateActivitySessions struct {
+	rows                 []CreateActivitySessionsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForCreateActivitySessions) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForCreateActivitySessions) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].ActivityID,
+		r.rows[0].SessionIndex,
+		r.rows[0].Sport,
+		r.rows[0].SubSport,
+		r.rows[0].StartTime,
+		r.rows[0].EndTime,
+		r.rows[0].TotalElapsedTime,
+		r.rows[0].TotalTimerTime,
+		r.rows[0].Distance,
+	}, nil
+}
+
+func (r iteratorForCreateActivitySessions) Err() error {
+	return nil
+}
+
+func (q *Queries) CreateActivitySessions(ctx context.Context, arg []CreateActivitySessionsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"activity_sessions"}, []string{"activity_id", "session_index", "sport", "sub_sport", "start_time", "end_time", "total_elapsed_time", "total_timer_time", "distance"}, &iteratorForCreateActivitySessions{rows: arg})
+}
+
+// iteratorForCreateLaps implements pgx.CopyFromSource.
+type iteratorForCreateLaps struct {
+	rows                 []CreateLapsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForCreateLaps) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForCreateLaps) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].ActivityID,
+		r.rows[0].LapIndex,
+		r.rows[0].LapTrigger,
+		r.rows[0].StartTime,
+		r.rows[0].EndTime,
+		r.rows[0].TotalElapsedTime,
+		r.rows[0].TotalTimerTime,
+		r.rows[0].Distance,
+		r.rows[0].AvgSpeed,
+		r.rows[0].MaxSpeed,
+		r.rows[0].AvgHeartRate,
+		r.rows[0].MaxHeartRate,
+		r.rows[0].AvgPower,
+		r.rows[0].MaxPower,
+	}, nil
+}
+
+func (r iteratorForCreateLaps) Err() error {
+	return nil
+}
+
+func (q *Queries) CreateLaps(ctx context.Context, arg []CreateLapsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"laps"}, []string{"activity_id", "lap_index", "lap_trigger", "start_time", "end_time", "total_elapsed_time", "total_timer_time", "distance", "avg_speed", "max_speed", "avg_heart_rate", "max_heart_rate", "avg_power", "max_power"}, &iteratorForCreateLaps{rows: arg})
+}
+
 // iteratorForCreateRecords implements pgx.CopyFromSource.
 type iteratorForCreateRecords struct {
 	rows                 []CreateRecordsParams
@@ -40,6 +277,7 @@ func (r iteratorForCreateRecords) Values() ([]interface{}, error) {
 		r.rows[0].GpsAccuracy,
 		r.rows[0].EnhancedAltitude,
 		r.rows[0].ActivityID,
+		r.rows[0].Power,
 	}, nil
 }
 
@@ -48,5 +286,42 @@ func (r iteratorForCreateRecords) Err() error {
 }
 
 func (q *Queries) CreateRecords(ctx context.Context, arg []CreateRecordsParams) (int64, error) {
-	return q.db.CopyFrom(ctx, []string{"records"}, []string{"time_stamp", "position", "altitude", "heart_rate", "cadence", "distance", "speed", "temperature", "gps_accuracy", "enhanced_altitude", "activity_id"}, &iteratorForCreateRecords{rows: arg})
+	return q.db.CopyFrom(ctx, []string{"records"}, []string{"time_stamp", "position", "altitude", "heart_rate", "cadence", "distance", "speed", "temperature", "gps_accuracy", "enhanced_altitude", "activity_id", "power"}, &iteratorForCreateRecords{rows: arg})
+}
+
+// iteratorForCreateTrainingLoad implements pgx.CopyFromSource.
+type iteratorForCreateTrainingLoad struct {
+	rows                 []CreateTrainingLoadParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForCreateTrainingLoad) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForCreateTrainingLoad) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].UserID,
+		r.rows[0].Day,
+		r.rows[0].Load,
+		r.rows[0].Ctl,
+		r.rows[0].Atl,
+		r.rows[0].Tsb,
+	}, nil
+}
+
+func (r iteratorForCreateTrainingLoad) Err() error {
+	return nil
+}
+
+func (q *Queries) CreateTrainingLoad(ctx context.Context, arg []CreateTrainingLoadParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"daily_training_load"}, []string{"user_id", "day", "load", "ctl", "atl", "tsb"}, &iteratorForCreateTrainingLoad{rows: arg})
 }
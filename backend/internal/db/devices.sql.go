@@ -0,0 +1,99 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: devices.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type CreateActivityDevicesParams struct {
+	ActivityID      int32         `json:"activityId"`
+	DeviceIndex     int16         `json:"deviceIndex"`
+	Creator         bool          `json:"creator"`
+	Manufacturer    string        `json:"manufacturer"`
+	Product         pgtype.Int4   `json:"product"`
+	ProductName     string        `json:"productName"`
+	SerialNumber    pgtype.Int8   `json:"serialNumber"`
+	SoftwareVersion string        `json:"softwareVersion"`
+	HardwareVersion pgtype.Int2   `json:"hardwareVersion"`
+	DeviceType      string        `json:"deviceType"`
+	SourceType      string        `json:"sourceType"`
+	AntDeviceNumber pgtype.Int4   `json:"antDeviceNumber"`
+	BatteryVoltage  pgtype.Float8 `json:"batteryVoltage"`
+	BatteryStatus   string        `json:"batteryStatus"`
+}
+
+const getActivityDevices = `-- name: GetActivityDevices :many
+SELECT
+    device_index,
+    creator,
+    manufacturer,
+    product,
+    product_name,
+    serial_number,
+    software_version,
+    hardware_version,
+    device_type,
+    source_type,
+    ant_device_number,
+    battery_voltage,
+    battery_status
+FROM activity_devices
+WHERE activity_id = $1
+ORDER BY device_index
+`
+
+type GetActivityDevicesRow struct {
+	DeviceIndex     int16         `json:"deviceIndex"`
+	Creator         bool          `json:"creator"`
+	Manufacturer    string        `json:"manufacturer"`
+	Product         pgtype.Int4   `json:"product"`
+	ProductName     string        `json:"productName"`
+	SerialNumber    pgtype.Int8   `json:"serialNumber"`
+	SoftwareVersion string        `json:"softwareVersion"`
+	HardwareVersion pgtype.Int2   `json:"hardwareVersion"`
+	DeviceType      string        `json:"deviceType"`
+	SourceType      string        `json:"sourceType"`
+	AntDeviceNumber pgtype.Int4   `json:"antDeviceNumber"`
+	BatteryVoltage  pgtype.Float8 `json:"batteryVoltage"`
+	BatteryStatus   string        `json:"batteryStatus"`
+}
+
+func (q *Queries) GetActivityDevices(ctx context.Context, activityID int32) ([]GetActivityDevicesRow, error) {
+	rows, err := q.db.Query(ctx, getActivityDevices, activityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetActivityDevicesRow
+	for rows.Next() {
+		var i GetActivityDevicesRow
+		if err := rows.Scan(
+			&i.DeviceIndex,
+			&i.Creator,
+			&i.Manufacturer,
+			&i.Product,
+			&i.ProductName,
+			&i.SerialNumber,
+			&i.SoftwareVersion,
+			&i.HardwareVersion,
+			&i.DeviceType,
+			&i.SourceType,
+			&i.AntDeviceNumber,
+			&i.BatteryVoltage,
+			&i.BatteryStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
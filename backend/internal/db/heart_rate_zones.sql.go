@@ -0,0 +1,109 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: heart_rate_zones.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type CreateActivityHeartRateZonesParams struct {
+	ActivityID   int32       `json:"activityId"`
+	Zone         int32       `json:"zone"`
+	MinHeartRate int32       `json:"minHeartRate"`
+	MaxHeartRate pgtype.Int4 `json:"maxHeartRate"`
+	Seconds      int32       `json:"seconds"`
+}
+
+const getActivityHeartRateZones = `-- name: GetActivityHeartRateZones :many
+SELECT
+    zone,
+    min_heart_rate,
+    max_heart_rate,
+    seconds
+FROM activity_heart_rate_zones
+WHERE activity_id = $1
+ORDER BY zone
+`
+
+type GetActivityHeartRateZonesRow struct {
+	Zone         int32       `json:"zone"`
+	MinHeartRate int32       `json:"minHeartRate"`
+	MaxHeartRate pgtype.Int4 `json:"maxHeartRate"`
+	Seconds      int32       `json:"seconds"`
+}
+
+func (q *Queries) GetActivityHeartRateZones(ctx context.Context, activityID int32) ([]GetActivityHeartRateZonesRow, error) {
+	rows, err := q.db.Query(ctx, getActivityHeartRateZones, activityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetActivityHeartRateZonesRow
+	for rows.Next() {
+		var i GetActivityHeartRateZonesRow
+		if err := rows.Scan(
+			&i.Zone,
+			&i.MinHeartRate,
+			&i.MaxHeartRate,
+			&i.Seconds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWeeklyHeartRateZones = `-- name: GetWeeklyHeartRateZones :many
+SELECT
+    (DATE_TRUNC('week', a.date_of_activity AT TIME ZONE 'UTC') AT TIME ZONE 'UTC')::timestamptz AS week_start,
+    z.zone,
+    SUM(z.seconds)::bigint AS seconds
+FROM activity_heart_rate_zones z
+JOIN activities a ON a.id = z.activity_id
+WHERE a.user_id = $1
+    AND a.date_of_activity >= $2
+GROUP BY week_start, z.zone
+ORDER BY week_start, z.zone
+`
+
+type GetWeeklyHeartRateZonesParams struct {
+	UserID string             `json:"userId"`
+	Since  pgtype.Timestamptz `json:"since"`
+}
+
+type GetWeeklyHeartRateZonesRow struct {
+	WeekStart pgtype.Timestamptz `json:"weekStart"`
+	Zone      int32              `json:"zone"`
+	Seconds   int64              `json:"seconds"`
+}
+
+// Time in each zone summed per week of the ride, from since onwards. Weeks
+// start on Monday in UTC.
+func (q *Queries) GetWeeklyHeartRateZones(ctx context.Context, arg GetWeeklyHeartRateZonesParams) ([]GetWeeklyHeartRateZonesRow, error) {
+	rows, err := q.db.Query(ctx, getWeeklyHeartRateZones, arg.UserID, arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWeeklyHeartRateZonesRow
+	for rows.Next() {
+		var i GetWeeklyHeartRateZonesRow
+		if err := rows.Scan(&i.WeekStart, &i.Zone, &i.Seconds); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,273 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: ingestion.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const claimIngestionJob = `-- name: ClaimIngestionJob :one
+UPDATE ingestion_jobs
+SET
+    status = 'running',
+    attempts = attempts + 1,
+    started_at = NOW()
+WHERE id = (
+    SELECT queued.id
+    FROM ingestion_jobs AS queued
+    WHERE queued.status = 'queued'
+        AND queued.attempts < $1
+    ORDER BY id
+    FOR UPDATE SKIP LOCKED
+    LIMIT 1
+)
+RETURNING id, user_id, filename, content_type, last_modified, data, activity_name, ride_type, description
+`
+
+type ClaimIngestionJobRow struct {
+	ID           int64              `json:"id"`
+	UserID       string             `json:"userId"`
+	Filename     string             `json:"filename"`
+	ContentType  string             `json:"contentType"`
+	LastModified pgtype.Timestamptz `json:"lastModified"`
+	Data         []byte             `json:"data"`
+	ActivityName string             `json:"activityName"`
+	RideType     string             `json:"rideType"`
+	Description  string             `json:"description"`
+}
+
+// Marks the oldest queued job with attempts left as running. Jobs locked by
+// another worker are skipped rather than waited for.
+func (q *Queries) ClaimIngestionJob(ctx context.Context, maxAttempts int32) (ClaimIngestionJobRow, error) {
+	row := q.db.QueryRow(ctx, claimIngestionJob, maxAttempts)
+	var i ClaimIngestionJobRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Filename,
+		&i.ContentType,
+		&i.LastModified,
+		&i.Data,
+		&i.ActivityName,
+		&i.RideType,
+		&i.Description,
+	)
+	return i, err
+}
+
+const completeIngestionJob = `-- name: CompleteIngestionJob :exec
+UPDATE ingestion_jobs
+SET
+    status = $1,
+    activity_id = $2,
+    skipped = $3,
+    failure_reason = $4,
+    error = $5,
+    data = NULL,
+    finished_at = NOW()
+WHERE id = $6
+`
+
+type CompleteIngestionJobParams struct {
+	Status        string      `json:"status"`
+	ActivityID    pgtype.Int4 `json:"activityId"`
+	Skipped       bool        `json:"skipped"`
+	FailureReason string      `json:"failureReason"`
+	Error         string      `json:"error"`
+	ID            int64       `json:"id"`
+}
+
+func (q *Queries) CompleteIngestionJob(ctx context.Context, arg CompleteIngestionJobParams) error {
+	_, err := q.db.Exec(ctx, completeIngestionJob,
+		arg.Status,
+		arg.ActivityID,
+		arg.Skipped,
+		arg.FailureReason,
+		arg.Error,
+		arg.ID,
+	)
+	return err
+}
+
+const enqueueIngestionJob = `-- name: EnqueueIngestionJob :one
+INSERT INTO ingestion_jobs (
+    user_id,
+    filename,
+    content_type,
+    last_modified,
+    data,
+    activity_name,
+    ride_type,
+    description
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, filename, status, created_at
+`
+
+type EnqueueIngestionJobParams struct {
+	UserID       string             `json:"userId"`
+	Filename     string             `json:"filename"`
+	ContentType  string             `json:"contentType"`
+	LastModified pgtype.Timestamptz `json:"lastModified"`
+	Data         []byte             `json:"data"`
+	ActivityName string             `json:"activityName"`
+	RideType     string             `json:"rideType"`
+	Description  string             `json:"description"`
+}
+
+type EnqueueIngestionJobRow struct {
+	ID        int64              `json:"id"`
+	Filename  string             `json:"filename"`
+	Status    string             `json:"status"`
+	CreatedAt pgtype.Timestamptz `json:"createdAt"`
+}
+
+func (q *Queries) EnqueueIngestionJob(ctx context.Context, arg EnqueueIngestionJobParams) (EnqueueIngestionJobRow, error) {
+	row := q.db.QueryRow(ctx, enqueueIngestionJob,
+		arg.UserID,
+		arg.Filename,
+		arg.ContentType,
+		arg.LastModified,
+		arg.Data,
+		arg.ActivityName,
+		arg.RideType,
+		arg.Description,
+	)
+	var i EnqueueIngestionJobRow
+	err := row.Scan(
+		&i.ID,
+		&i.Filename,
+		&i.Status,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getIngestionJobs = `-- name: GetIngestionJobs :many
+SELECT
+    id,
+    filename,
+    status,
+    activity_id,
+    skipped,
+    failure_reason,
+    error,
+    created_at,
+    started_at,
+    finished_at
+FROM ingestion_jobs
+WHERE user_id = $1
+    AND (cardinality($2::bigint[]) = 0 OR id = ANY($2::bigint[]))
+ORDER BY id DESC
+LIMIT $3
+`
+
+type GetIngestionJobsParams struct {
+	UserID   string  `json:"userId"`
+	Ids      []int64 `json:"ids"`
+	RowLimit int32   `json:"rowLimit"`
+}
+
+type GetIngestionJobsRow struct {
+	ID            int64              `json:"id"`
+	Filename      string             `json:"filename"`
+	Status        string             `json:"status"`
+	ActivityID    pgtype.Int4        `json:"activityId"`
+	Skipped       bool               `json:"skipped"`
+	FailureReason string             `json:"failureReason"`
+	Error         string             `json:"error"`
+	CreatedAt     pgtype.Timestamptz `json:"createdAt"`
+	StartedAt     pgtype.Timestamptz `json:"startedAt"`
+	FinishedAt    pgtype.Timestamptz `json:"finishedAt"`
+}
+
+// Lists the jobs of a user, newest first, optionally restricted to ids.
+func (q *Queries) GetIngestionJobs(ctx context.Context, arg GetIngestionJobsParams) ([]GetIngestionJobsRow, error) {
+	rows, err := q.db.Query(ctx, getIngestionJobs, arg.UserID, arg.Ids, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetIngestionJobsRow
+	for rows.Next() {
+		var i GetIngestionJobsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Filename,
+			&i.Status,
+			&i.ActivityID,
+			&i.Skipped,
+			&i.FailureReason,
+			&i.Error,
+			&i.CreatedAt,
+			&i.StartedAt,
+			&i.FinishedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const requeueIngestionJob = `-- name: RequeueIngestionJob :exec
+UPDATE ingestion_jobs
+SET
+    status = CASE WHEN attempts < $1 THEN 'queued' ELSE 'failed' END,
+    failure_reason = CASE WHEN attempts < $1 THEN failure_reason ELSE 'internal' END,
+    error = CASE WHEN attempts < $1 THEN error ELSE $2 END,
+    data = CASE WHEN attempts < $1 THEN data END,
+    started_at = NULL,
+    finished_at = CASE WHEN attempts < $1 THEN NULL ELSE NOW() END
+WHERE id = $3 AND status = 'running'
+`
+
+type RequeueIngestionJobParams struct {
+	MaxAttempts    int32  `json:"maxAttempts"`
+	ExhaustedError string `json:"exhaustedError"`
+	ID             int64  `json:"id"`
+}
+
+// Hands a running job back to the queue, or fails it as internal once it
+// has used up its attempts.
+func (q *Queries) RequeueIngestionJob(ctx context.Context, arg RequeueIngestionJobParams) error {
+	_, err := q.db.Exec(ctx, requeueIngestionJob, arg.MaxAttempts, arg.ExhaustedError, arg.ID)
+	return err
+}
+
+const requeueStaleIngestionJobs = `-- name: RequeueStaleIngestionJobs :execrows
+UPDATE ingestion_jobs
+SET
+    status = CASE WHEN attempts < $1 THEN 'queued' ELSE 'failed' END,
+    failure_reason = CASE WHEN attempts < $1 THEN failure_reason ELSE 'internal' END,
+    error = CASE WHEN attempts < $1 THEN error ELSE $2 END,
+    data = CASE WHEN attempts < $1 THEN data END,
+    started_at = NULL,
+    finished_at = CASE WHEN attempts < $1 THEN NULL ELSE NOW() END
+WHERE status = 'running'
+    AND started_at < $3
+`
+
+type RequeueStaleIngestionJobsParams struct {
+	MaxAttempts    int32              `json:"maxAttempts"`
+	ExhaustedError string             `json:"exhaustedError"`
+	StartedBefore  pgtype.Timestamptz `json:"startedBefore"`
+}
+
+// Returns jobs left running by a worker that went away, e.g. a crashed
+// server, to the queue. Jobs that have used up their attempts, e.g. because
+// they crash the worker every time, are failed as internal instead.
+func (q *Queries) RequeueStaleIngestionJobs(ctx context.Context, arg RequeueStaleIngestionJobsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, requeueStaleIngestionJobs, arg.MaxAttempts, arg.ExhaustedError, arg.StartedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
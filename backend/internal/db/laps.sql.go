@@ -0,0 +1,107 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: laps.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+type CreateLapsParams struct {
+	ActivityID       int32              `json:"activityId"`
+	LapIndex         int16              `json:"lapIndex"`
+	LapTrigger       string             `json:"lapTrigger"`
+	StartTime        pgtype.Timestamptz `json:"startTime"`
+	EndTime          pgtype.Timestamptz `json:"endTime"`
+	TotalElapsedTime time.Duration      `json:"totalElapsedTime"`
+	TotalTimerTime   time.Duration      `json:"totalTimerTime"`
+	Distance         decimal.Decimal    `json:"distance"`
+	AvgSpeed         decimal.Decimal    `json:"avgSpeed"`
+	MaxSpeed         decimal.Decimal    `json:"maxSpeed"`
+	AvgHeartRate     pgtype.Int2        `json:"avgHeartRate"`
+	MaxHeartRate     pgtype.Int2        `json:"maxHeartRate"`
+	AvgPower         pgtype.Int4        `json:"avgPower"`
+	MaxPower         pgtype.Int4        `json:"maxPower"`
+}
+
+const getActivityLaps = `-- name: GetActivityLaps :many
+SELECT
+    l.lap_index,
+    l.lap_trigger,
+    l.start_time,
+    l.end_time,
+    l.total_elapsed_time,
+    l.total_timer_time,
+    l.distance,
+    l.avg_speed,
+    l.max_speed,
+    l.avg_heart_rate,
+    l.max_heart_rate,
+    l.avg_power,
+    l.max_power
+FROM laps l
+JOIN activities a ON a.id = l.activity_id
+WHERE l.activity_id = $1 AND a.user_id = $2
+ORDER BY l.lap_index
+`
+
+type GetActivityLapsParams struct {
+	ActivityID int32  `json:"activityId"`
+	UserID     string `json:"userId"`
+}
+
+type GetActivityLapsRow struct {
+	LapIndex         int16              `json:"lapIndex"`
+	LapTrigger       string             `json:"lapTrigger"`
+	StartTime        pgtype.Timestamptz `json:"startTime"`
+	EndTime          pgtype.Timestamptz `json:"endTime"`
+	TotalElapsedTime time.Duration      `json:"totalElapsedTime"`
+	TotalTimerTime   time.Duration      `json:"totalTimerTime"`
+	Distance         decimal.Decimal    `json:"distance"`
+	AvgSpeed         decimal.Decimal    `json:"avgSpeed"`
+	MaxSpeed         decimal.Decimal    `json:"maxSpeed"`
+	AvgHeartRate     pgtype.Int2        `json:"avgHeartRate"`
+	MaxHeartRate     pgtype.Int2        `json:"maxHeartRate"`
+	AvgPower         pgtype.Int4        `json:"avgPower"`
+	MaxPower         pgtype.Int4        `json:"maxPower"`
+}
+
+func (q *Queries) GetActivityLaps(ctx context.Context, arg GetActivityLapsParams) ([]GetActivityLapsRow, error) {
+	rows, err := q.db.Query(ctx, getActivityLaps, arg.ActivityID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetActivityLapsRow
+	for rows.Next() {
+		var i GetActivityLapsRow
+		if err := rows.Scan(
+			&i.LapIndex,
+			&i.LapTrigger,
+			&i.StartTime,
+			&i.EndTime,
+			&i.TotalElapsedTime,
+			&i.TotalTimerTime,
+			&i.Distance,
+			&i.AvgSpeed,
+			&i.MaxSpeed,
+			&i.AvgHeartRate,
+			&i.MaxHeartRate,
+			&i.AvgPower,
+			&i.MaxPower,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
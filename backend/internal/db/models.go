@@ -12,39 +12,200 @@ import (
 )
 
 type Activity struct {
-	ID              int32              `json:"id"`
-	CreatedAt       pgtype.Timestamptz `json:"createdAt"`
-	DateOfActivity  pgtype.Timestamptz `json:"dateOfActivity"`
-	UserID          string             `json:"userId"`
-	Centroid        pgtype.Point       `json:"centroid"`
-	Distance        decimal.Decimal    `json:"distance"`
-	ActivityName    string             `json:"activityName"`
-	AvgSpeed        decimal.Decimal    `json:"avgSpeed"`
-	MaxSpeed        decimal.Decimal    `json:"maxSpeed"`
-	RideType        string             `json:"rideType"`
-	ElapsedTime     time.Duration      `json:"elapsedTime"`
-	TotalTime       time.Duration      `json:"totalTime"`
-	WeatherImpact   decimal.Decimal    `json:"weatherImpact"`
-	Headwind        int32              `json:"headwind"`
-	LongestHeadwind time.Time          `json:"longestHeadwind"`
-	AirSpeed        decimal.Decimal    `json:"airSpeed"`
-	Temp            decimal.Decimal    `json:"temp"`
+	ID                  int32              `json:"id"`
+	CreatedAt           pgtype.Timestamptz `json:"createdAt"`
+	DateOfActivity      pgtype.Timestamptz `json:"dateOfActivity"`
+	UserID              string             `json:"userId"`
+	Centroid            pgtype.Point       `json:"centroid"`
+	Distance            decimal.Decimal    `json:"distance"`
+	ActivityName        string             `json:"activityName"`
+	AvgSpeed            decimal.Decimal    `json:"avgSpeed"`
+	MaxSpeed            decimal.Decimal    `json:"maxSpeed"`
+	RideType            string             `json:"rideType"`
+	ElapsedTime         time.Duration      `json:"elapsedTime"`
+	TotalTime           time.Duration      `json:"totalTime"`
+	WeatherImpact       decimal.Decimal    `json:"weatherImpact"`
+	Headwind            int32              `json:"headwind"`
+	LongestHeadwind     time.Time          `json:"longestHeadwind"`
+	AirSpeed            decimal.Decimal    `json:"airSpeed"`
+	Temp                decimal.Decimal    `json:"temp"`
+	ContentHash         pgtype.Text        `json:"contentHash"`
+	DeviceFingerprint   pgtype.Text        `json:"deviceFingerprint"`
+	StartedAt           pgtype.Timestamptz `json:"startedAt"`
+	EndedAt             pgtype.Timestamptz `json:"endedAt"`
+	StartPosition       pgtype.Point       `json:"startPosition"`
+	DuplicateOf         pgtype.Int4        `json:"duplicateOf"`
+	Description         string             `json:"description"`
+	AvgPower            pgtype.Int4        `json:"avgPower"`
+	MaxPower            pgtype.Int4        `json:"maxPower"`
+	NormalizedPower     pgtype.Int4        `json:"normalizedPower"`
+	VariabilityIndex    pgtype.Float8      `json:"variabilityIndex"`
+	IntensityFactor     pgtype.Float8      `json:"intensityFactor"`
+	TrainingStressScore pgtype.Float8      `json:"trainingStressScore"`
+	Ftp                 pgtype.Int4        `json:"ftp"`
+	Indoor              bool               `json:"indoor"`
+	MovingTime          time.Duration      `json:"movingTime"`
+	RideTypeSource      string             `json:"rideTypeSource"`
+	ElevationGain       pgtype.Float8      `json:"elevationGain"`
+	ElevationLoss       pgtype.Float8      `json:"elevationLoss"`
+	MinAltitude         pgtype.Float8      `json:"minAltitude"`
+	MaxAltitude         pgtype.Float8      `json:"maxAltitude"`
+	Trimp               pgtype.Float8      `json:"trimp"`
+	CorrectedPoints     int32              `json:"correctedPoints"`
+	FilledPoints        int32              `json:"filledPoints"`
+}
+
+type ActivityBestEffort struct {
+	ActivityID     int32              `json:"activityId"`
+	Distance       int32              `json:"distance"`
+	Seconds        int32              `json:"seconds"`
+	StartedAt      pgtype.Timestamptz `json:"startedAt"`
+	PersonalRecord bool               `json:"personalRecord"`
+}
+
+type ActivityDevice struct {
+	ID              int32         `json:"id"`
+	ActivityID      int32         `json:"activityId"`
+	DeviceIndex     int16         `json:"deviceIndex"`
+	Creator         bool          `json:"creator"`
+	Manufacturer    string        `json:"manufacturer"`
+	Product         pgtype.Int4   `json:"product"`
+	ProductName     string        `json:"productName"`
+	SerialNumber    pgtype.Int8   `json:"serialNumber"`
+	SoftwareVersion string        `json:"softwareVersion"`
+	HardwareVersion pgtype.Int2   `json:"hardwareVersion"`
+	DeviceType      string        `json:"deviceType"`
+	SourceType      string        `json:"sourceType"`
+	AntDeviceNumber pgtype.Int4   `json:"antDeviceNumber"`
+	BatteryVoltage  pgtype.Float8 `json:"batteryVoltage"`
+	BatteryStatus   string        `json:"batteryStatus"`
+}
+
+type ActivityHeartRateZone struct {
+	ActivityID   int32       `json:"activityId"`
+	Zone         int32       `json:"zone"`
+	MinHeartRate int32       `json:"minHeartRate"`
+	MaxHeartRate pgtype.Int4 `json:"maxHeartRate"`
+	Seconds      int32       `json:"seconds"`
+}
+
+type ActivityOriginal struct {
+	ActivityID  int32              `json:"activityId"`
+	StorageKey  string             `json:"storageKey"`
+	Filename    string             `json:"filename"`
+	ContentType string             `json:"contentType"`
+	Size        int64              `json:"size"`
+	CreatedAt   pgtype.Timestamptz `json:"createdAt"`
+}
+
+type ActivityPause struct {
+	ID         int32              `json:"id"`
+	ActivityID int32              `json:"activityId"`
+	StartedAt  pgtype.Timestamptz `json:"startedAt"`
+	EndedAt    pgtype.Timestamptz `json:"endedAt"`
+	Source     string             `json:"source"`
+}
+
+type ActivityPowerCurf struct {
+	ActivityID int32   `json:"activityId"`
+	Durations  []int32 `json:"durations"`
+	Watts      []int32 `json:"watts"`
+}
+
+type ActivitySession struct {
+	ID               int32              `json:"id"`
+	ActivityID       int32              `json:"activityId"`
+	SessionIndex     int16              `json:"sessionIndex"`
+	Sport            string             `json:"sport"`
+	SubSport         string             `json:"subSport"`
+	StartTime        pgtype.Timestamptz `json:"startTime"`
+	EndTime          pgtype.Timestamptz `json:"endTime"`
+	TotalElapsedTime time.Duration      `json:"totalElapsedTime"`
+	TotalTimerTime   time.Duration      `json:"totalTimerTime"`
+	Distance         decimal.Decimal    `json:"distance"`
 }
 
 type ActivityWithRecordsView struct {
-	ID              int32              `json:"id"`
-	CreatedAt       pgtype.Timestamptz `json:"createdAt"`
-	UserID          string             `json:"userId"`
-	Distance        decimal.Decimal    `json:"distance"`
-	ActivityName    string             `json:"activityName"`
-	AvgSpeed        decimal.Decimal    `json:"avgSpeed"`
-	MaxSpeed        decimal.Decimal    `json:"maxSpeed"`
-	RideType        string             `json:"rideType"`
-	ElapsedTime     time.Duration      `json:"elapsedTime"`
-	TotalTime       time.Duration      `json:"totalTime"`
-	ElapsedTimeChar string             `json:"elapsedTimeChar"`
-	TotalTimeChar   string             `json:"totalTimeChar"`
-	Records         []Record           `json:"records"`
+	ID                  int32              `json:"id"`
+	CreatedAt           pgtype.Timestamptz `json:"createdAt"`
+	UserID              string             `json:"userId"`
+	Distance            decimal.Decimal    `json:"distance"`
+	ActivityName        string             `json:"activityName"`
+	AvgSpeed            decimal.Decimal    `json:"avgSpeed"`
+	MaxSpeed            decimal.Decimal    `json:"maxSpeed"`
+	RideType            string             `json:"rideType"`
+	ElapsedTime         time.Duration      `json:"elapsedTime"`
+	TotalTime           time.Duration      `json:"totalTime"`
+	ElapsedTimeChar     string             `json:"elapsedTimeChar"`
+	TotalTimeChar       string             `json:"totalTimeChar"`
+	MovingTime          time.Duration      `json:"movingTime"`
+	MovingTimeChar      string             `json:"movingTimeChar"`
+	DuplicateOf         pgtype.Int4        `json:"duplicateOf"`
+	Description         string             `json:"description"`
+	AvgPower            pgtype.Int4        `json:"avgPower"`
+	MaxPower            pgtype.Int4        `json:"maxPower"`
+	NormalizedPower     pgtype.Int4        `json:"normalizedPower"`
+	VariabilityIndex    pgtype.Float8      `json:"variabilityIndex"`
+	IntensityFactor     pgtype.Float8      `json:"intensityFactor"`
+	TrainingStressScore pgtype.Float8      `json:"trainingStressScore"`
+	Ftp                 pgtype.Int4        `json:"ftp"`
+	Indoor              bool               `json:"indoor"`
+	RideTypeSource      string             `json:"rideTypeSource"`
+	ElevationGain       pgtype.Float8      `json:"elevationGain"`
+	ElevationLoss       pgtype.Float8      `json:"elevationLoss"`
+	MinAltitude         pgtype.Float8      `json:"minAltitude"`
+	MaxAltitude         pgtype.Float8      `json:"maxAltitude"`
+	CorrectedPoints     int32              `json:"correctedPoints"`
+	FilledPoints        int32              `json:"filledPoints"`
+	Records             []Record           `json:"records"`
+}
+
+type DailyTrainingLoad struct {
+	UserID string      `json:"userId"`
+	Day    pgtype.Date `json:"day"`
+	Load   float64     `json:"load"`
+	Ctl    float64     `json:"ctl"`
+	Atl    float64     `json:"atl"`
+	Tsb    float64     `json:"tsb"`
+}
+
+type IngestionJob struct {
+	ID            int64              `json:"id"`
+	UserID        string             `json:"userId"`
+	Filename      string             `json:"filename"`
+	ContentType   string             `json:"contentType"`
+	LastModified  pgtype.Timestamptz `json:"lastModified"`
+	Data          []byte             `json:"data"`
+	ActivityName  string             `json:"activityName"`
+	RideType      string             `json:"rideType"`
+	Description   string             `json:"description"`
+	Status        string             `json:"status"`
+	Attempts      int32              `json:"attempts"`
+	ActivityID    pgtype.Int4        `json:"activityId"`
+	Skipped       bool               `json:"skipped"`
+	FailureReason string             `json:"failureReason"`
+	Error         string             `json:"error"`
+	CreatedAt     pgtype.Timestamptz `json:"createdAt"`
+	StartedAt     pgtype.Timestamptz `json:"startedAt"`
+	FinishedAt    pgtype.Timestamptz `json:"finishedAt"`
+}
+
+type Lap struct {
+	ID               int32              `json:"id"`
+	ActivityID       int32              `json:"activityId"`
+	LapIndex         int16              `json:"lapIndex"`
+	LapTrigger       string             `json:"lapTrigger"`
+	StartTime        pgtype.Timestamptz `json:"startTime"`
+	EndTime          pgtype.Timestamptz `json:"endTime"`
+	TotalElapsedTime time.Duration      `json:"totalElapsedTime"`
+	TotalTimerTime   time.Duration      `json:"totalTimerTime"`
+	Distance         decimal.Decimal    `json:"distance"`
+	AvgSpeed         decimal.Decimal    `json:"avgSpeed"`
+	MaxSpeed         decimal.Decimal    `json:"maxSpeed"`
+	AvgHeartRate     pgtype.Int2        `json:"avgHeartRate"`
+	MaxHeartRate     pgtype.Int2        `json:"maxHeartRate"`
+	AvgPower         pgtype.Int4        `json:"avgPower"`
+	MaxPower         pgtype.Int4        `json:"maxPower"`
 }
 
 type Record struct {
@@ -61,4 +222,15 @@ type Record struct {
 	EnhancedAltitude pgtype.Int4        `json:"enhancedAltitude"`
 	ActivityID       pgtype.Int4        `json:"activityId"`
 	Bearing          float64            `json:"bearing"`
+	Power            pgtype.Int4        `json:"power"`
+}
+
+type UserSetting struct {
+	UserID              string             `json:"userId"`
+	Ftp                 pgtype.Int4        `json:"ftp"`
+	UpdatedAt           pgtype.Timestamptz `json:"updatedAt"`
+	MaxHeartRate        pgtype.Int4        `json:"maxHeartRate"`
+	ThresholdHeartRate  pgtype.Int4        `json:"thresholdHeartRate"`
+	HeartRateZoneMethod pgtype.Text        `json:"heartRateZoneMethod"`
+	HeartRateZoneBounds []int32            `json:"heartRateZoneBounds"`
 }
@@ -0,0 +1,71 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: originals.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createActivityOriginal = `-- name: CreateActivityOriginal :exec
+INSERT INTO activity_originals (
+    activity_id,
+    storage_key,
+    filename,
+    content_type,
+    size
+) VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateActivityOriginalParams struct {
+	ActivityID  int32  `json:"activityId"`
+	StorageKey  string `json:"storageKey"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+}
+
+func (q *Queries) CreateActivityOriginal(ctx context.Context, arg CreateActivityOriginalParams) error {
+	_, err := q.db.Exec(ctx, createActivityOriginal,
+		arg.ActivityID,
+		arg.StorageKey,
+		arg.Filename,
+		arg.ContentType,
+		arg.Size,
+	)
+	return err
+}
+
+const getActivityOriginal = `-- name: GetActivityOriginal :one
+SELECT
+    o.activity_id,
+    o.storage_key,
+    o.filename,
+    o.content_type,
+    o.size,
+    o.created_at
+FROM activity_originals o
+JOIN activities a ON a.id = o.activity_id
+WHERE o.activity_id = $1 AND a.user_id = $2
+`
+
+type GetActivityOriginalParams struct {
+	ActivityID int32  `json:"activityId"`
+	UserID     string `json:"userId"`
+}
+
+func (q *Queries) GetActivityOriginal(ctx context.Context, arg GetActivityOriginalParams) (ActivityOriginal, error) {
+	row := q.db.QueryRow(ctx, getActivityOriginal, arg.ActivityID, arg.UserID)
+	var i ActivityOriginal
+	err := row.Scan(
+		&i.ActivityID,
+		&i.StorageKey,
+		&i.Filename,
+		&i.ContentType,
+		&i.Size,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,55 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: pauses.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type CreateActivityPausesParams struct {
+	ActivityID int32              `json:"activityId"`
+	StartedAt  pgtype.Timestamptz `json:"startedAt"`
+	EndedAt    pgtype.Timestamptz `json:"endedAt"`
+	Source     string             `json:"source"`
+}
+
+const getActivityPauses = `-- name: GetActivityPauses :many
+SELECT
+    started_at,
+    ended_at,
+    source
+FROM activity_pauses
+WHERE activity_id = $1
+ORDER BY started_at
+`
+
+type GetActivityPausesRow struct {
+	StartedAt pgtype.Timestamptz `json:"startedAt"`
+	EndedAt   pgtype.Timestamptz `json:"endedAt"`
+	Source    string             `json:"source"`
+}
+
+func (q *Queries) GetActivityPauses(ctx context.Context, activityID int32) ([]GetActivityPausesRow, error) {
+	rows, err := q.db.Query(ctx, getActivityPauses, activityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetActivityPausesRow
+	for rows.Next() {
+		var i GetActivityPausesRow
+		if err := rows.Scan(&i.StartedAt, &i.EndedAt, &i.Source); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
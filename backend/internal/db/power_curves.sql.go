@@ -0,0 +1,113 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: power_curves.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createActivityPowerCurve = `-- name: CreateActivityPowerCurve :exec
+INSERT INTO activity_power_curves (
+    activity_id,
+    durations,
+    watts
+) VALUES ($1, $2, $3)
+`
+
+type CreateActivityPowerCurveParams struct {
+	ActivityID int32   `json:"activityId"`
+	Durations  []int32 `json:"durations"`
+	Watts      []int32 `json:"watts"`
+}
+
+func (q *Queries) CreateActivityPowerCurve(ctx context.Context, arg CreateActivityPowerCurveParams) error {
+	_, err := q.db.Exec(ctx, createActivityPowerCurve, arg.ActivityID, arg.Durations, arg.Watts)
+	return err
+}
+
+const getActivityPowerCurve = `-- name: GetActivityPowerCurve :one
+SELECT
+    durations,
+    watts
+FROM activity_power_curves
+WHERE activity_id = $1
+`
+
+type GetActivityPowerCurveRow struct {
+	Durations []int32 `json:"durations"`
+	Watts     []int32 `json:"watts"`
+}
+
+func (q *Queries) GetActivityPowerCurve(ctx context.Context, activityID int32) (GetActivityPowerCurveRow, error) {
+	row := q.db.QueryRow(ctx, getActivityPowerCurve, activityID)
+	var i GetActivityPowerCurveRow
+	err := row.Scan(&i.Durations, &i.Watts)
+	return i, err
+}
+
+const getPowerCurveEnvelope = `-- name: GetPowerCurveEnvelope :many
+SELECT DISTINCT ON (p.durations[i])
+    p.durations[i]::integer AS duration,
+    p.watts[i]::integer AS watts,
+    a.id AS activity_id,
+    a.date_of_activity
+FROM activities a
+JOIN activity_power_curves p ON p.activity_id = a.id
+CROSS JOIN LATERAL GENERATE_SUBSCRIPTS(p.durations, 1) AS i
+WHERE a.user_id = $1
+    AND a.date_of_activity >= $2
+    AND a.date_of_activity < $3
+    AND ($4::text IS NULL OR a.ride_type = $4)
+ORDER BY p.durations[i], p.watts[i] DESC, a.date_of_activity
+`
+
+type GetPowerCurveEnvelopeParams struct {
+	UserID   string             `json:"userId"`
+	FromDate pgtype.Timestamptz `json:"fromDate"`
+	ToDate   pgtype.Timestamptz `json:"toDate"`
+	RideType pgtype.Text        `json:"rideType"`
+}
+
+type GetPowerCurveEnvelopeRow struct {
+	Duration       int32              `json:"duration"`
+	Watts          int32              `json:"watts"`
+	ActivityID     int32              `json:"activityId"`
+	DateOfActivity pgtype.Timestamptz `json:"dateOfActivity"`
+}
+
+// The best power for every duration over the user's rides in the date range,
+// with the ride that holds it. Ties go to the earliest ride.
+func (q *Queries) GetPowerCurveEnvelope(ctx context.Context, arg GetPowerCurveEnvelopeParams) ([]GetPowerCurveEnvelopeRow, error) {
+	rows, err := q.db.Query(ctx, getPowerCurveEnvelope,
+		arg.UserID,
+		arg.FromDate,
+		arg.ToDate,
+		arg.RideType,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPowerCurveEnvelopeRow
+	for rows.Next() {
+		var i GetPowerCurveEnvelopeRow
+		if err := rows.Scan(
+			&i.Duration,
+			&i.Watts,
+			&i.ActivityID,
+			&i.DateOfActivity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
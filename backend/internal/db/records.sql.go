@@ -23,10 +23,11 @@ type CreateRecordsParams struct {
 	GpsAccuracy      pgtype.Int2        `json:"gpsAccuracy"`
 	EnhancedAltitude pgtype.Int4        `json:"enhancedAltitude"`
 	ActivityID       pgtype.Int4        `json:"activityId"`
+	Power            pgtype.Int4        `json:"power"`
 }
 
 const getRecords = `-- name: GetRecords :many
-SELECT id, time_stamp, position, altitude, heart_rate, cadence, distance, speed, temperature, gps_accuracy, enhanced_altitude, activity_id, bearing
+SELECT id, time_stamp, position, altitude, heart_rate, cadence, distance, speed, temperature, gps_accuracy, enhanced_altitude, activity_id, bearing, power
 FROM records
 WHERE activity_id = $1
 `
@@ -54,6 +55,7 @@ func (q *Queries) GetRecords(ctx context.Context, activityID pgtype.Int4) ([]Rec
 			&i.EnhancedAltitude,
 			&i.ActivityID,
 			&i.Bearing,
+			&i.Power,
 		); err != nil {
 			return nil, err
 		}
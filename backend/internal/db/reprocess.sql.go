@@ -0,0 +1,214 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: reprocess.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+const clearActivityDetails = `-- name: ClearActivityDetails :exec
+WITH deleted_records AS (
+    DELETE FROM records r WHERE r.activity_id = $1::integer
+), deleted_sessions AS (
+    DELETE FROM activity_sessions s WHERE s.activity_id = $1::integer
+), deleted_laps AS (
+    DELETE FROM laps l WHERE l.activity_id = $1::integer
+), deleted_devices AS (
+    DELETE FROM activity_devices d WHERE d.activity_id = $1::integer
+), deleted_heart_rate_zones AS (
+    DELETE FROM activity_heart_rate_zones z WHERE z.activity_id = $1::integer
+), deleted_power_curves AS (
+    DELETE FROM activity_power_curves c WHERE c.activity_id = $1::integer
+), deleted_best_efforts AS (
+    DELETE FROM activity_best_efforts e WHERE e.activity_id = $1::integer
+)
+DELETE FROM activity_pauses p WHERE p.activity_id = $1::integer
+`
+
+// Removes the rows derived from the activity file so they can be stored again.
+func (q *Queries) ClearActivityDetails(ctx context.Context, activityID int32) error {
+	_, err := q.db.Exec(ctx, clearActivityDetails, activityID)
+	return err
+}
+
+const getActivityDate = `-- name: GetActivityDate :one
+SELECT date_of_activity
+FROM activities
+WHERE id = $1
+`
+
+// The stored start of the activity, read before reprocessing may move it.
+func (q *Queries) GetActivityDate(ctx context.Context, id int32) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, getActivityDate, id)
+	var date_of_activity pgtype.Timestamptz
+	err := row.Scan(&date_of_activity)
+	return date_of_activity, err
+}
+
+const getReprocessableActivities = `-- name: GetReprocessableActivities :many
+SELECT
+    o.activity_id,
+    a.user_id,
+    o.storage_key,
+    o.filename,
+    o.content_type
+FROM activity_originals o
+JOIN activities a ON a.id = o.activity_id
+WHERE ($1::integer IS NULL OR o.activity_id = $1)
+  AND ($2::text IS NULL OR a.user_id::text = $2)
+ORDER BY o.activity_id
+`
+
+type GetReprocessableActivitiesParams struct {
+	ActivityID pgtype.Int4 `json:"activityId"`
+	UserID     pgtype.Text `json:"userId"`
+}
+
+type GetReprocessableActivitiesRow struct {
+	ActivityID  int32  `json:"activityId"`
+	UserID      string `json:"userId"`
+	StorageKey  string `json:"storageKey"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+}
+
+// Activities with a stored original, optionally narrowed to one activity or
+// one user.
+func (q *Queries) GetReprocessableActivities(ctx context.Context, arg GetReprocessableActivitiesParams) ([]GetReprocessableActivitiesRow, error) {
+	rows, err := q.db.Query(ctx, getReprocessableActivities, arg.ActivityID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReprocessableActivitiesRow
+	for rows.Next() {
+		var i GetReprocessableActivitiesRow
+		if err := rows.Scan(
+			&i.ActivityID,
+			&i.UserID,
+			&i.StorageKey,
+			&i.Filename,
+			&i.ContentType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const replaceActivityMetrics = `-- name: ReplaceActivityMetrics :exec
+UPDATE activities SET
+    ride_type = CASE
+        WHEN ride_type_source = 'detected' THEN $1
+        ELSE ride_type
+    END,
+    distance = $2,
+    avg_speed = $3,
+    max_speed = $4,
+    elapsed_time = $5,
+    total_time = $6,
+    date_of_activity = $7,
+    content_hash = $8,
+    device_fingerprint = $9,
+    started_at = $10,
+    ended_at = $11,
+    start_position = $12,
+    avg_power = $13,
+    max_power = $14,
+    normalized_power = $15,
+    variability_index = $16,
+    intensity_factor = $17,
+    training_stress_score = $18,
+    ftp = $19,
+    indoor = $20,
+    moving_time = $21,
+    elevation_gain = $22,
+    elevation_loss = $23,
+    min_altitude = $24,
+    max_altitude = $25,
+    trimp = $26,
+    corrected_points = $27,
+    filled_points = $28
+WHERE id = $29
+`
+
+type ReplaceActivityMetricsParams struct {
+	RideType            string             `json:"rideType"`
+	Distance            decimal.Decimal    `json:"distance"`
+	AvgSpeed            decimal.Decimal    `json:"avgSpeed"`
+	MaxSpeed            decimal.Decimal    `json:"maxSpeed"`
+	ElapsedTime         time.Duration      `json:"elapsedTime"`
+	TotalTime           time.Duration      `json:"totalTime"`
+	DateOfActivity      pgtype.Timestamptz `json:"dateOfActivity"`
+	ContentHash         pgtype.Text        `json:"contentHash"`
+	DeviceFingerprint   pgtype.Text        `json:"deviceFingerprint"`
+	StartedAt           pgtype.Timestamptz `json:"startedAt"`
+	EndedAt             pgtype.Timestamptz `json:"endedAt"`
+	StartPosition       pgtype.Point       `json:"startPosition"`
+	AvgPower            pgtype.Int4        `json:"avgPower"`
+	MaxPower            pgtype.Int4        `json:"maxPower"`
+	NormalizedPower     pgtype.Int4        `json:"normalizedPower"`
+	VariabilityIndex    pgtype.Float8      `json:"variabilityIndex"`
+	IntensityFactor     pgtype.Float8      `json:"intensityFactor"`
+	TrainingStressScore pgtype.Float8      `json:"trainingStressScore"`
+	Ftp                 pgtype.Int4        `json:"ftp"`
+	Indoor              bool               `json:"indoor"`
+	MovingTime          time.Duration      `json:"movingTime"`
+	ElevationGain       pgtype.Float8      `json:"elevationGain"`
+	ElevationLoss       pgtype.Float8      `json:"elevationLoss"`
+	MinAltitude         pgtype.Float8      `json:"minAltitude"`
+	MaxAltitude         pgtype.Float8      `json:"maxAltitude"`
+	Trimp               pgtype.Float8      `json:"trimp"`
+	CorrectedPoints     int32              `json:"correctedPoints"`
+	FilledPoints        int32              `json:"filledPoints"`
+	ID                  int32              `json:"id"`
+}
+
+// Overwrites the values derived from the activity file. Fields the user can
+// edit (activity_name, description, a ride_type set by the user) and
+// duplicate_of are kept.
+func (q *Queries) ReplaceActivityMetrics(ctx context.Context, arg ReplaceActivityMetricsParams) error {
+	_, err := q.db.Exec(ctx, replaceActivityMetrics,
+		arg.RideType,
+		arg.Distance,
+		arg.AvgSpeed,
+		arg.MaxSpeed,
+		arg.ElapsedTime,
+		arg.TotalTime,
+		arg.DateOfActivity,
+		arg.ContentHash,
+		arg.DeviceFingerprint,
+		arg.StartedAt,
+		arg.EndedAt,
+		arg.StartPosition,
+		arg.AvgPower,
+		arg.MaxPower,
+		arg.NormalizedPower,
+		arg.VariabilityIndex,
+		arg.IntensityFactor,
+		arg.TrainingStressScore,
+		arg.Ftp,
+		arg.Indoor,
+		arg.MovingTime,
+		arg.ElevationGain,
+		arg.ElevationLoss,
+		arg.MinAltitude,
+		arg.MaxAltitude,
+		arg.Trimp,
+		arg.CorrectedPoints,
+		arg.FilledPoints,
+		arg.ID,
+	)
+	return err
+}
@@ -0,0 +1,81 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: sessions.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+type CreateActivitySessionsParams struct {
+	ActivityID       int32              `json:"activityId"`
+	SessionIndex     int16              `json:"sessionIndex"`
+	Sport            string             `json:"sport"`
+	SubSport         string             `json:"subSport"`
+	StartTime        pgtype.Timestamptz `json:"startTime"`
+	EndTime          pgtype.Timestamptz `json:"endTime"`
+	TotalElapsedTime time.Duration      `json:"totalElapsedTime"`
+	TotalTimerTime   time.Duration      `json:"totalTimerTime"`
+	Distance         decimal.Decimal    `json:"distance"`
+}
+
+const getActivitySessions = `-- name: GetActivitySessions :many
+SELECT
+    session_index,
+    sport,
+    sub_sport,
+    start_time,
+    end_time,
+    total_elapsed_time,
+    total_timer_time,
+    distance
+FROM activity_sessions
+WHERE activity_id = $1
+ORDER BY session_index
+`
+
+type GetActivitySessionsRow struct {
+	SessionIndex     int16              `json:"sessionIndex"`
+	Sport            string             `json:"sport"`
+	SubSport         string             `json:"subSport"`
+	StartTime        pgtype.Timestamptz `json:"startTime"`
+	EndTime          pgtype.Timestamptz `json:"endTime"`
+	TotalElapsedTime time.Duration      `json:"totalElapsedTime"`
+	TotalTimerTime   time.Duration      `json:"totalTimerTime"`
+	Distance         decimal.Decimal    `json:"distance"`
+}
+
+func (q *Queries) GetActivitySessions(ctx context.Context, activityID int32) ([]GetActivitySessionsRow, error) {
+	rows, err := q.db.Query(ctx, getActivitySessions, activityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetActivitySessionsRow
+	for rows.Next() {
+		var i GetActivitySessionsRow
+		if err := rows.Scan(
+			&i.SessionIndex,
+			&i.Sport,
+			&i.SubSport,
+			&i.StartTime,
+			&i.EndTime,
+			&i.TotalElapsedTime,
+			&i.TotalTimerTime,
+			&i.Distance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
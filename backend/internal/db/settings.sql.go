@@ -0,0 +1,105 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: settings.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const getUserSettings = `-- name: GetUserSettings :one
+SELECT
+    user_id,
+    ftp,
+    updated_at,
+    max_heart_rate,
+    threshold_heart_rate,
+    heart_rate_zone_method,
+    heart_rate_zone_bounds
+FROM user_settings
+WHERE user_id = $1
+`
+
+func (q *Queries) GetUserSettings(ctx context.Context, userID string) (UserSetting, error) {
+	row := q.db.QueryRow(ctx, getUserSettings, userID)
+	var i UserSetting
+	err := row.Scan(
+		&i.UserID,
+		&i.Ftp,
+		&i.UpdatedAt,
+		&i.MaxHeartRate,
+		&i.ThresholdHeartRate,
+		&i.HeartRateZoneMethod,
+		&i.HeartRateZoneBounds,
+	)
+	return i, err
+}
+
+const upsertUserSettings = `-- name: UpsertUserSettings :one
+INSERT INTO user_settings (
+    user_id,
+    ftp,
+    max_heart_rate,
+    threshold_heart_rate,
+    heart_rate_zone_method,
+    heart_rate_zone_bounds
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
+)
+ON CONFLICT (user_id) DO UPDATE
+SET
+    ftp = EXCLUDED.ftp,
+    max_heart_rate = EXCLUDED.max_heart_rate,
+    threshold_heart_rate = EXCLUDED.threshold_heart_rate,
+    heart_rate_zone_method = EXCLUDED.heart_rate_zone_method,
+    heart_rate_zone_bounds = EXCLUDED.heart_rate_zone_bounds,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING
+    user_id,
+    ftp,
+    updated_at,
+    max_heart_rate,
+    threshold_heart_rate,
+    heart_rate_zone_method,
+    heart_rate_zone_bounds
+`
+
+type UpsertUserSettingsParams struct {
+	UserID              string      `json:"userId"`
+	Ftp                 pgtype.Int4 `json:"ftp"`
+	MaxHeartRate        pgtype.Int4 `json:"maxHeartRate"`
+	ThresholdHeartRate  pgtype.Int4 `json:"thresholdHeartRate"`
+	HeartRateZoneMethod pgtype.Text `json:"heartRateZoneMethod"`
+	HeartRateZoneBounds []int32     `json:"heartRateZoneBounds"`
+}
+
+func (q *Queries) UpsertUserSettings(ctx context.Context, arg UpsertUserSettingsParams) (UserSetting, error) {
+	row := q.db.QueryRow(ctx, upsertUserSettings,
+		arg.UserID,
+		arg.Ftp,
+		arg.MaxHeartRate,
+		arg.ThresholdHeartRate,
+		arg.HeartRateZoneMethod,
+		arg.HeartRateZoneBounds,
+	)
+	var i UserSetting
+	err := row.Scan(
+		&i.UserID,
+		&i.Ftp,
+		&i.UpdatedAt,
+		&i.MaxHeartRate,
+		&i.ThresholdHeartRate,
+		&i.HeartRateZoneMethod,
+		&i.HeartRateZoneBounds,
+	)
+	return i, err
+}
@@ -0,0 +1,177 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: training_load.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type CreateTrainingLoadParams struct {
+	UserID string      `json:"userId"`
+	Day    pgtype.Date `json:"day"`
+	Load   float64     `json:"load"`
+	Ctl    float64     `json:"ctl"`
+	Atl    float64     `json:"atl"`
+	Tsb    float64     `json:"tsb"`
+}
+
+const deleteTrainingLoadFrom = `-- name: DeleteTrainingLoadFrom :exec
+DELETE FROM daily_training_load
+WHERE user_id = $1
+    AND day >= $2::date
+`
+
+type DeleteTrainingLoadFromParams struct {
+	UserID string      `json:"userId"`
+	Since  pgtype.Date `json:"since"`
+}
+
+func (q *Queries) DeleteTrainingLoadFrom(ctx context.Context, arg DeleteTrainingLoadFromParams) error {
+	_, err := q.db.Exec(ctx, deleteTrainingLoadFrom, arg.UserID, arg.Since)
+	return err
+}
+
+const getDailyActivityLoads = `-- name: GetDailyActivityLoads :many
+SELECT
+    (a.date_of_activity AT TIME ZONE 'UTC')::date AS day,
+    SUM(COALESCE(a.training_stress_score, a.trimp, 0))::double precision AS load
+FROM activities a
+WHERE a.user_id = $1
+    AND a.duplicate_of IS NULL
+    AND (a.date_of_activity AT TIME ZONE 'UTC')::date >= $2::date
+GROUP BY day
+ORDER BY day
+`
+
+type GetDailyActivityLoadsParams struct {
+	UserID string      `json:"userId"`
+	Since  pgtype.Date `json:"since"`
+}
+
+type GetDailyActivityLoadsRow struct {
+	Day  pgtype.Date `json:"day"`
+	Load float64     `json:"load"`
+}
+
+// The summed load of the user's rides per UTC day from since onwards: TSS
+// where there is power, otherwise TRIMP. Rides imported as duplicates of
+// another are left out.
+func (q *Queries) GetDailyActivityLoads(ctx context.Context, arg GetDailyActivityLoadsParams) ([]GetDailyActivityLoadsRow, error) {
+	rows, err := q.db.Query(ctx, getDailyActivityLoads, arg.UserID, arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDailyActivityLoadsRow
+	for rows.Next() {
+		var i GetDailyActivityLoadsRow
+		if err := rows.Scan(&i.Day, &i.Load); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTrainingLoad = `-- name: GetTrainingLoad :many
+SELECT
+    day,
+    load,
+    ctl,
+    atl,
+    tsb
+FROM daily_training_load
+WHERE user_id = $1
+    AND day >= $2::date
+    AND day <= $3::date
+ORDER BY day
+`
+
+type GetTrainingLoadParams struct {
+	UserID  string      `json:"userId"`
+	FromDay pgtype.Date `json:"fromDay"`
+	ToDay   pgtype.Date `json:"toDay"`
+}
+
+type GetTrainingLoadRow struct {
+	Day  pgtype.Date `json:"day"`
+	Load float64     `json:"load"`
+	Ctl  float64     `json:"ctl"`
+	Atl  float64     `json:"atl"`
+	Tsb  float64     `json:"tsb"`
+}
+
+func (q *Queries) GetTrainingLoad(ctx context.Context, arg GetTrainingLoadParams) ([]GetTrainingLoadRow, error) {
+	rows, err := q.db.Query(ctx, getTrainingLoad, arg.UserID, arg.FromDay, arg.ToDay)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTrainingLoadRow
+	for rows.Next() {
+		var i GetTrainingLoadRow
+		if err := rows.Scan(
+			&i.Day,
+			&i.Load,
+			&i.Ctl,
+			&i.Atl,
+			&i.Tsb,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTrainingLoadBefore = `-- name: GetTrainingLoadBefore :one
+SELECT
+    day,
+    ctl,
+    atl
+FROM daily_training_load
+WHERE user_id = $1
+    AND day < $2::date
+ORDER BY day DESC
+LIMIT 1
+`
+
+type GetTrainingLoadBeforeParams struct {
+	UserID string      `json:"userId"`
+	Before pgtype.Date `json:"before"`
+}
+
+type GetTrainingLoadBeforeRow struct {
+	Day pgtype.Date `json:"day"`
+	Ctl float64     `json:"ctl"`
+	Atl float64     `json:"atl"`
+}
+
+// The user's latest stored day before the given one.
+func (q *Queries) GetTrainingLoadBefore(ctx context.Context, arg GetTrainingLoadBeforeParams) (GetTrainingLoadBeforeRow, error) {
+	row := q.db.QueryRow(ctx, getTrainingLoadBefore, arg.UserID, arg.Before)
+	var i GetTrainingLoadBeforeRow
+	err := row.Scan(&i.Day, &i.Ctl, &i.Atl)
+	return i, err
+}
+
+const lockTrainingLoad = `-- name: LockTrainingLoad :exec
+SELECT pg_advisory_xact_lock(hashtext($1::text))
+`
+
+// Serialises rebuilds of a user's training load until the transaction ends.
+func (q *Queries) LockTrainingLoad(ctx context.Context, userID string) error {
+	_, err := q.db.Exec(ctx, lockTrainingLoad, userID)
+	return err
+}
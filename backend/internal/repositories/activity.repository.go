@@ -3,16 +3,56 @@ package repositories
 import (
 	"context"
 
+	"github.com/jackc/pgx/v5/pgtype"
+
 	"github.com/notaduck/backend/internal/db"
 )
 
 type ActivityRepository interface {
 	CreateActivity(ctx context.Context, params db.CreateActivityParams) (int32, error)
+	FindDuplicateActivity(ctx context.Context, params db.FindDuplicateActivityParams) (int32, error)
+	GetOverlappingActivities(ctx context.Context, params db.GetOverlappingActivitiesParams) ([]db.GetOverlappingActivitiesRow, error)
 	UpdateActivity(ctx context.Context, params db.UpdateActivityParams) (db.ActivityWithRecordsView, error)
+	DeleteActivity(ctx context.Context, params db.DeleteActivityParams) (pgtype.Timestamptz, error)
 	GetActivities(ctx context.Context, userId string) ([]db.GetActivitiesRow, error)
 	GetActivity(ctx context.Context, id int32) (db.GetActivityRow, error)
-	GetActivityAndRecords(ctx context.Context, id int32) (db.ActivityWithRecordsView, error)
+	GetActivityAndRecords(ctx context.Context, params db.GetActivityWithRecordsViewParams) (db.ActivityWithRecordsView, error)
 	GetActivityStats(ctx context.Context, userId string) (db.GetActivityStatsRow, error)
+	CreateActivitySessions(ctx context.Context, params []db.CreateActivitySessionsParams) (int64, error)
+	GetActivitySessions(ctx context.Context, activityId int32) ([]db.GetActivitySessionsRow, error)
+	CreateLaps(ctx context.Context, params []db.CreateLapsParams) (int64, error)
+	GetActivityLaps(ctx context.Context, params db.GetActivityLapsParams) ([]db.GetActivityLapsRow, error)
+	CreateActivityDevices(ctx context.Context, params []db.CreateActivityDevicesParams) (int64, error)
+	GetActivityDevices(ctx context.Context, activityId int32) ([]db.GetActivityDevicesRow, error)
+	CreateActivityPauses(ctx context.Context, params []db.CreateActivityPausesParams) (int64, error)
+	GetActivityPauses(ctx context.Context, activityId int32) ([]db.GetActivityPausesRow, error)
+	CreateActivityHeartRateZones(ctx context.Context, params []db.CreateActivityHeartRateZonesParams) (int64, error)
+	GetActivityHeartRateZones(ctx context.Context, activityId int32) ([]db.GetActivityHeartRateZonesRow, error)
+	GetWeeklyHeartRateZones(ctx context.Context, params db.GetWeeklyHeartRateZonesParams) ([]db.GetWeeklyHeartRateZonesRow, error)
+	CreateActivityPowerCurve(ctx context.Context, params db.CreateActivityPowerCurveParams) error
+	GetActivityPowerCurve(ctx context.Context, activityId int32) (db.GetActivityPowerCurveRow, error)
+	GetPowerCurveEnvelope(ctx context.Context, params db.GetPowerCurveEnvelopeParams) ([]db.GetPowerCurveEnvelopeRow, error)
+	CreateActivityBestEfforts(ctx context.Context, params []db.CreateActivityBestEffortsParams) (int64, error)
+	GetActivityBestEfforts(ctx context.Context, activityId int32) ([]db.GetActivityBestEffortsRow, error)
+	GetPreviousBestEfforts(ctx context.Context, params db.GetPreviousBestEffortsParams) ([]db.GetPreviousBestEffortsRow, error)
+	// RefreshPersonalRecords flags the best efforts of the user's rides after
+	// a date against the rides before them again.
+	RefreshPersonalRecords(ctx context.Context, params db.RefreshPersonalRecordsParams) error
+	GetPersonalRecords(ctx context.Context, userId string) ([]db.GetPersonalRecordsRow, error)
+	LockTrainingLoad(ctx context.Context, userId string) error
+	GetTrainingLoadBefore(ctx context.Context, params db.GetTrainingLoadBeforeParams) (db.GetTrainingLoadBeforeRow, error)
+	GetDailyActivityLoads(ctx context.Context, params db.GetDailyActivityLoadsParams) ([]db.GetDailyActivityLoadsRow, error)
+	DeleteTrainingLoadFrom(ctx context.Context, params db.DeleteTrainingLoadFromParams) error
+	CreateTrainingLoad(ctx context.Context, params []db.CreateTrainingLoadParams) (int64, error)
+	GetTrainingLoad(ctx context.Context, params db.GetTrainingLoadParams) ([]db.GetTrainingLoadRow, error)
+	CreateActivityOriginal(ctx context.Context, params db.CreateActivityOriginalParams) error
+	GetActivityOriginal(ctx context.Context, params db.GetActivityOriginalParams) (db.ActivityOriginal, error)
+	GetReprocessableActivities(ctx context.Context, params db.GetReprocessableActivitiesParams) ([]db.GetReprocessableActivitiesRow, error)
+	GetActivityDate(ctx context.Context, activityId int32) (pgtype.Timestamptz, error)
+	ReplaceActivityMetrics(ctx context.Context, params db.ReplaceActivityMetricsParams) error
+	ClearActivityDetails(ctx context.Context, activityId int32) error
+	GetUserSettings(ctx context.Context, userId string) (db.UserSetting, error)
+	UpsertUserSettings(ctx context.Context, params db.UpsertUserSettingsParams) (db.UserSetting, error)
 }
 
 type activityRepository struct {
@@ -29,6 +69,14 @@ func (ar *activityRepository) CreateActivity(ctx context.Context, params db.Crea
 	return ar.Queries.CreateActivity(ctx, params)
 }
 
+func (ar *activityRepository) FindDuplicateActivity(ctx context.Context, params db.FindDuplicateActivityParams) (int32, error) {
+	return ar.Queries.FindDuplicateActivity(ctx, params)
+}
+
+func (ar *activityRepository) GetOverlappingActivities(ctx context.Context, params db.GetOverlappingActivitiesParams) ([]db.GetOverlappingActivitiesRow, error) {
+	return ar.Queries.GetOverlappingActivities(ctx, params)
+}
+
 func (ar *activityRepository) GetActivities(ctx context.Context, userId string) ([]db.GetActivitiesRow, error) {
 	return ar.Queries.GetActivities(ctx, userId)
 }
@@ -37,8 +85,8 @@ func (ar *activityRepository) GetActivity(ctx context.Context, id int32) (db.Get
 	return ar.Queries.GetActivity(ctx, id)
 }
 
-func (ar *activityRepository) GetActivityAndRecords(ctx context.Context, id int32) (db.ActivityWithRecordsView, error) {
-	return ar.Queries.GetActivityWithRecordsView(ctx, id)
+func (ar *activityRepository) GetActivityAndRecords(ctx context.Context, params db.GetActivityWithRecordsViewParams) (db.ActivityWithRecordsView, error) {
+	return ar.Queries.GetActivityWithRecordsView(ctx, params)
 }
 
 func (ar *activityRepository) GetActivityStats(ctx context.Context, userId string) (db.GetActivityStatsRow, error) {
@@ -48,3 +96,139 @@ func (ar *activityRepository) GetActivityStats(ctx context.Context, userId strin
 func (ar *activityRepository) UpdateActivity(ctx context.Context, params db.UpdateActivityParams) (db.ActivityWithRecordsView, error) {
 	return ar.Queries.UpdateActivity(ctx, params)
 }
+
+func (ar *activityRepository) DeleteActivity(ctx context.Context, params db.DeleteActivityParams) (pgtype.Timestamptz, error) {
+	return ar.Queries.DeleteActivity(ctx, params)
+}
+
+func (ar *activityRepository) CreateActivitySessions(ctx context.Context, params []db.CreateActivitySessionsParams) (int64, error) {
+	return ar.Queries.CreateActivitySessions(ctx, params)
+}
+
+func (ar *activityRepository) GetActivitySessions(ctx context.Context, activityId int32) ([]db.GetActivitySessionsRow, error) {
+	return ar.Queries.GetActivitySessions(ctx, activityId)
+}
+
+func (ar *activityRepository) CreateLaps(ctx context.Context, params []db.CreateLapsParams) (int64, error) {
+	return ar.Queries.CreateLaps(ctx, params)
+}
+
+func (ar *activityRepository) GetActivityLaps(ctx context.Context, params db.GetActivityLapsParams) ([]db.GetActivityLapsRow, error) {
+	return ar.Queries.GetActivityLaps(ctx, params)
+}
+
+func (ar *activityRepository) CreateActivityDevices(ctx context.Context, params []db.CreateActivityDevicesParams) (int64, error) {
+	return ar.Queries.CreateActivityDevices(ctx, params)
+}
+
+func (ar *activityRepository) GetActivityDevices(ctx context.Context, activityId int32) ([]db.GetActivityDevicesRow, error) {
+	return ar.Queries.GetActivityDevices(ctx, activityId)
+}
+
+func (ar *activityRepository) CreateActivityPauses(ctx context.Context, params []db.CreateActivityPausesParams) (int64, error) {
+	return ar.Queries.CreateActivityPauses(ctx, params)
+}
+
+func (ar *activityRepository) GetActivityPauses(ctx context.Context, activityId int32) ([]db.GetActivityPausesRow, error) {
+	return ar.Queries.GetActivityPauses(ctx, activityId)
+}
+
+func (ar *activityRepository) CreateActivityHeartRateZones(ctx context.Context, params []db.CreateActivityHeartRateZonesParams) (int64, error) {
+	return ar.Queries.CreateActivityHeartRateZones(ctx, params)
+}
+
+func (ar *activityRepository) GetActivityHeartRateZones(ctx context.Context, activityId int32) ([]db.GetActivityHeartRateZonesRow, error) {
+	return ar.Queries.GetActivityHeartRateZones(ctx, activityId)
+}
+
+func (ar *activityRepository) GetWeeklyHeartRateZones(ctx context.Context, params db.GetWeeklyHeartRateZonesParams) ([]db.GetWeeklyHeartRateZonesRow, error) {
+	return ar.Queries.GetWeeklyHeartRateZones(ctx, params)
+}
+
+func (ar *activityRepository) CreateActivityPowerCurve(ctx context.Context, params db.CreateActivityPowerCurveParams) error {
+	return ar.Queries.CreateActivityPowerCurve(ctx, params)
+}
+
+func (ar *activityRepository) GetActivityPowerCurve(ctx context.Context, activityId int32) (db.GetActivityPowerCurveRow, error) {
+	return ar.Queries.GetActivityPowerCurve(ctx, activityId)
+}
+
+func (ar *activityRepository) GetPowerCurveEnvelope(ctx context.Context, params db.GetPowerCurveEnvelopeParams) ([]db.GetPowerCurveEnvelopeRow, error) {
+	return ar.Queries.GetPowerCurveEnvelope(ctx, params)
+}
+
+func (ar *activityRepository) CreateActivityBestEfforts(ctx context.Context, params []db.CreateActivityBestEffortsParams) (int64, error) {
+	return ar.Queries.CreateActivityBestEfforts(ctx, params)
+}
+
+func (ar *activityRepository) GetActivityBestEfforts(ctx context.Context, activityId int32) ([]db.GetActivityBestEffortsRow, error) {
+	return ar.Queries.GetActivityBestEfforts(ctx, activityId)
+}
+
+func (ar *activityRepository) GetPreviousBestEfforts(ctx context.Context, params db.GetPreviousBestEffortsParams) ([]db.GetPreviousBestEffortsRow, error) {
+	return ar.Queries.GetPreviousBestEfforts(ctx, params)
+}
+
+func (ar *activityRepository) RefreshPersonalRecords(ctx context.Context, params db.RefreshPersonalRecordsParams) error {
+	return ar.Queries.RefreshPersonalRecords(ctx, params)
+}
+
+func (ar *activityRepository) GetPersonalRecords(ctx context.Context, userId string) ([]db.GetPersonalRecordsRow, error) {
+	return ar.Queries.GetPersonalRecords(ctx, userId)
+}
+
+func (ar *activityRepository) LockTrainingLoad(ctx context.Context, userId string) error {
+	return ar.Queries.LockTrainingLoad(ctx, userId)
+}
+
+func (ar *activityRepository) GetTrainingLoadBefore(ctx context.Context, params db.GetTrainingLoadBeforeParams) (db.GetTrainingLoadBeforeRow, error) {
+	return ar.Queries.GetTrainingLoadBefore(ctx, params)
+}
+
+func (ar *activityRepository) GetDailyActivityLoads(ctx context.Context, params db.GetDailyActivityLoadsParams) ([]db.GetDailyActivityLoadsRow, error) {
+	return ar.Queries.GetDailyActivityLoads(ctx, params)
+}
+
+func (ar *activityRepository) DeleteTrainingLoadFrom(ctx context.Context, params db.DeleteTrainingLoadFromParams) error {
+	return ar.Queries.DeleteTrainingLoadFrom(ctx, params)
+}
+
+func (ar *activityRepository) CreateTrainingLoad(ctx context.Context, params []db.CreateTrainingLoadParams) (int64, error) {
+	return ar.Queries.CreateTrainingLoad(ctx, params)
+}
+
+func (ar *activityRepository) GetTrainingLoad(ctx context.Context, params db.GetTrainingLoadParams) ([]db.GetTrainingLoadRow, error) {
+	return ar.Queries.GetTrainingLoad(ctx, params)
+}
+
+func (ar *activityRepository) CreateActivityOriginal(ctx context.Context, params db.CreateActivityOriginalParams) error {
+	return ar.Queries.CreateActivityOriginal(ctx, params)
+}
+
+func (ar *activityRepository) GetActivityOriginal(ctx context.Context, params db.GetActivityOriginalParams) (db.ActivityOriginal, error) {
+	return ar.Queries.GetActivityOriginal(ctx, params)
+}
+
+func (ar *activityRepository) GetReprocessableActivities(ctx context.Context, params db.GetReprocessableActivitiesParams) ([]db.GetReprocessableActivitiesRow, error) {
+	return ar.Queries.GetReprocessableActivities(ctx, params)
+}
+
+func (ar *activityRepository) GetActivityDate(ctx context.Context, activityId int32) (pgtype.Timestamptz, error) {
+	return ar.Queries.GetActivityDate(ctx, activityId)
+}
+
+func (ar *activityRepository) ReplaceActivityMetrics(ctx context.Context, params db.ReplaceActivityMetricsParams) error {
+	return ar.Queries.ReplaceActivityMetrics(ctx, params)
+}
+
+func (ar *activityRepository) ClearActivityDetails(ctx context.Context, activityId int32) error {
+	return ar.Queries.ClearActivityDetails(ctx, activityId)
+}
+
+func (ar *activityRepository) GetUserSettings(ctx context.Context, userId string) (db.UserSetting, error) {
+	return ar.Queries.GetUserSettings(ctx, userId)
+}
+
+func (ar *activityRepository) UpsertUserSettings(ctx context.Context, params db.UpsertUserSettingsParams) (db.UserSetting, error) {
+	return ar.Queries.UpsertUserSettings(ctx, params)
+}
@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/notaduck/backend/internal/db"
+)
+
+type IngestionJobRepository interface {
+	EnqueueIngestionJob(ctx context.Context, params db.EnqueueIngestionJobParams) (db.EnqueueIngestionJobRow, error)
+	// ClaimIngestionJob returns pgx.ErrNoRows when no job with fewer than
+	// maxAttempts attempts is queued.
+	ClaimIngestionJob(ctx context.Context, maxAttempts int32) (db.ClaimIngestionJobRow, error)
+	CompleteIngestionJob(ctx context.Context, params db.CompleteIngestionJobParams) error
+	RequeueIngestionJob(ctx context.Context, params db.RequeueIngestionJobParams) error
+	RequeueStaleIngestionJobs(ctx context.Context, params db.RequeueStaleIngestionJobsParams) (int64, error)
+	GetIngestionJobs(ctx context.Context, params db.GetIngestionJobsParams) ([]db.GetIngestionJobsRow, error)
+}
+
+type ingestionJobRepository struct {
+	Queries *db.Queries
+}
+
+func NewIngestionJobRepository(queries *db.Queries) IngestionJobRepository {
+	return &ingestionJobRepository{
+		Queries: queries,
+	}
+}
+
+func (jr *ingestionJobRepository) EnqueueIngestionJob(ctx context.Context, params db.EnqueueIngestionJobParams) (db.EnqueueIngestionJobRow, error) {
+	return jr.Queries.EnqueueIngestionJob(ctx, params)
+}
+
+func (jr *ingestionJobRepository) ClaimIngestionJob(ctx context.Context, maxAttempts int32) (db.ClaimIngestionJobRow, error) {
+	return jr.Queries.ClaimIngestionJob(ctx, maxAttempts)
+}
+
+func (jr *ingestionJobRepository) CompleteIngestionJob(ctx context.Context, params db.CompleteIngestionJobParams) error {
+	return jr.Queries.CompleteIngestionJob(ctx, params)
+}
+
+func (jr *ingestionJobRepository) RequeueIngestionJob(ctx context.Context, params db.RequeueIngestionJobParams) error {
+	return jr.Queries.RequeueIngestionJob(ctx, params)
+}
+
+func (jr *ingestionJobRepository) RequeueStaleIngestionJobs(ctx context.Context, params db.RequeueStaleIngestionJobsParams) (int64, error) {
+	return jr.Queries.RequeueStaleIngestionJobs(ctx, params)
+}
+
+func (jr *ingestionJobRepository) GetIngestionJobs(ctx context.Context, params db.GetIngestionJobsParams) ([]db.GetIngestionJobsRow, error) {
+	return jr.Queries.GetIngestionJobs(ctx, params)
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/notaduck/backend/internal/db"
+)
+
+// TxBeginner is implemented by *pgxpool.Pool and *pgx.Conn.
+type TxBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// Repositories are the repositories bound to a single transaction.
+type Repositories struct {
+	Activities ActivityRepository
+	Records    RecordRepository
+}
+
+// UnitOfWork runs a group of repository calls atomically.
+type UnitOfWork interface {
+	// Do runs fn in a transaction that commits when fn returns nil and rolls
+	// back otherwise.
+	Do(ctx context.Context, fn func(repos Repositories) error) error
+}
+
+type unitOfWork struct {
+	db      TxBeginner
+	queries *db.Queries
+}
+
+func NewUnitOfWork(conn TxBeginner, queries *db.Queries) UnitOfWork {
+	return &unitOfWork{
+		db:      conn,
+		queries: queries,
+	}
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn func(repos Repositories) error) error {
+	tx, err := u.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	queries := u.queries.WithTx(tx)
+	repos := Repositories{
+		Activities: NewActivityRepository(queries),
+		Records:    NewRecordRepository(queries),
+	}
+
+	if err := fn(repos); err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			return errors.Join(err, fmt.Errorf("failed to roll back transaction: %w", rbErr))
+		}
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
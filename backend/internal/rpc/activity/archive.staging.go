@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// maxStagedArchiveSize caps an export archive staged for import.
+	maxStagedArchiveSize = 20 << 30
+	// maxArchiveChunkSize caps a single UploadArchiveChunk message.
+	maxArchiveChunkSize = 16 << 20
+	// stagedArchiveTTL is how long an archive that was never imported is
+	// kept before it is swept.
+	stagedArchiveTTL = 24 * time.Hour
+)
+
+var (
+	errUnknownArchiveUpload = errors.New("unknown archive upload")
+	errArchiveChunkOffset   = errors.New("archive chunk does not continue the upload")
+	errArchiveTooLarge      = errors.New("archive is too large")
+)
+
+// archiveStaging keeps export archives on disk while their chunks arrive,
+// one folder per user so uploads cannot be read across users.
+type archiveStaging struct {
+	dir string
+	// locks holds a *sync.Mutex per staged archive path, so concurrent
+	// chunks of one upload cannot both pass the offset check and both write.
+	locks sync.Map
+}
+
+func newArchiveStaging(dir string) *archiveStaging {
+	return &archiveStaging{dir: dir}
+}
+
+// create starts a new staged archive for the user and returns its id.
+// Archives left over from abandoned uploads are swept first.
+func (s *archiveStaging) create(userID string) (string, error) {
+	s.sweep()
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	uploadID := hex.EncodeToString(id)
+
+	path, err := s.path(userID, uploadID)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return "", err
+	}
+	return uploadID, file.Close()
+}
+
+// append writes chunk at offset, which must be the size staged so far, and
+// returns the new size.
+func (s *archiveStaging) append(userID, uploadID string, offset int64, chunk []byte) (int64, error) {
+	path, err := s.path(userID, uploadID)
+	if err != nil {
+		return 0, err
+	}
+	unlock := s.lock(path)
+	defer unlock()
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, errUnknownArchiveUpload
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	if info.Size() != offset {
+		return 0, fmt.Errorf("%w: expected offset %d, got %d", errArchiveChunkOffset, info.Size(), offset)
+	}
+	if offset+int64(len(chunk)) > maxStagedArchiveSize {
+		return 0, fmt.Errorf("%w: the maximum size is %d bytes", errArchiveTooLarge, int64(maxStagedArchiveSize))
+	}
+
+	if _, err := file.Write(chunk); err != nil {
+		return 0, err
+	}
+	return offset + int64(len(chunk)), nil
+}
+
+// open returns the staged archive and its size. The caller closes the file
+// and removes the upload.
+func (s *archiveStaging) open(userID, uploadID string) (*os.File, int64, error) {
+	path, err := s.path(userID, uploadID)
+	if err != nil {
+		return nil, 0, err
+	}
+	unlock := s.lock(path)
+	defer unlock()
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, 0, errUnknownArchiveUpload
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+	return file, info.Size(), nil
+}
+
+func (s *archiveStaging) remove(userID, uploadID string) {
+	path, err := s.path(userID, uploadID)
+	if err != nil {
+		return
+	}
+	unlock := s.lock(path)
+	defer unlock()
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		slog.Error("failed to remove staged archive", "path", path, "error", err)
+	}
+	s.locks.Delete(path)
+}
+
+// lock holds the mutex of the staged archive at path until the returned
+// function is called.
+func (s *archiveStaging) lock(path string) func() {
+	mu, _ := s.locks.LoadOrStore(path, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+// sweep removes the staged archives not written to within stagedArchiveTTL.
+func (s *archiveStaging) sweep() {
+	cutoff := time.Now().Add(-stagedArchiveTTL)
+	_ = filepath.WalkDir(s.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return nil
+		}
+		if info, err := entry.Info(); err == nil && info.ModTime().Before(cutoff) {
+			if err := os.Remove(path); err != nil {
+				slog.Error("failed to remove stale staged archive", "path", path, "error", err)
+			}
+			s.locks.Delete(path)
+		}
+		return nil
+	})
+}
+
+// path returns where the upload is staged, rejecting ids that could point
+// outside the user's folder.
+func (s *archiveStaging) path(userID, uploadID string) (string, error) {
+	if userID == "" || filepath.Base(userID) != userID || userID == ".." {
+		return "", errUnknownArchiveUpload
+	}
+	if decoded, err := hex.DecodeString(uploadID); err != nil || len(decoded) != 16 {
+		return "", errUnknownArchiveUpload
+	}
+	return filepath.Join(s.dir, userID, uploadID+".zip"), nil
+}
@@ -2,9 +2,12 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -15,14 +18,52 @@ import (
 	"github.com/notaduck/backend/internal/rpc/middleware"
 	service "github.com/notaduck/backend/internal/services"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 type ActivityHandler struct {
-	service service.ActivityService
+	service   service.ActivityService
+	ingestion service.IngestionService
+	// admins holds the ids of the users allowed to call admin RPCs.
+	admins map[string]struct{}
+	// archives keeps export archives while their chunks are uploaded.
+	archives *archiveStaging
 }
 
-func NewActivityHandler(service service.ActivityService) *ActivityHandler {
-	return &ActivityHandler{service: service}
+type ActivityHandlerOption func(*ActivityHandler)
+
+func NewActivityHandler(service service.ActivityService, ingestion service.IngestionService, options ...ActivityHandlerOption) *ActivityHandler {
+	handler := &ActivityHandler{
+		service:   service,
+		ingestion: ingestion,
+		admins:    map[string]struct{}{},
+		archives:  newArchiveStaging(filepath.Join(os.TempDir(), "velovoyager-archives")),
+	}
+
+	for _, option := range options {
+		option(handler)
+	}
+
+	return handler
+}
+
+// WithArchiveStagingDir keeps uploaded export archives in dir until they are
+// imported instead of the system temp folder.
+func WithArchiveStagingDir(dir string) ActivityHandlerOption {
+	return func(h *ActivityHandler) {
+		h.archives = newArchiveStaging(dir)
+	}
+}
+
+// WithAdmins grants the users with the given ids access to admin RPCs.
+func WithAdmins(userIDs []string) ActivityHandlerOption {
+	return func(h *ActivityHandler) {
+		for _, id := range userIDs {
+			if id = strings.TrimSpace(id); id != "" {
+				h.admins[id] = struct{}{}
+			}
+		}
+	}
 }
 
 func (h *ActivityHandler) UploadActivities(
@@ -36,17 +77,55 @@ func (h *ActivityHandler) UploadActivities(
 		return nil, connect.NewError(connect.CodeUnauthenticated, err)
 	}
 
-	var currentFileBuffer []byte
+	var jobs []service.IngestionJob
+	var rejected []service.FileResult
+	var current *streamedFile
+
+	// enqueue queues a completed file for ingestion, or records why it was
+	// rejected.
+	enqueue := func(file *streamedFile) error {
+		filename := file.header.GetFilename()
+
+		payload, err := file.payload()
+		if err != nil {
+			slog.ErrorContext(ctx, "rejected streamed file", "filename", filename, "error", err)
+			rejected = append(rejected, service.NewFileResult(filename, nil, err))
+			return nil
+		}
+
+		queued, err := h.ingestion.Enqueue(ctx, []service.ActivityFilePayload{payload}, user.ID)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to queue %q", filename))
+		}
+		jobs = append(jobs, queued...)
+		return nil
+	}
 
-	// Process incoming stream of file chunks
+	// Process incoming stream of file headers and chunks
 	for stream.Receive() {
 		req := stream.Msg()
 
-		// Handle incoming payload
 		switch payload := req.GetPayload().(type) {
+		case *activityv1.UploadActivitiesRequest_FileHeader:
+			if current != nil {
+				if err := enqueue(current); err != nil {
+					return nil, err
+				}
+			}
+
+			header := payload.FileHeader
+			if header.GetSize() < 0 || header.GetSize() > maxStreamedFileSize {
+				return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("file %q exceeds the maximum size of %d bytes", header.GetFilename(), maxStreamedFileSize))
+			}
+			current = newStreamedFile(header)
+
 		case *activityv1.UploadActivitiesRequest_FileChunk:
-			// Append file chunk to buffer
-			currentFileBuffer = append(currentFileBuffer, payload.FileChunk...)
+			if current == nil {
+				return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("file chunk received before a file header"))
+			}
+			if err := current.write(payload.FileChunk); err != nil {
+				return nil, connect.NewError(connect.CodeInvalidArgument, err)
+			}
 
 		case *activityv1.UploadActivitiesRequest_Metadata:
 			// Optional: Handle metadata
@@ -59,21 +138,14 @@ func (h *ActivityHandler) UploadActivities(
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
-	// Ensure file buffer is not empty
-	if len(currentFileBuffer) == 0 {
+	if current == nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("no file data received"))
 	}
-
-	// Process the file yourself
-	log.Printf("Received file of size %d bytes from user %s", len(currentFileBuffer), user.ID)
-
-	// Create the response
-	response := &activityv1.UploadActivitiesResponse{
-		Status: "success",
+	if err := enqueue(current); err != nil {
+		return nil, err
 	}
 
-	// Return the response
-	return connect.NewResponse(response), nil
+	return connect.NewResponse(convertQueuedUploadToProto(jobs, rejected)), nil
 }
 
 func (h *ActivityHandler) UploadActivitiesUnary(
@@ -91,9 +163,11 @@ func (h *ActivityHandler) UploadActivitiesUnary(
 	}
 
 	files := make([]service.ActivityFilePayload, 0, len(req.Msg.Files))
+	var rejected []service.FileResult
 	for _, file := range req.Msg.Files {
 		if len(file.GetData()) == 0 {
-			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("file %q has no data", file.GetFilename()))
+			rejected = append(rejected, service.NewFileResult(file.GetFilename(), nil, fmt.Errorf("file %q: %w", file.GetFilename(), service.ErrEmptyFile)))
+			continue
 		}
 
 		payload := service.ActivityFilePayload{
@@ -109,15 +183,155 @@ func (h *ActivityHandler) UploadActivitiesUnary(
 		files = append(files, payload)
 	}
 
-	if _, err := h.service.CreateActivitiesFromBytes(ctx, files, user.ID); err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+	jobs, err := h.ingestion.Enqueue(ctx, files, user.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to queue the uploaded files"))
+	}
+
+	return connect.NewResponse(convertQueuedUploadToProto(jobs, rejected)), nil
+}
+
+// UploadArchiveChunk stages a chunk of an export archive for ImportArchive,
+// starting a new upload when no upload id is given.
+func (h *ActivityHandler) UploadArchiveChunk(
+	ctx context.Context,
+	req *connect.Request[activityv1.UploadArchiveChunkRequest],
+) (*connect.Response[activityv1.UploadArchiveChunkResponse], error) {
+	user := middleware.RetrieveUserFromContext(ctx)
+	if user == nil {
+		err := fmt.Errorf("user not authenticated")
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	chunk := req.Msg.GetChunk()
+	if len(chunk) > maxArchiveChunkSize {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("archive chunks are limited to %d bytes", maxArchiveChunkSize))
+	}
+
+	uploadID := req.Msg.GetUploadId()
+	if uploadID == "" {
+		if req.Msg.GetOffset() != 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%w: a new upload starts at offset 0", errArchiveChunkOffset))
+		}
+		var err error
+		if uploadID, err = h.archives.create(user.ID); err != nil {
+			slog.ErrorContext(ctx, "failed to stage archive", "error", err)
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to stage the archive"))
+		}
 	}
 
+	size, err := h.archives.append(user.ID, uploadID, req.Msg.GetOffset(), chunk)
+	switch {
+	case errors.Is(err, errUnknownArchiveUpload):
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, errArchiveChunkOffset), errors.Is(err, errArchiveTooLarge):
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	case err != nil:
+		slog.ErrorContext(ctx, "failed to stage archive chunk", "upload_id", uploadID, "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to stage the archive"))
+	}
+
+	return connect.NewResponse(&activityv1.UploadArchiveChunkResponse{
+		UploadId: uploadID,
+		Size:     size,
+	}), nil
+}
+
+// ImportArchive ingests a Strava or Garmin export archive staged with
+// UploadArchiveChunk and streams the outcome of every activity file as it is
+// processed.
+func (h *ActivityHandler) ImportArchive(
+	ctx context.Context,
+	req *connect.Request[activityv1.ImportArchiveRequest],
+	stream *connect.ServerStream[activityv1.ImportArchiveProgress],
+) error {
+	user := middleware.RetrieveUserFromContext(ctx)
+	if user == nil {
+		err := fmt.Errorf("user not authenticated")
+		return connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	uploadID := req.Msg.GetUploadId()
+	archive, size, err := h.archives.open(user.ID, uploadID)
+	switch {
+	case errors.Is(err, errUnknownArchiveUpload):
+		return connect.NewError(connect.CodeNotFound, err)
+	case err != nil:
+		slog.ErrorContext(ctx, "failed to open staged archive", "upload_id", uploadID, "error", err)
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to open the archive"))
+	}
+	defer h.archives.remove(user.ID, uploadID)
+	defer archive.Close()
+
+	if size == 0 {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("no archive data received"))
+	}
+
+	slog.InfoContext(ctx, "importing archive", "filename", req.Msg.GetFilename(), "size", size)
+
+	_, err = h.service.ImportArchive(ctx, archive, size, user.ID, func(progress service.ArchiveProgress) error {
+		return stream.Send(&activityv1.ImportArchiveProgress{
+			Processed: int32(progress.Processed),
+			Total:     int32(progress.Total),
+			Result:    convertFileResultToProto(progress.Result),
+		})
+	})
+
+	switch {
+	case errors.Is(err, service.ErrInvalidArchive):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case err != nil:
+		slog.ErrorContext(ctx, "failed to import archive", "upload_id", uploadID, "error", err)
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to import the archive"))
+	}
+
+	return nil
+}
+
+func convertUploadResultToProto(result *service.UploadBatchResult) *activityv1.UploadActivitiesResponse {
 	response := &activityv1.UploadActivitiesResponse{
-		Status: "success",
+		Results: make([]*activityv1.UploadFileResult, len(result.Files)),
 	}
 
-	return connect.NewResponse(response), nil
+	for i, file := range result.Files {
+		response.Results[i] = convertFileResultToProto(file)
+		if file.Activity != nil {
+			response.ActivityIds = append(response.ActivityIds, file.ActivityID)
+		}
+	}
+
+	response.Status = uploadStatus(response.Results)
+
+	return response
+}
+
+func convertFileResultToProto(file service.FileResult) *activityv1.UploadFileResult {
+	return &activityv1.UploadFileResult{
+		Filename:      file.Filename,
+		ActivityId:    file.ActivityID,
+		Error:         file.Error,
+		FailureReason: convertFailureReasonToProto(file.Reason),
+		Skipped:       file.Skipped,
+	}
+}
+
+func convertFailureReasonToProto(reason service.FailureReason) activityv1.UploadFailureReason {
+	switch reason {
+	case "":
+		return activityv1.UploadFailureReason_UPLOAD_FAILURE_REASON_UNSPECIFIED
+	case service.FailureUnsupportedFormat:
+		return activityv1.UploadFailureReason_UPLOAD_FAILURE_REASON_UNSUPPORTED_FORMAT
+	case service.FailureCorruptFile:
+		return activityv1.UploadFailureReason_UPLOAD_FAILURE_REASON_CORRUPT_FILE
+	case service.FailureNoRecords:
+		return activityv1.UploadFailureReason_UPLOAD_FAILURE_REASON_NO_RECORDS
+	case service.FailureDuplicate:
+		return activityv1.UploadFailureReason_UPLOAD_FAILURE_REASON_DUPLICATE
+	case service.FailureEmptyFile:
+		return activityv1.UploadFailureReason_UPLOAD_FAILURE_REASON_EMPTY_FILE
+	default:
+		return activityv1.UploadFailureReason_UPLOAD_FAILURE_REASON_INTERNAL
+	}
 }
 
 // GetActivity handles fetching a single activity by ID with records.
@@ -134,8 +348,14 @@ func (h *ActivityHandler) GetActivity(
 		return nil, connect.NewError(connect.CodeUnauthenticated, err)
 	}
 
-	// Fetch activity from the service
-	activity, err := h.service.GetSingleActivityById(ctx, req.Msg.ActivityId, user.ID)
+	// Fetch activity from the service at the requested resolution
+	activity, err := h.service.GetActivityAtResolution(ctx, req.Msg.ActivityId, user.ID, service.ActivityResolution{
+		Tolerance: req.Msg.Tolerance,
+		MaxPoints: int(req.Msg.MaxPoints),
+	})
+	if errors.Is(err, service.ErrInvalidActivityResolution) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to get activity", "error", err, "activity_id", req.Msg.ActivityId)
 		// Wrap the error with an appropriate ConnectRPC error code
@@ -151,31 +371,184 @@ func (h *ActivityHandler) GetActivity(
 	return connectResp, nil
 }
 
+func (h *ActivityHandler) GetActivityLaps(
+	ctx context.Context,
+	req *connect.Request[activityv1.GetActivityLapsRequest],
+) (*connect.Response[activityv1.GetActivityLapsResponse], error) {
+
+	user := middleware.RetrieveUserFromContext(ctx)
+	if user == nil {
+		err := fmt.Errorf("user not authenticated")
+		slog.ErrorContext(ctx, "failed to retrieve user from context", "error", err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	laps, err := h.service.GetActivityLaps(ctx, req.Msg.ActivityId, user.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get laps", "error", err, "activity_id", req.Msg.ActivityId)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get laps"))
+	}
+
+	return connect.NewResponse(&activityv1.GetActivityLapsResponse{
+		Laps: convertLapsToProto(laps),
+	}), nil
+}
+
+func convertLapsToProto(laps []service.Lap) []*activityv1.Lap {
+	optional := func(value *int32) int32 {
+		if value == nil {
+			return 0
+		}
+		return *value
+	}
+
+	protobufLaps := make([]*activityv1.Lap, len(laps))
+	for i, lap := range laps {
+		protobufLaps[i] = &activityv1.Lap{
+			Index:        int32(lap.Index),
+			Trigger:      lap.Trigger,
+			StartTime:    timestamppb.New(lap.StartTime),
+			EndTime:      timestamppb.New(lap.EndTime),
+			ElapsedTime:  lap.ElapsedTime,
+			TimerTime:    lap.TimerTime,
+			Distance:     lap.Distance,
+			AvgSpeed:     lap.AvgSpeed,
+			MaxSpeed:     lap.MaxSpeed,
+			AvgHeartRate: optional(lap.AvgHeartRate),
+			MaxHeartRate: optional(lap.MaxHeartRate),
+			AvgPower:     optional(lap.AvgPower),
+			MaxPower:     optional(lap.MaxPower),
+		}
+	}
+	return protobufLaps
+}
+
+func convertPausesToProto(pauses []service.Pause) []*activityv1.ActivityPause {
+	protobufPauses := make([]*activityv1.ActivityPause, len(pauses))
+	for i, pause := range pauses {
+		protobufPauses[i] = &activityv1.ActivityPause{
+			StartedAt:   timestamppb.New(pause.StartedAt),
+			EndedAt:     timestamppb.New(pause.EndedAt),
+			ElapsedTime: pause.ElapsedTime,
+			Source:      pause.Source,
+		}
+	}
+	return protobufPauses
+}
+
+func convertHeartRateZonesToProto(zones []service.HeartRateZone) *activityv1.HeartRateZones {
+	protobufZones := make([]*activityv1.HeartRateZone, len(zones))
+	for i, zone := range zones {
+		protobufZones[i] = &activityv1.HeartRateZone{
+			Zone:         zone.Zone,
+			MinHeartRate: zone.MinHeartRate,
+			Seconds:      zone.Seconds,
+		}
+		if zone.MaxHeartRate != nil {
+			protobufZones[i].MaxHeartRate = wrapperspb.Int32(*zone.MaxHeartRate)
+		}
+	}
+	return &activityv1.HeartRateZones{Zones: protobufZones}
+}
+
+func convertPowerCurveToProto(points []service.PowerCurvePoint) []*activityv1.PowerCurvePoint {
+	protobufPoints := make([]*activityv1.PowerCurvePoint, len(points))
+	for i, point := range points {
+		protobufPoints[i] = &activityv1.PowerCurvePoint{
+			Duration:   point.Duration,
+			Watts:      point.Watts,
+			ActivityId: point.ActivityID,
+		}
+		if point.Date != nil {
+			protobufPoints[i].Date = timestamppb.New(*point.Date)
+		}
+	}
+	return protobufPoints
+}
+
+func convertBestEffortsToProto(efforts []service.BestEffort) []*activityv1.BestEffort {
+	protobufEfforts := make([]*activityv1.BestEffort, len(efforts))
+	for i, effort := range efforts {
+		protobufEfforts[i] = &activityv1.BestEffort{
+			Distance:       effort.Distance,
+			Seconds:        effort.Seconds,
+			StartedAt:      timestamppb.New(effort.StartedAt),
+			PersonalRecord: effort.PersonalRecord,
+		}
+	}
+	return protobufEfforts
+}
+
+func convertDevicesToProto(devices []service.Device) []*activityv1.ActivityDevice {
+	protobufDevices := make([]*activityv1.ActivityDevice, len(devices))
+	for i, device := range devices {
+		protobufDevices[i] = &activityv1.ActivityDevice{
+			Index:           int32(device.Index),
+			Creator:         device.Creator,
+			Manufacturer:    device.Manufacturer,
+			ProductName:     device.ProductName,
+			SoftwareVersion: device.SoftwareVersion,
+			DeviceType:      device.DeviceType,
+			SourceType:      device.SourceType,
+			BatteryStatus:   device.BatteryStatus,
+		}
+		if device.Product != nil {
+			protobufDevices[i].Product = wrapperspb.Int32(*device.Product)
+		}
+		if device.SerialNumber != nil {
+			protobufDevices[i].SerialNumber = wrapperspb.Int64(*device.SerialNumber)
+		}
+		if device.HardwareVersion != nil {
+			protobufDevices[i].HardwareVersion = wrapperspb.Int32(int32(*device.HardwareVersion))
+		}
+		if device.AntDeviceNumber != nil {
+			protobufDevices[i].AntDeviceNumber = wrapperspb.Int32(*device.AntDeviceNumber)
+		}
+		if device.BatteryVoltage != nil {
+			protobufDevices[i].BatteryVoltage = wrapperspb.Double(*device.BatteryVoltage)
+		}
+	}
+	return protobufDevices
+}
+
+func convertPointToProto(point *service.Point) *activityv1.Point {
+	if point == nil {
+		return nil
+	}
+	return &activityv1.Point{X: point.X, Y: point.Y}
+}
+
 func convertActivityToProto(activity *service.Activity) *activityv1.GetActivityResponse {
 	protobufRecords := make([]*activityv1.Record, len(activity.Records))
 	for i, rec := range activity.Records {
 		protobufRecords[i] = &activityv1.Record{
 			Id:          rec.ID,
-			Coordinates: &activityv1.Point{X: rec.Coordinates.X, Y: rec.Coordinates.Y},
+			Coordinates: convertPointToProto(rec.Coordinates),
 			Speed:       rec.Speed,
 			TimeStamp:   timestamppb.New(rec.TimeStamp),
 			Distance:    rec.Distance,
 			HeartRate:   int32(rec.HeartRate),
 			Cadence:     int32(rec.Cadence),
+			Power:       rec.Power,
 		}
 	}
 
 	response := &activityv1.GetActivityResponse{
-		Id:           activity.ID,
-		CreatedAt:    activity.CreatedAt.String(),
-		Distance:     activity.Distance,
-		ActivityName: activity.ActivityName,
-		AvgSpeed:     activity.AvgSpeed,
-		MaxSpeed:     activity.MaxSpeed,
-		ElapsedTime:  activity.ElapsedTime,
-		TotalTime:    activity.TotalTime,
-		Records:      protobufRecords,
-		RideType:     activity.RideType,
+		Id:               activity.ID,
+		CreatedAt:        activity.CreatedAt.String(),
+		Distance:         activity.Distance,
+		ActivityName:     activity.ActivityName,
+		AvgSpeed:         activity.AvgSpeed,
+		MaxSpeed:         activity.MaxSpeed,
+		ElapsedTime:      activity.ElapsedTime,
+		TotalTime:        activity.TotalTime,
+		MovingTime:       activity.MovingTime,
+		Records:          protobufRecords,
+		RideType:         activity.RideType,
+		RideTypeDetected: activity.RideTypeDetected,
+		CorrectedPoints:  activity.CorrectedPoints,
+		EncodedRoute:     activity.EncodedRoute,
+		FilledPoints:     activity.FilledPoints,
 	}
 
 	if activity.AvgHeartRate != nil {
@@ -190,6 +563,69 @@ func convertActivityToProto(activity *service.Activity) *activityv1.GetActivityR
 	if activity.MaxCadence != nil {
 		response.MaxCadence = *activity.MaxCadence
 	}
+	if activity.DuplicateOf != nil {
+		response.DuplicateOf = *activity.DuplicateOf
+	}
+	for i := range activity.Route {
+		response.Route = append(response.Route, convertPointToProto(&activity.Route[i]))
+	}
+	response.Description = activity.Description
+	response.Indoor = activity.Indoor
+
+	for _, session := range activity.Sessions {
+		response.Sessions = append(response.Sessions, &activityv1.ActivitySession{
+			Index:       int32(session.Index),
+			Sport:       session.Sport,
+			SubSport:    session.SubSport,
+			StartTime:   timestamppb.New(session.StartTime),
+			EndTime:     timestamppb.New(session.EndTime),
+			ElapsedTime: session.ElapsedTime,
+			TimerTime:   session.TimerTime,
+			Distance:    session.Distance,
+		})
+	}
+	response.Laps = convertLapsToProto(activity.Laps)
+	response.Devices = convertDevicesToProto(activity.Devices)
+	response.Pauses = convertPausesToProto(activity.Pauses)
+	if len(activity.HeartRateZones) > 0 {
+		response.HeartRateZones = convertHeartRateZonesToProto(activity.HeartRateZones)
+	}
+	response.PowerCurve = convertPowerCurveToProto(activity.PowerCurve)
+	response.BestEfforts = convertBestEffortsToProto(activity.BestEfforts)
+
+	if activity.AvgPower != nil {
+		response.AvgPower = wrapperspb.Int32(*activity.AvgPower)
+	}
+	if activity.MaxPower != nil {
+		response.MaxPower = wrapperspb.Int32(*activity.MaxPower)
+	}
+	if activity.NormalizedPower != nil {
+		response.NormalizedPower = wrapperspb.Int32(*activity.NormalizedPower)
+	}
+	if activity.VariabilityIndex != nil {
+		response.VariabilityIndex = wrapperspb.Double(*activity.VariabilityIndex)
+	}
+	if activity.IntensityFactor != nil {
+		response.IntensityFactor = wrapperspb.Double(*activity.IntensityFactor)
+	}
+	if activity.TrainingStressScore != nil {
+		response.TrainingStressScore = wrapperspb.Double(*activity.TrainingStressScore)
+	}
+	if activity.FTP != nil {
+		response.Ftp = wrapperspb.Int32(*activity.FTP)
+	}
+	if activity.ElevationGain != nil {
+		response.ElevationGain = wrapperspb.Double(*activity.ElevationGain)
+	}
+	if activity.ElevationLoss != nil {
+		response.ElevationLoss = wrapperspb.Double(*activity.ElevationLoss)
+	}
+	if activity.MinAltitude != nil {
+		response.MinAltitude = wrapperspb.Double(*activity.MinAltitude)
+	}
+	if activity.MaxAltitude != nil {
+		response.MaxAltitude = wrapperspb.Double(*activity.MaxAltitude)
+	}
 
 	return response
 }
@@ -219,6 +655,11 @@ func (h *ActivityHandler) GetActivities(
 			TotalTime:    activity.TotalTime,
 			Distance:     activity.Distance,
 			ElapsedTime:  activity.ElapsedTime,
+			MovingTime:   activity.MovingTime,
+			Indoor:       activity.Indoor,
+		}
+		if activity.ElevationGain != nil {
+			activityList[i].ElevationGain = wrapperspb.Double(*activity.ElevationGain)
 		}
 	}
 
@@ -249,14 +690,6 @@ func (h *ActivityHandler) UpdateActivity(
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid activity ID"))
 	}
 
-	// Allowed ride types (same as HTTP handler)
-	allowedRideTypes := map[string]struct{}{
-		"road":   {},
-		"gravel": {},
-		"mtb":    {},
-		"tt":     {},
-	}
-
 	// Prepare update parameters
 	params := db.UpdateActivityParams{
 		ActivityName: pgtype.Text{Valid: false},
@@ -276,7 +709,7 @@ func (h *ActivityHandler) UpdateActivity(
 	// Check if ride type is provided and update
 	if req.Msg.RideType != nil && req.Msg.RideType.Value != "" {
 		rideType := strings.ToLower(strings.TrimSpace(req.Msg.RideType.Value))
-		if _, ok := allowedRideTypes[rideType]; !ok {
+		if !service.IsRideType(rideType) {
 			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid ride type"))
 		}
 		params.RideType = pgtype.Text{String: rideType, Valid: true}
@@ -299,7 +732,7 @@ func (h *ActivityHandler) UpdateActivity(
 	for i, rec := range updatedActivity.Records {
 		protobufRecords[i] = &activityv1.Record{
 			Id:          rec.ID,
-			Coordinates: &activityv1.Point{X: rec.Coordinates.X, Y: rec.Coordinates.Y},
+			Coordinates: convertPointToProto(rec.Coordinates),
 			Speed:       rec.Speed,
 			TimeStamp:   timestamppb.New(rec.TimeStamp),
 			Distance:    rec.Distance,
@@ -309,16 +742,18 @@ func (h *ActivityHandler) UpdateActivity(
 
 	// Create the Protobuf response
 	activityResponse := &activityv1.GetActivityResponse{
-		Id:           updatedActivity.ID,
-		CreatedAt:    updatedActivity.CreatedAt.String(),
-		Distance:     updatedActivity.Distance,
-		ActivityName: updatedActivity.ActivityName,
-		AvgSpeed:     updatedActivity.AvgSpeed,
-		MaxSpeed:     updatedActivity.MaxSpeed,
-		ElapsedTime:  updatedActivity.ElapsedTime,
-		TotalTime:    updatedActivity.TotalTime,
-		RideType:     updatedActivity.RideType,
-		Records:      protobufRecords,
+		Id:               updatedActivity.ID,
+		CreatedAt:        updatedActivity.CreatedAt.String(),
+		Distance:         updatedActivity.Distance,
+		ActivityName:     updatedActivity.ActivityName,
+		AvgSpeed:         updatedActivity.AvgSpeed,
+		MaxSpeed:         updatedActivity.MaxSpeed,
+		ElapsedTime:      updatedActivity.ElapsedTime,
+		TotalTime:        updatedActivity.TotalTime,
+		MovingTime:       updatedActivity.MovingTime,
+		RideType:         updatedActivity.RideType,
+		Records:          protobufRecords,
+		RideTypeDetected: updatedActivity.RideTypeDetected,
 	}
 
 	// Create a ConnectRPC response
@@ -327,3 +762,30 @@ func (h *ActivityHandler) UpdateActivity(
 
 	return connectResp, nil
 }
+
+// DeleteActivity deletes an activity of the user.
+func (h *ActivityHandler) DeleteActivity(
+	ctx context.Context,
+	req *connect.Request[activityv1.DeleteActivityRequest],
+) (*connect.Response[activityv1.DeleteActivityResponse], error) {
+	user := middleware.RetrieveUserFromContext(ctx)
+	if user == nil {
+		err := fmt.Errorf("user not authenticated")
+		slog.ErrorContext(ctx, "failed to retrieve user from context", "error", err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if req.Msg.ActivityId <= 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid activity ID"))
+	}
+
+	err := h.service.DeleteActivity(ctx, req.Msg.ActivityId, user.ID)
+	if errors.Is(err, service.ErrActivityNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to delete activity"))
+	}
+
+	return connect.NewResponse(&activityv1.DeleteActivityResponse{}), nil
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	activityv1 "github.com/notaduck/backend/gen/activity/v1"
+	"github.com/notaduck/backend/internal/rpc/middleware"
+	service "github.com/notaduck/backend/internal/services"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetIngestionJobs reports the progress of files queued by uploads.
+func (h *ActivityHandler) GetIngestionJobs(
+	ctx context.Context,
+	req *connect.Request[activityv1.GetIngestionJobsRequest],
+) (*connect.Response[activityv1.GetIngestionJobsResponse], error) {
+	user := middleware.RetrieveUserFromContext(ctx)
+	if user == nil {
+		err := fmt.Errorf("user not authenticated")
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	jobs, err := h.ingestion.GetIngestionJobs(ctx, user.ID, req.Msg.GetJobIds())
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get ingestion jobs", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get ingestion jobs"))
+	}
+
+	return connect.NewResponse(&activityv1.GetIngestionJobsResponse{
+		Jobs: convertIngestionJobsToProto(jobs),
+	}), nil
+}
+
+// convertQueuedUploadToProto reports the jobs queued by an upload along with
+// the files rejected before they could be queued.
+func convertQueuedUploadToProto(jobs []service.IngestionJob, rejected []service.FileResult) *activityv1.UploadActivitiesResponse {
+	response := convertUploadResultToProto(&service.UploadBatchResult{Files: rejected})
+	response.Jobs = convertIngestionJobsToProto(jobs)
+	if len(jobs) > 0 {
+		response.Status = "queued"
+	}
+	return response
+}
+
+func convertIngestionJobsToProto(jobs []service.IngestionJob) []*activityv1.IngestionJob {
+	protobufJobs := make([]*activityv1.IngestionJob, len(jobs))
+	for i, job := range jobs {
+		protobufJobs[i] = &activityv1.IngestionJob{
+			Id:            job.ID,
+			Filename:      job.Filename,
+			Status:        convertJobStatusToProto(job.Status),
+			Skipped:       job.Skipped,
+			FailureReason: convertFailureReasonToProto(job.Reason),
+			Error:         job.Error,
+			CreatedAt:     timestamppb.New(job.CreatedAt),
+		}
+		if job.ActivityID != nil {
+			protobufJobs[i].ActivityId = *job.ActivityID
+		}
+		if job.StartedAt != nil {
+			protobufJobs[i].StartedAt = timestamppb.New(*job.StartedAt)
+		}
+		if job.FinishedAt != nil {
+			protobufJobs[i].FinishedAt = timestamppb.New(*job.FinishedAt)
+		}
+	}
+	return protobufJobs
+}
+
+func convertJobStatusToProto(status service.JobStatus) activityv1.IngestionJobStatus {
+	switch status {
+	case service.JobQueued:
+		return activityv1.IngestionJobStatus_INGESTION_JOB_STATUS_QUEUED
+	case service.JobRunning:
+		return activityv1.IngestionJobStatus_INGESTION_JOB_STATUS_RUNNING
+	case service.JobDone:
+		return activityv1.IngestionJobStatus_INGESTION_JOB_STATUS_DONE
+	case service.JobFailed:
+		return activityv1.IngestionJobStatus_INGESTION_JOB_STATUS_FAILED
+	default:
+		return activityv1.IngestionJobStatus_INGESTION_JOB_STATUS_UNSPECIFIED
+	}
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	activityv1 "github.com/notaduck/backend/gen/activity/v1"
+	"github.com/notaduck/backend/internal/rpc/middleware"
+	service "github.com/notaduck/backend/internal/services"
+)
+
+func (h *ActivityHandler) GetOriginalFile(
+	ctx context.Context,
+	req *connect.Request[activityv1.GetOriginalFileRequest],
+) (*connect.Response[activityv1.GetOriginalFileResponse], error) {
+	user := middleware.RetrieveUserFromContext(ctx)
+	if user == nil {
+		err := fmt.Errorf("user not authenticated")
+		slog.ErrorContext(ctx, "failed to retrieve user from context", "error", err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	original, err := h.service.GetOriginalFile(ctx, req.Msg.ActivityId, user.ID)
+	if errors.Is(err, service.ErrOriginalNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get original file", "error", err, "activity_id", req.Msg.ActivityId)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get original file"))
+	}
+	defer original.Content.Close()
+
+	data, err := io.ReadAll(original.Content)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to read original file", "error", err, "activity_id", req.Msg.ActivityId)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get original file"))
+	}
+
+	return connect.NewResponse(&activityv1.GetOriginalFileResponse{
+		Filename:    original.Filename,
+		ContentType: original.ContentType,
+		Data:        data,
+	}), nil
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	activityv1 "github.com/notaduck/backend/gen/activity/v1"
+	"github.com/notaduck/backend/internal/rpc/middleware"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *ActivityHandler) GetPersonalRecords(
+	ctx context.Context,
+	req *connect.Request[activityv1.GetPersonalRecordsRequest],
+) (*connect.Response[activityv1.GetPersonalRecordsResponse], error) {
+	user := middleware.RetrieveUserFromContext(ctx)
+	if user == nil {
+		err := fmt.Errorf("user not authenticated")
+		slog.ErrorContext(ctx, "failed to retrieve user from context", "error", err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	records, err := h.service.GetPersonalRecords(ctx, user.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get personal records", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get personal records"))
+	}
+
+	response := &activityv1.GetPersonalRecordsResponse{
+		Records: make([]*activityv1.PersonalRecord, len(records)),
+	}
+	for i, record := range records {
+		response.Records[i] = &activityv1.PersonalRecord{
+			Distance:     record.Distance,
+			Seconds:      record.Seconds,
+			StartedAt:    timestamppb.New(record.StartedAt),
+			ActivityId:   record.ActivityID,
+			ActivityName: record.ActivityName,
+			Date:         timestamppb.New(record.Date),
+		}
+	}
+
+	return connect.NewResponse(response), nil
+}
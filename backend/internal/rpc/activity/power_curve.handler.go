@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	activityv1 "github.com/notaduck/backend/gen/activity/v1"
+	"github.com/notaduck/backend/internal/rpc/middleware"
+	service "github.com/notaduck/backend/internal/services"
+)
+
+func (h *ActivityHandler) GetPowerCurve(
+	ctx context.Context,
+	req *connect.Request[activityv1.GetPowerCurveRequest],
+) (*connect.Response[activityv1.GetPowerCurveResponse], error) {
+	user := middleware.RetrieveUserFromContext(ctx)
+	if user == nil {
+		err := fmt.Errorf("user not authenticated")
+		slog.ErrorContext(ctx, "failed to retrieve user from context", "error", err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	filter := service.PowerCurveFilter{RideType: req.Msg.RideType}
+	if req.Msg.From != nil {
+		filter.From = req.Msg.From.AsTime()
+	}
+	if req.Msg.To != nil {
+		filter.To = req.Msg.To.AsTime()
+	}
+
+	points, err := h.service.GetPowerCurve(ctx, user.ID, filter)
+	if errors.Is(err, service.ErrInvalidPowerCurveFilter) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get power curve", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get power curve"))
+	}
+
+	return connect.NewResponse(&activityv1.GetPowerCurveResponse{
+		Points: convertPowerCurveToProto(points),
+	}), nil
+}
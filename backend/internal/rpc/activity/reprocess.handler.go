@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	activityv1 "github.com/notaduck/backend/gen/activity/v1"
+	"github.com/notaduck/backend/internal/rpc/middleware"
+	service "github.com/notaduck/backend/internal/services"
+)
+
+// ReprocessActivities derives the data of existing activities again from
+// their stored originals and streams the outcome of every activity. It is
+// restricted to admins as it may touch the activities of every user.
+func (h *ActivityHandler) ReprocessActivities(
+	ctx context.Context,
+	req *connect.Request[activityv1.ReprocessActivitiesRequest],
+	stream *connect.ServerStream[activityv1.ReprocessActivitiesProgress],
+) error {
+	user := middleware.RetrieveUserFromContext(ctx)
+	if user == nil {
+		err := fmt.Errorf("user not authenticated")
+		return connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if _, ok := h.admins[user.ID]; !ok {
+		slog.WarnContext(ctx, "reprocessing denied to non-admin user", "userID", user.ID)
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("reprocessing requires admin access"))
+	}
+
+	filter, err := reprocessFilter(req.Msg)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	slog.InfoContext(ctx, "reprocessing activities", "admin", user.ID, "activityID", filter.ActivityID, "userID", filter.UserID)
+
+	_, err = h.service.ReprocessActivities(ctx, filter, func(progress service.ReprocessProgress) error {
+		return stream.Send(&activityv1.ReprocessActivitiesProgress{
+			Processed: int32(progress.Processed),
+			Total:     int32(progress.Total),
+			Result:    convertFileResultToProto(progress.Result),
+		})
+	})
+
+	switch {
+	case errors.Is(err, service.ErrNoFileStore):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	case err != nil:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	return nil
+}
+
+// reprocessFilter requires the scope to be explicit so that an empty request
+// never reprocesses every activity.
+func reprocessFilter(msg *activityv1.ReprocessActivitiesRequest) (service.ReprocessFilter, error) {
+	scopes := 0
+	for _, set := range []bool{msg.GetActivityId() != 0, msg.GetUserId() != "", msg.GetAll()} {
+		if set {
+			scopes++
+		}
+	}
+	if scopes != 1 {
+		return service.ReprocessFilter{}, fmt.Errorf("exactly one of activity_id, user_id or all must be set")
+	}
+
+	return service.ReprocessFilter{
+		ActivityID: msg.GetActivityId(),
+		UserID:     msg.GetUserId(),
+	}, nil
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	activityv1 "github.com/notaduck/backend/gen/activity/v1"
+	"github.com/notaduck/backend/internal/rpc/middleware"
+	service "github.com/notaduck/backend/internal/services"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func (h *ActivityHandler) GetUserSettings(
+	ctx context.Context,
+	req *connect.Request[activityv1.GetUserSettingsRequest],
+) (*connect.Response[activityv1.UserSettings], error) {
+	user := middleware.RetrieveUserFromContext(ctx)
+	if user == nil {
+		err := fmt.Errorf("user not authenticated")
+		slog.ErrorContext(ctx, "failed to retrieve user from context", "error", err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	settings, err := h.service.GetUserSettings(ctx, user.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get user settings", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get settings"))
+	}
+
+	return connect.NewResponse(convertUserSettingsToProto(settings)), nil
+}
+
+func (h *ActivityHandler) UpdateUserSettings(
+	ctx context.Context,
+	req *connect.Request[activityv1.UpdateUserSettingsRequest],
+) (*connect.Response[activityv1.UserSettings], error) {
+	user := middleware.RetrieveUserFromContext(ctx)
+	if user == nil {
+		err := fmt.Errorf("user not authenticated")
+		slog.ErrorContext(ctx, "failed to retrieve user from context", "error", err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	var settings service.UserSettings
+	if msg := req.Msg.Settings; msg != nil {
+		if msg.Ftp != nil {
+			ftp := msg.Ftp.Value
+			settings.FTP = &ftp
+		}
+		if msg.MaxHeartRate != nil {
+			maxHeartRate := msg.MaxHeartRate.Value
+			settings.MaxHeartRate = &maxHeartRate
+		}
+		if msg.ThresholdHeartRate != nil {
+			thresholdHeartRate := msg.ThresholdHeartRate.Value
+			settings.ThresholdHeartRate = &thresholdHeartRate
+		}
+		settings.HeartRateZoneMethod = msg.HeartRateZoneMethod
+		settings.HeartRateZoneBounds = msg.HeartRateZoneBounds
+	}
+
+	updated, err := h.service.UpdateUserSettings(ctx, user.ID, settings)
+	if errors.Is(err, service.ErrInvalidSettings) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to update user settings", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update settings"))
+	}
+
+	return connect.NewResponse(convertUserSettingsToProto(updated)), nil
+}
+
+func convertUserSettingsToProto(settings *service.UserSettings) *activityv1.UserSettings {
+	response := &activityv1.UserSettings{
+		HeartRateZoneMethod: settings.HeartRateZoneMethod,
+		HeartRateZoneBounds: settings.HeartRateZoneBounds,
+	}
+	if settings.FTP != nil {
+		response.Ftp = wrapperspb.Int32(*settings.FTP)
+	}
+	if settings.MaxHeartRate != nil {
+		response.MaxHeartRate = wrapperspb.Int32(*settings.MaxHeartRate)
+	}
+	if settings.ThresholdHeartRate != nil {
+		response.ThresholdHeartRate = wrapperspb.Int32(*settings.ThresholdHeartRate)
+	}
+	return response
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"connectrpc.com/connect"
+	activityv1 "github.com/notaduck/backend/gen/activity/v1"
+	"github.com/notaduck/backend/internal/rpc/middleware"
+	service "github.com/notaduck/backend/internal/services"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *ActivityHandler) GetTrainingLoad(
+	ctx context.Context,
+	req *connect.Request[activityv1.GetTrainingLoadRequest],
+) (*connect.Response[activityv1.GetTrainingLoadResponse], error) {
+	user := middleware.RetrieveUserFromContext(ctx)
+	if user == nil {
+		err := fmt.Errorf("user not authenticated")
+		slog.ErrorContext(ctx, "failed to retrieve user from context", "error", err)
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	var from, to time.Time
+	if req.Msg.From != nil {
+		from = req.Msg.From.AsTime()
+	}
+	if req.Msg.To != nil {
+		to = req.Msg.To.AsTime()
+	}
+
+	days, err := h.service.GetTrainingLoad(ctx, user.ID, from, to)
+	if errors.Is(err, service.ErrInvalidTrainingLoadRange) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get training load", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get training load"))
+	}
+
+	response := &activityv1.GetTrainingLoadResponse{
+		Days: make([]*activityv1.TrainingLoadDay, len(days)),
+	}
+	for i, day := range days {
+		response.Days[i] = &activityv1.TrainingLoadDay{
+			Date: timestamppb.New(day.Date),
+			Load: day.Load,
+			Ctl:  day.CTL,
+			Atl:  day.ATL,
+			Tsb:  day.TSB,
+		}
+	}
+
+	return connect.NewResponse(response), nil
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strings"
+	"time"
+
+	activityv1 "github.com/notaduck/backend/gen/activity/v1"
+	service "github.com/notaduck/backend/internal/services"
+)
+
+// maxStreamedFileSize caps a single file in a streaming upload, matching the
+// 25MB per file limit advertised by the dashboard.
+const maxStreamedFileSize = 25 << 20
+
+// streamedFile collects the chunks of one file announced by an
+// UploadFileHeader in the UploadActivities stream.
+type streamedFile struct {
+	header *activityv1.UploadFileHeader
+	data   bytes.Buffer
+	hasher hash.Hash
+}
+
+func newStreamedFile(header *activityv1.UploadFileHeader) *streamedFile {
+	return &streamedFile{
+		header: header,
+		hasher: sha256.New(),
+	}
+}
+
+func (f *streamedFile) write(chunk []byte) error {
+	if int64(f.data.Len()+len(chunk)) > maxStreamedFileSize {
+		return fmt.Errorf("file %q exceeds the maximum size of %d bytes", f.header.GetFilename(), maxStreamedFileSize)
+	}
+
+	f.data.Write(chunk)
+	f.hasher.Write(chunk)
+
+	return nil
+}
+
+// payload validates the received bytes against the header and converts the
+// file into the service payload.
+func (f *streamedFile) payload() (service.ActivityFilePayload, error) {
+	if f.data.Len() == 0 {
+		return service.ActivityFilePayload{}, fmt.Errorf("file %q: %w", f.header.GetFilename(), service.ErrEmptyFile)
+	}
+
+	if size := f.header.GetSize(); size > 0 && size != int64(f.data.Len()) {
+		return service.ActivityFilePayload{}, fmt.Errorf("%w: file %q is incomplete, received %d of %d bytes", service.ErrCorruptFile, f.header.GetFilename(), f.data.Len(), size)
+	}
+
+	if expected := strings.TrimSpace(f.header.GetSha256()); expected != "" {
+		if actual := hex.EncodeToString(f.hasher.Sum(nil)); !strings.EqualFold(expected, actual) {
+			return service.ActivityFilePayload{}, fmt.Errorf("%w: file %q failed checksum verification", service.ErrCorruptFile, f.header.GetFilename())
+		}
+	}
+
+	payload := service.ActivityFilePayload{
+		Filename:    f.header.GetFilename(),
+		ContentType: f.header.GetContentType(),
+		Data:        f.data.Bytes(),
+	}
+
+	if lm := f.header.GetLastModified(); lm > 0 {
+		payload.LastModified = time.UnixMilli(lm)
+	}
+
+	return payload, nil
+}
+
+// uploadStatus summarises per-file results into the legacy status string.
+func uploadStatus(results []*activityv1.UploadFileResult) string {
+	failed := 0
+	for _, result := range results {
+		if result.GetError() != "" {
+			failed++
+		}
+	}
+
+	switch {
+	case failed == 0:
+		return "success"
+	case failed == len(results):
+		return "failed"
+	default:
+		return "partial"
+	}
+}
@@ -2,8 +2,11 @@ package rpcserver
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/nedpals/supabase-go"
 	"github.com/newrelic/go-agent/v3/newrelic"
@@ -18,6 +21,10 @@ import (
 	service "github.com/notaduck/backend/internal/services"
 )
 
+// shutdownTimeout is how long requests in flight may take to finish once the
+// server is stopped.
+const shutdownTimeout = 10 * time.Second
+
 type Server struct {
 	activityHandler  *handlers.ActivityHandler
 	config           *config.Config
@@ -26,9 +33,13 @@ type Server struct {
 	newRelic         *newrelic.Application
 }
 
-func NewServer(cfg *config.Config, activityService service.ActivityService, newRelic *newrelic.Application) *Server {
+func NewServer(cfg *config.Config, activityService service.ActivityService, ingestionService service.IngestionService, newRelic *newrelic.Application) *Server {
 
-	activityHandler := handlers.NewActivityHandler(activityService)
+	activityHandler := handlers.NewActivityHandler(
+		activityService,
+		ingestionService,
+		handlers.WithAdmins(strings.Split(cfg.AdminUserIDs, ",")),
+	)
 	sc := supabase.CreateClient(cfg.SupabaseUrl, cfg.SupabaseKey)
 
 	return &Server{
@@ -90,7 +101,21 @@ func (s *Server) Start(ctx context.Context) error {
 		log.Printf(" - %s\n", route)
 	}
 
+	// Stop accepting requests once ctx is cancelled, giving those in flight
+	// a moment to finish.
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Server shutdown: %v", err)
+		}
+	}()
+
 	// Start the server
 	log.Println("Listen and serve")
-	return server.ListenAndServe()
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
 }
@@ -2,7 +2,12 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"strconv"
 	"strings"
@@ -10,15 +15,9 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/notaduck/backend/internal/db"
+	service "github.com/notaduck/backend/internal/services"
 )
 
-var allowedRideTypes = map[string]struct{}{
-	"road":   {},
-	"gravel": {},
-	"mtb":    {},
-	"tt":     {},
-}
-
 func (s *APIServer) handlePatchActivity(w http.ResponseWriter, r *http.Request) error {
 
 	activityIdStr := r.URL.Query().Get("activityId")
@@ -67,7 +66,7 @@ func (s *APIServer) handlePatchActivity(w http.ResponseWriter, r *http.Request)
 
 	if payload.RideType != nil {
 		rideType := strings.ToLower(strings.TrimSpace(*payload.RideType))
-		if _, ok := allowedRideTypes[rideType]; !ok {
+		if !service.IsRideType(rideType) {
 			return WriteJSON(w, http.StatusBadRequest, ApiError{Error: "invalid ride type"})
 		}
 		params.RideType = pgtype.Text{String: rideType, Valid: true}
@@ -112,6 +111,35 @@ func (s *APIServer) handleGetActivity(w http.ResponseWriter, r *http.Request) er
 
 }
 
+func (s *APIServer) handleGetOriginalFile(w http.ResponseWriter, r *http.Request) error {
+	activityID, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+	if err != nil {
+		return WriteJSON(w, http.StatusBadRequest, ApiError{Error: "activity id must be a number"})
+	}
+
+	user := RetrieveUserFromContext(r.Context())
+
+	original, err := s.activityService.GetOriginalFile(r.Context(), int32(activityID), user.ID)
+	if errors.Is(err, service.ErrOriginalNotFound) {
+		return WriteJSON(w, http.StatusNotFound, ApiError{Error: "no original file was found for this activity"})
+	}
+	if err != nil {
+		slog.Error("failed to get original file", "activityID", activityID, "error", err)
+		return WriteJSON(w, http.StatusInternalServerError, ApiError{Error: "failed to get original file"})
+	}
+	defer original.Content.Close()
+
+	w.Header().Set("Content-Type", original.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(original.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": original.Filename}))
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, original.Content); err != nil {
+		slog.Error("failed to send original file", "activityID", activityID, "error", err)
+	}
+	return nil
+}
+
 func (s *APIServer) handleGetActivityStats(w http.ResponseWriter, r *http.Request) error {
 
 	user := RetrieveUserFromContext(r.Context())
@@ -166,7 +194,7 @@ func (s *APIServer) handlePostActivity(w http.ResponseWriter, r *http.Request) e
 	// Check if files were included in the request.
 	files := r.MultipartForm.File["files"]
 	if files == nil || len(files) == 0 {
-		return WriteJSON(w, http.StatusBadRequest, ApiError{"Please select one or more .fit files to upload and try again! 📁🚀"})
+		return WriteJSON(w, http.StatusBadRequest, ApiError{"Please select one or more .fit, .gpx or .tcx files to upload and try again! 📁🚀"})
 	}
 
 	// Retrieve the user from the context.
@@ -176,13 +204,108 @@ func (s *APIServer) handlePostActivity(w http.ResponseWriter, r *http.Request) e
 		return WriteJSON(w, http.StatusInternalServerError, ApiError{"User not found in the request context."})
 	}
 
-	// Process the uploaded files by calling your service layer.
-	activityDetails, err := s.activityService.CreateActivities(r.Context(), files, user.ID)
+	payloads := make([]service.ActivityFilePayload, 0, len(files))
+	for _, fileHeader := range files {
+		payload, err := readUploadedFile(fileHeader)
+		if err != nil {
+			return WriteJSON(w, http.StatusBadRequest, ApiError{Error: err.Error()})
+		}
+		payloads = append(payloads, payload)
+	}
+
+	// The files are ingested in the background; their progress is reported by
+	// GET /ingestion-jobs.
+	jobs, err := s.ingestionService.Enqueue(r.Context(), payloads, user.ID)
+	if err != nil {
+		return WriteJSON(w, http.StatusInternalServerError, ApiError{Error: "failed to queue the uploaded files"})
+	}
+
+	return WriteJSON(w, http.StatusAccepted, map[string]any{"jobs": jobs})
+}
+
+func readUploadedFile(fileHeader *multipart.FileHeader) (service.ActivityFilePayload, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return service.ActivityFilePayload{}, fmt.Errorf("failed to read %q: %w", fileHeader.Filename, err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return service.ActivityFilePayload{}, fmt.Errorf("failed to read %q: %w", fileHeader.Filename, err)
+	}
+
+	return service.ActivityFilePayload{
+		Filename:    fileHeader.Filename,
+		ContentType: fileHeader.Header.Get("Content-Type"),
+		Data:        data,
+	}, nil
+}
+
+func (s *APIServer) handleGetIngestionJobs(w http.ResponseWriter, r *http.Request) error {
+	var ids []int64
+	if value := r.URL.Query().Get("ids"); value != "" {
+		for _, part := range strings.Split(value, ",") {
+			id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+			if err != nil {
+				return WriteJSON(w, http.StatusBadRequest, ApiError{Error: fmt.Sprintf("invalid job id %q", part)})
+			}
+			ids = append(ids, id)
+		}
+	}
+
+	user := RetrieveUserFromContext(r.Context())
+
+	jobs, err := s.ingestionService.GetIngestionJobs(r.Context(), user.ID, ids)
+	if err != nil {
+		slog.Error("failed to get ingestion jobs", "error", err)
+		return WriteJSON(w, http.StatusInternalServerError, ApiError{Error: "failed to get ingestion jobs"})
+	}
+
+	return WriteJSON(w, http.StatusOK, jobs)
+}
+
+// uploadResultStatus is 200 when every file was ingested, 207 when some
+// failed and 422 when none could be ingested.
+func uploadResultStatus(result *service.UploadBatchResult) int {
+	switch failed := result.Failed(); {
+	case failed > 0 && failed == len(result.Files):
+		return http.StatusUnprocessableEntity
+	case failed > 0:
+		return http.StatusMultiStatus
+	default:
+		return http.StatusOK
+	}
+}
+
+func (s *APIServer) handleImportArchive(w http.ResponseWriter, r *http.Request) error {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		return WriteJSON(w, http.StatusBadRequest, ApiError{"Content-Type must be multipart/form-data."})
+	}
+
+	// Archives larger than 32 MB are buffered on disk by the multipart reader.
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		return WriteJSON(w, http.StatusBadRequest, ApiError{"Error parsing multipart form: " + err.Error()})
+	}
+
+	file, header, err := r.FormFile("archive")
+	if err != nil {
+		return WriteJSON(w, http.StatusBadRequest, ApiError{"Please select a Strava or Garmin export .zip to import."})
+	}
+	defer file.Close()
+
+	user := RetrieveUserFromContext(r.Context())
+	if user == nil {
+		return WriteJSON(w, http.StatusInternalServerError, ApiError{"User not found in the request context."})
+	}
+
+	result, err := s.activityService.ImportArchive(r.Context(), file, header.Size, user.ID, nil)
+	if errors.Is(err, service.ErrInvalidArchive) {
+		return WriteJSON(w, http.StatusBadRequest, ApiError{Error: err.Error()})
+	}
 	if err != nil {
-		// Properly pass on the error message to the client.
 		return WriteJSON(w, http.StatusInternalServerError, ApiError{Error: err.Error()})
 	}
 
-	// Send the successful response back to the client.
-	return WriteJSON(w, http.StatusOK, activityDetails)
+	return WriteJSON(w, uploadResultStatus(result), result)
 }
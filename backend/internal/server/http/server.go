@@ -23,12 +23,16 @@ const (
 
 type APIServer struct {
 	listenAddr      string
+	pool            *pgxpool.Pool
 	queries         *db.Queries
 	activityService service.ActivityService
-	config          *config.Config
+	// ingestionService only queues uploads; the worker pool runs in the RPC
+	// server process.
+	ingestionService service.IngestionService
+	config           *config.Config
 }
 
-func NewAPIServer(options ...func(*APIServer)) *APIServer {
+func NewAPIServer(options ...func(*APIServer)) (*APIServer, error) {
 	server := &APIServer{
 		listenAddr: "127.0.0.1:3030",
 	}
@@ -42,27 +46,35 @@ func NewAPIServer(options ...func(*APIServer)) *APIServer {
 		server.config = cfg
 	}
 
-	if server.queries == nil {
-		ctx := context.Background()
+	ctx := context.Background()
 
+	if server.pool == nil {
 		pool, err := pgxpool.New(ctx, server.config.DbConnectionString)
 		if err != nil {
-			panic(fmt.Sprintf("failed to connect to database: %v", err))
+			return nil, fmt.Errorf("failed to connect to database: %w", err)
 		}
 
 		if err := pool.Ping(ctx); err != nil {
-			panic("could not ping the database")
+			return nil, fmt.Errorf("could not ping the database: %w", err)
 		}
 
-		server.queries = db.New(pool)
+		server.pool = pool
+	}
+
+	if server.queries == nil {
+		server.queries = db.New(server.pool)
 	}
 
-	activityRepo := repositories.NewActivityRepository(server.queries)
-	recordRepo := repositories.NewRecordRepository(server.queries)
-	activityService := service.NewActivityService(activityRepo, recordRepo)
-	server.activityService = activityService
+	if server.activityService == nil {
+		activityService, err := service.NewActivityServiceFromConfig(ctx, server.config, server.pool, server.queries)
+		if err != nil {
+			return nil, err
+		}
+		server.activityService = activityService
+	}
+	server.ingestionService = service.NewIngestionService(repositories.NewIngestionJobRepository(server.queries), server.activityService)
 
-	return server
+	return server, nil
 }
 
 func WithConfig(config *config.Config) func(*APIServer) {
@@ -71,12 +83,26 @@ func WithConfig(config *config.Config) func(*APIServer) {
 	}
 }
 
+func WithDbPool(pool *pgxpool.Pool) func(*APIServer) {
+	return func(s *APIServer) {
+		s.pool = pool
+	}
+}
+
 func WithDbQueries(q *db.Queries) func(*APIServer) {
 	return func(s *APIServer) {
 		s.queries = q
 	}
 }
 
+// WithActivityService shares an activity service created elsewhere, e.g. by
+// the RPC server in the same process.
+func WithActivityService(activityService service.ActivityService) func(*APIServer) {
+	return func(s *APIServer) {
+		s.activityService = activityService
+	}
+}
+
 func WithListenAddr(addr string) func(*APIServer) {
 	return func(s *APIServer) {
 		s.listenAddr = addr
@@ -97,10 +123,15 @@ func (s *APIServer) Run() {
 	}
 
 	router.Handle("GET /activity/", buildChain(makeHTTPHandleFunc(s.handleGetActivity), protectedChain...))
+	router.Handle("GET /activity/{id}/original", buildChain(makeHTTPHandleFunc(s.handleGetOriginalFile), protectedChain...))
 	router.Handle("PATCH /activity", buildChain(makeHTTPHandleFunc(s.handlePatchActivity), protectedChain...))
 	router.Handle("GET /activities", buildChain(makeHTTPHandleFunc(s.handleGetActivities), protectedChain...))
 	router.Handle("POST /activity", buildChain(makeHTTPHandleFunc(s.handlePostActivity), protectedChain...))
+	router.Handle("GET /ingestion-jobs", buildChain(makeHTTPHandleFunc(s.handleGetIngestionJobs), protectedChain...))
+	router.Handle("POST /activity/import", buildChain(makeHTTPHandleFunc(s.handleImportArchive), protectedChain...))
 	router.Handle("GET /stats", buildChain(makeHTTPHandleFunc(s.handleGetActivityStats), protectedChain...))
+	router.Handle("GET /settings", buildChain(makeHTTPHandleFunc(s.handleGetSettings), protectedChain...))
+	router.Handle("PUT /settings", buildChain(makeHTTPHandleFunc(s.handlePutSettings), protectedChain...))
 
 	router.Handle("/register", buildChain(makeHTTPHandleFunc(s.handleRegistration), publicChain...))
 
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	service "github.com/notaduck/backend/internal/services"
+)
+
+func (s *APIServer) handleGetSettings(w http.ResponseWriter, r *http.Request) error {
+
+	user := RetrieveUserFromContext(r.Context())
+
+	settings, err := s.activityService.GetUserSettings(r.Context(), user.ID)
+
+	if err != nil {
+		slog.Error("failed to get user settings", "error", err)
+		return WriteJSON(w, http.StatusInternalServerError, ApiError{Error: "failed to get settings"})
+	}
+
+	return WriteJSON(w, http.StatusOK, settings)
+}
+
+func (s *APIServer) handlePutSettings(w http.ResponseWriter, r *http.Request) error {
+
+	var payload service.UserSettings
+
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		slog.Error("failed to parse request body", "error", err)
+		return WriteJSON(w, http.StatusBadRequest, ApiError{Error: err.Error()})
+	}
+
+	user := RetrieveUserFromContext(r.Context())
+
+	settings, err := s.activityService.UpdateUserSettings(r.Context(), user.ID, payload)
+
+	if errors.Is(err, service.ErrInvalidSettings) {
+		return WriteJSON(w, http.StatusBadRequest, ApiError{Error: err.Error()})
+	}
+	if err != nil {
+		slog.Error("failed to update user settings", "error", err)
+		return WriteJSON(w, http.StatusInternalServerError, ApiError{Error: "failed to update settings"})
+	}
+
+	return WriteJSON(w, http.StatusOK, settings)
+}
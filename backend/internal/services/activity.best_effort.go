@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/tormoder/fit"
+
+	"github.com/notaduck/backend/internal/db"
+)
+
+// bestEffortDistances are the distances, in m, of the fastest efforts kept
+// for each ride.
+var bestEffortDistances = []int32{1000, 5000, 10000, 20000, 40000, 100000}
+
+// BestEffort is the fastest stretch of a ride over Distance m, timed in
+// elapsed seconds. PersonalRecord is set when no earlier ride of the user was
+// as fast over the distance when the ride was ingested.
+type BestEffort struct {
+	Distance       int32     `json:"distance"`
+	Seconds        int32     `json:"seconds"`
+	StartedAt      time.Time `json:"startedAt"`
+	PersonalRecord bool      `json:"personalRecord"`
+}
+
+// PersonalRecord is the user's fastest effort over Distance m and the ride
+// it was set on.
+type PersonalRecord struct {
+	Distance     int32     `json:"distance"`
+	Seconds      int32     `json:"seconds"`
+	StartedAt    time.Time `json:"startedAt"`
+	ActivityID   int32     `json:"activityId"`
+	ActivityName string    `json:"activityName"`
+	Date         time.Time `json:"date"`
+}
+
+// distanceSample is the distance in m covered at a point in time.
+type distanceSample struct {
+	Meters float64
+	At     time.Time
+}
+
+// distanceSamples returns the cumulative distance of the records, skipping
+// records without a distance or timestamp and those where the distance
+// drops.
+func distanceSamples(records []*fit.RecordMsg) []distanceSample {
+	var samples []distanceSample
+	for _, record := range records {
+		if record.Distance == 0xFFFFFFFF || record.Timestamp.IsZero() {
+			continue
+		}
+		meters := record.GetDistanceScaled()
+		if len(samples) > 0 && (meters < samples[len(samples)-1].Meters || !record.Timestamp.After(samples[len(samples)-1].At)) {
+			continue
+		}
+		samples = append(samples, distanceSample{Meters: meters, At: record.Timestamp})
+	}
+	return samples
+}
+
+// bestEfforts finds the fastest effort over each of bestEffortDistances the
+// ride covers. For every sample it takes the latest start that still covers
+// the distance, interpolating the start time between the two samples around
+// it, so the effort is timed over the distance exactly.
+func bestEfforts(records []*fit.RecordMsg) []db.CreateActivityBestEffortsParams {
+	samples := distanceSamples(records)
+	if len(samples) < 2 {
+		return nil
+	}
+
+	var efforts []db.CreateActivityBestEffortsParams
+	for _, distance := range bestEffortDistances {
+		target := float64(distance)
+		if samples[len(samples)-1].Meters-samples[0].Meters < target {
+			break
+		}
+
+		best := math.Inf(1)
+		var bestStart time.Time
+		start := 0
+		for end := 1; end < len(samples); end++ {
+			if samples[end].Meters-samples[0].Meters < target {
+				continue
+			}
+			for samples[end].Meters-samples[start+1].Meters >= target {
+				start++
+			}
+
+			from, next := samples[start], samples[start+1]
+			startedAt := from.At
+			if span := next.Meters - from.Meters; span > 0 {
+				fraction := (samples[end].Meters - target - from.Meters) / span
+				startedAt = from.At.Add(time.Duration(fraction * float64(next.At.Sub(from.At))))
+			}
+
+			if seconds := samples[end].At.Sub(startedAt).Seconds(); seconds < best {
+				best = seconds
+				bestStart = startedAt
+			}
+		}
+
+		efforts = append(efforts, db.CreateActivityBestEffortsParams{
+			Distance:  distance,
+			Seconds:   int32(math.Max(1, math.Round(best))),
+			StartedAt: pgtype.Timestamptz{Time: bestStart, Valid: true},
+		})
+	}
+
+	return efforts
+}
+
+// markPersonalRecords flags the efforts faster than the previous best of the
+// user over the same distance. The first effort over a distance is a record.
+func markPersonalRecords(efforts []db.CreateActivityBestEffortsParams, previous []db.GetPreviousBestEffortsRow) {
+	fastest := make(map[int32]int32, len(previous))
+	for _, row := range previous {
+		fastest[row.Distance] = row.Seconds
+	}
+	for i := range efforts {
+		seconds, ok := fastest[efforts[i].Distance]
+		efforts[i].PersonalRecord = !ok || efforts[i].Seconds < seconds
+	}
+}
+
+func convertBestEfforts(rows []db.GetActivityBestEffortsRow) []BestEffort {
+	efforts := make([]BestEffort, len(rows))
+	for i, row := range rows {
+		efforts[i] = BestEffort{
+			Distance:       row.Distance,
+			Seconds:        row.Seconds,
+			StartedAt:      row.StartedAt.Time,
+			PersonalRecord: row.PersonalRecord,
+		}
+	}
+	return efforts
+}
+
+// GetPersonalRecords returns the user's fastest effort over each distance,
+// ordered by distance.
+func (s *activityService) GetPersonalRecords(ctx context.Context, userId string) ([]PersonalRecord, error) {
+	rows, err := s.activityRepo.GetPersonalRecords(ctx, userId)
+	if err != nil {
+		slog.Error("failed to get personal records", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	records := make([]PersonalRecord, len(rows))
+	for i, row := range rows {
+		records[i] = PersonalRecord{
+			Distance:     row.Distance,
+			Seconds:      row.Seconds,
+			StartedAt:    row.StartedAt.Time,
+			ActivityID:   row.ActivityID,
+			ActivityName: row.ActivityName,
+			Date:         row.DateOfActivity.Time,
+		}
+	}
+	return records, nil
+}
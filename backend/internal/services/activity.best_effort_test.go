@@ -0,0 +1,96 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tormoder/fit"
+
+	"github.com/notaduck/backend/internal/db"
+)
+
+// distanceRecords records one sample per second covering the given speeds in
+// m/s.
+func distanceRecords(start time.Time, speeds ...float64) []*fit.RecordMsg {
+	records := make([]*fit.RecordMsg, len(speeds)+1)
+	var meters float64
+	for i := range records {
+		if i > 0 {
+			meters += speeds[i-1]
+		}
+		records[i] = fit.NewRecordMsg()
+		records[i].Timestamp = start.Add(time.Duration(i) * time.Second)
+		records[i].Distance = uint32(meters * 100)
+	}
+	return records
+}
+
+func repeatMetersPerSecond(speed float64, seconds int) []float64 {
+	speeds := make([]float64, seconds)
+	for i := range speeds {
+		speeds[i] = speed
+	}
+	return speeds
+}
+
+func TestBestEfforts(t *testing.T) {
+	start := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+
+	// 6 km at 5 m/s with a kilometre at 10 m/s starting after 2 km.
+	speeds := repeatMetersPerSecond(5, 400)
+	speeds = append(speeds, repeatMetersPerSecond(10, 100)...)
+	speeds = append(speeds, repeatMetersPerSecond(5, 600)...)
+	records := distanceRecords(start, speeds...)
+	// Records without a distance are skipped.
+	records = append(records[:10], append([]*fit.RecordMsg{fit.NewRecordMsg()}, records[10:]...)...)
+
+	efforts := bestEfforts(records)
+	require.Len(t, efforts, 2, "no 10 km effort in a 6 km ride")
+
+	assert.Equal(t, int32(1000), efforts[0].Distance)
+	assert.Equal(t, int32(100), efforts[0].Seconds)
+	assert.Equal(t, start.Add(400*time.Second), efforts[0].StartedAt.Time)
+
+	assert.Equal(t, int32(5000), efforts[1].Distance)
+	assert.Equal(t, int32(900), efforts[1].Seconds, "the fast kilometre and 4 km at 5 m/s")
+}
+
+func TestBestEffortsInterpolatesTheStart(t *testing.T) {
+	start := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+
+	// Samples every 10 s at 8 m/s: a kilometre is 125 s, which falls between
+	// two samples.
+	var records []*fit.RecordMsg
+	for i := 0; i <= 20; i++ {
+		record := fit.NewRecordMsg()
+		record.Timestamp = start.Add(time.Duration(i*10) * time.Second)
+		record.Distance = uint32(i * 80 * 100)
+		records = append(records, record)
+	}
+
+	efforts := bestEfforts(records)
+	require.Len(t, efforts, 1)
+	assert.Equal(t, int32(125), efforts[0].Seconds)
+}
+
+func TestBestEffortsWithoutDistance(t *testing.T) {
+	assert.Nil(t, bestEfforts([]*fit.RecordMsg{fit.NewRecordMsg(), fit.NewRecordMsg()}))
+}
+
+func TestMarkPersonalRecords(t *testing.T) {
+	efforts := []db.CreateActivityBestEffortsParams{
+		{Distance: 1000, Seconds: 90},
+		{Distance: 5000, Seconds: 600},
+		{Distance: 10000, Seconds: 1300},
+	}
+	markPersonalRecords(efforts, []db.GetPreviousBestEffortsRow{
+		{Distance: 1000, Seconds: 95},
+		{Distance: 5000, Seconds: 600},
+	})
+
+	assert.True(t, efforts[0].PersonalRecord)
+	assert.False(t, efforts[1].PersonalRecord, "equalling a record is not a new one")
+	assert.True(t, efforts[2].PersonalRecord, "the first effort over a distance")
+}
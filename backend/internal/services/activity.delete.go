@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/notaduck/backend/internal/db"
+	"github.com/notaduck/backend/internal/repositories"
+)
+
+// ErrActivityNotFound is returned for an activity that does not exist or
+// belongs to another user.
+var ErrActivityNotFound = errors.New("activity not found")
+
+func (s *activityService) DeleteActivity(ctx context.Context, activityId int32, userId string) error {
+	var storedKey string
+
+	err := s.uow.Do(ctx, func(repos repositories.Repositories) error {
+		original, err := repos.Activities.GetActivityOriginal(ctx, db.GetActivityOriginalParams{
+			ActivityID: activityId,
+			UserID:     userId,
+		})
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		dateOfActivity, err := repos.Activities.DeleteActivity(ctx, db.DeleteActivityParams{
+			ID:     activityId,
+			UserID: userId,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrActivityNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		// The day of the ride and every day after it lose its load, and
+		// later rides may regain the records it held.
+		if err := refreshTrainingLoad(ctx, repos.Activities, userId, dateOfActivity.Time); err != nil {
+			return err
+		}
+		if err := repos.Activities.RefreshPersonalRecords(ctx, db.RefreshPersonalRecordsParams{
+			UserID: userId,
+			After:  dateOfActivity,
+		}); err != nil {
+			return err
+		}
+
+		storedKey = original.StorageKey
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, ErrActivityNotFound) {
+			slog.Error("failed to delete activity", "activityID", activityId, "userID", userId, "error", err)
+		}
+		return err
+	}
+
+	slog.Info("deleted activity", "activityID", activityId, "userID", userId)
+
+	// The original is removed only once the activity is gone for good.
+	if storedKey != "" && s.fileStore != nil {
+		if err := s.fileStore.Delete(context.WithoutCancel(ctx), storedKey); err != nil {
+			slog.Warn("failed to delete original file of a deleted activity", "key", storedKey, "error", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/tormoder/fit"
+
+	"github.com/notaduck/backend/internal/db"
+)
+
+// Device is the head unit or a sensor that recorded an activity.
+type Device struct {
+	Index           int      `json:"index"`
+	Creator         bool     `json:"creator"`
+	Manufacturer    string   `json:"manufacturer,omitempty"`
+	Product         *int32   `json:"product,omitempty"`
+	ProductName     string   `json:"productName,omitempty"`
+	SerialNumber    *int64   `json:"serialNumber,omitempty"`
+	SoftwareVersion string   `json:"softwareVersion,omitempty"`
+	HardwareVersion *int16   `json:"hardwareVersion,omitempty"`
+	DeviceType      string   `json:"deviceType,omitempty"`
+	SourceType      string   `json:"sourceType,omitempty"`
+	AntDeviceNumber *int32   `json:"antDeviceNumber,omitempty"`
+	BatteryVoltage  *float64 `json:"batteryVoltage,omitempty"`
+	BatteryStatus   string   `json:"batteryStatus,omitempty"`
+}
+
+// unindexedDeviceOffset numbers devices reported without a device index, so
+// they do not collide with the indexes (0-254) assigned by the head unit.
+const unindexedDeviceOffset = 256
+
+// fitDevices merges the file id and the device info messages of a FIT file
+// into one row per device. Devices report device info repeatedly, e.g. at the
+// start and end of a ride; later valid values win so the last battery reading
+// is kept.
+//
+// The FIT sport message is not exposed for activity files by the decoder; the
+// sport of the ride is stored per session instead.
+func fitDevices(fileId fit.FileIdMsg, infos []*fit.DeviceInfoMsg) []db.CreateActivityDevicesParams {
+	devices := map[int16]*db.CreateActivityDevicesParams{}
+
+	device := func(index int16) *db.CreateActivityDevicesParams {
+		if devices[index] == nil {
+			devices[index] = &db.CreateActivityDevicesParams{DeviceIndex: index}
+		}
+		return devices[index]
+	}
+
+	if fileId.Manufacturer != fit.ManufacturerInvalid || fileId.SerialNumber != 0 {
+		creator := device(int16(fit.DeviceIndexCreator))
+		creator.Creator = true
+		creator.Manufacturer = fitEnumName(fileId.Manufacturer)
+		creator.Product = fitProduct(fileId.Product)
+		creator.ProductName = fileId.ProductName
+		if creator.ProductName == "" {
+			creator.ProductName = fitProductName(fileId.Manufacturer, fileId.Product)
+		}
+		creator.SerialNumber = pgtype.Int8{Int64: int64(fileId.SerialNumber), Valid: fileId.SerialNumber != 0}
+	}
+
+	unindexed := map[string]int16{}
+	for _, info := range infos {
+		index := int16(info.DeviceIndex)
+		if info.DeviceIndex == 0xFF {
+			key := fmt.Sprintf("%d/%d", info.SerialNumber, info.AntDeviceNumber)
+			if _, ok := unindexed[key]; !ok {
+				unindexed[key] = unindexedDeviceOffset + int16(len(unindexed))
+			}
+			index = unindexed[key]
+		}
+
+		mergeDeviceInfo(device(index), info)
+	}
+
+	rows := make([]db.CreateActivityDevicesParams, 0, len(devices))
+	for _, row := range devices {
+		row.Creator = row.Creator || row.DeviceIndex == int16(fit.DeviceIndexCreator)
+		rows = append(rows, *row)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].DeviceIndex < rows[j].DeviceIndex })
+
+	return rows
+}
+
+func mergeDeviceInfo(row *db.CreateActivityDevicesParams, info *fit.DeviceInfoMsg) {
+	if name := fitEnumName(info.Manufacturer); name != "" {
+		row.Manufacturer = name
+	}
+	if product := fitProduct(info.Product); product.Valid {
+		row.Product = product
+	}
+	if info.ProductName != "" {
+		row.ProductName = info.ProductName
+	} else if name := fitProductName(info.Manufacturer, info.Product); name != "" && row.ProductName == "" {
+		row.ProductName = name
+	}
+	if info.SerialNumber != 0 {
+		row.SerialNumber = pgtype.Int8{Int64: int64(info.SerialNumber), Valid: true}
+	}
+	if version := info.GetSoftwareVersionScaled(); !math.IsNaN(version) {
+		row.SoftwareVersion = strconv.FormatFloat(version, 'f', 2, 64)
+	}
+	if info.HardwareVersion != 0xFF {
+		row.HardwareVersion = pgtype.Int2{Int16: int16(info.HardwareVersion), Valid: true}
+	}
+	if deviceType := fitDeviceType(info); deviceType != "" {
+		row.DeviceType = deviceType
+	}
+	if sourceType := fitEnumName(info.SourceType); sourceType != "" {
+		row.SourceType = sourceType
+	}
+	if info.AntDeviceNumber != 0 {
+		row.AntDeviceNumber = pgtype.Int4{Int32: int32(info.AntDeviceNumber), Valid: true}
+	}
+	if voltage := info.GetBatteryVoltageScaled(); !math.IsNaN(voltage) {
+		row.BatteryVoltage = pgtype.Float8{Float64: voltage, Valid: true}
+	}
+	if status := fitEnumName(info.BatteryStatus); status != "" {
+		row.BatteryStatus = status
+	}
+}
+
+func fitProduct(product uint16) pgtype.Int4 {
+	return pgtype.Int4{Int32: int32(product), Valid: product != 0xFFFF}
+}
+
+// fitProductName names known Garmin (and Garmin owned brands') products, e.g.
+// edge530.
+func fitProductName(manufacturer fit.Manufacturer, product uint16) string {
+	if product == 0xFFFF {
+		return ""
+	}
+	switch manufacturer {
+	case fit.ManufacturerGarmin, fit.ManufacturerDynastream, fit.ManufacturerDynastreamOem, fit.ManufacturerTacx:
+		return fitEnumName(fit.GarminProduct(product))
+	default:
+		return ""
+	}
+}
+
+// fitDeviceType names the device type, whose meaning depends on how the
+// device is connected, e.g. bike_power for an ANT+ power meter.
+func fitDeviceType(info *fit.DeviceInfoMsg) string {
+	if info.DeviceType == 0xFF {
+		return ""
+	}
+	switch deviceType := info.GetDeviceType().(type) {
+	case fmt.Stringer:
+		return fitEnumName(deviceType)
+	default:
+		return fmt.Sprint(deviceType)
+	}
+}
+
+func convertDevices(rows []db.GetActivityDevicesRow) []Device {
+	devices := make([]Device, len(rows))
+	for i, row := range rows {
+		devices[i] = Device{
+			Index:           int(row.DeviceIndex),
+			Creator:         row.Creator,
+			Manufacturer:    row.Manufacturer,
+			Product:         optionalInt4(row.Product),
+			ProductName:     row.ProductName,
+			SoftwareVersion: row.SoftwareVersion,
+			DeviceType:      row.DeviceType,
+			SourceType:      row.SourceType,
+			AntDeviceNumber: optionalInt4(row.AntDeviceNumber),
+			BatteryVoltage:  optionalFloat8(row.BatteryVoltage),
+			BatteryStatus:   row.BatteryStatus,
+		}
+		if row.SerialNumber.Valid {
+			devices[i].SerialNumber = &row.SerialNumber.Int64
+		}
+		if row.HardwareVersion.Valid {
+			devices[i].HardwareVersion = &row.HardwareVersion.Int16
+		}
+	}
+	return devices
+}
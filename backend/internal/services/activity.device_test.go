@@ -0,0 +1,58 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tormoder/fit"
+)
+
+func TestFitDevices(t *testing.T) {
+	fileId := fit.NewFileIdMsg()
+	fileId.Manufacturer = fit.ManufacturerGarmin
+	fileId.Product = uint16(fit.GarminProductEdge530)
+	fileId.SerialNumber = 3333
+
+	headUnit := fit.NewDeviceInfoMsg()
+	headUnit.DeviceIndex = fit.DeviceIndexCreator
+	headUnit.Manufacturer = fit.ManufacturerGarmin
+	headUnit.SoftwareVersion = 920
+
+	powerMeterStart := fit.NewDeviceInfoMsg()
+	powerMeterStart.DeviceIndex = 2
+	powerMeterStart.SourceType = fit.SourceTypeAntplus
+	powerMeterStart.DeviceType = uint8(fit.AntplusDeviceTypeBikePower)
+	powerMeterStart.Manufacturer = fit.ManufacturerSram
+	powerMeterStart.AntDeviceNumber = 12345
+	powerMeterStart.BatteryStatus = fit.BatteryStatusGood
+
+	powerMeterEnd := fit.NewDeviceInfoMsg()
+	powerMeterEnd.DeviceIndex = 2
+	powerMeterEnd.BatteryStatus = fit.BatteryStatusLow
+	powerMeterEnd.BatteryVoltage = 768
+
+	devices := fitDevices(*fileId, []*fit.DeviceInfoMsg{powerMeterStart, headUnit, powerMeterEnd})
+	require.Len(t, devices, 2)
+
+	creator := devices[0]
+	assert.True(t, creator.Creator)
+	assert.Equal(t, "garmin", creator.Manufacturer)
+	assert.Equal(t, "edge530", creator.ProductName)
+	assert.Equal(t, int64(3333), creator.SerialNumber.Int64)
+	assert.Equal(t, "9.20", creator.SoftwareVersion)
+
+	powerMeter := devices[1]
+	assert.False(t, powerMeter.Creator)
+	assert.Equal(t, "sram", powerMeter.Manufacturer)
+	assert.Equal(t, "bike_power", powerMeter.DeviceType)
+	assert.Equal(t, "antplus", powerMeter.SourceType)
+	assert.Equal(t, int32(12345), powerMeter.AntDeviceNumber.Int32)
+	assert.Equal(t, "low", powerMeter.BatteryStatus)
+	assert.Equal(t, 3.0, powerMeter.BatteryVoltage.Float64)
+	assert.False(t, powerMeter.SerialNumber.Valid)
+}
+
+func TestFitDevicesWithoutDeviceInformation(t *testing.T) {
+	assert.Empty(t, fitDevices(*fit.NewFileIdMsg(), nil))
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"math"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/tormoder/fit"
+
+	"github.com/notaduck/backend/internal/db"
+)
+
+// elevationHysteresis is the altitude change, in m, that must build up before
+// it counts as climbing or descending. Smaller swings are barometric or GPS
+// noise and would inflate the totals on flat rides.
+const elevationHysteresis = 3.0
+
+// elevationProfile summarises the altitude of a ride in m.
+type elevationProfile struct {
+	Gain float64
+	Loss float64
+	Min  float64
+	Max  float64
+	// Valid is false when no record carries an altitude.
+	Valid bool
+}
+
+// trackElevation sums the climbing and descending of the records. The
+// altitude is only followed once it has moved elevationHysteresis away from
+// the last counted altitude, so noise around a level stretch cancels out.
+func trackElevation(records []*fit.RecordMsg) elevationProfile {
+	var profile elevationProfile
+	var reference float64
+
+	for _, record := range records {
+		altitude, ok := recordAltitude(record)
+		if !ok {
+			continue
+		}
+
+		if !profile.Valid {
+			profile = elevationProfile{Min: altitude, Max: altitude, Valid: true}
+			reference = altitude
+			continue
+		}
+
+		profile.Min = math.Min(profile.Min, altitude)
+		profile.Max = math.Max(profile.Max, altitude)
+
+		switch {
+		case altitude-reference >= elevationHysteresis:
+			profile.Gain += altitude - reference
+			reference = altitude
+		case reference-altitude >= elevationHysteresis:
+			profile.Loss += reference - altitude
+			reference = altitude
+		}
+	}
+
+	return profile
+}
+
+// recordAltitude returns the altitude in m, preferring the enhanced field.
+func recordAltitude(record *fit.RecordMsg) (float64, bool) {
+	if altitude := record.GetEnhancedAltitudeScaled(); !math.IsNaN(altitude) {
+		return altitude, true
+	}
+	if altitude := record.GetAltitudeScaled(); !math.IsNaN(altitude) {
+		return altitude, true
+	}
+	return 0, false
+}
+
+// applyElevation stores the elevation profile on the activity, leaving the
+// columns empty for rides without altitude data.
+func applyElevation(params *db.CreateActivityParams, profile elevationProfile) {
+	if !profile.Valid {
+		return
+	}
+	params.ElevationGain = pgtype.Float8{Float64: math.Round(profile.Gain), Valid: true}
+	params.ElevationLoss = pgtype.Float8{Float64: math.Round(profile.Loss), Valid: true}
+	params.MinAltitude = pgtype.Float8{Float64: profile.Min, Valid: true}
+	params.MaxAltitude = pgtype.Float8{Float64: profile.Max, Valid: true}
+}
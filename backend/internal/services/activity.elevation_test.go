@@ -0,0 +1,53 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tormoder/fit"
+)
+
+// altitudeRecords records the given altitudes in m in the enhanced field.
+func altitudeRecords(altitudes ...float64) []*fit.RecordMsg {
+	records := make([]*fit.RecordMsg, len(altitudes))
+	for i, altitude := range altitudes {
+		records[i] = fit.NewRecordMsg()
+		records[i].EnhancedAltitude = uint32((altitude + 500) * 5)
+	}
+	return records
+}
+
+func TestTrackElevationIgnoresNoise(t *testing.T) {
+	// Barometric jitter of ±1 m around 100 m, then a 20 m climb and a 15 m
+	// descent, each with jitter of its own.
+	profile := trackElevation(altitudeRecords(100, 101, 99, 100.8, 99.4, 100, 105, 104, 110, 109.2, 120, 119, 112, 113, 105, 105.6))
+
+	assert.True(t, profile.Valid)
+	assert.InDelta(t, 20, profile.Gain, 0.01)
+	assert.InDelta(t, 15, profile.Loss, 0.01)
+	assert.InDelta(t, 99, profile.Min, 0.01)
+	assert.InDelta(t, 120, profile.Max, 0.01)
+}
+
+func TestTrackElevationPrefersEnhancedAltitude(t *testing.T) {
+	records := altitudeRecords(1000, 1010)
+	// The plain field is only a fallback for files without the enhanced one.
+	records[0].Altitude = uint16((100 + 500) * 5)
+	records[1].Altitude = uint16((100 + 500) * 5)
+
+	profile := trackElevation(records)
+	assert.InDelta(t, 10, profile.Gain, 0.01)
+	assert.InDelta(t, 1010, profile.Max, 0.01)
+
+	legacy := fit.NewRecordMsg()
+	legacy.Altitude = uint16((50 + 500) * 5)
+	profile = trackElevation([]*fit.RecordMsg{legacy})
+	assert.True(t, profile.Valid)
+	assert.InDelta(t, 50, profile.Min, 0.01)
+}
+
+func TestTrackElevationWithoutAltitude(t *testing.T) {
+	profile := trackElevation([]*fit.RecordMsg{fit.NewRecordMsg(), fit.NewRecordMsg()})
+	assert.False(t, profile.Valid)
+	assert.Zero(t, profile.Gain)
+}
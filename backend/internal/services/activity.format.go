@@ -0,0 +1,104 @@
+package service
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tormoder/fit"
+)
+
+type activityFormat int
+
+const (
+	formatUnknown activityFormat = iota
+	formatFIT
+	formatGPX
+	formatTCX
+)
+
+// sniffLength is the number of leading bytes inspected when detecting the
+// format of an uploaded activity file.
+const sniffLength = 512
+
+func (f activityFormat) String() string {
+	switch f {
+	case formatFIT:
+		return "fit"
+	case formatGPX:
+		return "gpx"
+	case formatTCX:
+		return "tcx"
+	default:
+		return "unknown"
+	}
+}
+
+// decodedActivity is an activity read from a text based export (GPX, TCX)
+// with its samples converted into FIT record messages, so it can run through
+// the same processRecords pipeline as FIT uploads.
+type decodedActivity struct {
+	Name          string
+	StartTime     time.Time
+	TotalDuration time.Duration
+	TimerDuration time.Duration
+	// Distance and MaxSpeed are file supplied totals in meters and m/s. When
+	// zero the values derived from the records are used instead.
+	Distance float64
+	MaxSpeed float64
+	// Device identifies the recording device when the export names it.
+	Device  string
+	Records []*fit.RecordMsg
+	// MotionFromTrack is set when the speed and distance of the records are
+	// derived from their positions, so they follow the cleaned track.
+	MotionFromTrack bool
+}
+
+// detectActivityFormat sniffs the file header first and falls back to the
+// file extension when the content is inconclusive.
+func detectActivityFormat(filename string, header []byte) activityFormat {
+	if isFitHeader(header) {
+		return formatFIT
+	}
+
+	if isXMLWithRoot(header, "gpx") {
+		return formatGPX
+	}
+
+	if isXMLWithRoot(header, "TrainingCenterDatabase") {
+		return formatTCX
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".fit":
+		return formatFIT
+	case ".gpx":
+		return formatGPX
+	case ".tcx":
+		return formatTCX
+	}
+
+	return formatUnknown
+}
+
+// isFitHeader reports whether header starts with a FIT file header, which is
+// 12 or 14 bytes long and carries the ".FIT" signature at offset 8.
+func isFitHeader(header []byte) bool {
+	if len(header) < 12 {
+		return false
+	}
+	if header[0] != 12 && header[0] != 14 {
+		return false
+	}
+	return bytes.Equal(header[8:12], []byte(".FIT"))
+}
+
+func isXMLWithRoot(header []byte, root string) bool {
+	trimmed := bytes.TrimPrefix(header, []byte("\xef\xbb\xbf"))
+	trimmed = bytes.TrimSpace(trimmed)
+	if !bytes.HasPrefix(trimmed, []byte("<")) {
+		return false
+	}
+	return bytes.Contains(trimmed, []byte("<"+root+" ")) || bytes.Contains(trimmed, []byte("<"+root+">"))
+}
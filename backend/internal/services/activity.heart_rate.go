@@ -0,0 +1,239 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/tormoder/fit"
+
+	"github.com/notaduck/backend/internal/db"
+)
+
+const (
+	// HeartRateZonesMaxHR splits five zones at 60, 70, 80 and 90% of the
+	// maximum heart rate.
+	HeartRateZonesMaxHR = "max_hr"
+	// HeartRateZonesLTHR splits five zones at 81, 90, 94 and 100% of the
+	// lactate threshold heart rate, following Friel's cycling zones.
+	HeartRateZonesLTHR = "lthr"
+	// HeartRateZonesCustom uses the bounds set by the user.
+	HeartRateZonesCustom = "custom"
+)
+
+var (
+	maxHeartRateZoneFractions       = []float64{0.60, 0.70, 0.80, 0.90}
+	thresholdHeartRateZoneFractions = []float64{0.81, 0.90, 0.94, 1.00}
+)
+
+// maxCustomHeartRateZones caps the number of zones a user can define.
+const maxCustomHeartRateZones = 10
+
+// maxHeartRateGap is the longest gap between two heart rate samples that is
+// counted in the zone of the earlier sample. Longer gaps are pauses and count
+// as a single second, as for power.
+const maxHeartRateGap = 5 * time.Second
+
+// HeartRateZone is the time an activity spent in a heart rate zone. Zones are
+// numbered from 1; the bounds in bpm are those in effect at ingestion.
+type HeartRateZone struct {
+	Zone         int32 `json:"zone"`
+	MinHeartRate int32 `json:"minHeartRate"`
+	// MaxHeartRate is nil for the open-ended top zone.
+	MaxHeartRate *int32 `json:"maxHeartRate,omitempty"`
+	Seconds      int32  `json:"seconds"`
+}
+
+// heartRateSample is a heart rate held for a duration.
+type heartRateSample struct {
+	HeartRate uint8
+	Duration  time.Duration
+}
+
+// validateHeartRateZoneSettings checks the heart rate settings and that the
+// selected zone method has the values it is based on.
+func validateHeartRateZoneSettings(settings UserSettings) error {
+	if settings.MaxHeartRate != nil && (*settings.MaxHeartRate < 100 || *settings.MaxHeartRate > 250) {
+		return fmt.Errorf("%w: max heart rate must be between 100 and 250 bpm", ErrInvalidSettings)
+	}
+	if settings.ThresholdHeartRate != nil && (*settings.ThresholdHeartRate < 80 || *settings.ThresholdHeartRate > 230) {
+		return fmt.Errorf("%w: threshold heart rate must be between 80 and 230 bpm", ErrInvalidSettings)
+	}
+	if len(settings.HeartRateZoneBounds) >= maxCustomHeartRateZones {
+		return fmt.Errorf("%w: at most %d heart rate zones can be defined", ErrInvalidSettings, maxCustomHeartRateZones)
+	}
+	for i, bound := range settings.HeartRateZoneBounds {
+		if bound < 30 || bound > 250 {
+			return fmt.Errorf("%w: heart rate zone bounds must be between 30 and 250 bpm", ErrInvalidSettings)
+		}
+		if i > 0 && bound <= settings.HeartRateZoneBounds[i-1] {
+			return fmt.Errorf("%w: heart rate zone bounds must be ascending", ErrInvalidSettings)
+		}
+	}
+
+	switch settings.HeartRateZoneMethod {
+	case "":
+	case HeartRateZonesMaxHR:
+		if settings.MaxHeartRate == nil {
+			return fmt.Errorf("%w: max_hr zones need a max heart rate", ErrInvalidSettings)
+		}
+	case HeartRateZonesLTHR:
+		if settings.ThresholdHeartRate == nil {
+			return fmt.Errorf("%w: lthr zones need a threshold heart rate", ErrInvalidSettings)
+		}
+	case HeartRateZonesCustom:
+		if len(settings.HeartRateZoneBounds) == 0 {
+			return fmt.Errorf("%w: custom zones need at least one bound", ErrInvalidSettings)
+		}
+	default:
+		return fmt.Errorf("%w: unknown heart rate zone method %q", ErrInvalidSettings, settings.HeartRateZoneMethod)
+	}
+	return nil
+}
+
+// heartRateZoneBounds returns the bounds in bpm between consecutive zones,
+// ascending, or nil when the user has no zones configured. Each bound is the
+// lowest heart rate of the next zone.
+func (settings UserSettings) heartRateZoneBounds() []int32 {
+	switch settings.HeartRateZoneMethod {
+	case HeartRateZonesMaxHR:
+		if settings.MaxHeartRate != nil {
+			return scaleHeartRate(*settings.MaxHeartRate, maxHeartRateZoneFractions)
+		}
+	case HeartRateZonesLTHR:
+		if settings.ThresholdHeartRate != nil {
+			return scaleHeartRate(*settings.ThresholdHeartRate, thresholdHeartRateZoneFractions)
+		}
+	case HeartRateZonesCustom:
+		return settings.HeartRateZoneBounds
+	}
+	return nil
+}
+
+func scaleHeartRate(heartRate int32, fractions []float64) []int32 {
+	bounds := make([]int32, len(fractions))
+	for i, fraction := range fractions {
+		bounds[i] = int32(math.Round(float64(heartRate) * fraction))
+	}
+	return bounds
+}
+
+// heartRateSamples returns how long each recorded heart rate was held: until
+// the next sample, or a single second before a gap longer than
+// maxHeartRateGap and for the last sample. It is nil without heart rate data.
+func heartRateSamples(records []*fit.RecordMsg) []heartRateSample {
+	var samples []heartRateSample
+	var previous *fit.RecordMsg
+
+	for _, record := range records {
+		if record.HeartRate == 0xFF || record.HeartRate == 0 || record.Timestamp.IsZero() {
+			continue
+		}
+
+		if previous != nil {
+			gap := record.Timestamp.Sub(previous.Timestamp)
+			if gap > maxHeartRateGap || gap <= 0 {
+				gap = time.Second
+			}
+			samples[len(samples)-1].Duration = gap
+		}
+
+		samples = append(samples, heartRateSample{HeartRate: record.HeartRate, Duration: time.Second})
+		previous = record
+	}
+
+	return samples
+}
+
+// heartRateZoneTimes sums the time of the samples in each of the zones split
+// by bounds. It returns nil without samples or zones.
+func heartRateZoneTimes(samples []heartRateSample, bounds []int32) []db.CreateActivityHeartRateZonesParams {
+	if len(samples) == 0 || len(bounds) == 0 {
+		return nil
+	}
+
+	durations := make([]time.Duration, len(bounds)+1)
+	for _, sample := range samples {
+		zone, _ := slices.BinarySearch(bounds, int32(sample.HeartRate)+1)
+		durations[zone] += sample.Duration
+	}
+
+	zones := make([]db.CreateActivityHeartRateZonesParams, len(durations))
+	for i, duration := range durations {
+		zones[i] = db.CreateActivityHeartRateZonesParams{
+			Zone:    int32(i + 1),
+			Seconds: int32(math.Round(duration.Seconds())),
+		}
+		if i > 0 {
+			zones[i].MinHeartRate = bounds[i-1]
+		}
+		if i < len(bounds) {
+			zones[i].MaxHeartRate = pgtype.Int4{Int32: bounds[i] - 1, Valid: true}
+		}
+	}
+	return zones
+}
+
+func convertHeartRateZones(rows []db.GetActivityHeartRateZonesRow) []HeartRateZone {
+	zones := make([]HeartRateZone, len(rows))
+	for i, row := range rows {
+		zones[i] = HeartRateZone{
+			Zone:         row.Zone,
+			MinHeartRate: row.MinHeartRate,
+			MaxHeartRate: optionalInt4(row.MaxHeartRate),
+			Seconds:      row.Seconds,
+		}
+	}
+	return zones
+}
+
+// statsHeartRateZoneWeeks is the number of weeks, including the current one,
+// of heart rate zone totals in the stats.
+const statsHeartRateZoneWeeks = 12
+
+// UserStats holds the distance and elevation totals of a user together with
+// the weekly time in each heart rate zone.
+type UserStats struct {
+	db.GetActivityStatsRow
+	HeartRateZoneWeeks []HeartRateZoneWeek `json:"heartRateZoneWeeks"`
+}
+
+// HeartRateZoneWeek is the time spent in each heart rate zone during the
+// week starting on Monday WeekStart. Seconds[0] is zone 1.
+type HeartRateZoneWeek struct {
+	WeekStart time.Time `json:"weekStart"`
+	Seconds   []int64   `json:"seconds"`
+}
+
+// weeklyHeartRateZones spreads the zone totals over every week from
+// firstWeek on, so weeks without rides are reported with no time.
+func weeklyHeartRateZones(rows []db.GetWeeklyHeartRateZonesRow, firstWeek time.Time, weeks int) []HeartRateZoneWeek {
+	result := make([]HeartRateZoneWeek, weeks)
+	for i := range result {
+		result[i] = HeartRateZoneWeek{WeekStart: firstWeek.AddDate(0, 0, 7*i), Seconds: []int64{}}
+	}
+
+	for _, row := range rows {
+		week := int(startOfWeek(row.WeekStart.Time).Sub(firstWeek) / (7 * 24 * time.Hour))
+		if week < 0 || week >= weeks || row.Zone < 1 {
+			continue
+		}
+		seconds := result[week].Seconds
+		for len(seconds) < int(row.Zone) {
+			seconds = append(seconds, 0)
+		}
+		seconds[row.Zone-1] += row.Seconds
+		result[week].Seconds = seconds
+	}
+
+	return result
+}
+
+// startOfWeek returns midnight UTC on the Monday of the week of t.
+func startOfWeek(t time.Time) time.Time {
+	t = t.UTC()
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, time.UTC)
+}
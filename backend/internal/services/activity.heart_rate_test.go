@@ -0,0 +1,121 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tormoder/fit"
+
+	"github.com/notaduck/backend/internal/db"
+)
+
+func int32Pointer(value int32) *int32 {
+	return &value
+}
+
+// heartRateRecords records the given heart rates at the given offsets in
+// seconds.
+func heartRateRecords(start time.Time, samples ...[2]int) []*fit.RecordMsg {
+	records := make([]*fit.RecordMsg, len(samples))
+	for i, sample := range samples {
+		records[i] = fit.NewRecordMsg()
+		records[i].Timestamp = start.Add(time.Duration(sample[0]) * time.Second)
+		records[i].HeartRate = uint8(sample[1])
+	}
+	return records
+}
+
+func TestHeartRateZoneBounds(t *testing.T) {
+	maxHR := UserSettings{HeartRateZoneMethod: HeartRateZonesMaxHR, MaxHeartRate: int32Pointer(190)}
+	assert.Equal(t, []int32{114, 133, 152, 171}, maxHR.heartRateZoneBounds())
+
+	lthr := UserSettings{HeartRateZoneMethod: HeartRateZonesLTHR, ThresholdHeartRate: int32Pointer(170)}
+	assert.Equal(t, []int32{138, 153, 160, 170}, lthr.heartRateZoneBounds())
+
+	custom := UserSettings{HeartRateZoneMethod: HeartRateZonesCustom, HeartRateZoneBounds: []int32{120, 150}}
+	assert.Equal(t, []int32{120, 150}, custom.heartRateZoneBounds())
+
+	assert.Nil(t, UserSettings{MaxHeartRate: int32Pointer(190)}.heartRateZoneBounds(), "no zones without a method")
+}
+
+func TestHeartRateZoneTimes(t *testing.T) {
+	start := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	records := heartRateRecords(start,
+		[2]int{0, 110},
+		[2]int{2, 119},
+		[2]int{5, 120},
+		[2]int{6, 149},
+		[2]int{7, 150},
+		// A 10 minute pause counts as a single second.
+		[2]int{607, 160},
+	)
+	// Samples without a heart rate are left out.
+	records = append(records, fit.NewRecordMsg())
+
+	samples := heartRateSamples(records)
+	require.Len(t, samples, 6)
+	assert.Equal(t, 2*time.Second, samples[0].Duration)
+	assert.Equal(t, time.Second, samples[4].Duration)
+
+	zones := heartRateZoneTimes(samples, []int32{120, 150})
+	assert.Equal(t, []db.CreateActivityHeartRateZonesParams{
+		{Zone: 1, MinHeartRate: 0, MaxHeartRate: pgtype.Int4{Int32: 119, Valid: true}, Seconds: 5},
+		{Zone: 2, MinHeartRate: 120, MaxHeartRate: pgtype.Int4{Int32: 149, Valid: true}, Seconds: 2},
+		{Zone: 3, MinHeartRate: 150, Seconds: 2},
+	}, zones)
+
+	assert.Nil(t, heartRateZoneTimes(samples, nil), "no zones configured")
+	assert.Nil(t, heartRateZoneTimes(heartRateSamples([]*fit.RecordMsg{fit.NewRecordMsg()}), []int32{120}))
+}
+
+func TestValidateHeartRateZoneSettings(t *testing.T) {
+	valid := []UserSettings{
+		{},
+		{MaxHeartRate: int32Pointer(185)},
+		{HeartRateZoneMethod: HeartRateZonesMaxHR, MaxHeartRate: int32Pointer(185)},
+		{HeartRateZoneMethod: HeartRateZonesLTHR, ThresholdHeartRate: int32Pointer(165)},
+		{HeartRateZoneMethod: HeartRateZonesCustom, HeartRateZoneBounds: []int32{110, 130, 150, 170}},
+	}
+	for _, settings := range valid {
+		assert.NoError(t, validateHeartRateZoneSettings(settings))
+	}
+
+	invalid := []UserSettings{
+		{MaxHeartRate: int32Pointer(300)},
+		{ThresholdHeartRate: int32Pointer(40)},
+		{HeartRateZoneMethod: HeartRateZonesMaxHR},
+		{HeartRateZoneMethod: HeartRateZonesLTHR, MaxHeartRate: int32Pointer(185)},
+		{HeartRateZoneMethod: HeartRateZonesCustom},
+		{HeartRateZoneMethod: HeartRateZonesCustom, HeartRateZoneBounds: []int32{150, 130}},
+		{HeartRateZoneMethod: "percent"},
+	}
+	for _, settings := range invalid {
+		err := validateHeartRateZoneSettings(settings)
+		assert.True(t, errors.Is(err, ErrInvalidSettings), "%+v: %v", settings, err)
+	}
+}
+
+func TestWeeklyHeartRateZones(t *testing.T) {
+	firstWeek := startOfWeek(time.Date(2024, 6, 5, 15, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC), firstWeek)
+
+	week := func(days int) pgtype.Timestamptz {
+		return pgtype.Timestamptz{Time: firstWeek.AddDate(0, 0, days), Valid: true}
+	}
+	weeks := weeklyHeartRateZones([]db.GetWeeklyHeartRateZonesRow{
+		{WeekStart: week(0), Zone: 1, Seconds: 600},
+		{WeekStart: week(0), Zone: 3, Seconds: 300},
+		{WeekStart: week(14), Zone: 2, Seconds: 60},
+		{WeekStart: week(28), Zone: 2, Seconds: 60},
+	}, firstWeek, 3)
+
+	require.Len(t, weeks, 3)
+	assert.Equal(t, []int64{600, 0, 300}, weeks[0].Seconds)
+	assert.Equal(t, firstWeek.AddDate(0, 0, 7), weeks[1].WeekStart)
+	assert.Empty(t, weeks[1].Seconds)
+	assert.Equal(t, []int64{0, 60}, weeks[2].Seconds)
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/tormoder/fit"
+
+	"github.com/notaduck/backend/internal/db"
+	"github.com/notaduck/backend/utils"
+)
+
+// Lap is a manual or automatic lap of an activity as recorded by the device.
+type Lap struct {
+	Index           int           `json:"index"`
+	Trigger         string        `json:"trigger"`
+	StartTime       time.Time     `json:"startTime"`
+	EndTime         time.Time     `json:"endTime"`
+	Distance        float64       `json:"distance"`
+	AvgSpeed        float64       `json:"avgSpeed"`
+	MaxSpeed        float64       `json:"maxSpeed"`
+	AvgHeartRate    *int32        `json:"avgHeartRate,omitempty"`
+	MaxHeartRate    *int32        `json:"maxHeartRate,omitempty"`
+	AvgPower        *int32        `json:"avgPower,omitempty"`
+	MaxPower        *int32        `json:"maxPower,omitempty"`
+	ElapsedTime     string        `json:"elapsedTime"`
+	TimerTime       string        `json:"timerTime"`
+	ElapsedDuration time.Duration `json:"-"`
+	TimerDuration   time.Duration `json:"-"`
+}
+
+func (s *activityService) GetActivityLaps(ctx context.Context, activityId int32, userId string) ([]Lap, error) {
+	laps, err := s.activityRepo.GetActivityLaps(ctx, db.GetActivityLapsParams{
+		ActivityID: activityId,
+		UserID:     userId,
+	})
+	if err != nil {
+		slog.Error("failed to retrieve laps", "activityId", activityId, "error", err)
+		return nil, err
+	}
+
+	return convertLaps(laps), nil
+}
+
+// fitLaps converts the lap messages of a FIT activity into lap rows ordered by
+// start time. Speeds are stored in km/h and distances in km, like activities.
+func fitLaps(laps []*fit.LapMsg) []db.CreateLapsParams {
+	sorted := make([]*fit.LapMsg, len(laps))
+	copy(sorted, laps)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartTime.Before(sorted[j].StartTime)
+	})
+
+	rows := make([]db.CreateLapsParams, len(sorted))
+	for i, lap := range sorted {
+		elapsed := fitDuration(lap.TotalElapsedTime)
+		timer := fitDuration(lap.TotalTimerTime)
+
+		end := lap.Timestamp
+		if end.IsZero() || end.Before(lap.StartTime) {
+			end = lap.StartTime.Add(elapsed)
+		}
+
+		var distance float64
+		if lap.TotalDistance != 0xFFFFFFFF {
+			distance = float64(lap.TotalDistance) / 100000
+		}
+
+		avgSpeed := lapSpeed(lap.GetEnhancedAvgSpeedScaled(), lap.AvgSpeed)
+		if avgSpeed == 0 && timer > 0 {
+			avgSpeed = distance / timer.Hours()
+		}
+
+		rows[i] = db.CreateLapsParams{
+			LapIndex:         int16(i),
+			LapTrigger:       fitEnumName(lap.LapTrigger),
+			StartTime:        pgtype.Timestamptz{Time: lap.StartTime, Valid: true},
+			EndTime:          pgtype.Timestamptz{Time: end, Valid: true},
+			TotalElapsedTime: elapsed,
+			TotalTimerTime:   timer,
+			Distance:         decimal.NewFromFloat(distance),
+			AvgSpeed:         decimal.NewFromFloat(avgSpeed),
+			MaxSpeed:         decimal.NewFromFloat(lapSpeed(lap.GetEnhancedMaxSpeedScaled(), lap.MaxSpeed)),
+			AvgHeartRate:     pgtype.Int2{Int16: int16(lap.AvgHeartRate), Valid: lap.AvgHeartRate != 0xFF},
+			MaxHeartRate:     pgtype.Int2{Int16: int16(lap.MaxHeartRate), Valid: lap.MaxHeartRate != 0xFF},
+			AvgPower:         pgtype.Int4{Int32: int32(lap.AvgPower), Valid: lap.AvgPower != 0xFFFF},
+			MaxPower:         pgtype.Int4{Int32: int32(lap.MaxPower), Valid: lap.MaxPower != 0xFFFF},
+		}
+	}
+
+	return rows
+}
+
+// lapSpeed returns a lap speed in km/h, preferring the enhanced field (m/s)
+// over the legacy one (mm/s). Unset speeds are zero.
+func lapSpeed(enhanced float64, legacy uint16) float64 {
+	switch {
+	case !math.IsNaN(enhanced):
+		return enhanced * 3.6
+	case legacy != 0xFFFF:
+		return float64(legacy) * 3.6 / 1000
+	default:
+		return 0
+	}
+}
+
+func convertLaps(rows []db.GetActivityLapsRow) []Lap {
+	optional := func(value int32, valid bool) *int32 {
+		if !valid {
+			return nil
+		}
+		return &value
+	}
+
+	laps := make([]Lap, len(rows))
+	for i, row := range rows {
+		laps[i] = Lap{
+			Index:           int(row.LapIndex),
+			Trigger:         row.LapTrigger,
+			StartTime:       row.StartTime.Time,
+			EndTime:         row.EndTime.Time,
+			Distance:        row.Distance.InexactFloat64(),
+			AvgSpeed:        row.AvgSpeed.InexactFloat64(),
+			MaxSpeed:        row.MaxSpeed.InexactFloat64(),
+			AvgHeartRate:    optional(int32(row.AvgHeartRate.Int16), row.AvgHeartRate.Valid),
+			MaxHeartRate:    optional(int32(row.MaxHeartRate.Int16), row.MaxHeartRate.Valid),
+			AvgPower:        optional(row.AvgPower.Int32, row.AvgPower.Valid),
+			MaxPower:        optional(row.MaxPower.Int32, row.MaxPower.Valid),
+			ElapsedTime:     utils.FormatDuration(row.TotalElapsedTime),
+			TimerTime:       utils.FormatDuration(row.TotalTimerTime),
+			ElapsedDuration: row.TotalElapsedTime,
+			TimerDuration:   row.TotalTimerTime,
+		}
+	}
+	return laps
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tormoder/fit"
+)
+
+func TestFitLaps(t *testing.T) {
+	start := time.Date(2024, 5, 1, 6, 0, 0, 0, time.UTC)
+
+	interval := fit.NewLapMsg()
+	interval.StartTime = start.Add(10 * time.Minute)
+	interval.Timestamp = start.Add(15 * time.Minute)
+	interval.LapTrigger = fit.LapTriggerManual
+	interval.TotalElapsedTime = 300000
+	interval.TotalTimerTime = 300000
+	interval.TotalDistance = 400000
+	interval.EnhancedAvgSpeed = 13333
+	interval.MaxSpeed = 15000
+	interval.AvgHeartRate = 171
+	interval.MaxHeartRate = 182
+	interval.AvgPower = 320
+	interval.MaxPower = 610
+
+	warmup := fit.NewLapMsg()
+	warmup.StartTime = start
+	warmup.LapTrigger = fit.LapTriggerDistance
+	warmup.TotalElapsedTime = 600000
+	warmup.TotalTimerTime = 540000
+	warmup.TotalDistance = 450000
+
+	laps := fitLaps([]*fit.LapMsg{interval, warmup})
+	require.Len(t, laps, 2)
+
+	assert.Equal(t, "distance", laps[0].LapTrigger)
+	assert.Equal(t, start.Add(10*time.Minute), laps[0].EndTime.Time)
+	assert.InDelta(t, 30.0, laps[0].AvgSpeed.InexactFloat64(), 0.001, "derived from distance and timer time")
+	assert.True(t, laps[0].MaxSpeed.IsZero())
+	assert.False(t, laps[0].AvgHeartRate.Valid)
+	assert.False(t, laps[0].AvgPower.Valid)
+
+	assert.Equal(t, int16(1), laps[1].LapIndex)
+	assert.Equal(t, "manual", laps[1].LapTrigger)
+	assert.Equal(t, 4.0, laps[1].Distance.InexactFloat64())
+	assert.InDelta(t, 48.0, laps[1].AvgSpeed.InexactFloat64(), 0.01)
+	assert.InDelta(t, 54.0, laps[1].MaxSpeed.InexactFloat64(), 0.001)
+	assert.Equal(t, int16(171), laps[1].AvgHeartRate.Int16)
+	assert.Equal(t, int32(610), laps[1].MaxPower.Int32)
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"path/filepath"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/notaduck/backend/internal/db"
+	"github.com/notaduck/backend/internal/repositories"
+)
+
+// ErrOriginalNotFound is returned when the original file of an activity is
+// not kept, e.g. because it was uploaded before originals were stored.
+var ErrOriginalNotFound = errors.New("original file not found")
+
+// OriginalFile is the file an activity was created from.
+type OriginalFile struct {
+	Filename    string
+	ContentType string
+	Size        int64
+	Content     io.ReadCloser
+}
+
+func WithFileStore(store FileStore) ActivityServiceOption {
+	return func(s *activityService) {
+		s.fileStore = store
+	}
+}
+
+// storeOriginal puts the uploaded file into the file store under key and
+// records it for the activity.
+func (s *activityService) storeOriginal(ctx context.Context, repos repositories.Repositories, activityId int32, key string, upload activityUpload) error {
+	contentType := originalContentType(upload.Filename, upload.ContentType)
+
+	if err := s.fileStore.Put(ctx, key, upload.Data, contentType); err != nil {
+		return fmt.Errorf("failed to store original file: %w", err)
+	}
+
+	return repos.Activities.CreateActivityOriginal(ctx, db.CreateActivityOriginalParams{
+		ActivityID:  activityId,
+		StorageKey:  key,
+		Filename:    upload.Filename,
+		ContentType: contentType,
+		Size:        int64(len(upload.Data)),
+	})
+}
+
+// deleteOriginal removes a stored original whose activity was rolled back.
+func (s *activityService) deleteOriginal(ctx context.Context, key string) {
+	if err := s.fileStore.Delete(context.WithoutCancel(ctx), key); err != nil {
+		slog.Warn("failed to delete original file of a rolled back activity", "key", key, "error", err)
+	}
+}
+
+func (s *activityService) GetOriginalFile(ctx context.Context, activityId int32, userId string) (*OriginalFile, error) {
+	if s.fileStore == nil {
+		return nil, ErrOriginalNotFound
+	}
+
+	original, err := s.activityRepo.GetActivityOriginal(ctx, db.GetActivityOriginalParams{
+		ActivityID: activityId,
+		UserID:     userId,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrOriginalNotFound
+	}
+	if err != nil {
+		slog.Error("failed to retrieve original file", "activityID", activityId, "error", err)
+		return nil, err
+	}
+
+	content, err := s.fileStore.Get(ctx, original.StorageKey)
+	if errors.Is(err, ErrFileNotFound) {
+		slog.Warn("original file missing from the file store", "activityID", activityId, "key", original.StorageKey)
+		return nil, ErrOriginalNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &OriginalFile{
+		Filename:    original.Filename,
+		ContentType: original.ContentType,
+		Size:        original.Size,
+		Content:     content,
+	}, nil
+}
+
+// originalContentType prefers the content type sent with the upload unless it
+// is the generic one browsers use for unknown extensions.
+func originalContentType(filename, contentType string) string {
+	if contentType != "" && contentType != "application/octet-stream" {
+		return contentType
+	}
+	if byExtension := mime.TypeByExtension(filepath.Ext(filename)); byExtension != "" {
+		return byExtension
+	}
+	return "application/octet-stream"
+}
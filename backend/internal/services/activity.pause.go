@@ -0,0 +1,205 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/tormoder/fit"
+
+	"github.com/notaduck/backend/internal/db"
+	"github.com/notaduck/backend/utils"
+)
+
+// DefaultAutoPauseSpeed is the speed in km/h below which the rider counts as
+// stopped when a file carries no timer events.
+const DefaultAutoPauseSpeed = 3.0
+
+// minAutoPause is the shortest stop detected from the recorded speed. Shorter
+// dips below the threshold, e.g. a tight switchback, count as moving.
+const minAutoPause = 5 * time.Second
+
+const (
+	// pauseSourceTimer marks a pause of the device timer, manual or auto-pause.
+	pauseSourceTimer = "timer"
+	// pauseSourceAuto marks a stop detected from the recorded speed.
+	pauseSourceAuto = "auto"
+)
+
+// Pause is a period in which the rider was stopped.
+type Pause struct {
+	StartedAt       time.Time     `json:"startedAt"`
+	EndedAt         time.Time     `json:"endedAt"`
+	Source          string        `json:"source"`
+	ElapsedTime     string        `json:"elapsedTime"`
+	ElapsedDuration time.Duration `json:"-"`
+}
+
+// ParseAutoPauseSpeed parses the auto-pause speed in km/h, defaulting to
+// DefaultAutoPauseSpeed when empty. Zero disables speed based detection.
+func ParseAutoPauseSpeed(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return DefaultAutoPauseSpeed, nil
+	}
+
+	speed, err := strconv.ParseFloat(value, 64)
+	if err != nil || speed < 0 {
+		return 0, fmt.Errorf("invalid auto-pause speed %q: expected a speed in km/h of 0 or more", value)
+	}
+	return speed, nil
+}
+
+func WithAutoPauseSpeed(speed float64) ActivityServiceOption {
+	return func(s *activityService) {
+		s.autoPauseSpeed = speed
+	}
+}
+
+// activityPauses returns the stopped periods of an activity. The timer events
+// of the device are authoritative; files without them (GPX, TCX, or FIT files
+// from devices that do not log the timer) fall back to the recorded speed.
+func activityPauses(records []*fit.RecordMsg, events []*fit.EventMsg, autoPauseSpeed float64) []db.CreateActivityPausesParams {
+	if pauses, ok := timerPauses(events); ok {
+		return pauses
+	}
+	return speedPauses(records, autoPauseSpeed)
+}
+
+// timerPauses pairs every timer stop with the next timer start. It reports
+// false when the file has no timer events at all.
+func timerPauses(events []*fit.EventMsg) ([]db.CreateActivityPausesParams, bool) {
+	var timerEvents []*fit.EventMsg
+	for _, event := range events {
+		if event.Event == fit.EventTimer {
+			timerEvents = append(timerEvents, event)
+		}
+	}
+	if len(timerEvents) == 0 {
+		return nil, false
+	}
+
+	sort.SliceStable(timerEvents, func(i, j int) bool {
+		return timerEvents[i].Timestamp.Before(timerEvents[j].Timestamp)
+	})
+
+	var pauses []db.CreateActivityPausesParams
+	var stoppedAt time.Time
+	for _, event := range timerEvents {
+		switch event.EventType {
+		case fit.EventTypeStop, fit.EventTypeStopAll, fit.EventTypeStopDisable, fit.EventTypeStopDisableAll:
+			if stoppedAt.IsZero() {
+				stoppedAt = event.Timestamp
+			}
+		case fit.EventTypeStart:
+			if !stoppedAt.IsZero() && event.Timestamp.After(stoppedAt) {
+				pauses = append(pauses, newPause(stoppedAt, event.Timestamp, pauseSourceTimer))
+			}
+			stoppedAt = time.Time{}
+		}
+	}
+
+	return pauses, true
+}
+
+// speedPauses detects the periods in which the recorded speed stays below
+// autoPauseSpeed (km/h) for at least minAutoPause.
+func speedPauses(records []*fit.RecordMsg, autoPauseSpeed float64) []db.CreateActivityPausesParams {
+	if autoPauseSpeed <= 0 {
+		return nil
+	}
+	threshold := autoPauseSpeed / 3.6
+
+	var pauses []db.CreateActivityPausesParams
+	appendPause := func(start, end time.Time) {
+		if end.Sub(start) >= minAutoPause {
+			pauses = append(pauses, newPause(start, end, pauseSourceAuto))
+		}
+	}
+
+	var stoppedAt time.Time
+	for i := 1; i < len(records); i++ {
+		previous, record := records[i-1], records[i]
+
+		speed, ok := recordSpeed(previous, record)
+		stopped := ok && speed < threshold
+
+		switch {
+		case stopped && stoppedAt.IsZero():
+			stoppedAt = previous.Timestamp
+		case !stopped && !stoppedAt.IsZero():
+			appendPause(stoppedAt, previous.Timestamp)
+			stoppedAt = time.Time{}
+		}
+	}
+	if !stoppedAt.IsZero() {
+		appendPause(stoppedAt, records[len(records)-1].Timestamp)
+	}
+
+	return pauses
+}
+
+// recordSpeed returns the speed in m/s at record, from the speed field or else
+// from the distance covered since previous.
+func recordSpeed(previous, record *fit.RecordMsg) (float64, bool) {
+	if record.Speed != 0xFFFF {
+		return float64(record.Speed) / 1000, true
+	}
+
+	elapsed := record.Timestamp.Sub(previous.Timestamp).Seconds()
+	if record.Distance == 0xFFFFFFFF || previous.Distance == 0xFFFFFFFF || elapsed <= 0 {
+		return 0, false
+	}
+	return (float64(record.Distance) - float64(previous.Distance)) / 100 / elapsed, true
+}
+
+func newPause(start, end time.Time, source string) db.CreateActivityPausesParams {
+	return db.CreateActivityPausesParams{
+		StartedAt: pgtype.Timestamptz{Time: start, Valid: true},
+		EndedAt:   pgtype.Timestamptz{Time: end, Valid: true},
+		Source:    source,
+	}
+}
+
+// movingTime is the time between the first and last record minus the parts
+// of the pauses that fall within it.
+func movingTime(records []*fit.RecordMsg, pauses []db.CreateActivityPausesParams) time.Duration {
+	if len(records) == 0 {
+		return 0
+	}
+
+	start, end := records[0].Timestamp, records[len(records)-1].Timestamp
+	moving := end.Sub(start)
+	for _, pause := range pauses {
+		pauseStart, pauseEnd := pause.StartedAt.Time, pause.EndedAt.Time
+		if pauseStart.Before(start) {
+			pauseStart = start
+		}
+		if pauseEnd.After(end) {
+			pauseEnd = end
+		}
+		if pauseEnd.After(pauseStart) {
+			moving -= pauseEnd.Sub(pauseStart)
+		}
+	}
+
+	return max(moving, 0)
+}
+
+func convertPauses(rows []db.GetActivityPausesRow) []Pause {
+	pauses := make([]Pause, len(rows))
+	for i, row := range rows {
+		elapsed := row.EndedAt.Time.Sub(row.StartedAt.Time)
+		pauses[i] = Pause{
+			StartedAt:       row.StartedAt.Time,
+			EndedAt:         row.EndedAt.Time,
+			Source:          row.Source,
+			ElapsedTime:     utils.FormatDuration(elapsed),
+			ElapsedDuration: elapsed,
+		}
+	}
+	return pauses
+}
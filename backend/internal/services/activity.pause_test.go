@@ -0,0 +1,103 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tormoder/fit"
+)
+
+func timerEvent(timestamp time.Time, eventType fit.EventType) *fit.EventMsg {
+	event := fit.NewEventMsg()
+	event.Timestamp = timestamp
+	event.Event = fit.EventTimer
+	event.EventType = eventType
+	return event
+}
+
+func TestActivityPausesFromTimerEvents(t *testing.T) {
+	start := time.Date(2024, 5, 4, 9, 0, 0, 0, time.UTC)
+	records := trainerRecords(start, make([]uint16, 601)...)
+
+	events := []*fit.EventMsg{
+		timerEvent(start, fit.EventTypeStart),
+		timerEvent(start.Add(2*time.Minute), fit.EventTypeStopAll),
+		timerEvent(start.Add(3*time.Minute), fit.EventTypeStart),
+		timerEvent(start.Add(10*time.Minute), fit.EventTypeStopDisableAll),
+	}
+
+	// Timer events take precedence over the (zero) recorded speed.
+	pauses := activityPauses(records, events, DefaultAutoPauseSpeed)
+	require.Len(t, pauses, 1)
+	assert.Equal(t, pauseSourceTimer, pauses[0].Source)
+	assert.Equal(t, start.Add(2*time.Minute), pauses[0].StartedAt.Time)
+	assert.Equal(t, start.Add(3*time.Minute), pauses[0].EndedAt.Time)
+
+	assert.Equal(t, 9*time.Minute, movingTime(records, pauses))
+}
+
+func TestActivityPausesFromSpeed(t *testing.T) {
+	start := time.Date(2024, 5, 4, 9, 0, 0, 0, time.UTC)
+
+	var speeds []uint16
+	speeds = append(speeds, repeatSpeed(8000, 60)...)
+	speeds = append(speeds, repeatSpeed(0, 30)...) // stopped at a light
+	speeds = append(speeds, repeatSpeed(8000, 60)...)
+	speeds = append(speeds, repeatSpeed(500, 3)...) // slow corner, too short to count
+	speeds = append(speeds, repeatSpeed(8000, 60)...)
+	records := trainerRecords(start, speeds...)
+
+	pauses := activityPauses(records, nil, DefaultAutoPauseSpeed)
+	require.Len(t, pauses, 1)
+	assert.Equal(t, pauseSourceAuto, pauses[0].Source)
+	assert.Equal(t, start.Add(59*time.Second), pauses[0].StartedAt.Time)
+	assert.Equal(t, start.Add(89*time.Second), pauses[0].EndedAt.Time)
+
+	assert.Equal(t, 182*time.Second, movingTime(records, pauses))
+}
+
+func TestActivityPausesDisabled(t *testing.T) {
+	start := time.Date(2024, 5, 4, 9, 0, 0, 0, time.UTC)
+	records := trainerRecords(start, repeatSpeed(0, 60)...)
+
+	assert.Empty(t, activityPauses(records, nil, 0))
+	assert.Equal(t, 59*time.Second, movingTime(records, nil))
+}
+
+func TestSetMovingTimeBasesAverageSpeedOnMovingTime(t *testing.T) {
+	stats := ActivityStats{
+		Distance: decimal.NewFromInt(30),
+		AvgSpeed: decimal.NewFromInt(20),
+	}
+
+	stats.setMovingTime(time.Hour)
+	assert.Equal(t, 30.0, stats.AvgSpeed.InexactFloat64())
+
+	stats = ActivityStats{AvgSpeed: decimal.NewFromInt(20)}
+	stats.setMovingTime(0)
+	assert.Equal(t, 20.0, stats.AvgSpeed.InexactFloat64())
+}
+
+func TestParseAutoPauseSpeed(t *testing.T) {
+	speed, err := ParseAutoPauseSpeed("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultAutoPauseSpeed, speed)
+
+	speed, err = ParseAutoPauseSpeed(" 4.5 ")
+	require.NoError(t, err)
+	assert.Equal(t, 4.5, speed)
+
+	_, err = ParseAutoPauseSpeed("-1")
+	assert.Error(t, err)
+}
+
+func repeatSpeed(speed uint16, seconds int) []uint16 {
+	speeds := make([]uint16, seconds)
+	for i := range speeds {
+		speeds[i] = speed
+	}
+	return speeds
+}
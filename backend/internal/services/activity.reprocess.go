@@ -0,0 +1,145 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/notaduck/backend/internal/db"
+)
+
+// ErrNoFileStore is returned when reprocessing without a file store holding
+// the originals.
+var ErrNoFileStore = errors.New("no file store is configured")
+
+// ReprocessFilter selects the activities to reprocess. The zero value selects
+// every activity with a stored original.
+type ReprocessFilter struct {
+	// ActivityID limits reprocessing to a single activity.
+	ActivityID int32
+	// UserID limits reprocessing to the activities of a single user.
+	UserID string
+}
+
+// ReprocessProgress is reported after each reprocessed activity.
+type ReprocessProgress struct {
+	Processed int
+	Total     int
+	Result    FileResult
+}
+
+// ReprocessActivities runs the stored originals of the selected activities
+// through the ingestion pipeline again and replaces their derived data in
+// place, keeping their ids and the fields edited by the user. It calls
+// progress after each activity.
+func (s *activityService) ReprocessActivities(ctx context.Context, filter ReprocessFilter, progress func(ReprocessProgress) error) (*UploadBatchResult, error) {
+	if s.fileStore == nil {
+		return nil, ErrNoFileStore
+	}
+
+	originals, err := s.activityRepo.GetReprocessableActivities(ctx, db.GetReprocessableActivitiesParams{
+		ActivityID: pgtype.Int4{Int32: filter.ActivityID, Valid: filter.ActivityID != 0},
+		UserID:     pgtype.Text{String: filter.UserID, Valid: filter.UserID != ""},
+	})
+	if err != nil {
+		slog.Error("failed to list activities to reprocess", "error", err)
+		return nil, err
+	}
+
+	slog.Info("reprocessing activities", "activities", len(originals), "activityID", filter.ActivityID, "userID", filter.UserID)
+
+	result := &UploadBatchResult{Files: make([]FileResult, 0, len(originals))}
+
+	for i, original := range originals {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		activity, err := s.reprocessActivity(ctx, original)
+		if err != nil {
+			slog.Error("failed to reprocess activity", "activityID", original.ActivityID, "error", err)
+		}
+
+		fileResult := NewFileResult(original.Filename, activity, err)
+		if err != nil {
+			fileResult.ActivityID = original.ActivityID
+		}
+		result.Files = append(result.Files, fileResult)
+
+		if progress != nil {
+			if err := progress(ReprocessProgress{Processed: i + 1, Total: len(originals), Result: fileResult}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (s *activityService) reprocessActivity(ctx context.Context, original db.GetReprocessableActivitiesRow) (*Activity, error) {
+	content, err := s.fileStore.Get(ctx, original.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read original file: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("file %q: %w", original.Filename, ErrEmptyFile)
+	}
+
+	// Data is left unset: the original is already stored.
+	upload := activityUpload{
+		Filename:    original.Filename,
+		UserID:      original.UserID,
+		ContentHash: contentHash(data),
+		ContentType: original.ContentType,
+		ReprocessID: original.ActivityID,
+	}
+
+	return s.processFitData(ctx, bytes.NewReader(data), upload)
+}
+
+// replaceActivityParams carries the values derived from the file over to the
+// update of an existing activity.
+func replaceActivityParams(activityId int32, params db.CreateActivityParams) db.ReplaceActivityMetricsParams {
+	return db.ReplaceActivityMetricsParams{
+		ID:                  activityId,
+		RideType:            params.RideType,
+		Distance:            params.Distance,
+		AvgSpeed:            params.AvgSpeed,
+		MaxSpeed:            params.MaxSpeed,
+		ElapsedTime:         params.ElapsedTime,
+		TotalTime:           params.TotalTime,
+		DateOfActivity:      params.DateOfActivity,
+		ContentHash:         params.ContentHash,
+		DeviceFingerprint:   params.DeviceFingerprint,
+		StartedAt:           params.StartedAt,
+		EndedAt:             params.EndedAt,
+		StartPosition:       params.StartPosition,
+		AvgPower:            params.AvgPower,
+		MaxPower:            params.MaxPower,
+		NormalizedPower:     params.NormalizedPower,
+		VariabilityIndex:    params.VariabilityIndex,
+		IntensityFactor:     params.IntensityFactor,
+		TrainingStressScore: params.TrainingStressScore,
+		Ftp:                 params.Ftp,
+		Indoor:              params.Indoor,
+		MovingTime:          params.MovingTime,
+		ElevationGain:       params.ElevationGain,
+		ElevationLoss:       params.ElevationLoss,
+		MinAltitude:         params.MinAltitude,
+		MaxAltitude:         params.MaxAltitude,
+		Trimp:               params.Trimp,
+		CorrectedPoints:     params.CorrectedPoints,
+		FilledPoints:        params.FilledPoints,
+	}
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"math"
+	"sort"
+
+	"github.com/tormoder/fit"
+
+	"github.com/notaduck/backend/utils"
+)
+
+const (
+	RideTypeRoad   = "road"
+	RideTypeGravel = "gravel"
+	RideTypeMTB    = "mtb"
+	RideTypeTT     = "tt"
+	RideTypeIndoor = "indoor"
+)
+
+var rideTypes = map[string]struct{}{
+	RideTypeRoad:   {},
+	RideTypeGravel: {},
+	RideTypeMTB:    {},
+	RideTypeTT:     {},
+	RideTypeIndoor: {},
+}
+
+// IsRideType reports whether rideType is one of the known ride types.
+func IsRideType(rideType string) bool {
+	_, ok := rideTypes[rideType]
+	return ok
+}
+
+const (
+	// RideTypeDetected marks a ride type classified during ingestion;
+	// reprocessing classifies the activity again.
+	RideTypeDetected = "detected"
+	// RideTypeUserSet marks a ride type chosen by the user, either edited or
+	// carried over from an export manifest. It is never overwritten.
+	RideTypeUserSet = "user"
+)
+
+const (
+	// sinuosityWindow is the length of track, in km, over which the path
+	// length is compared with the straight-line distance.
+	sinuosityWindow = 0.2
+	// minMovingSpeed is the speed, in km/h, below which samples are left out
+	// of the speed distribution.
+	minMovingSpeed = 3.0
+)
+
+// rideFeatures summarises the recorded track for the ride type heuristic.
+type rideFeatures struct {
+	// MedianSpeed is the median moving speed in km/h.
+	MedianSpeed float64
+	// SpeedVariation is the coefficient of variation of the moving speed.
+	SpeedVariation float64
+	// ClimbPerKm is the ascent in m per km of track.
+	ClimbPerKm float64
+	// Sinuosity is the path length divided by the straight-line distance,
+	// measured over sinuosityWindow sections of the track; 1 is straight.
+	Sinuosity float64
+}
+
+// detectRideType classifies a ride from the sport recorded by the device or,
+// when that is inconclusive, from the shape of the recorded track.
+func detectRideType(sessions []*fit.SessionMsg, indoor bool, records []*fit.RecordMsg) string {
+	if indoor {
+		return RideTypeIndoor
+	}
+	if rideType, ok := fitRideType(sessions); ok {
+		return rideType
+	}
+
+	features, ok := extractRideFeatures(records)
+	if !ok {
+		return RideTypeRoad
+	}
+	return classifyRideFeatures(features)
+}
+
+// fitRideType maps the sub-sport of the first cycling session onto a ride
+// type. Generic sub-sports, e.g. commuting, report false.
+func fitRideType(sessions []*fit.SessionMsg) (string, bool) {
+	for _, session := range sessions {
+		if session.Sport != fit.SportCycling && session.Sport != fit.SportEBiking {
+			continue
+		}
+
+		switch session.SubSport {
+		case fit.SubSportIndoorCycling, fit.SubSportSpin, fit.SubSportVirtualActivity:
+			return RideTypeIndoor, true
+		case fit.SubSportRoad, fit.SubSportTrackCycling:
+			return RideTypeRoad, true
+		case fit.SubSportGravelCycling, fit.SubSportCyclocross, fit.SubSportMixedSurface:
+			return RideTypeGravel, true
+		case fit.SubSportMountain, fit.SubSportDownhill, fit.SubSportEBikeMountain, fit.SubSportBmx:
+			return RideTypeMTB, true
+		}
+		return "", false
+	}
+	return "", false
+}
+
+// classifyRideFeatures tells ride types apart by how fast, steady, hilly and
+// twisty the ride was. Time trials are fast and steady on flat roads, mountain
+// bike rides slow on twisty or steep trails, gravel rides in between.
+func classifyRideFeatures(features rideFeatures) string {
+	switch {
+	case features.MedianSpeed >= 34 && features.SpeedVariation <= 0.15 && features.ClimbPerKm < 8:
+		return RideTypeTT
+	case features.MedianSpeed < 18 && (features.Sinuosity >= 1.25 || features.ClimbPerKm >= 25):
+		return RideTypeMTB
+	case features.MedianSpeed < 24 && features.Sinuosity >= 1.1:
+		return RideTypeGravel
+	default:
+		return RideTypeRoad
+	}
+}
+
+// extractRideFeatures reports false when the records carry no moving speed,
+// e.g. a file without speed, distance or positions.
+func extractRideFeatures(records []*fit.RecordMsg) (rideFeatures, bool) {
+	var speeds []float64
+	for i := 1; i < len(records); i++ {
+		speed, ok := recordSpeed(records[i-1], records[i])
+		if kmh := speed * 3.6; ok && kmh >= minMovingSpeed {
+			speeds = append(speeds, kmh)
+		}
+	}
+	if len(speeds) == 0 {
+		return rideFeatures{}, false
+	}
+
+	features := rideFeatures{
+		MedianSpeed:    median(speeds),
+		SpeedVariation: coefficientOfVariation(speeds),
+		Sinuosity:      1,
+	}
+
+	pathLength, sinuosity := trackSinuosity(records)
+	if sinuosity > 0 {
+		features.Sinuosity = sinuosity
+	}
+	if pathLength > 0 {
+		features.ClimbPerKm = trackElevation(records).Gain / pathLength
+	}
+
+	return features, true
+}
+
+// trackSinuosity returns the length of the track in km and its sinuosity.
+func trackSinuosity(records []*fit.RecordMsg) (float64, float64) {
+	var total, pathSum, chordSum, window float64
+	var windowStart, previous *fit.RecordMsg
+
+	for _, record := range records {
+		if record.PositionLat.Invalid() || record.PositionLong.Invalid() {
+			continue
+		}
+		if previous == nil {
+			windowStart, previous = record, record
+			continue
+		}
+
+		segment := utils.Haversine(previous.PositionLat.Degrees(), previous.PositionLong.Degrees(), record.PositionLat.Degrees(), record.PositionLong.Degrees())
+		total += segment
+		window += segment
+		previous = record
+
+		if window >= sinuosityWindow {
+			chord := utils.Haversine(windowStart.PositionLat.Degrees(), windowStart.PositionLong.Degrees(), record.PositionLat.Degrees(), record.PositionLong.Degrees())
+			pathSum += window
+			chordSum += chord
+			windowStart, window = record, 0
+		}
+	}
+
+	if chordSum == 0 {
+		return total, 0
+	}
+	return total, pathSum / chordSum
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+func coefficientOfVariation(values []float64) float64 {
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	mean := sum / float64(len(values))
+	if mean == 0 {
+		return 0
+	}
+
+	var squares float64
+	for _, value := range values {
+		squares += (value - mean) * (value - mean)
+	}
+	return math.Sqrt(squares/float64(len(values))) / mean
+}
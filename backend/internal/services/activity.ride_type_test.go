@@ -0,0 +1,111 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tormoder/fit"
+)
+
+// trackRecords records one sample per second, moving by the given north and
+// east steps in metres at the given speed in mm/s.
+func trackRecords(start time.Time, speed uint16, steps [][2]float64) []*fit.RecordMsg {
+	const metresPerDegree = 111_320.0
+
+	lat, lon := 55.6761, 12.5683
+	records := make([]*fit.RecordMsg, len(steps))
+	for i, step := range steps {
+		lat += step[0] / metresPerDegree
+		lon += step[1] / metresPerDegree / 0.5641 // cos(55.68°)
+
+		records[i] = fit.NewRecordMsg()
+		records[i].Timestamp = start.Add(time.Duration(i) * time.Second)
+		records[i].PositionLat = fit.NewLatitudeDegrees(lat)
+		records[i].PositionLong = fit.NewLongitudeDegrees(lon)
+		records[i].Speed = speed
+	}
+	return records
+}
+
+func cyclingSession(subSport fit.SubSport) *fit.SessionMsg {
+	session := fit.NewSessionMsg()
+	session.Sport = fit.SportCycling
+	session.SubSport = subSport
+	return session
+}
+
+func TestFitRideType(t *testing.T) {
+	rideType, ok := fitRideType([]*fit.SessionMsg{cyclingSession(fit.SubSportMountain)})
+	assert.True(t, ok)
+	assert.Equal(t, RideTypeMTB, rideType)
+
+	rideType, ok = fitRideType([]*fit.SessionMsg{cyclingSession(fit.SubSportGravelCycling)})
+	assert.True(t, ok)
+	assert.Equal(t, RideTypeGravel, rideType)
+
+	rideType, ok = fitRideType([]*fit.SessionMsg{cyclingSession(fit.SubSportVirtualActivity)})
+	assert.True(t, ok)
+	assert.Equal(t, RideTypeIndoor, rideType)
+
+	_, ok = fitRideType([]*fit.SessionMsg{cyclingSession(fit.SubSportGeneric)})
+	assert.False(t, ok, "generic cycling is left to the heuristic")
+
+	running := fit.NewSessionMsg()
+	running.Sport = fit.SportRunning
+	running.SubSport = fit.SubSportTrail
+	_, ok = fitRideType([]*fit.SessionMsg{running})
+	assert.False(t, ok)
+}
+
+func TestDetectRideTypeFromTrack(t *testing.T) {
+	start := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	generic := []*fit.SessionMsg{cyclingSession(fit.SubSportGeneric)}
+
+	// Flat, straight and steady at 40 km/h.
+	straight := make([][2]float64, 600)
+	for i := range straight {
+		straight[i] = [2]float64{11.11, 0}
+	}
+	assert.Equal(t, RideTypeTT, detectRideType(generic, false, trackRecords(start, 11111, straight)))
+
+	// Zigzagging at 12 km/h, turning every 10 seconds.
+	twisty := make([][2]float64, 600)
+	for i := range twisty {
+		east := 2.67
+		if (i/10)%2 == 1 {
+			east = -east
+		}
+		twisty[i] = [2]float64{2, east}
+	}
+	assert.Equal(t, RideTypeMTB, detectRideType(generic, false, trackRecords(start, 3333, twisty)))
+
+	// The sub-sport recorded by the device wins over the heuristic.
+	assert.Equal(t, RideTypeRoad, detectRideType([]*fit.SessionMsg{cyclingSession(fit.SubSportRoad)}, false, trackRecords(start, 3333, twisty)))
+	assert.Equal(t, RideTypeIndoor, detectRideType(generic, true, trackRecords(start, 11111, straight)))
+}
+
+func TestDetectRideTypeWithoutSpeedDefaultsToRoad(t *testing.T) {
+	records := trainerRecords(time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC), repeatSpeed(0xFFFF, 60)...)
+	assert.Equal(t, RideTypeRoad, detectRideType(nil, false, records))
+}
+
+func TestClassifyRideFeatures(t *testing.T) {
+	tests := []struct {
+		name     string
+		features rideFeatures
+		want     string
+	}{
+		{"steady fast flat", rideFeatures{MedianSpeed: 38, SpeedVariation: 0.08, ClimbPerKm: 2, Sinuosity: 1.01}, RideTypeTT},
+		{"fast hilly group ride", rideFeatures{MedianSpeed: 30, SpeedVariation: 0.3, ClimbPerKm: 12, Sinuosity: 1.03}, RideTypeRoad},
+		{"twisty singletrack", rideFeatures{MedianSpeed: 13, SpeedVariation: 0.5, ClimbPerKm: 15, Sinuosity: 1.4}, RideTypeMTB},
+		{"steep trail", rideFeatures{MedianSpeed: 11, SpeedVariation: 0.4, ClimbPerKm: 30, Sinuosity: 1.1}, RideTypeMTB},
+		{"winding gravel road", rideFeatures{MedianSpeed: 21, SpeedVariation: 0.35, ClimbPerKm: 9, Sinuosity: 1.15}, RideTypeGravel},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, classifyRideFeatures(test.features))
+		})
+	}
+}
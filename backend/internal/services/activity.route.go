@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/notaduck/backend/utils"
+)
+
+const (
+	// earthRadius is the mean radius of the earth in m.
+	earthRadius = 6371000.0
+	// maxRouteTolerance caps the simplification tolerance in m; coarser
+	// routes no longer resemble the ride.
+	maxRouteTolerance = 10000.0
+	// polylinePrecision is the factor coordinates are scaled by in an
+	// encoded polyline, five decimals as map libraries expect.
+	polylinePrecision = 1e5
+)
+
+// ErrInvalidActivityResolution is returned for a negative or out of range
+// route tolerance or record count.
+var ErrInvalidActivityResolution = errors.New("invalid activity resolution")
+
+// ActivityResolution reduces the detail of an activity for display. A zero
+// Tolerance leaves the route unset and a zero MaxPoints keeps every record.
+type ActivityResolution struct {
+	// Tolerance is the furthest, in m, the simplified route may stray from
+	// the recorded positions.
+	Tolerance float64
+	// MaxPoints is the number of records the streams are averaged down to.
+	MaxPoints int
+}
+
+// GetActivityAtResolution returns the activity with its route simplified and
+// its records averaged down as the resolution asks.
+func (s *activityService) GetActivityAtResolution(ctx context.Context, activityId int32, userId string, resolution ActivityResolution) (*Activity, error) {
+	if math.IsNaN(resolution.Tolerance) || resolution.Tolerance < 0 || resolution.Tolerance > maxRouteTolerance {
+		return nil, fmt.Errorf("%w: the tolerance must be between 0 and %g m", ErrInvalidActivityResolution, maxRouteTolerance)
+	}
+	if resolution.MaxPoints < 0 {
+		return nil, fmt.Errorf("%w: the number of points must not be negative", ErrInvalidActivityResolution)
+	}
+
+	activity, err := s.GetSingleActivityById(ctx, activityId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if resolution.Tolerance > 0 {
+		activity.Route = simplifyRoute(routePoints(activity.Records), resolution.Tolerance)
+		activity.EncodedRoute = encodePolyline(activity.Route)
+	}
+	if resolution.MaxPoints > 0 {
+		activity.Records = downsampleRecords(activity.Records, resolution.MaxPoints)
+	}
+
+	return activity, nil
+}
+
+// routePoints returns the positions of the records that have one.
+func routePoints(records []Record) []Point {
+	var points []Point
+	for _, record := range records {
+		if record.Coordinates != nil {
+			points = append(points, *record.Coordinates)
+		}
+	}
+	return points
+}
+
+// simplifyRoute reduces the route with the Douglas-Peucker algorithm: the
+// point furthest from the line between the ends of a stretch is kept when it
+// is more than tolerance m off, and both halves are simplified in turn. The
+// ends of the route are always kept.
+func simplifyRoute(points []Point, tolerance float64) []Point {
+	if len(points) < 3 {
+		return points
+	}
+
+	projected := projectRoute(points)
+	keep := make([]bool, len(points))
+	keep[0], keep[len(points)-1] = true, true
+
+	stack := [][2]int{{0, len(points) - 1}}
+	for len(stack) > 0 {
+		stretch := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		first, last := stretch[0], stretch[1]
+
+		furthest, distance := -1, tolerance
+		for i := first + 1; i < last; i++ {
+			if d := segmentDistance(projected[i], projected[first], projected[last]); d > distance {
+				furthest, distance = i, d
+			}
+		}
+		if furthest < 0 {
+			continue
+		}
+
+		keep[furthest] = true
+		stack = append(stack, [2]int{first, furthest}, [2]int{furthest, last})
+	}
+
+	simplified := make([]Point, 0, len(points))
+	for i, point := range points {
+		if keep[i] {
+			simplified = append(simplified, point)
+		}
+	}
+	return simplified
+}
+
+// projectRoute maps the positions to m on a plane through the first point,
+// which is close enough over the span of a ride.
+func projectRoute(points []Point) []Point {
+	cosLat := math.Cos(utils.DegToRad(points[0].Y))
+
+	projected := make([]Point, len(points))
+	for i, point := range points {
+		projected[i] = Point{
+			X: utils.DegToRad(point.X-points[0].X) * earthRadius * cosLat,
+			Y: utils.DegToRad(point.Y-points[0].Y) * earthRadius,
+		}
+	}
+	return projected
+}
+
+// segmentDistance returns the distance from p to the segment from a to b.
+func segmentDistance(p, a, b Point) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	if dx == 0 && dy == 0 {
+		return math.Hypot(p.X-a.X, p.Y-a.Y)
+	}
+	t := ((p.X-a.X)*dx + (p.Y-a.Y)*dy) / (dx*dx + dy*dy)
+	t = math.Max(0, math.Min(1, t))
+	return math.Hypot(p.X-(a.X+t*dx), p.Y-(a.Y+t*dy))
+}
+
+// encodePolyline encodes the route in the encoded polyline algorithm format
+// with five decimals, latitude first.
+func encodePolyline(points []Point) string {
+	var encoded strings.Builder
+	var lat, long int64
+	for _, point := range points {
+		nextLat := int64(math.Round(point.Y * polylinePrecision))
+		nextLong := int64(math.Round(point.X * polylinePrecision))
+		encodePolylineValue(&encoded, nextLat-lat)
+		encodePolylineValue(&encoded, nextLong-long)
+		lat, long = nextLat, nextLong
+	}
+	return encoded.String()
+}
+
+func encodePolylineValue(encoded *strings.Builder, value int64) {
+	shifted := value << 1
+	if value < 0 {
+		shifted = ^shifted
+	}
+	for shifted >= 0x20 {
+		encoded.WriteByte(byte((0x20 | (shifted & 0x1f)) + 63))
+		shifted >>= 5
+	}
+	encoded.WriteByte(byte(shifted + 63))
+}
+
+// downsampleRecords averages the records down to at most maxPoints. Each
+// bucket of consecutive records keeps the ID, time, distance and position of
+// its first record, so samples still line up with the route, and averages
+// the speed. Heart rate, cadence and power are averaged over the records
+// that have one, and are 0 for a bucket without any.
+func downsampleRecords(records []Record, maxPoints int) []Record {
+	if maxPoints <= 0 || len(records) <= maxPoints {
+		return records
+	}
+
+	sampled := make([]Record, maxPoints)
+	for bucket := range sampled {
+		from := bucket * len(records) / maxPoints
+		to := (bucket + 1) * len(records) / maxPoints
+
+		var speed, cadence, power, heartRate float64
+		var cadenceCount, powerCount, heartRateCount int
+		for _, record := range records[from:to] {
+			speed += record.Speed
+			if record.hasCadence {
+				cadence += float64(record.Cadence)
+				cadenceCount++
+			}
+			if record.hasPower {
+				power += float64(record.Power)
+				powerCount++
+			}
+			if record.HeartRate > 0 {
+				heartRate += float64(record.HeartRate)
+				heartRateCount++
+			}
+		}
+
+		sample := records[from]
+		sample.Speed = speed / float64(to-from)
+		sample.Cadence, sample.hasCadence = 0, cadenceCount > 0
+		if cadenceCount > 0 {
+			sample.Cadence = int16(math.Round(cadence / float64(cadenceCount)))
+		}
+		sample.Power, sample.hasPower = 0, powerCount > 0
+		if powerCount > 0 {
+			sample.Power = int32(math.Round(power / float64(powerCount)))
+		}
+		sample.HeartRate = 0
+		if heartRateCount > 0 {
+			sample.HeartRate = int16(math.Round(heartRate / float64(heartRateCount)))
+		}
+		sampled[bucket] = sample
+	}
+	return sampled
+}
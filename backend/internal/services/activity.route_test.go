@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimplifyRoute(t *testing.T) {
+	// A straight line north with a 100 m detour east halfway and a few
+	// metres of GPS noise.
+	var points []Point
+	for i := 0; i <= 100; i++ {
+		x := 10.0
+		if i%2 == 1 {
+			x += 0.00002 // about 1 m
+		}
+		if i == 50 {
+			x += 0.0015 // about 100 m
+		}
+		points = append(points, Point{X: x, Y: 55 + float64(i)*0.0001})
+	}
+
+	simplified := simplifyRoute(points, 10)
+	assert.Equal(t, []Point{points[0], points[49], points[50], points[51], points[100]}, simplified)
+
+	assert.Equal(t, []Point{points[0], points[100]}, simplifyRoute(points, 500), "the detour is within the tolerance")
+	assert.Len(t, simplifyRoute(points, 0.1), len(points), "the noise is beyond the tolerance")
+}
+
+func TestSimplifyRouteLoop(t *testing.T) {
+	// A square loop that ends where it starts keeps its corners.
+	points := []Point{
+		{X: 10, Y: 55}, {X: 10.005, Y: 55}, {X: 10.01, Y: 55},
+		{X: 10.01, Y: 55.01}, {X: 10, Y: 55.01}, {X: 10, Y: 55},
+	}
+	simplified := simplifyRoute(points, 10)
+	assert.Equal(t, []Point{points[0], points[2], points[3], points[4], points[5]}, simplified)
+}
+
+func TestEncodePolyline(t *testing.T) {
+	points := []Point{
+		{X: -120.2, Y: 38.5},
+		{X: -120.95, Y: 40.7},
+		{X: -126.453, Y: 43.252},
+	}
+	assert.Equal(t, "_p~iF~ps|U_ulLnnqC_mqNvxq`@", encodePolyline(points))
+	assert.Empty(t, encodePolyline(nil))
+}
+
+func TestDownsampleRecords(t *testing.T) {
+	records := make([]Record, 10)
+	for i := range records {
+		records[i] = Record{
+			ID:       int32(i + 1),
+			Speed:    float64(i),
+			Distance: int32(i * 1000),
+			Cadence:  80,
+			Power:    int32(100 * (i % 2)),
+
+			hasCadence: true,
+			hasPower:   true,
+		}
+		if i < 5 {
+			records[i].HeartRate = 150
+		}
+	}
+	// The cadence sensor drops out for the last bucket and the power meter
+	// for one record of the second.
+	for i := 6; i < len(records); i++ {
+		records[i].Cadence, records[i].hasCadence = 0, false
+	}
+	records[3].Power, records[3].hasPower = 0, false
+
+	sampled := downsampleRecords(records, 3)
+	require.Len(t, sampled, 3)
+	assert.Equal(t, []int32{1, 4, 7}, []int32{sampled[0].ID, sampled[1].ID, sampled[2].ID})
+	assert.Equal(t, int32(3000), sampled[1].Distance)
+	assert.InDelta(t, 1.0, sampled[0].Speed, 1e-9)
+	assert.InDelta(t, 7.5, sampled[2].Speed, 1e-9)
+	assert.Equal(t, int16(80), sampled[1].Cadence)
+	assert.Equal(t, int16(0), sampled[2].Cadence, "no cadence sensor in the bucket")
+	assert.Equal(t, int32(50), sampled[1].Power, "averaged over the records with power")
+	assert.Equal(t, int32(50), sampled[2].Power)
+	assert.Equal(t, int16(150), sampled[1].HeartRate, "averaged over the records with heart rate")
+	assert.Equal(t, int16(0), sampled[2].HeartRate)
+
+	assert.Len(t, downsampleRecords(records, 20), 10, "fewer records than points")
+}
+
+func TestGetActivityAtResolutionRejectsInvalidResolution(t *testing.T) {
+	s := &activityService{}
+
+	_, err := s.GetActivityAtResolution(context.Background(), 1, "user", ActivityResolution{Tolerance: -1})
+	assert.ErrorIs(t, err, ErrInvalidActivityResolution)
+
+	_, err = s.GetActivityAtResolution(context.Background(), 1, "user", ActivityResolution{MaxPoints: -1})
+	assert.ErrorIs(t, err, ErrInvalidActivityResolution)
+}
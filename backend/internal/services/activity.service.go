@@ -1,43 +1,85 @@
 package service
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"log/slog"
 	"math"
-	"mime/multipart"
-	"path/filepath"
-	"sync"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/shopspring/decimal"
 	"github.com/tormoder/fit"
 
+	"github.com/notaduck/backend/internal/config"
 	"github.com/notaduck/backend/internal/db"
 	"github.com/notaduck/backend/internal/repositories"
 	"github.com/notaduck/backend/utils"
 )
 
 type Activity struct {
-	ID              int32         `json:"id"`
-	CreatedAt       time.Time     `json:"createdAt"`
-	Distance        float64       `json:"distance"`
-	ActivityName    string        `json:"activityName"`
-	AvgSpeed        float64       `json:"avgSpeed"`
-	MaxSpeed        float64       `json:"maxSpeed"`
-	RideType        string        `json:"rideType"`
-	AvgHeartRate    *float64      `json:"avgHeartRate,omitempty"`
-	MaxHeartRate    *float64      `json:"maxHeartRate,omitempty"`
-	AvgCadence      *float64      `json:"avgCadence,omitempty"`
-	MaxCadence      *float64      `json:"maxCadence,omitempty"`
-	ElapsedTime     string        `json:"elapsedTime"`
-	TotalTime       string        `json:"totalTime"`
-	ElapsedDuration time.Duration `json:"-"`
-	TotalDuration   time.Duration `json:"-"`
-	Records         []Record      `json:"records"`
+	ID           int32     `json:"id"`
+	CreatedAt    time.Time `json:"createdAt"`
+	Distance     float64   `json:"distance"`
+	ActivityName string    `json:"activityName"`
+	AvgSpeed     float64   `json:"avgSpeed"`
+	MaxSpeed     float64   `json:"maxSpeed"`
+	RideType     string    `json:"rideType"`
+	// RideTypeDetected is false once the user has chosen the ride type.
+	RideTypeDetected bool          `json:"rideTypeDetected"`
+	AvgHeartRate     *float64      `json:"avgHeartRate,omitempty"`
+	MaxHeartRate     *float64      `json:"maxHeartRate,omitempty"`
+	AvgCadence       *float64      `json:"avgCadence,omitempty"`
+	MaxCadence       *float64      `json:"maxCadence,omitempty"`
+	ElapsedTime      string        `json:"elapsedTime"`
+	TotalTime        string        `json:"totalTime"`
+	MovingTime       string        `json:"movingTime"`
+	ElapsedDuration  time.Duration `json:"-"`
+	TotalDuration    time.Duration `json:"-"`
+	MovingDuration   time.Duration `json:"-"`
+	DuplicateOf      *int32        `json:"duplicateOf,omitempty"`
+	Description      string        `json:"description,omitempty"`
+	Indoor           bool          `json:"indoor"`
+	// Power metrics are nil for rides without power data; intensity factor
+	// and TSS also need an FTP set at ingestion.
+	AvgPower            *int32   `json:"avgPower,omitempty"`
+	MaxPower            *int32   `json:"maxPower,omitempty"`
+	NormalizedPower     *int32   `json:"normalizedPower,omitempty"`
+	VariabilityIndex    *float64 `json:"variabilityIndex,omitempty"`
+	IntensityFactor     *float64 `json:"intensityFactor,omitempty"`
+	TrainingStressScore *float64 `json:"trainingStressScore,omitempty"`
+	FTP                 *int32   `json:"ftp,omitempty"`
+	// Elevation in m is nil for rides without altitude data.
+	ElevationGain *float64 `json:"elevationGain,omitempty"`
+	ElevationLoss *float64 `json:"elevationLoss,omitempty"`
+	MinAltitude   *float64 `json:"minAltitude,omitempty"`
+	MaxAltitude   *float64 `json:"maxAltitude,omitempty"`
+	// CorrectedPoints counts the GPS spikes moved or dropped by the track
+	// cleaning at ingestion, and FilledPoints the missing fixes it
+	// interpolated.
+	CorrectedPoints int32     `json:"correctedPoints"`
+	FilledPoints    int32     `json:"filledPoints"`
+	Sessions        []Session `json:"sessions,omitempty"`
+	Laps            []Lap     `json:"laps,omitempty"`
+	Devices         []Device  `json:"devices,omitempty"`
+	Pauses          []Pause   `json:"pauses,omitempty"`
+	// HeartRateZones is empty for rides without heart rate data or ingested
+	// before the user configured zones.
+	HeartRateZones []HeartRateZone `json:"heartRateZones,omitempty"`
+	// PowerCurve is the mean-maximal power of the ride, empty without power
+	// data.
+	PowerCurve []PowerCurvePoint `json:"powerCurve,omitempty"`
+	// BestEfforts holds the fastest effort over each distance the ride covers.
+	BestEfforts []BestEffort `json:"bestEfforts,omitempty"`
+	// Route is the simplified track and EncodedRoute the same in the encoded
+	// polyline format; both are only set at a requested resolution.
+	Route        []Point  `json:"route,omitempty"`
+	EncodedRoute string   `json:"encodedRoute,omitempty"`
+	Records      []Record `json:"records"`
 }
 
 type Point struct {
@@ -47,12 +89,17 @@ type Point struct {
 
 type Record struct {
 	ID          int32     `json:"id"`
-	Coordinates Point     `json:"coordinates"`
+	Coordinates *Point    `json:"coordinates"` // nil without a GPS position
 	Speed       float64   `json:"speed"`
 	TimeStamp   time.Time `json:"timeStamp"`
 	Distance    int32     `json:"distance"`
 	HeartRate   int16     `json:"heartRate"`
 	Cadence     int16     `json:"cadence"`
+	Power       int32     `json:"power"`
+	// hasCadence and hasPower are unset for records without a cadence or
+	// power sensor, whose Cadence and Power are 0.
+	hasCadence bool
+	hasPower   bool
 }
 
 type ActivityFilePayload struct {
@@ -60,6 +107,11 @@ type ActivityFilePayload struct {
 	ContentType  string
 	Data         []byte
 	LastModified time.Time
+	// Name, RideType and Description override the values derived from the
+	// file, e.g. with those carried over from an export manifest.
+	Name        string
+	RideType    string
+	Description string
 }
 
 type ActivitySummary struct {
@@ -68,28 +120,120 @@ type ActivitySummary struct {
 	Distance        float64       `json:"distance"`
 	ElapsedTime     string        `json:"elapsedTime"`
 	TotalTime       string        `json:"totalTime"`
+	MovingTime      string        `json:"movingTime"`
+	Indoor          bool          `json:"indoor"`
+	ElevationGain   *float64      `json:"elevationGain,omitempty"`
 	ElapsedDuration time.Duration `json:"-"`
 	TotalDuration   time.Duration `json:"-"`
+	MovingDuration  time.Duration `json:"-"`
 }
 
 type ActivityService interface {
 	UpdateActivity(ctx context.Context, activityData db.UpdateActivityParams) (*Activity, error)
+	// DeleteActivity deletes the user's activity and its stored original,
+	// and refreshes the training load and personal records it counted in.
+	DeleteActivity(ctx context.Context, activityID int32, userID string) error
 	GetSingleActivityById(ctx context.Context, activityId int32, userId string) (*Activity, error)
+	// GetActivityAtResolution returns the activity with a simplified route
+	// and its records averaged down for display.
+	GetActivityAtResolution(ctx context.Context, activityId int32, userId string, resolution ActivityResolution) (*Activity, error)
+	GetActivityLaps(ctx context.Context, activityId int32, userId string) ([]Lap, error)
 	GetActivities(ctx context.Context, userId string) ([]ActivitySummary, error)
-	CreateActivities(ctx context.Context, files []*multipart.FileHeader, userID string) ([]*Activity, error)
-	CreateActivitiesFromBytes(ctx context.Context, files []ActivityFilePayload, userID string) ([]*Activity, error)
-	GetActivityStats(ctx context.Context, userID string) (*db.GetActivityStatsRow, error)
+	CreateActivityFromBytes(ctx context.Context, file ActivityFilePayload, userID string) (*Activity, error)
+	// ImportArchive ingests every activity file of a Strava or Garmin export
+	// ZIP, calling progress after each file.
+	ImportArchive(ctx context.Context, archive io.ReaderAt, size int64, userID string, progress func(ArchiveProgress) error) (*UploadBatchResult, error)
+	GetActivityStats(ctx context.Context, userID string) (*UserStats, error)
+	// GetPowerCurve returns the best power for each duration over the user's
+	// rides selected by filter.
+	GetPowerCurve(ctx context.Context, userID string, filter PowerCurveFilter) ([]PowerCurvePoint, error)
+	// GetPersonalRecords returns the user's fastest effort over each of the
+	// best effort distances.
+	GetPersonalRecords(ctx context.Context, userID string) ([]PersonalRecord, error)
+	// GetTrainingLoad returns the user's daily fitness, fatigue and form
+	// between two UTC days.
+	GetTrainingLoad(ctx context.Context, userID string, from, to time.Time) ([]TrainingLoadDay, error)
+	GetUserSettings(ctx context.Context, userID string) (*UserSettings, error)
+	UpdateUserSettings(ctx context.Context, userID string, settings UserSettings) (*UserSettings, error)
+	// GetOriginalFile returns the file the activity was created from. The
+	// caller closes its Content.
+	GetOriginalFile(ctx context.Context, activityID int32, userID string) (*OriginalFile, error)
+	// ReprocessActivities derives the data of the selected activities again
+	// from their stored originals, calling progress after each activity.
+	ReprocessActivities(ctx context.Context, filter ReprocessFilter, progress func(ReprocessProgress) error) (*UploadBatchResult, error)
 }
 
 type activityService struct {
-	activityRepo repositories.ActivityRepository
-	recordRepo   repositories.RecordRepository
+	activityRepo    repositories.ActivityRepository
+	recordRepo      repositories.RecordRepository
+	uow             repositories.UnitOfWork
+	duplicatePolicy DuplicatePolicy
+	// autoPauseSpeed is the speed in km/h below which the rider counts as
+	// stopped in files without timer events.
+	autoPauseSpeed float64
+	// fileStore keeps the uploaded originals; nil disables keeping them.
+	fileStore FileStore
+}
+
+type ActivityServiceOption func(*activityService)
+
+func NewActivityService(ar repositories.ActivityRepository, rr repositories.RecordRepository, uow repositories.UnitOfWork, options ...ActivityServiceOption) ActivityService {
+	service := &activityService{
+		activityRepo:    ar,
+		recordRepo:      rr,
+		uow:             uow,
+		duplicatePolicy: DuplicatePolicySkip,
+		autoPauseSpeed:  DefaultAutoPauseSpeed,
+	}
+
+	for _, option := range options {
+		option(service)
+	}
+
+	return service
+}
+
+// NewActivityServiceFromConfig creates the activity service with the
+// duplicate policy, auto-pause speed and file store read from the
+// environment. Every command builds its activity service this way.
+func NewActivityServiceFromConfig(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool, queries *db.Queries) (ActivityService, error) {
+	duplicatePolicy, err := ParseDuplicatePolicy(cfg.DuplicatePolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	autoPauseSpeed, err := ParseAutoPauseSpeed(cfg.AutoPauseSpeed)
+	if err != nil {
+		return nil, err
+	}
+
+	fileStore, err := NewFileStore(ctx, FileStoreConfig{
+		Kind:        cfg.FileStore,
+		Path:        cfg.FileStorePath,
+		S3Endpoint:  cfg.S3Endpoint,
+		S3Bucket:    cfg.S3Bucket,
+		S3AccessKey: cfg.S3AccessKey,
+		S3SecretKey: cfg.S3SecretKey,
+		S3Region:    cfg.S3Region,
+		S3UseSSL:    cfg.S3UseSSL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the file store: %w", err)
+	}
+
+	return NewActivityService(
+		repositories.NewActivityRepository(queries),
+		repositories.NewRecordRepository(queries),
+		repositories.NewUnitOfWork(pool, queries),
+		WithDuplicatePolicy(duplicatePolicy),
+		WithAutoPauseSpeed(autoPauseSpeed),
+		WithFileStore(fileStore),
+	), nil
 }
 
-func NewActivityService(ar repositories.ActivityRepository, rr repositories.RecordRepository) ActivityService {
-	return &activityService{
-		activityRepo: ar,
-		recordRepo:   rr,
+func WithDuplicatePolicy(policy DuplicatePolicy) ActivityServiceOption {
+	return func(s *activityService) {
+		s.duplicatePolicy = policy
 	}
 }
 
@@ -133,105 +277,207 @@ func (s *activityService) UpdateActivity(ctx context.Context, activityData db.Up
 }
 
 func (s *activityService) GetSingleActivityById(ctx context.Context, activityId int32, userId string) (*Activity, error) {
-	activityEntity, err := s.activityRepo.GetActivityAndRecords(ctx, activityId)
+	activityDetails, err := loadActivity(ctx, s.activityRepo, activityId, userId)
 
 	if err != nil {
 		slog.Error("failed to retrieve activity", "activityId", activityId, "error", err)
 		return nil, err
 	}
 
-	activityDetails := convertActivityEntityToDomainModel(&activityEntity)
-
 	return activityDetails, nil
 }
 
-func (s *activityService) CreateActivities(ctx context.Context, files []*multipart.FileHeader, userId string) ([]*Activity, error) {
+// loadActivity reads an activity of the user with its records, sessions,
+// laps, devices, pauses and time in heart rate zones.
+func loadActivity(ctx context.Context, activities repositories.ActivityRepository, activityId int32, userId string) (*Activity, error) {
+	activityEntity, err := activities.GetActivityAndRecords(ctx, db.GetActivityWithRecordsViewParams{
+		ID:     activityId,
+		UserID: userId,
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	var activities []*Activity
+	sessions, err := activities.GetActivitySessions(ctx, activityId)
+	if err != nil {
+		return nil, err
+	}
 
-	errorCh := make(chan error, len(files))
-	activityCh := make(chan *Activity, len(files))
-	var wg sync.WaitGroup
+	laps, err := activities.GetActivityLaps(ctx, db.GetActivityLapsParams{
+		ActivityID: activityId,
+		UserID:     activityEntity.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	for _, fileHeader := range files {
-		wg.Add(1)
-		go func(fh *multipart.FileHeader) {
-			defer wg.Done()
+	devices, err := activities.GetActivityDevices(ctx, activityId)
+	if err != nil {
+		return nil, err
+	}
 
-			activity, err := s.processFitFile(ctx, fh, userId)
-			if err != nil {
-				errorCh <- err
-				return
-			}
-			activityCh <- activity
+	pauses, err := activities.GetActivityPauses(ctx, activityId)
+	if err != nil {
+		return nil, err
+	}
 
-		}(fileHeader)
+	heartRateZones, err := activities.GetActivityHeartRateZones(ctx, activityId)
+	if err != nil {
+		return nil, err
 	}
 
-	wg.Wait()
-	close(activityCh)
-	close(errorCh)
+	activity := convertActivityEntityToDomainModel(&activityEntity)
+	activity.Sessions = convertSessions(sessions)
+	activity.Laps = convertLaps(laps)
+	activity.Devices = convertDevices(devices)
+	activity.Pauses = convertPauses(pauses)
+	activity.HeartRateZones = convertHeartRateZones(heartRateZones)
 
-	for activity := range activityCh {
-		activities = append(activities, activity)
+	activity.PowerCurve, err = loadPowerCurve(ctx, activities, activityId)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(errorCh) > 0 {
-		return nil, <-errorCh
+	bestEfforts, err := activities.GetActivityBestEfforts(ctx, activityId)
+	if err != nil {
+		return nil, err
 	}
+	activity.BestEfforts = convertBestEfforts(bestEfforts)
 
-	return activities, nil
+	return activity, nil
 }
 
-func (s *activityService) CreateActivitiesFromBytes(ctx context.Context, files []ActivityFilePayload, userId string) ([]*Activity, error) {
-	activities := make([]*Activity, 0, len(files))
+func (s *activityService) CreateActivityFromBytes(ctx context.Context, file ActivityFilePayload, userId string) (*Activity, error) {
+	if len(file.Data) == 0 {
+		return nil, fmt.Errorf("file %q: %w", file.Filename, ErrEmptyFile)
+	}
 
-	for _, file := range files {
-		if len(file.Data) == 0 {
-			return nil, fmt.Errorf("file %q has no data", file.Filename)
-		}
+	upload := activityUpload{
+		Filename:    file.Filename,
+		UserID:      userId,
+		ContentHash: contentHash(file.Data),
+		Name:        file.Name,
+		RideType:    file.RideType,
+		Description: file.Description,
+		Data:        file.Data,
+		ContentType: file.ContentType,
+	}
 
-		reader := bytes.NewReader(file.Data)
-		activity, err := s.processFitData(ctx, reader, file.Filename, userId)
-		if err != nil {
-			return nil, err
-		}
+	return s.processFitData(ctx, bytes.NewReader(file.Data), upload)
+}
 
-		activities = append(activities, activity)
+func (s *activityService) processFitData(ctx context.Context, reader io.Reader, upload activityUpload) (*Activity, error) {
+	buffered := bufio.NewReaderSize(reader, sniffLength)
+	header, _ := buffered.Peek(sniffLength)
+
+	switch detectActivityFormat(upload.Filename, header) {
+	case formatFIT:
+		return s.processFit(ctx, buffered, upload)
+	case formatGPX:
+		return s.processGPX(ctx, buffered, upload)
+	case formatTCX:
+		return s.processTCX(ctx, buffered, upload)
+	default:
+		return nil, ErrUnsupportedFormat
 	}
-
-	return activities, nil
 }
 
-func (s *activityService) processFitFile(ctx context.Context, fileHeader *multipart.FileHeader, userId string) (*Activity, error) {
-	file, err := fileHeader.Open()
+func (s *activityService) processFit(ctx context.Context, reader io.Reader, upload activityUpload) (*Activity, error) {
+	fit, err := fit.Decode(reader)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", ErrCorruptFile, err)
+	}
+
+	activity, err := fit.Activity()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorruptFile, err)
 	}
-	defer file.Close()
 
-	return s.processFitData(ctx, file, fileHeader.Filename, userId)
+	upload.Device = fitDevice(fit.FileId)
+
+	return s.createActivityRecord(ctx, fit.FileId, activity, upload)
 }
 
-func (s *activityService) processFitData(ctx context.Context, reader io.Reader, filename string, userId string) (*Activity, error) {
-	if filepath.Ext(filename) != ".fit" {
-		return nil, fmt.Errorf("invalid file type: only .fit files are allowed")
+func (s *activityService) processGPX(ctx context.Context, reader io.Reader, upload activityUpload) (*Activity, error) {
+	gpx, err := decodeGPX(reader)
+	if err != nil {
+		return nil, err
 	}
 
-	fit, err := fit.Decode(reader)
+	return s.createDecodedActivity(ctx, gpx, upload)
+}
+
+func (s *activityService) processTCX(ctx context.Context, reader io.Reader, upload activityUpload) (*Activity, error) {
+	tcx, err := decodeTCX(reader)
 	if err != nil {
 		return nil, err
 	}
 
-	activity, err := fit.Activity()
+	return s.createDecodedActivity(ctx, tcx, upload)
+}
+
+func (s *activityService) createDecodedActivity(ctx context.Context, activity *decodedActivity, upload activityUpload) (*Activity, error) {
+	corrections := cleanTrack(activity.Records)
+	if corrections != (trackCorrections{}) && activity.MotionFromTrack {
+		deriveTrackMotion(activity.Records)
+	}
+
+	records, stats, err := s.processRecords(activity.Records)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.createActivityRecord(ctx, activity, userId)
+	if activity.Distance > 0 {
+		stats.Distance = decimal.NewFromFloat(activity.Distance / 1000)
+	}
+	if activity.MaxSpeed > 0 {
+		stats.MaxSpeed = decimal.NewFromFloat(activity.MaxSpeed * 3.6)
+	}
+
+	pauses := activityPauses(activity.Records, nil, s.autoPauseSpeed)
+	stats.setMovingTime(movingTime(activity.Records, pauses))
+
+	name := activity.Name
+	if name == "" {
+		name = getActivityName(activity.StartTime)
+	}
+
+	upload.Device = activity.Device
+
+	return s.persistActivity(ctx, upload, &activityRows{
+		Activity: db.CreateActivityParams{
+			Distance:        stats.Distance,
+			UserID:          upload.UserID,
+			TotalTime:       activity.TotalDuration,
+			ElapsedTime:     activity.TimerDuration,
+			MovingTime:      stats.MovingTime,
+			AvgSpeed:        stats.AvgSpeed,
+			MaxSpeed:        stats.MaxSpeed,
+			RideType:        detectRideType(nil, stats.Indoor, activity.Records),
+			RideTypeSource:  RideTypeDetected,
+			ActivityName:    name,
+			DateOfActivity:  pgtype.Timestamptz{Time: activity.StartTime, Valid: true},
+			Indoor:          stats.Indoor,
+			CorrectedPoints: int32(corrections.Spikes),
+			FilledPoints:    int32(corrections.Filled),
+		},
+		Records:     records,
+		Pauses:      pauses,
+		Power:       activityPower(activity.Records),
+		PowerCurve:  meanMaximalPower(powerSeries(activity.Records)),
+		Elevation:   stats.Elevation,
+		HeartRate:   heartRateSamples(activity.Records),
+		BestEfforts: bestEfforts(activity.Records),
+	})
 }
 
-func (s *activityService) createActivityRecord(ctx context.Context, activity *fit.ActivityFile, userId string) (*Activity, error) {
+func (s *activityService) createActivityRecord(ctx context.Context, fileId fit.FileIdMsg, activity *fit.ActivityFile, upload activityUpload) (*Activity, error) {
+	sessions, err := fitSessions(activity.Sessions)
+	if err != nil {
+		return nil, err
+	}
+
+	corrections := cleanTrack(activity.Records)
 
 	records, stats, err := s.processRecords(activity.Records)
 
@@ -239,52 +485,319 @@ func (s *activityService) createActivityRecord(ctx context.Context, activity *fi
 		return nil, err
 	}
 
-	totalRideDuration := time.Duration(activity.Sessions[0].TotalElapsedTime) * time.Microsecond
-	elapsedDuration := time.Duration(activity.Sessions[0].TotalTimerTime) * time.Microsecond
+	// A file with several sessions (e.g. a ride paused and resumed as a new
+	// session) is stored as one activity spanning all of them.
+	totalRideDuration, elapsedDuration := sessionTotals(sessions)
+
+	pauses := activityPauses(activity.Records, activity.Events, s.autoPauseSpeed)
+	stats.setMovingTime(movingTime(activity.Records, pauses))
+
+	startTime := sessions[0].StartTime.Time
+	if activity.Activity != nil && !activity.Activity.LocalTimestamp.IsZero() {
+		startTime = activity.Activity.LocalTimestamp
+	}
 
 	dateOfActivity := pgtype.Timestamptz{
-		Time:  activity.Activity.LocalTimestamp,
+		Time:  startTime,
 		Valid: true,
 	}
 
-	activityId, err := s.activityRepo.CreateActivity(ctx, db.CreateActivityParams{
-		Distance:       stats.Distance,
-		UserID:         userId,
-		TotalTime:      totalRideDuration,
-		ElapsedTime:    elapsedDuration,
-		AvgSpeed:       stats.AvgSpeed,
-		MaxSpeed:       stats.MaxSpeed,
-		RideType:       "road",
-		ActivityName:   getActivityName(activity.Activity.LocalTimestamp),
-		DateOfActivity: dateOfActivity,
+	indoor := stats.Indoor || indoorSessions(sessions)
+
+	return s.persistActivity(ctx, upload, &activityRows{
+		Activity: db.CreateActivityParams{
+			Distance:        stats.Distance,
+			UserID:          upload.UserID,
+			TotalTime:       totalRideDuration,
+			ElapsedTime:     elapsedDuration,
+			MovingTime:      stats.MovingTime,
+			AvgSpeed:        stats.AvgSpeed,
+			MaxSpeed:        stats.MaxSpeed,
+			RideType:        detectRideType(activity.Sessions, indoor, activity.Records),
+			RideTypeSource:  RideTypeDetected,
+			ActivityName:    getActivityName(startTime),
+			DateOfActivity:  dateOfActivity,
+			Indoor:          indoor,
+			CorrectedPoints: int32(corrections.Spikes),
+			FilledPoints:    int32(corrections.Filled),
+		},
+		Records:     records,
+		Sessions:    sessions,
+		Laps:        fitLaps(activity.Laps),
+		Devices:     fitDevices(fileId, activity.DeviceInfos),
+		Pauses:      pauses,
+		Power:       activityPower(activity.Records),
+		PowerCurve:  meanMaximalPower(powerSeries(activity.Records)),
+		Elevation:   stats.Elevation,
+		HeartRate:   heartRateSamples(activity.Records),
+		BestEfforts: bestEfforts(activity.Records),
 	})
-	if err != nil {
-		return nil, err
+}
+
+// activityRows holds everything stored for a single ingested activity. The
+// activity id of the child rows is filled in once the activity is created.
+type activityRows struct {
+	Activity db.CreateActivityParams
+	Records  []db.CreateRecordsParams
+	Sessions []db.CreateActivitySessionsParams
+	Laps     []db.CreateLapsParams
+	Devices  []db.CreateActivityDevicesParams
+	Pauses   []db.CreateActivityPausesParams
+	// Power is nil when the records carry no power data.
+	Power      *powerMetrics
+	PowerCurve db.CreateActivityPowerCurveParams
+	Elevation  elevationProfile
+	// HeartRate is nil when the records carry no heart rate; the time in
+	// each zone is derived from it once the user's zones are known.
+	HeartRate      []heartRateSample
+	HeartRateZones []db.CreateActivityHeartRateZonesParams
+	// BestEfforts are flagged as personal records against the user's earlier
+	// rides once the activity is stored.
+	BestEfforts []db.CreateActivityBestEffortsParams
+}
+
+func activityPower(records []*fit.RecordMsg) *powerMetrics {
+	metrics, ok := computePowerMetrics(records)
+	if !ok {
+		return nil
 	}
+	return &metrics
+}
 
-	for i := range records {
-		records[i].ActivityID = pgtype.Int4{Int32: int32(activityId), Valid: true}
+// persistActivity stores the activity row and its child rows in one
+// transaction and returns the activity as read back from
+// activity_with_records_view. A failure at any step leaves no trace of the
+// activity behind.
+func (s *activityService) persistActivity(ctx context.Context, upload activityUpload, rows *activityRows) (*Activity, error) {
+	var activity *Activity
+	var storedKey string
+
+	params := &rows.Activity
+	records := rows.Records
+
+	first, last := records[0], records[len(records)-1]
+	params.ContentHash = pgtype.Text{String: upload.ContentHash, Valid: upload.ContentHash != ""}
+	params.StartedAt = first.TimeStamp
+	params.EndedAt = last.TimeStamp
+	for _, record := range records {
+		if record.Position.Valid {
+			params.StartPosition = record.Position
+			break
+		}
 	}
 
-	if _, err := s.recordRepo.CreateRecords(ctx, records); err != nil {
-		return nil, err
+	if fingerprint := deviceFingerprint(upload.Device, first.TimeStamp.Time); fingerprint != "" {
+		params.DeviceFingerprint = pgtype.Text{String: fingerprint, Valid: true}
 	}
 
-	activityEntity, err := s.activityRepo.GetActivityAndRecords(ctx, activityId)
+	if upload.Name != "" {
+		params.ActivityName = upload.Name
+	}
+	if upload.RideType != "" {
+		params.RideType = upload.RideType
+		params.RideTypeSource = RideTypeUserSet
+	}
+	params.Description = upload.Description
+
+	if rows.Power != nil || len(rows.HeartRate) > 0 {
+		settings, err := s.trainingSettings(ctx, upload.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if rows.Power != nil {
+			var ftp int32
+			if settings.FTP != nil {
+				ftp = *settings.FTP
+			}
+			applyPowerMetrics(params, *rows.Power, ftp)
+		}
+		rows.HeartRateZones = heartRateZoneTimes(rows.HeartRate, settings.heartRateZoneBounds())
+		params.Trimp = heartRateTRIMP(rows.HeartRateZones)
+	}
+	applyElevation(params, rows.Elevation)
+
+	err := s.uow.Do(ctx, func(repos repositories.Repositories) error {
+		activityId, refreshFrom, err := s.saveActivity(ctx, repos, upload, params)
+		if err != nil {
+			return err
+		}
+
+		for i := range records {
+			records[i].ActivityID = pgtype.Int4{Int32: int32(activityId), Valid: true}
+		}
+
+		if _, err := repos.Records.CreateRecords(ctx, records); err != nil {
+			return err
+		}
+
+		if len(rows.Sessions) > 0 {
+			for i := range rows.Sessions {
+				rows.Sessions[i].ActivityID = activityId
+			}
+
+			if _, err := repos.Activities.CreateActivitySessions(ctx, rows.Sessions); err != nil {
+				return err
+			}
+		}
+
+		if len(rows.Laps) > 0 {
+			for i := range rows.Laps {
+				rows.Laps[i].ActivityID = activityId
+			}
+
+			if _, err := repos.Activities.CreateLaps(ctx, rows.Laps); err != nil {
+				return err
+			}
+		}
+
+		if len(rows.Devices) > 0 {
+			for i := range rows.Devices {
+				rows.Devices[i].ActivityID = activityId
+			}
+
+			if _, err := repos.Activities.CreateActivityDevices(ctx, rows.Devices); err != nil {
+				return err
+			}
+		}
+
+		if len(rows.Pauses) > 0 {
+			for i := range rows.Pauses {
+				rows.Pauses[i].ActivityID = activityId
+			}
+
+			if _, err := repos.Activities.CreateActivityPauses(ctx, rows.Pauses); err != nil {
+				return err
+			}
+		}
+
+		if len(rows.HeartRateZones) > 0 {
+			for i := range rows.HeartRateZones {
+				rows.HeartRateZones[i].ActivityID = activityId
+			}
+
+			if _, err := repos.Activities.CreateActivityHeartRateZones(ctx, rows.HeartRateZones); err != nil {
+				return err
+			}
+		}
+
+		if len(rows.PowerCurve.Durations) > 0 {
+			rows.PowerCurve.ActivityID = activityId
+
+			if err := repos.Activities.CreateActivityPowerCurve(ctx, rows.PowerCurve); err != nil {
+				return err
+			}
+		}
+
+		if len(rows.BestEfforts) > 0 {
+			previous, err := repos.Activities.GetPreviousBestEfforts(ctx, db.GetPreviousBestEffortsParams{
+				UserID:     upload.UserID,
+				ActivityID: activityId,
+				Before:     params.DateOfActivity,
+			})
+			if err != nil {
+				return err
+			}
+			markPersonalRecords(rows.BestEfforts, previous)
+
+			for i := range rows.BestEfforts {
+				rows.BestEfforts[i].ActivityID = activityId
+			}
+
+			if _, err := repos.Activities.CreateActivityBestEfforts(ctx, rows.BestEfforts); err != nil {
+				return err
+			}
+		}
+
+		if err := refreshTrainingLoad(ctx, repos.Activities, upload.UserID, refreshFrom.Time); err != nil {
+			return err
+		}
+
+		// Later rides lose or regain their records when the efforts of this
+		// one are added or changed, e.g. by reprocessing or a late upload.
+		if err := repos.Activities.RefreshPersonalRecords(ctx, db.RefreshPersonalRecordsParams{
+			UserID: upload.UserID,
+			After:  refreshFrom,
+		}); err != nil {
+			return err
+		}
+
+		if s.fileStore != nil && len(upload.Data) > 0 {
+			storedKey = originalFileKey(upload.UserID, activityId, upload.Filename)
+			if err := s.storeOriginal(ctx, repos, activityId, storedKey, upload); err != nil {
+				return err
+			}
+		}
+
+		activity, err = loadActivity(ctx, repos.Activities, activityId, upload.UserID)
+		return err
+	})
 
 	if err != nil {
+		if storedKey != "" {
+			s.deleteOriginal(ctx, storedKey)
+		}
 		return nil, err
 	}
 
-	return convertActivityEntityToDomainModel(&activityEntity), nil
+	return activity, nil
+}
+
+// saveActivity creates the activity row, or when reprocessing replaces the
+// derived values of the existing one and clears its child rows. It returns
+// the activity id and the earliest start the training load and personal
+// records must be refreshed from: when reprocessing moves the start, the
+// days between the stored and the new one changed as well.
+func (s *activityService) saveActivity(ctx context.Context, repos repositories.Repositories, upload activityUpload, params *db.CreateActivityParams) (int32, pgtype.Timestamptz, error) {
+	refreshFrom := params.DateOfActivity
+
+	if upload.ReprocessID != 0 {
+		stored, err := repos.Activities.GetActivityDate(ctx, upload.ReprocessID)
+		if err != nil {
+			return 0, refreshFrom, err
+		}
+		if stored.Valid && stored.Time.Before(refreshFrom.Time) {
+			refreshFrom = stored
+		}
+
+		if err := repos.Activities.ReplaceActivityMetrics(ctx, replaceActivityParams(upload.ReprocessID, *params)); err != nil {
+			return 0, refreshFrom, err
+		}
+		return upload.ReprocessID, refreshFrom, repos.Activities.ClearActivityDetails(ctx, upload.ReprocessID)
+	}
+
+	duplicateOf, err := s.checkDuplicate(ctx, repos, *params)
+	if err != nil {
+		return 0, refreshFrom, err
+	}
+	params.DuplicateOf = duplicateOf
+
+	activityId, err := repos.Activities.CreateActivity(ctx, *params)
+	if err != nil {
+		return 0, refreshFrom, s.duplicateFromInsertError(err)
+	}
+	return activityId, refreshFrom, nil
+}
+
+// applyPowerMetrics stores the power metrics on the activity, rating the
+// intensity against ftp when it is set.
+func applyPowerMetrics(params *db.CreateActivityParams, metrics powerMetrics, ftp int32) {
+	params.AvgPower = pgtype.Int4{Int32: int32(math.Round(metrics.Avg)), Valid: true}
+	params.MaxPower = pgtype.Int4{Int32: int32(metrics.Max), Valid: true}
+	params.NormalizedPower = pgtype.Int4{Int32: int32(math.Round(metrics.Normalized)), Valid: true}
+	params.VariabilityIndex = pgtype.Float8{Float64: metrics.VariabilityIndex, Valid: metrics.VariabilityIndex > 0}
+
+	if ftp > 0 {
+		intensityFactor, tss := metrics.intensity(ftp)
+		params.IntensityFactor = pgtype.Float8{Float64: intensityFactor, Valid: true}
+		params.TrainingStressScore = pgtype.Float8{Float64: tss, Valid: true}
+		params.Ftp = pgtype.Int4{Int32: ftp, Valid: true}
+	}
 }
 
 func (s *activityService) processRecords(records []*fit.RecordMsg) ([]db.CreateRecordsParams, *ActivityStats, error) {
 
 	var distance float64
-	var totalElevationChange uint32
-	var previousElevation uint16
-	var previousElevationSet bool
+	var previousPosition *fit.RecordMsg
 	var numberOfSpeed float64
 	var sumOfSpeed float64
 	var maxSpeed uint16
@@ -292,73 +805,111 @@ func (s *activityService) processRecords(records []*fit.RecordMsg) ([]db.CreateR
 	var stats ActivityStats
 
 	for index, record := range records {
-		if !(record.PositionLat.Invalid() && record.PositionLong.Invalid()) {
-
-			newRecord := db.CreateRecordsParams{
-				TimeStamp:        pgtype.Timestamptz{Time: record.Timestamp, Valid: true},
-				Position:         pgtype.Point{P: pgtype.Vec2{X: float64(record.PositionLong.Degrees()), Y: float64(record.PositionLat.Degrees())}, Valid: true},
-				Altitude:         pgtype.Int4{Int32: int32(record.Altitude), Valid: true},
-				HeartRate:        pgtype.Int2{Int16: int16(record.HeartRate), Valid: true},
-				Cadence:          pgtype.Int2{Int16: int16(record.Cadence), Valid: true},
-				Distance:         pgtype.Int4{Int32: int32(record.Distance), Valid: true},
-				Speed:            pgtype.Int4{Int32: int32(record.Speed), Valid: true},
-				Temperature:      pgtype.Int2{Int16: int16(record.Temperature), Valid: true},
-				GpsAccuracy:      pgtype.Int2{Int16: int16(record.GpsAccuracy), Valid: true},
-				EnhancedAltitude: pgtype.Int4{Int32: int32(record.EnhancedAltitude), Valid: true},
-				ActivityID:       pgtype.Int4{Int32: int32(0)},
-			}
-
-			recordEntities = append(recordEntities, newRecord)
-
-			if index != 0 {
-
-				speed := record.Speed
-				numberOfSpeed = numberOfSpeed + 1
-				sumOfSpeed = sumOfSpeed + float64(speed)
+		hasPosition := !(record.PositionLat.Invalid() && record.PositionLong.Invalid())
+
+		newRecord := db.CreateRecordsParams{
+			TimeStamp:        pgtype.Timestamptz{Time: record.Timestamp, Valid: true},
+			Position:         pgtype.Point{Valid: hasPosition},
+			Altitude:         pgtype.Int4{Int32: int32(record.Altitude), Valid: true},
+			HeartRate:        pgtype.Int2{Int16: int16(record.HeartRate), Valid: record.HeartRate != 0xFF},
+			Cadence:          pgtype.Int2{Int16: int16(record.Cadence), Valid: record.Cadence != 0xFF},
+			Distance:         pgtype.Int4{Int32: int32(record.Distance), Valid: record.Distance != 0xFFFFFFFF},
+			Speed:            pgtype.Int4{Int32: int32(record.Speed), Valid: record.Speed != 0xFFFF},
+			Temperature:      pgtype.Int2{Int16: int16(record.Temperature), Valid: record.Temperature != 0x7F},
+			GpsAccuracy:      pgtype.Int2{Int16: int16(record.GpsAccuracy), Valid: record.GpsAccuracy != 0xFF},
+			EnhancedAltitude: pgtype.Int4{Int32: int32(record.EnhancedAltitude), Valid: true},
+			Power:            pgtype.Int4{Int32: int32(record.Power), Valid: record.Power != 0xFFFF},
+			ActivityID:       pgtype.Int4{Int32: int32(0)},
+		}
+		if hasPosition {
+			newRecord.Position.P = pgtype.Vec2{X: float64(record.PositionLong.Degrees()), Y: float64(record.PositionLat.Degrees())}
+		}
 
-				if maxSpeed < speed {
-					maxSpeed = speed
-				}
+		recordEntities = append(recordEntities, newRecord)
 
+		if hasPosition {
+			if previousPosition != nil {
+				lat1 := previousPosition.PositionLat.Degrees()
+				long1 := previousPosition.PositionLong.Degrees()
 				lat2 := record.PositionLat.Degrees()
 				long2 := record.PositionLong.Degrees()
-				lat1 := records[index-1].PositionLat.Degrees()
-				long1 := records[index-1].PositionLong.Degrees()
 
 				if !math.IsNaN(lat1) && !math.IsNaN(long1) && !math.IsNaN(lat2) && !math.IsNaN(long2) {
 					distance += utils.Haversine(lat1, long1, lat2, long2)
 				}
+			}
+			previousPosition = record
+		}
 
-				if previousElevationSet {
-					currentElevation := uint32(record.Altitude)
-					previousElevationUint32 := uint32(previousElevation)
-					if currentElevation > previousElevationUint32 {
-						elevationChange := currentElevation - previousElevationUint32
-						totalElevationChange += elevationChange
-					}
-				}
+		if index != 0 {
 
-				previousElevation = record.Altitude
-				previousElevationSet = true
-			} else if !previousElevationSet {
-				previousElevation = record.Altitude
-				previousElevationSet = true
+			// Speeds no bike reaches are glitches and would set the max speed.
+			speed := record.Speed
+			if speed != 0xFFFF && float64(speed)/1000 <= maxTrackSpeed {
+				numberOfSpeed = numberOfSpeed + 1
+				sumOfSpeed = sumOfSpeed + float64(speed)
+
+				if maxSpeed < speed {
+					maxSpeed = speed
+				}
 			}
 		}
 	}
 
-	avgSpeedMs := sumOfSpeed / numberOfSpeed
+	if len(recordEntities) == 0 {
+		return nil, nil, ErrNoRecords
+	}
+
+	// Trainer rides carry no positions; their distance comes from the
+	// distance (or speed) reported by the trainer or speed sensor.
+	if previousPosition == nil {
+		stats.Indoor = true
+		distance = sensorDistance(records)
+	}
+
+	var avgSpeedMs float64
+	if numberOfSpeed > 0 {
+		avgSpeedMs = sumOfSpeed / numberOfSpeed
+	}
 	avgSpeedKmH := avgSpeedMs * 3.60 / 1000.00
 	maxSpeedKmH := float64(maxSpeed) * 3.60 / 1000.00
 
 	stats.AvgSpeed = decimal.NewFromFloat(avgSpeedKmH)
 	stats.MaxSpeed = decimal.NewFromFloat(maxSpeedKmH)
 	stats.Distance = decimal.NewFromFloat(distance)
+	stats.Elevation = trackElevation(records)
 
 	return recordEntities, &stats, nil
 }
 
-func (s *activityService) GetActivityStats(ctx context.Context, userId string) (*db.GetActivityStatsRow, error) {
+// sensorDistance returns the distance in kilometers covered by records without
+// positions, from the last recorded distance or else by integrating speed.
+func sensorDistance(records []*fit.RecordMsg) float64 {
+	var lastDistance uint32
+	var hasDistance bool
+	for _, record := range records {
+		if record.Distance != 0xFFFFFFFF && record.Distance >= lastDistance {
+			lastDistance = record.Distance
+			hasDistance = true
+		}
+	}
+	if hasDistance {
+		return float64(lastDistance) / 100000
+	}
+
+	var meters float64
+	for i := 1; i < len(records); i++ {
+		speed := records[i].Speed
+		elapsed := records[i].Timestamp.Sub(records[i-1].Timestamp).Seconds()
+		if speed == 0xFFFF || elapsed <= 0 {
+			continue
+		}
+		meters += float64(speed) / 1000 * elapsed
+	}
+	return meters / 1000
+}
+
+func (s *activityService) GetActivityStats(ctx context.Context, userId string) (*UserStats, error) {
 	stats, err := s.activityRepo.GetActivityStats(ctx, userId)
 
 	if err != nil {
@@ -366,7 +917,20 @@ func (s *activityService) GetActivityStats(ctx context.Context, userId string) (
 		return nil, err
 	}
 
-	return &stats, nil
+	firstWeek := startOfWeek(time.Now()).AddDate(0, 0, -7*(statsHeartRateZoneWeeks-1))
+	zones, err := s.activityRepo.GetWeeklyHeartRateZones(ctx, db.GetWeeklyHeartRateZonesParams{
+		UserID: userId,
+		Since:  pgtype.Timestamptz{Time: firstWeek, Valid: true},
+	})
+	if err != nil {
+		slog.Error("failed to get weekly heart rate zones", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	return &UserStats{
+		GetActivityStatsRow: stats,
+		HeartRateZoneWeeks:  weeklyHeartRateZones(zones, firstWeek, statsHeartRateZoneWeeks),
+	}, nil
 
 }
 
@@ -383,17 +947,52 @@ func convertActivityEntityToDomainModel(activityEntity *db.ActivityWithRecordsVi
 		RideType:        activityEntity.RideType,
 		ElapsedTime:     activityEntity.ElapsedTimeChar,
 		TotalTime:       activityEntity.TotalTimeChar,
+		MovingTime:      activityEntity.MovingTimeChar,
 		AvgHeartRate:    avgHeartRate,
 		MaxHeartRate:    maxHeartRate,
 		AvgCadence:      avgCadence,
 		MaxCadence:      maxCadence,
 		ElapsedDuration: activityEntity.ElapsedTime,
 		TotalDuration:   activityEntity.TotalTime,
+		MovingDuration:  activityEntity.MovingTime,
 		Records:         convertRecords(activityEntity.Records),
 	}
+	activity.Description = activityEntity.Description
+	activity.AvgPower = optionalInt4(activityEntity.AvgPower)
+	activity.MaxPower = optionalInt4(activityEntity.MaxPower)
+	activity.NormalizedPower = optionalInt4(activityEntity.NormalizedPower)
+	activity.VariabilityIndex = optionalFloat8(activityEntity.VariabilityIndex)
+	activity.IntensityFactor = optionalFloat8(activityEntity.IntensityFactor)
+	activity.TrainingStressScore = optionalFloat8(activityEntity.TrainingStressScore)
+	activity.FTP = optionalInt4(activityEntity.Ftp)
+	activity.Indoor = activityEntity.Indoor
+	activity.ElevationGain = optionalFloat8(activityEntity.ElevationGain)
+	activity.ElevationLoss = optionalFloat8(activityEntity.ElevationLoss)
+	activity.MinAltitude = optionalFloat8(activityEntity.MinAltitude)
+	activity.MaxAltitude = optionalFloat8(activityEntity.MaxAltitude)
+	activity.CorrectedPoints = activityEntity.CorrectedPoints
+	activity.FilledPoints = activityEntity.FilledPoints
+	activity.RideTypeDetected = activityEntity.RideTypeSource == RideTypeDetected
+	if activityEntity.DuplicateOf.Valid {
+		activity.DuplicateOf = &activityEntity.DuplicateOf.Int32
+	}
 	return activity
 }
 
+func optionalInt4(value pgtype.Int4) *int32 {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Int32
+}
+
+func optionalFloat8(value pgtype.Float8) *float64 {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Float64
+}
+
 func calculateAggregateMetrics(records []db.Record) (avgHeartRate, maxHeartRate, avgCadence, maxCadence *float64) {
 	var (
 		heartRateSum  float64
@@ -458,8 +1057,12 @@ func convertActivitySummaries(activityEntities []db.GetActivitiesRow) []Activity
 			Distance:        activity.Distance.InexactFloat64(),
 			ElapsedTime:     activity.ElapsedTimeChar,
 			TotalTime:       activity.TotalTimeChar,
+			MovingTime:      activity.MovingTimeChar,
+			Indoor:          activity.Indoor,
+			ElevationGain:   optionalFloat8(activity.ElevationGain),
 			ElapsedDuration: activity.ElapsedTime,
 			TotalDuration:   activity.TotalTime,
+			MovingDuration:  activity.MovingTime,
 		}
 	}
 	return summaries
@@ -489,10 +1092,16 @@ func convertRecords(recordEntities []db.Record) []Record {
 			HeartRate: heartRate,
 			Distance:  record.Distance.Int32,
 			Cadence:   cadence,
-			Coordinates: Point{
+			Power:     record.Power.Int32,
+
+			hasCadence: record.Cadence.Valid && record.Cadence.Int16 != 0xFF,
+			hasPower:   record.Power.Valid && record.Power.Int32 != 0xFFFF,
+		}
+		if record.Position.Valid {
+			records[i].Coordinates = &Point{
 				X: record.Position.P.X,
 				Y: record.Position.P.Y,
-			},
+			}
 		}
 	}
 	return records
@@ -513,6 +1122,21 @@ type ActivityStats struct {
 	ElapsedTime pgtype.Time
 	AvgSpeed    decimal.Decimal
 	MaxSpeed    decimal.Decimal
+	// MovingTime excludes pauses; once set, AvgSpeed is based on it.
+	MovingTime time.Duration
+	// Indoor is set when no record has a position, e.g. for trainer rides.
+	Indoor bool
+	// Elevation is derived from the altitude of the records.
+	Elevation elevationProfile
+}
+
+// setMovingTime stores the moving time and bases the average speed on it,
+// keeping the per-record average when no moving time is known.
+func (stats *ActivityStats) setMovingTime(moving time.Duration) {
+	stats.MovingTime = moving
+	if moving > 0 && stats.Distance.IsPositive() {
+		stats.AvgSpeed = stats.Distance.Div(decimal.NewFromFloat(moving.Hours()))
+	}
 }
 
 func getActivityName(t time.Time) string {
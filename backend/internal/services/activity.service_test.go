@@ -1,16 +1,24 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/notaduck/backend/internal/db"
 	"github.com/notaduck/backend/internal/repositories"
 	"github.com/notaduck/backend/misc/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	fit "github.com/tormoder/fit"
@@ -19,6 +27,7 @@ import (
 type ActivityServiceTestSuite struct {
 	suite.Suite
 	pgContainer *testhelpers.PostgresContainer
+	pool        *pgxpool.Pool
 	queries     *db.Queries
 	ctx         context.Context
 }
@@ -42,6 +51,7 @@ func (s *ActivityServiceTestSuite) SetupSuite() {
 
 	queries := db.New(pool)
 
+	s.pool = pool
 	s.queries = queries
 
 }
@@ -61,7 +71,7 @@ func (s *ActivityServiceTestSuite) TestActivityServiceReturnsAnActivity() {
 	recordRepo := repositories.NewRecordRepository(s.queries)
 	activityRepo := repositories.NewActivityRepository(s.queries)
 
-	activityService := NewActivityService(activityRepo, recordRepo)
+	activityService := NewActivityService(activityRepo, recordRepo, repositories.NewUnitOfWork(s.pool, s.queries))
 
 	activity, err := activityService.GetSingleActivityById(s.ctx, 1, userId)
 
@@ -142,7 +152,7 @@ func (s *ActivityServiceTestSuite) TestProcessRecords() {
 	// Assuming you have a method to create an instance of activityService
 	recordRepo := repositories.NewRecordRepository(s.queries)
 	activityRepo := repositories.NewActivityRepository(s.queries)
-	activityService := NewActivityService(activityRepo, recordRepo)
+	activityService := NewActivityService(activityRepo, recordRepo, repositories.NewUnitOfWork(s.pool, s.queries))
 	_ = activityService
 	_ = records
 	// activityService.
@@ -159,6 +169,275 @@ func (s *ActivityServiceTestSuite) TestProcessRecords() {
 	// s.Greater(stats.Distance, 0.0, "Distance should be calculated and greater than 0.")
 }
 
+func TestProcessRecordsStoresUnsetValuesAsNull(t *testing.T) {
+	start := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	records := northboundRecords(start, 2)
+	records[0].HeartRate, records[0].Cadence, records[0].Temperature, records[0].GpsAccuracy = 140, 85, 21, 4
+	records[1].HeartRate, records[1].Cadence, records[1].Temperature, records[1].GpsAccuracy = 0xFF, 0xFF, 0x7F, 0xFF
+
+	rows, _, err := (&activityService{}).processRecords(records)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, pgtype.Int2{Int16: 140, Valid: true}, rows[0].HeartRate)
+	assert.Equal(t, pgtype.Int2{Int16: 85, Valid: true}, rows[0].Cadence)
+	assert.Equal(t, pgtype.Int2{Int16: 21, Valid: true}, rows[0].Temperature)
+	assert.Equal(t, pgtype.Int2{Int16: 4, Valid: true}, rows[0].GpsAccuracy)
+
+	assert.False(t, rows[1].HeartRate.Valid)
+	assert.False(t, rows[1].Cadence.Valid)
+	assert.False(t, rows[1].Temperature.Valid)
+	assert.False(t, rows[1].GpsAccuracy.Valid)
+}
+
+// failingRecordsUnitOfWork runs the real transaction but fails every record
+// insert, simulating an error halfway through ingesting a file.
+type failingRecordsUnitOfWork struct {
+	repositories.UnitOfWork
+}
+
+type failingRecordRepository struct{}
+
+func (failingRecordRepository) CreateRecords(ctx context.Context, params []db.CreateRecordsParams) (int64, error) {
+	return 0, errors.New("injected record failure")
+}
+
+func (u failingRecordsUnitOfWork) Do(ctx context.Context, fn func(repos repositories.Repositories) error) error {
+	return u.UnitOfWork.Do(ctx, func(repos repositories.Repositories) error {
+		repos.Records = failingRecordRepository{}
+		return fn(repos)
+	})
+}
+
+func (s *ActivityServiceTestSuite) TestActivitiesOfOtherUsersAreNotFound() {
+	otherUserId := "7d3b1c3e-5f0a-4a8e-9b1d-2c6f4e8a9b10"
+
+	activityService := NewActivityService(
+		repositories.NewActivityRepository(s.queries),
+		repositories.NewRecordRepository(s.queries),
+		repositories.NewUnitOfWork(s.pool, s.queries),
+	)
+
+	_, err := activityService.GetSingleActivityById(s.ctx, 1, otherUserId)
+	s.ErrorIs(err, pgx.ErrNoRows)
+
+	_, err = activityService.GetActivityAtResolution(s.ctx, 1, otherUserId, ActivityResolution{Tolerance: 10})
+	s.ErrorIs(err, pgx.ErrNoRows)
+
+	laps, err := activityService.GetActivityLaps(s.ctx, 1, otherUserId)
+	s.NoError(err)
+	s.Empty(laps)
+}
+
+func (s *ActivityServiceTestSuite) countActivities(userId string) int {
+	var count int
+	err := s.pool.QueryRow(s.ctx, "SELECT COUNT(*) FROM activities WHERE user_id = $1", userId).Scan(&count)
+	s.Require().NoError(err)
+	return count
+}
+
+func (s *ActivityServiceTestSuite) TestCreateActivityFromBytesIsAtomic() {
+	userId := "04961e85-8280-4fb3-80d4-a5072bcec9b1"
+	file := ActivityFilePayload{Filename: "ride.gpx", Data: []byte(sampleGPX)}
+
+	activityRepo := repositories.NewActivityRepository(s.queries)
+	recordRepo := repositories.NewRecordRepository(s.queries)
+	unitOfWork := repositories.NewUnitOfWork(s.pool, s.queries)
+
+	before := s.countActivities(userId)
+
+	failing := NewActivityService(activityRepo, recordRepo, failingRecordsUnitOfWork{unitOfWork})
+	_, err := failing.CreateActivityFromBytes(s.ctx, file, userId)
+	s.ErrorContains(err, "injected record failure")
+	s.Equal(before, s.countActivities(userId), "activity row must be rolled back with its records")
+
+	activityService := NewActivityService(activityRepo, recordRepo, unitOfWork)
+	activity, err := activityService.CreateActivityFromBytes(s.ctx, file, userId)
+	s.Require().NoError(err)
+	s.Len(activity.Records, 2)
+	s.Equal(before+1, s.countActivities(userId))
+}
+
+func (s *ActivityServiceTestSuite) TestCreateActivityFromBytesDetectsDuplicates() {
+	userId := "04961e85-8280-4fb3-80d4-a5072bcec9b1"
+	ride := strings.ReplaceAll(sampleGPX, "2024-05-01", "2024-06-02")
+	// Same ride recorded by another device: different bytes, same time and place.
+	otherDevice := strings.ReplaceAll(ride, "<ele>12.4</ele>", "<ele>12.9</ele>")
+
+	activityRepo := repositories.NewActivityRepository(s.queries)
+	recordRepo := repositories.NewRecordRepository(s.queries)
+	unitOfWork := repositories.NewUnitOfWork(s.pool, s.queries)
+
+	skipping := NewActivityService(activityRepo, recordRepo, unitOfWork)
+	original, err := skipping.CreateActivityFromBytes(s.ctx, ActivityFilePayload{Filename: "ride.gpx", Data: []byte(ride)}, userId)
+	s.Require().NoError(err)
+
+	_, err = skipping.CreateActivityFromBytes(s.ctx, ActivityFilePayload{Filename: "ride.gpx", Data: []byte(ride)}, userId)
+	var duplicate *DuplicateActivityError
+	s.Require().ErrorAs(err, &duplicate)
+	s.True(duplicate.Exact)
+	s.True(duplicate.Skipped)
+	s.Equal(original.ID, duplicate.ExistingID)
+
+	rejecting := NewActivityService(activityRepo, recordRepo, unitOfWork, WithDuplicatePolicy(DuplicatePolicyReject))
+	_, err = rejecting.CreateActivityFromBytes(s.ctx, ActivityFilePayload{Filename: "watch.gpx", Data: []byte(otherDevice)}, userId)
+	s.Require().ErrorAs(err, &duplicate)
+	s.False(duplicate.Exact)
+	s.False(duplicate.Skipped)
+	s.Equal(FailureDuplicate, FailureReasonFor(err))
+
+	flagging := NewActivityService(activityRepo, recordRepo, unitOfWork, WithDuplicatePolicy(DuplicatePolicyFlag))
+	flagged, err := flagging.CreateActivityFromBytes(s.ctx, ActivityFilePayload{Filename: "watch.gpx", Data: []byte(otherDevice)}, userId)
+	s.Require().NoError(err)
+	s.Require().NotNil(flagged.DuplicateOf)
+	s.Equal(original.ID, *flagged.DuplicateOf)
+}
+
+func (s *ActivityServiceTestSuite) TestImportArchiveCarriesOverManifest() {
+	userId := "04961e85-8280-4fb3-80d4-a5072bcec9b1"
+	ride := strings.ReplaceAll(sampleGPX, "2024-05-01", "2024-07-03")
+	archive := buildZip(s.T(), map[string][]byte{
+		"activities.csv":      []byte("Activity ID,Activity Name,Activity Type,Activity Description,Filename\n1,Club ride,Mountain Bike Ride,Muddy,activities/1.gpx.gz\n"),
+		"activities/1.gpx.gz": gzipBytes(s.T(), []byte(ride)),
+		"activities/2.tcx":    []byte("not xml"),
+	})
+
+	activityService := NewActivityService(
+		repositories.NewActivityRepository(s.queries),
+		repositories.NewRecordRepository(s.queries),
+		repositories.NewUnitOfWork(s.pool, s.queries),
+	)
+
+	var progress []ArchiveProgress
+	result, err := activityService.ImportArchive(s.ctx, bytes.NewReader(archive), int64(len(archive)), userId, func(p ArchiveProgress) error {
+		progress = append(progress, p)
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Require().Len(progress, 2)
+	s.Equal(2, progress[1].Processed)
+	s.Equal(2, progress[1].Total)
+	s.Equal(1, result.Failed())
+
+	activities := result.Activities()
+	s.Require().Len(activities, 1)
+	s.Equal("Club ride", activities[0].ActivityName)
+	s.Equal("mtb", activities[0].RideType)
+	s.Equal("Muddy", activities[0].Description)
+}
+
+func (s *ActivityServiceTestSuite) TestReprocessActivitiesKeepsUserEdits() {
+	userId := "04961e85-8280-4fb3-80d4-a5072bcec9b1"
+	ride := strings.ReplaceAll(sampleGPX, "2024-05-01", "2024-08-04")
+
+	store, err := NewLocalFileStore(s.T().TempDir())
+	s.Require().NoError(err)
+
+	activityRepo := repositories.NewActivityRepository(s.queries)
+	activityService := NewActivityService(
+		activityRepo,
+		repositories.NewRecordRepository(s.queries),
+		repositories.NewUnitOfWork(s.pool, s.queries),
+		WithFileStore(store),
+	)
+
+	created, err := activityService.CreateActivityFromBytes(s.ctx, ActivityFilePayload{Filename: "ride.gpx", Data: []byte(ride)}, userId)
+	s.Require().NoError(err)
+
+	_, err = activityService.UpdateActivity(s.ctx, db.UpdateActivityParams{
+		ID:           created.ID,
+		UserID:       userId,
+		ActivityName: pgtype.Text{String: "Renamed ride", Valid: true},
+		RideType:     pgtype.Text{String: "gravel", Valid: true},
+	})
+	s.Require().NoError(err)
+
+	// Simulate data derived by an older, buggy version of the pipeline.
+	_, err = s.pool.Exec(s.ctx, "UPDATE activities SET distance = 999 WHERE id = $1", created.ID)
+	s.Require().NoError(err)
+
+	result, err := activityService.ReprocessActivities(s.ctx, ReprocessFilter{ActivityID: created.ID}, nil)
+	s.Require().NoError(err)
+	s.Require().Len(result.Files, 1)
+	s.Zero(result.Failed())
+
+	reprocessed := result.Files[0].Activity
+	s.Require().NotNil(reprocessed)
+	s.Equal(created.ID, reprocessed.ID)
+	s.Equal(created.Distance, reprocessed.Distance)
+	s.Equal("Renamed ride", reprocessed.ActivityName)
+	s.Equal("gravel", reprocessed.RideType)
+	s.Len(reprocessed.Records, len(created.Records))
+}
+
+func (s *ActivityServiceTestSuite) dailyLoad(userId, day string) float64 {
+	var load float64
+	err := s.pool.QueryRow(s.ctx, "SELECT load FROM daily_training_load WHERE user_id = $1 AND day = $2::date", userId, day).Scan(&load)
+	s.Require().NoError(err)
+	return load
+}
+
+func (s *ActivityServiceTestSuite) TestReprocessActivitiesRefreshesTheStoredDay() {
+	userId := "04961e85-8280-4fb3-80d4-a5072bcec9b1"
+	ride := strings.ReplaceAll(sampleGPX, "2024-05-01", "2030-01-10")
+
+	store, err := NewLocalFileStore(s.T().TempDir())
+	s.Require().NoError(err)
+
+	activityRepo := repositories.NewActivityRepository(s.queries)
+	activityService := NewActivityService(
+		activityRepo,
+		repositories.NewRecordRepository(s.queries),
+		repositories.NewUnitOfWork(s.pool, s.queries),
+		WithFileStore(store),
+	)
+
+	created, err := activityService.CreateActivityFromBytes(s.ctx, ActivityFilePayload{Filename: "ride.gpx", Data: []byte(ride)}, userId)
+	s.Require().NoError(err)
+
+	// Simulate a start time an older pipeline got wrong by two days.
+	_, err = s.pool.Exec(s.ctx, "UPDATE activities SET date_of_activity = '2030-01-08T08:00:00Z', training_stress_score = 50 WHERE id = $1", created.ID)
+	s.Require().NoError(err)
+	s.Require().NoError(refreshTrainingLoad(s.ctx, activityRepo, userId, time.Date(2030, 1, 8, 0, 0, 0, 0, time.UTC)))
+	s.Require().InDelta(50, s.dailyLoad(userId, "2030-01-08"), 1e-9)
+
+	result, err := activityService.ReprocessActivities(s.ctx, ReprocessFilter{ActivityID: created.ID}, nil)
+	s.Require().NoError(err)
+	s.Require().Zero(result.Failed())
+
+	s.Zero(s.dailyLoad(userId, "2030-01-08"), "the day the ride moved away from is rebuilt")
+}
+
+func (s *ActivityServiceTestSuite) TestDeleteActivityRefreshesTrainingLoad() {
+	userId := "04961e85-8280-4fb3-80d4-a5072bcec9b1"
+	ride := strings.ReplaceAll(sampleGPX, "2024-05-01", "2031-03-05")
+
+	activityRepo := repositories.NewActivityRepository(s.queries)
+	activityService := NewActivityService(
+		activityRepo,
+		repositories.NewRecordRepository(s.queries),
+		repositories.NewUnitOfWork(s.pool, s.queries),
+	)
+
+	created, err := activityService.CreateActivityFromBytes(s.ctx, ActivityFilePayload{Filename: "ride.gpx", Data: []byte(ride)}, userId)
+	s.Require().NoError(err)
+
+	_, err = s.pool.Exec(s.ctx, "UPDATE activities SET training_stress_score = 80 WHERE id = $1", created.ID)
+	s.Require().NoError(err)
+	s.Require().NoError(refreshTrainingLoad(s.ctx, activityRepo, userId, time.Date(2031, 3, 5, 0, 0, 0, 0, time.UTC)))
+	s.Require().InDelta(80, s.dailyLoad(userId, "2031-03-05"), 1e-9)
+
+	s.ErrorIs(activityService.DeleteActivity(s.ctx, created.ID, "7d3b1c3e-5f0a-4a8e-9b1d-2c6f4e8a9b10"), ErrActivityNotFound)
+
+	s.Require().NoError(activityService.DeleteActivity(s.ctx, created.ID, userId))
+	s.ErrorIs(activityService.DeleteActivity(s.ctx, created.ID, userId), ErrActivityNotFound)
+
+	days, err := activityService.GetTrainingLoad(s.ctx, userId, time.Date(2031, 3, 5, 0, 0, 0, 0, time.UTC), time.Date(2031, 3, 5, 0, 0, 0, 0, time.UTC))
+	s.Require().NoError(err)
+	s.Require().Len(days, 1)
+	s.Zero(days[0].Load, "the deleted ride no longer counts")
+}
+
 func TestActivityServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(ActivityServiceTestSuite))
 }
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/tormoder/fit"
+
+	"github.com/notaduck/backend/internal/db"
+	"github.com/notaduck/backend/utils"
+)
+
+// Session is a part of an activity recorded as its own FIT session, e.g. the
+// legs of a brick workout or the two halves of a ride split by a café stop.
+type Session struct {
+	Index           int           `json:"index"`
+	Sport           string        `json:"sport"`
+	SubSport        string        `json:"subSport"`
+	StartTime       time.Time     `json:"startTime"`
+	EndTime         time.Time     `json:"endTime"`
+	Distance        float64       `json:"distance"`
+	ElapsedTime     string        `json:"elapsedTime"`
+	TimerTime       string        `json:"timerTime"`
+	ElapsedDuration time.Duration `json:"-"`
+	TimerDuration   time.Duration `json:"-"`
+}
+
+// fitSessions converts the session messages of a FIT activity into session
+// rows ordered by start time. Activity files without a session are rejected
+// as there is nothing to take the activity totals from.
+func fitSessions(sessions []*fit.SessionMsg) ([]db.CreateActivitySessionsParams, error) {
+	if len(sessions) == 0 {
+		return nil, fmt.Errorf("%w: fit file contains no session messages", ErrCorruptFile)
+	}
+
+	sorted := make([]*fit.SessionMsg, len(sessions))
+	copy(sorted, sessions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartTime.Before(sorted[j].StartTime)
+	})
+
+	rows := make([]db.CreateActivitySessionsParams, len(sorted))
+	for i, session := range sorted {
+		elapsed := fitDuration(session.TotalElapsedTime)
+		timer := fitDuration(session.TotalTimerTime)
+
+		end := session.Timestamp
+		if end.IsZero() || end.Before(session.StartTime) {
+			end = session.StartTime.Add(elapsed)
+		}
+
+		distance := decimal.Zero
+		if session.TotalDistance != 0xFFFFFFFF {
+			distance = decimal.NewFromFloat(float64(session.TotalDistance) / 100000)
+		}
+
+		rows[i] = db.CreateActivitySessionsParams{
+			SessionIndex:     int16(i),
+			Sport:            fitEnumName(session.Sport),
+			SubSport:         fitEnumName(session.SubSport),
+			StartTime:        pgtype.Timestamptz{Time: session.StartTime, Valid: true},
+			EndTime:          pgtype.Timestamptz{Time: end, Valid: true},
+			TotalElapsedTime: elapsed,
+			TotalTimerTime:   timer,
+			Distance:         distance,
+		}
+	}
+
+	return rows, nil
+}
+
+// sessionTotals returns the elapsed time from the start of the first session
+// to the end of the last one, so breaks between sessions count as elapsed,
+// and the summed timer time of all sessions.
+func sessionTotals(sessions []db.CreateActivitySessionsParams) (elapsed, timer time.Duration) {
+	for _, session := range sessions {
+		timer += session.TotalTimerTime
+	}
+
+	first, last := sessions[0], sessions[len(sessions)-1]
+	elapsed = last.EndTime.Time.Sub(first.StartTime.Time)
+	if elapsed <= 0 {
+		for _, session := range sessions {
+			elapsed += session.TotalElapsedTime
+		}
+	}
+
+	return elapsed, timer
+}
+
+// indoorSubSports are the FIT sub-sports of rides recorded indoors. Virtual
+// rides have positions from the virtual world but are still indoor rides.
+var indoorSubSports = map[string]struct{}{
+	"indoor_cycling":   {},
+	"virtual_activity": {},
+	"spin":             {},
+}
+
+func indoorSessions(sessions []db.CreateActivitySessionsParams) bool {
+	for _, session := range sessions {
+		if _, ok := indoorSubSports[session.SubSport]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// fitDuration converts a FIT time field (milliseconds) into a duration.
+func fitDuration(ms uint32) time.Duration {
+	if ms == 0xFFFFFFFF {
+		return 0
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// fitEnumName turns the CamelCase name of a FIT enum value into snake_case,
+// e.g. IndoorCycling becomes indoor_cycling. Invalid and unknown values,
+// which print as Invalid or Type(123), are empty.
+func fitEnumName(value fmt.Stringer) string {
+	name := value.String()
+	if name == "Invalid" || strings.Contains(name, "(") {
+		return ""
+	}
+
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) && i > 0 {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+func convertSessions(rows []db.GetActivitySessionsRow) []Session {
+	sessions := make([]Session, len(rows))
+	for i, row := range rows {
+		sessions[i] = Session{
+			Index:           int(row.SessionIndex),
+			Sport:           row.Sport,
+			SubSport:        row.SubSport,
+			StartTime:       row.StartTime.Time,
+			EndTime:         row.EndTime.Time,
+			Distance:        row.Distance.InexactFloat64(),
+			ElapsedTime:     utils.FormatDuration(row.TotalElapsedTime),
+			TimerTime:       utils.FormatDuration(row.TotalTimerTime),
+			ElapsedDuration: row.TotalElapsedTime,
+			TimerDuration:   row.TotalTimerTime,
+		}
+	}
+	return sessions
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tormoder/fit"
+)
+
+func TestFitSessionsRejectsFileWithoutSessions(t *testing.T) {
+	_, err := fitSessions(nil)
+	assert.ErrorIs(t, err, ErrCorruptFile)
+}
+
+func TestFitSessionsSpansAllSessions(t *testing.T) {
+	start := time.Date(2024, 5, 1, 6, 0, 0, 0, time.UTC)
+
+	second := fit.NewSessionMsg()
+	second.StartTime = start.Add(90 * time.Minute)
+	second.Timestamp = start.Add(150 * time.Minute)
+	second.Sport = fit.SportCycling
+	second.SubSport = fit.SubSportIndoorCycling
+	second.TotalElapsedTime = 3600000
+	second.TotalTimerTime = 3000000
+	second.TotalDistance = 3000000
+
+	first := fit.NewSessionMsg()
+	first.StartTime = start
+	first.Sport = fit.SportCycling
+	first.SubSport = fit.SubSportRoad
+	first.TotalElapsedTime = 3600000
+	first.TotalTimerTime = 3300000
+
+	sessions, err := fitSessions([]*fit.SessionMsg{second, first})
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+
+	assert.Equal(t, int16(0), sessions[0].SessionIndex)
+	assert.Equal(t, "road", sessions[0].SubSport)
+	assert.Equal(t, start.Add(time.Hour), sessions[0].EndTime.Time)
+	assert.True(t, sessions[0].Distance.IsZero())
+
+	assert.Equal(t, "cycling", sessions[1].Sport)
+	assert.Equal(t, "indoor_cycling", sessions[1].SubSport)
+	assert.Equal(t, 50*time.Minute, sessions[1].TotalTimerTime)
+	assert.Equal(t, 30.0, sessions[1].Distance.InexactFloat64())
+
+	elapsed, timer := sessionTotals(sessions)
+	assert.Equal(t, 150*time.Minute, elapsed)
+	assert.Equal(t, 105*time.Minute, timer)
+}
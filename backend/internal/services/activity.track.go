@@ -0,0 +1,181 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"github.com/tormoder/fit"
+
+	"github.com/notaduck/backend/utils"
+)
+
+const (
+	// maxTrackSpeed is the fastest plausible speed between two GPS points in
+	// m/s, about 126 km/h. Faster jumps are position spikes.
+	maxTrackSpeed = 35.0
+	// maxTrackAcceleration is the largest plausible change in the speed
+	// between GPS points in m/s².
+	maxTrackAcceleration = 10.0
+	// maxGPSAccuracy is the worst accuracy in m a device may report for a
+	// point to be kept.
+	maxGPSAccuracy = 50
+	// maxTrackInterpolationGap is the longest time between two good points
+	// that the points in between are interpolated over. Across longer gaps
+	// bad points are dropped.
+	maxTrackInterpolationGap = 30 * time.Second
+	// maxRejectedTrackPoints is the longest run of rejected points after
+	// which the track is taken to have really moved, e.g. when the first fix
+	// was the bad one.
+	maxRejectedTrackPoints = 10
+)
+
+// trackCorrections counts the points cleanTrack changed.
+type trackCorrections struct {
+	// Spikes is the number of rejected points, interpolated or dropped.
+	Spikes int
+	// Filled is the number of points without a position that were
+	// interpolated.
+	Filled int
+}
+
+// cleanTrack removes GPS spikes from the records before any stats are
+// derived from their positions. A point is rejected when the device reports
+// a poor accuracy for it, or when reaching it from the last good point takes
+// an impossible speed or acceleration. Rejected points, and points without a
+// position, between two good points at most maxTrackInterpolationGap apart
+// are interpolated from them; other rejected points lose their position.
+func cleanTrack(records []*fit.RecordMsg) trackCorrections {
+	rejected := rejectTrackPoints(records)
+
+	var corrections trackCorrections
+	previous := -1
+	for i, record := range records {
+		if rejected[i] || !hasPosition(record) {
+			continue
+		}
+		if previous >= 0 && i-previous > 1 {
+			fillTrackGap(records[previous:i+1], rejected[previous:i+1], &corrections)
+		}
+		previous = i
+	}
+
+	for i, record := range records {
+		if rejected[i] {
+			record.PositionLat = fit.NewLatitudeInvalid()
+			record.PositionLong = fit.NewLongitudeInvalid()
+			corrections.Spikes++
+		}
+	}
+
+	return corrections
+}
+
+// rejectTrackPoints flags the points with a poor accuracy or an impossible
+// jump from the last good point.
+func rejectTrackPoints(records []*fit.RecordMsg) []bool {
+	rejected := make([]bool, len(records))
+
+	var anchor *fit.RecordMsg
+	var anchorSpeed float64
+	// haveAnchorSpeed is unset until the speed into the anchor is known, so a
+	// track that starts at speed is not judged against standing still.
+	var haveAnchorSpeed bool
+	var run int
+
+	for i, record := range records {
+		if !hasPosition(record) {
+			continue
+		}
+		if record.GpsAccuracy != 0xFF && record.GpsAccuracy > maxGPSAccuracy {
+			rejected[i] = true
+			continue
+		}
+		if anchor == nil {
+			anchor, haveAnchorSpeed = record, false
+			continue
+		}
+
+		// Without increasing timestamps there is no speed to judge by.
+		elapsed := record.Timestamp.Sub(anchor.Timestamp).Seconds()
+		if anchor.Timestamp.IsZero() || record.Timestamp.IsZero() || elapsed <= 0 {
+			anchor, haveAnchorSpeed = record, false
+			continue
+		}
+
+		speed := trackDistance(anchor, record) / elapsed
+		accelerating := haveAnchorSpeed && math.Abs(speed-anchorSpeed)/elapsed > maxTrackAcceleration
+		if run < maxRejectedTrackPoints && (speed > maxTrackSpeed || accelerating) {
+			rejected[i] = true
+			run++
+			continue
+		}
+
+		anchor, anchorSpeed, haveAnchorSpeed, run = record, speed, true, 0
+	}
+
+	return rejected
+}
+
+// fillTrackGap interpolates the points between the first and last record,
+// both good, by time when they are close enough and drops the rejected ones
+// otherwise. Handled points are cleared in rejected and counted in
+// corrections.
+func fillTrackGap(records []*fit.RecordMsg, rejected []bool, corrections *trackCorrections) {
+	from, to := records[0], records[len(records)-1]
+	gap := to.Timestamp.Sub(from.Timestamp)
+	if from.Timestamp.IsZero() || gap <= 0 || gap > maxTrackInterpolationGap {
+		return
+	}
+
+	fromLat, fromLong := from.PositionLat.Degrees(), from.PositionLong.Degrees()
+	toLat, toLong := to.PositionLat.Degrees(), to.PositionLong.Degrees()
+
+	for i, record := range records[1 : len(records)-1] {
+		fraction := record.Timestamp.Sub(from.Timestamp).Seconds() / gap.Seconds()
+		if record.Timestamp.IsZero() || fraction < 0 || fraction > 1 {
+			continue
+		}
+		if rejected[i+1] {
+			corrections.Spikes++
+		} else {
+			corrections.Filled++
+		}
+		record.PositionLat = fit.NewLatitudeDegrees(fromLat + (toLat-fromLat)*fraction)
+		record.PositionLong = fit.NewLongitudeDegrees(fromLong + (toLong-fromLong)*fraction)
+		rejected[i+1] = false
+	}
+}
+
+// deriveTrackMotion sets the speed and cumulative distance of the records
+// from their positions, for formats that carry neither. Records without a
+// position keep the distance covered so far.
+func deriveTrackMotion(records []*fit.RecordMsg) {
+	var meters float64
+	var previous *fit.RecordMsg
+
+	for _, record := range records {
+		record.Speed = 0
+		if hasPosition(record) {
+			if previous != nil {
+				segment := trackDistance(previous, record)
+				meters += segment
+
+				if elapsed := record.Timestamp.Sub(previous.Timestamp).Seconds(); elapsed > 0 {
+					record.Speed = toFitSpeed(segment / elapsed)
+				}
+			}
+			previous = record
+		}
+		record.Distance = uint32(math.Round(meters * 100))
+	}
+}
+
+func hasPosition(record *fit.RecordMsg) bool {
+	return !record.PositionLat.Invalid() && !record.PositionLong.Invalid()
+}
+
+// trackDistance returns the distance between the positions of two records
+// in m.
+func trackDistance(from, to *fit.RecordMsg) float64 {
+	return utils.Haversine(from.PositionLat.Degrees(), from.PositionLong.Degrees(), to.PositionLat.Degrees(), to.PositionLong.Degrees()) * 1000
+}
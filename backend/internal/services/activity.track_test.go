@@ -0,0 +1,140 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tormoder/fit"
+)
+
+// metersPerDegree is the length of a degree of latitude used by Haversine.
+const metersPerDegree = 6371000 * math.Pi / 180
+
+// northboundRecords records a ride heading north at 8 m/s with a position
+// every second.
+func northboundRecords(start time.Time, seconds int) []*fit.RecordMsg {
+	return northboundRecordsAt(start, seconds, 8)
+}
+
+// northboundRecordsAt records a ride heading north at speed m/s with a
+// position every second.
+func northboundRecordsAt(start time.Time, seconds int, speed float64) []*fit.RecordMsg {
+	records := make([]*fit.RecordMsg, seconds)
+	for i := range records {
+		records[i] = fit.NewRecordMsg()
+		records[i].Timestamp = start.Add(time.Duration(i) * time.Second)
+		records[i].PositionLat = fit.NewLatitudeDegrees(55 + float64(i)*speed/metersPerDegree)
+		records[i].PositionLong = fit.NewLongitudeDegrees(12)
+	}
+	return records
+}
+
+func TestCleanTrackInterpolatesSpikes(t *testing.T) {
+	start := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	records := northboundRecords(start, 20)
+	expected := records[5].PositionLat.Degrees()
+
+	// A jump of 500 m east and back, as in an urban canyon.
+	records[5].PositionLong = fit.NewLongitudeDegrees(12.008)
+	// A fix the device itself reports as poor.
+	records[12].GpsAccuracy = 80
+	records[12].PositionLong = fit.NewLongitudeDegrees(12.0005)
+
+	assert.Equal(t, trackCorrections{Spikes: 2}, cleanTrack(records))
+	assert.InDelta(t, expected, records[5].PositionLat.Degrees(), 1e-6)
+	assert.InDelta(t, 12, records[5].PositionLong.Degrees(), 1e-6)
+	assert.InDelta(t, 12, records[12].PositionLong.Degrees(), 1e-6)
+}
+
+func TestCleanTrackFillsShortDropouts(t *testing.T) {
+	start := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	records := northboundRecords(start, 20)
+	expected := records[8].PositionLat.Degrees()
+
+	// A tunnel: five seconds without a fix.
+	for _, record := range records[6:11] {
+		record.PositionLat = fit.NewLatitudeInvalid()
+		record.PositionLong = fit.NewLongitudeInvalid()
+	}
+
+	assert.Equal(t, trackCorrections{Filled: 5}, cleanTrack(records))
+	assert.True(t, hasPosition(records[8]))
+	assert.InDelta(t, expected, records[8].PositionLat.Degrees(), 1e-6)
+}
+
+func TestCleanTrackCountsSpikesAndDropoutsSeparately(t *testing.T) {
+	start := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	records := northboundRecords(start, 20)
+
+	// A spike right before the fix is lost for three seconds: all four
+	// points are interpolated, but only one was corrected.
+	records[5].PositionLong = fit.NewLongitudeDegrees(12.008)
+	for _, record := range records[6:9] {
+		record.PositionLat = fit.NewLatitudeInvalid()
+		record.PositionLong = fit.NewLongitudeInvalid()
+	}
+
+	assert.Equal(t, trackCorrections{Spikes: 1, Filled: 3}, cleanTrack(records))
+	for _, record := range records[5:9] {
+		assert.InDelta(t, 12, record.PositionLong.Degrees(), 1e-6)
+	}
+}
+
+func TestCleanTrackDropsSpikesAcrossLongGaps(t *testing.T) {
+	start := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	records := northboundRecords(start, 10)
+
+	// The fix is lost for a minute after a spike, so there is nothing close
+	// enough to interpolate from.
+	records[5].PositionLong = fit.NewLongitudeDegrees(12.008)
+	for _, record := range records[6:] {
+		record.Timestamp = record.Timestamp.Add(time.Minute)
+	}
+
+	assert.Equal(t, trackCorrections{Spikes: 1}, cleanTrack(records))
+	assert.False(t, hasPosition(records[5]))
+	assert.True(t, hasPosition(records[6]), "the track resumes after the gap")
+}
+
+func TestCleanTrackKeepsCleanTracks(t *testing.T) {
+	start := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	assert.Zero(t, cleanTrack(northboundRecords(start, 60)))
+
+	// Without timestamps there is no speed to judge the points by.
+	records := northboundRecords(time.Time{}, 5)
+	for _, record := range records {
+		record.Timestamp = time.Time{}
+	}
+	records[2].PositionLong = fit.NewLongitudeDegrees(12.008)
+	assert.Zero(t, cleanTrack(records))
+}
+
+func TestCleanTrackKeepsTracksStartingAtSpeed(t *testing.T) {
+	start := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+
+	// A file split mid-descent at 20 m/s: the second fix is no acceleration
+	// from standing still.
+	records := northboundRecordsAt(start, 30, 20)
+	assert.Zero(t, cleanTrack(records))
+
+	// Spikes are still caught once the speed is known.
+	records = northboundRecordsAt(start, 30, 20)
+	records[10].PositionLong = fit.NewLongitudeDegrees(12.008)
+	assert.Equal(t, trackCorrections{Spikes: 1}, cleanTrack(records))
+}
+
+func TestDeriveTrackMotionFollowsTheCleanedTrack(t *testing.T) {
+	start := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	records := northboundRecords(start, 11)
+	records[5].PositionLong = fit.NewLongitudeDegrees(12.008)
+
+	deriveTrackMotion(records)
+	assert.Greater(t, records[10].GetDistanceScaled(), 900.0, "the spike adds a kilometre")
+
+	cleanTrack(records)
+	deriveTrackMotion(records)
+	assert.InDelta(t, 80, records[10].GetDistanceScaled(), 0.1)
+	assert.InDelta(t, 8, records[6].GetSpeedScaled(), 0.01)
+}
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/notaduck/backend/internal/db"
+	"github.com/notaduck/backend/internal/repositories"
+)
+
+const (
+	// chronicTrainingLoadDays is the time constant of fitness (CTL).
+	chronicTrainingLoadDays = 42
+	// acuteTrainingLoadDays is the time constant of fatigue (ATL).
+	acuteTrainingLoadDays = 7
+	// defaultTrainingLoadDays is the length of the series returned when the
+	// request leaves the start of the range open.
+	defaultTrainingLoadDays = 365
+	// maxTrainingLoadDays caps the length of a requested series.
+	maxTrainingLoadDays = 10 * 366
+)
+
+// ErrInvalidTrainingLoadRange is returned for a training load range that ends
+// before it starts or spans more than maxTrainingLoadDays.
+var ErrInvalidTrainingLoadRange = errors.New("invalid training load range")
+
+// TrainingLoadDay is the training load of a user on a UTC day. Load is the
+// summed TSS of the day's rides, or TRIMP for rides without power. TSB is
+// the balance of the previous day, so a hard ride shows in form the day
+// after.
+type TrainingLoadDay struct {
+	Date time.Time `json:"date"`
+	Load float64   `json:"load"`
+	CTL  float64   `json:"ctl"`
+	ATL  float64   `json:"atl"`
+	TSB  float64   `json:"tsb"`
+}
+
+// trainingLoad carries fitness and fatigue from one day to the next.
+type trainingLoad struct {
+	// Day is the last day included, at midnight UTC.
+	Day time.Time
+	CTL float64
+	ATL float64
+}
+
+// next returns the day after Day with the given load.
+func (l trainingLoad) next(load float64) (trainingLoad, TrainingLoadDay) {
+	day := TrainingLoadDay{
+		Date: l.Day.AddDate(0, 0, 1),
+		Load: load,
+		CTL:  l.CTL + (load-l.CTL)/chronicTrainingLoadDays,
+		ATL:  l.ATL + (load-l.ATL)/acuteTrainingLoadDays,
+		TSB:  l.CTL - l.ATL,
+	}
+	return trainingLoad{Day: day.Date, CTL: day.CTL, ATL: day.ATL}, day
+}
+
+// trainingLoadDays continues from the given day with the daily loads, which
+// are ordered and all after it, until the last of them. Days without rides
+// have no load.
+func trainingLoadDays(from trainingLoad, loads []db.GetDailyActivityLoadsRow) []TrainingLoadDay {
+	if len(loads) == 0 {
+		return nil
+	}
+
+	last := loads[len(loads)-1].Day.Time
+	days := make([]TrainingLoadDay, 0, int(last.Sub(from.Day).Hours()/24))
+
+	state := from
+	for _, row := range loads {
+		for state.Day.Before(row.Day.Time.AddDate(0, 0, -1)) {
+			var day TrainingLoadDay
+			state, day = state.next(0)
+			days = append(days, day)
+		}
+		var day TrainingLoadDay
+		state, day = state.next(row.Load)
+		days = append(days, day)
+	}
+
+	return days
+}
+
+// heartRateTRIMP is the training impulse of a ride after Edwards: the minutes
+// in each heart rate zone weighted by the zone number. It is invalid without
+// heart rate zones.
+func heartRateTRIMP(zones []db.CreateActivityHeartRateZonesParams) pgtype.Float8 {
+	if len(zones) == 0 {
+		return pgtype.Float8{}
+	}
+	var trimp float64
+	for _, zone := range zones {
+		trimp += float64(zone.Seconds) / 60 * float64(zone.Zone)
+	}
+	return pgtype.Float8{Float64: math.Round(trimp*10) / 10, Valid: true}
+}
+
+// refreshTrainingLoad rebuilds the stored daily training load of the user
+// from the UTC day of since onwards, or entirely when nothing is stored
+// before it. It runs in the transaction that changed the rides and locks the
+// user's training load, so concurrent uploads do not interleave.
+func refreshTrainingLoad(ctx context.Context, activities repositories.ActivityRepository, userId string, since time.Time) error {
+	if err := activities.LockTrainingLoad(ctx, userId); err != nil {
+		return err
+	}
+
+	day := utcDay(since)
+	state := trainingLoad{Day: day.AddDate(0, 0, -1)}
+
+	previous, err := activities.GetTrainingLoadBefore(ctx, db.GetTrainingLoadBeforeParams{
+		UserID: userId,
+		Before: pgtype.Date{Time: day, Valid: true},
+	})
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		day = time.Time{}
+	case err != nil:
+		return err
+	default:
+		state = trainingLoad{Day: previous.Day.Time, CTL: previous.Ctl, ATL: previous.Atl}
+	}
+
+	loads, err := activities.GetDailyActivityLoads(ctx, db.GetDailyActivityLoadsParams{
+		UserID: userId,
+		Since:  pgtype.Date{Time: day, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if day.IsZero() && len(loads) > 0 {
+		state = trainingLoad{Day: loads[0].Day.Time.AddDate(0, 0, -1)}
+	}
+
+	if err := activities.DeleteTrainingLoadFrom(ctx, db.DeleteTrainingLoadFromParams{
+		UserID: userId,
+		Since:  pgtype.Date{Time: day, Valid: true},
+	}); err != nil {
+		return err
+	}
+
+	days := trainingLoadDays(state, loads)
+	if len(days) == 0 {
+		return nil
+	}
+
+	params := make([]db.CreateTrainingLoadParams, len(days))
+	for i, day := range days {
+		params[i] = db.CreateTrainingLoadParams{
+			UserID: userId,
+			Day:    pgtype.Date{Time: day.Date, Valid: true},
+			Load:   day.Load,
+			Ctl:    day.CTL,
+			Atl:    day.ATL,
+			Tsb:    day.TSB,
+		}
+	}
+	_, err = activities.CreateTrainingLoad(ctx, params)
+	return err
+}
+
+// GetTrainingLoad returns the user's training load for every day from from
+// to to, both UTC days. A zero to is today and a zero from the
+// defaultTrainingLoadDays before to. Days after the user's latest ride carry
+// the decaying load forward.
+func (s *activityService) GetTrainingLoad(ctx context.Context, userId string, from, to time.Time) ([]TrainingLoadDay, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+	to = utcDay(to)
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -(defaultTrainingLoadDays - 1))
+	}
+	from = utcDay(from)
+
+	if from.After(to) {
+		return nil, fmt.Errorf("%w: the range starts after it ends", ErrInvalidTrainingLoadRange)
+	}
+	if to.Sub(from) >= maxTrainingLoadDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: at most %d days can be requested", ErrInvalidTrainingLoadRange, maxTrainingLoadDays)
+	}
+
+	state := trainingLoad{Day: from.AddDate(0, 0, -1)}
+	previous, err := s.activityRepo.GetTrainingLoadBefore(ctx, db.GetTrainingLoadBeforeParams{
+		UserID: userId,
+		Before: pgtype.Date{Time: from, Valid: true},
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		slog.Error("failed to get training load", slog.String("err", err.Error()))
+		return nil, err
+	}
+	if err == nil {
+		state = trainingLoad{Day: previous.Day.Time, CTL: previous.Ctl, ATL: previous.Atl}
+	}
+
+	rows, err := s.activityRepo.GetTrainingLoad(ctx, db.GetTrainingLoadParams{
+		UserID:  userId,
+		FromDay: pgtype.Date{Time: from, Valid: true},
+		ToDay:   pgtype.Date{Time: to, Valid: true},
+	})
+	if err != nil {
+		slog.Error("failed to get training load", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	return fillTrainingLoad(state, rows, from, to), nil
+}
+
+// fillTrainingLoad returns a day for each day from from to to, taking the
+// stored rows as they are and continuing from state with rest days where
+// nothing is stored.
+func fillTrainingLoad(state trainingLoad, rows []db.GetTrainingLoadRow, from, to time.Time) []TrainingLoadDay {
+	days := make([]TrainingLoadDay, 0, int(to.Sub(from).Hours()/24)+1)
+
+	for state.Day.Before(from.AddDate(0, 0, -1)) {
+		state, _ = state.next(0)
+	}
+
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		if len(rows) > 0 && rows[0].Day.Time.Equal(date) {
+			row := rows[0]
+			rows = rows[1:]
+			days = append(days, TrainingLoadDay{Date: date, Load: row.Load, CTL: row.Ctl, ATL: row.Atl, TSB: row.Tsb})
+			state = trainingLoad{Day: date, CTL: row.Ctl, ATL: row.Atl}
+			continue
+		}
+		var day TrainingLoadDay
+		state, day = state.next(0)
+		days = append(days, day)
+	}
+
+	return days
+}
+
+// utcDay returns midnight UTC of the UTC day of t.
+func utcDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/notaduck/backend/internal/db"
+)
+
+func date(year int, month time.Month, day int) pgtype.Date {
+	return pgtype.Date{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC), Valid: true}
+}
+
+func TestTrainingLoadDays(t *testing.T) {
+	start := trainingLoad{Day: time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)}
+
+	days := trainingLoadDays(start, []db.GetDailyActivityLoadsRow{
+		{Day: date(2024, 6, 1), Load: 84},
+		{Day: date(2024, 6, 4), Load: 70},
+	})
+	require.Len(t, days, 4, "rest days between rides are filled in")
+
+	assert.Equal(t, date(2024, 6, 1).Time, days[0].Date)
+	assert.InDelta(t, 2, days[0].CTL, 0.001)
+	assert.InDelta(t, 12, days[0].ATL, 0.001)
+	assert.Zero(t, days[0].TSB, "form lags a day behind")
+
+	assert.Zero(t, days[1].Load)
+	assert.InDelta(t, 2-12, days[1].TSB, 0.001)
+	assert.Less(t, days[2].ATL, days[1].ATL, "fatigue fades on rest days")
+	assert.Equal(t, 70.0, days[3].Load)
+
+	assert.Nil(t, trainingLoadDays(start, nil))
+}
+
+func TestFillTrainingLoad(t *testing.T) {
+	state := trainingLoad{Day: time.Date(2024, 5, 29, 0, 0, 0, 0, time.UTC), CTL: 42, ATL: 14}
+	rows := []db.GetTrainingLoadRow{
+		{Day: date(2024, 6, 2), Load: 100, Ctl: 50, Atl: 30, Tsb: 1},
+	}
+
+	days := fillTrainingLoad(state, rows, date(2024, 6, 1).Time, date(2024, 6, 4).Time)
+	require.Len(t, days, 4)
+
+	// Two rest days lead up to the 1st.
+	assert.InDelta(t, 42*41.0/42*41/42*41/42, days[0].CTL, 0.001)
+	assert.Equal(t, TrainingLoadDay{Date: date(2024, 6, 2).Time, Load: 100, CTL: 50, ATL: 30, TSB: 1}, days[1])
+	assert.InDelta(t, 50*41.0/42, days[2].CTL, 0.001, "decays after the latest stored day")
+	assert.InDelta(t, 20, days[2].TSB, 0.001)
+	assert.Equal(t, date(2024, 6, 4).Time, days[3].Date)
+}
+
+func TestHeartRateTRIMP(t *testing.T) {
+	trimp := heartRateTRIMP([]db.CreateActivityHeartRateZonesParams{
+		{Zone: 1, Seconds: 600},
+		{Zone: 2, Seconds: 1200},
+		{Zone: 4, Seconds: 300},
+	})
+	assert.True(t, trimp.Valid)
+	assert.InDelta(t, 10+40+20, trimp.Float64, 0.001)
+
+	assert.False(t, heartRateTRIMP(nil).Valid)
+}
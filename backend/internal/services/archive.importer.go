@@ -0,0 +1,336 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"strings"
+)
+
+// maxArchiveEntrySize caps the decompressed size of a single activity file or
+// manifest so a crafted archive cannot exhaust memory.
+const maxArchiveEntrySize = 64 << 20
+
+// maxNestedArchiveSize caps the decompressed size of a zip inside the
+// archive. Garmin exports ship parts of several hundred MB, which are
+// extracted to a temporary file rather than held in memory.
+const maxNestedArchiveSize = 8 << 30
+
+// maxNestedArchiveDepth bounds how deep zips inside zips are followed. Garmin
+// exports wrap the uploaded files in a second level of archives.
+const maxNestedArchiveDepth = 2
+
+var ErrInvalidArchive = errors.New("file is not a valid zip archive")
+
+// stravaRideTypes maps Strava activity types onto our ride types. Other types,
+// including the generic "Ride" Strava defaults to, keep the ride type detected
+// during ingestion.
+var stravaRideTypes = map[string]string{
+	"virtual ride":         RideTypeIndoor,
+	"gravel ride":          RideTypeGravel,
+	"mountain bike ride":   RideTypeMTB,
+	"e-mountain bike ride": RideTypeMTB,
+}
+
+// ArchiveProgress is reported after each activity file of an archive import.
+type ArchiveProgress struct {
+	Processed int
+	Total     int
+	Result    FileResult
+}
+
+// archiveEntry is an activity file found while walking an export archive.
+type archiveEntry struct {
+	// Path is the entry path, prefixed with the paths of any enclosing
+	// archives.
+	Path string
+	file *zip.File
+}
+
+// manifestEntry holds what a Strava activities.csv row says about a file.
+type manifestEntry struct {
+	Name        string
+	RideType    string
+	Description string
+}
+
+func (s *activityService) ImportArchive(ctx context.Context, archive io.ReaderAt, size int64, userId string, progress func(ArchiveProgress) error) (*UploadBatchResult, error) {
+	reader, err := zip.NewReader(archive, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+
+	var parts nestedArchives
+	defer parts.close()
+
+	entries, manifest, err := walkArchive(reader, "", 0, &parts)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("importing activity archive", "userID", userId, "files", len(entries), "manifestEntries", len(manifest))
+
+	result := &UploadBatchResult{Files: make([]FileResult, 0, len(entries))}
+
+	for i, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		activity, err := s.importArchiveEntry(ctx, entry, manifest, userId)
+		if err != nil {
+			slog.Error("failed to import archive entry", "path", entry.Path, "error", err)
+		}
+
+		fileResult := NewFileResult(entry.Path, activity, err)
+		result.Files = append(result.Files, fileResult)
+
+		if progress != nil {
+			if err := progress(ArchiveProgress{Processed: i + 1, Total: len(entries), Result: fileResult}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (s *activityService) importArchiveEntry(ctx context.Context, entry archiveEntry, manifest map[string]manifestEntry, userId string) (*Activity, error) {
+	data, err := readArchiveEntry(entry.file)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := ActivityFilePayload{
+		Filename:     strings.TrimSuffix(path.Base(entry.Path), ".gz"),
+		Data:         data,
+		LastModified: entry.file.Modified,
+	}
+
+	if meta, ok := manifest[path.Base(entry.Path)]; ok {
+		payload.Name = meta.Name
+		payload.RideType = meta.RideType
+		payload.Description = meta.Description
+	}
+
+	return s.CreateActivityFromBytes(ctx, payload, userId)
+}
+
+// walkArchive collects the activity files of an archive, following nested
+// zips, along with the Strava manifest keyed by activity file name. Nested
+// zips are extracted into parts, which must stay open while the entries are
+// read.
+func walkArchive(reader *zip.Reader, prefix string, depth int, parts *nestedArchives) ([]archiveEntry, map[string]manifestEntry, error) {
+	var entries []archiveEntry
+	manifest := map[string]manifestEntry{}
+
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		name := strings.ToLower(path.Base(file.Name))
+
+		switch {
+		case name == "activities.csv":
+			rows, err := readStravaManifest(file)
+			if err != nil {
+				return nil, nil, err
+			}
+			for key, row := range rows {
+				manifest[key] = row
+			}
+
+		case strings.HasSuffix(name, ".zip") && depth < maxNestedArchiveDepth:
+			nested, err := parts.extract(file)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			nestedEntries, nestedManifest, err := walkArchive(nested, prefix+file.Name+"/", depth+1, parts)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			entries = append(entries, nestedEntries...)
+			for key, row := range nestedManifest {
+				manifest[key] = row
+			}
+
+		case isActivityFileName(name):
+			entries = append(entries, archiveEntry{Path: prefix + file.Name, file: file})
+		}
+	}
+
+	return entries, manifest, nil
+}
+
+// nestedArchives holds the zips found inside an archive, extracted to
+// temporary files.
+type nestedArchives struct {
+	files []*os.File
+}
+
+// extract copies the nested zip to a temporary file and opens it.
+func (n *nestedArchives) extract(file *zip.File) (*zip.Reader, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidArchive, file.Name, err)
+	}
+	defer rc.Close()
+
+	tmp, err := os.CreateTemp("", "archive-part-*.zip")
+	if err != nil {
+		return nil, err
+	}
+	n.files = append(n.files, tmp)
+
+	size, err := io.Copy(tmp, io.LimitReader(rc, maxNestedArchiveSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidArchive, file.Name, err)
+	}
+	if size > maxNestedArchiveSize {
+		return nil, fmt.Errorf("%w: %s exceeds %d bytes when decompressed", ErrInvalidArchive, file.Name, int64(maxNestedArchiveSize))
+	}
+
+	reader, err := zip.NewReader(tmp, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidArchive, file.Name, err)
+	}
+	return reader, nil
+}
+
+// close removes the extracted zips.
+func (n *nestedArchives) close() {
+	for _, file := range n.files {
+		file.Close()
+		if err := os.Remove(file.Name()); err != nil {
+			slog.Error("failed to remove extracted archive part", "path", file.Name(), "error", err)
+		}
+	}
+	n.files = nil
+}
+
+func isActivityFileName(name string) bool {
+	name = strings.TrimSuffix(name, ".gz")
+	for _, ext := range []string{".fit", ".gpx", ".tcx"} {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// readStravaManifest reads activities.csv from a Strava export, keyed by the
+// base name of the file each row refers to.
+func readStravaManifest(file *zip.File) (map[string]manifestEntry, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidArchive, file.Name, err)
+	}
+	defer rc.Close()
+
+	reader := csv.NewReader(io.LimitReader(rc, maxArchiveEntrySize))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidArchive, file.Name, err)
+	}
+
+	// Strava repeats some column names; the first occurrence is the one we want.
+	columns := map[string]int{}
+	for i, column := range header {
+		column = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+		if _, ok := columns[column]; !ok {
+			columns[column] = i
+		}
+	}
+
+	filenameColumn, ok := columns["Filename"]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s has no Filename column", ErrInvalidArchive, file.Name)
+	}
+
+	field := func(row []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	manifest := map[string]manifestEntry{}
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidArchive, file.Name, err)
+		}
+
+		if filenameColumn >= len(row) || strings.TrimSpace(row[filenameColumn]) == "" {
+			continue
+		}
+
+		manifest[path.Base(strings.TrimSpace(row[filenameColumn]))] = manifestEntry{
+			Name:        field(row, "Activity Name"),
+			RideType:    stravaRideTypes[strings.ToLower(field(row, "Activity Type"))],
+			Description: field(row, "Activity Description"),
+		}
+	}
+
+	return manifest, nil
+}
+
+// readArchiveEntry returns the contents of an activity file, decompressing
+// gzipped entries.
+func readArchiveEntry(file *zip.File) ([]byte, error) {
+	data, err := readArchiveFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasSuffix(strings.ToLower(file.Name), ".gz") {
+		return data, nil
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorruptFile, err)
+	}
+	defer gz.Close()
+
+	return readLimited(gz, file.Name)
+}
+
+func readArchiveFile(file *zip.File) ([]byte, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorruptFile, err)
+	}
+	defer rc.Close()
+
+	return readLimited(rc, file.Name)
+}
+
+func readLimited(r io.Reader, name string) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxArchiveEntrySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorruptFile, err)
+	}
+	if len(data) > maxArchiveEntrySize {
+		return nil, fmt.Errorf("%w: %s exceeds %d bytes when decompressed", ErrCorruptFile, name, maxArchiveEntrySize)
+	}
+	return data, nil
+}
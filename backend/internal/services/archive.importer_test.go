@@ -0,0 +1,129 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buildZip(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, data := range files {
+		w, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	return buf.Bytes()
+}
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	_, err := writer.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	return buf.Bytes()
+}
+
+func TestWalkArchive(t *testing.T) {
+	manifest := "Activity ID,Activity Date,Activity Name,Activity Type,Activity Description,Elapsed Time,Filename,Elapsed Time\n" +
+		"1,\"May 1, 2024\",Morning loop,Gravel Ride,\"Dusty, fast\",1200,activities/1.gpx.gz,1200\n" +
+		"2,\"May 2, 2024\",Lunch run,Run,,1800,activities/2.gpx,1800\n"
+
+	nested := buildZip(t, map[string][]byte{"3.fit": []byte("fit")})
+
+	archive := buildZip(t, map[string][]byte{
+		"activities.csv":       []byte(manifest),
+		"activities/1.gpx.gz":  gzipBytes(t, []byte(sampleGPX)),
+		"activities/2.gpx":     []byte(sampleGPX),
+		"media/photo.jpg":      []byte("jpg"),
+		"uploads/part1.zip":    nested,
+		"profile/settings.csv": []byte("a,b\n"),
+	})
+
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+
+	var parts nestedArchives
+	defer parts.close()
+
+	entries, rows, err := walkArchive(reader, "", 0, &parts)
+	require.NoError(t, err)
+
+	paths := make([]string, len(entries))
+	for i, entry := range entries {
+		paths[i] = entry.Path
+	}
+	assert.ElementsMatch(t, []string{"activities/1.gpx.gz", "activities/2.gpx", "uploads/part1.zip/3.fit"}, paths)
+
+	assert.Equal(t, manifestEntry{Name: "Morning loop", RideType: "gravel", Description: "Dusty, fast"}, rows["1.gpx.gz"])
+	assert.Equal(t, manifestEntry{Name: "Lunch run"}, rows["2.gpx"])
+
+	for _, entry := range entries {
+		if entry.Path != "activities/1.gpx.gz" {
+			continue
+		}
+		data, err := readArchiveEntry(entry.file)
+		require.NoError(t, err)
+		assert.Equal(t, sampleGPX, string(data))
+	}
+}
+
+func TestWalkArchiveExtractsLargeNestedArchives(t *testing.T) {
+	// A Garmin export part larger than a single activity file may be. The
+	// padding is stored uncompressed so the part itself exceeds the cap.
+	var part bytes.Buffer
+	writer := zip.NewWriter(&part)
+	for name, data := range map[string][]byte{
+		"3.fit":       []byte("fit"),
+		"padding.bin": make([]byte, maxArchiveEntrySize+1),
+	} {
+		w, err := writer.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store})
+		require.NoError(t, err)
+		_, err = w.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	require.Greater(t, part.Len(), maxArchiveEntrySize)
+
+	archive := buildZip(t, map[string][]byte{"DI_CONNECT/DI-Connect-Uploaded-Files/UploadedFiles_0-_Part1.zip": part.Bytes()})
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+
+	var parts nestedArchives
+	entries, _, err := walkArchive(reader, "", 0, &parts)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "DI_CONNECT/DI-Connect-Uploaded-Files/UploadedFiles_0-_Part1.zip/3.fit", entries[0].Path)
+
+	data, err := readArchiveEntry(entries[0].file)
+	require.NoError(t, err)
+	assert.Equal(t, "fit", string(data))
+
+	require.Len(t, parts.files, 1)
+	extracted := parts.files[0].Name()
+	parts.close()
+	assert.NoFileExists(t, extracted, "the extracted part is removed")
+}
+
+func TestImportArchiveRejectsNonZip(t *testing.T) {
+	service := &activityService{}
+	data := []byte("not a zip")
+
+	_, err := service.ImportArchive(context.Background(), bytes.NewReader(data), int64(len(data)), "user", nil)
+	assert.ErrorIs(t, err, ErrInvalidArchive)
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/tormoder/fit"
+
+	"github.com/notaduck/backend/internal/db"
+	"github.com/notaduck/backend/internal/repositories"
+	"github.com/notaduck/backend/utils"
+)
+
+// DuplicatePolicy decides what happens to an upload that matches an activity
+// the user already has.
+type DuplicatePolicy string
+
+const (
+	// DuplicatePolicyReject fails the upload of any duplicate.
+	DuplicatePolicyReject DuplicatePolicy = "reject"
+	// DuplicatePolicySkip leaves duplicates out and reports the existing activity.
+	DuplicatePolicySkip DuplicatePolicy = "skip"
+	// DuplicatePolicyFlag skips exact duplicates but imports likely duplicates
+	// and flags them with the activity they overlap.
+	DuplicatePolicyFlag DuplicatePolicy = "flag"
+)
+
+// likelyDuplicateRadius is the maximum distance in kilometers between the
+// start points of two overlapping activities for them to count as one ride.
+const likelyDuplicateRadius = 0.25
+
+// uniqueViolation is the Postgres error code raised by the unique index on
+// (user_id, content_hash) when the same file is ingested concurrently.
+const uniqueViolation = "23505"
+
+// ParseDuplicatePolicy parses a policy name, defaulting to skip when empty.
+func ParseDuplicatePolicy(value string) (DuplicatePolicy, error) {
+	switch policy := DuplicatePolicy(strings.ToLower(strings.TrimSpace(value))); policy {
+	case "":
+		return DuplicatePolicySkip, nil
+	case DuplicatePolicyReject, DuplicatePolicySkip, DuplicatePolicyFlag:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown duplicate policy %q: expected reject, skip or flag", value)
+	}
+}
+
+// DuplicateActivityError reports that an upload matches an existing activity.
+type DuplicateActivityError struct {
+	ExistingID int32
+	// Exact is set when the file content or the device recording is the same,
+	// and unset for overlapping rides recorded by different devices.
+	Exact bool
+	// Skipped is set when the policy ignores the upload instead of failing it.
+	Skipped bool
+}
+
+func (e *DuplicateActivityError) Error() string {
+	switch {
+	case e.ExistingID == 0:
+		return ErrDuplicateActivity.Error()
+	case e.Exact:
+		return fmt.Sprintf("%s as activity %d", ErrDuplicateActivity, e.ExistingID)
+	default:
+		return fmt.Sprintf("activity is likely a duplicate of activity %d", e.ExistingID)
+	}
+}
+
+func (e *DuplicateActivityError) Unwrap() error {
+	return ErrDuplicateActivity
+}
+
+// activityUpload identifies an uploaded file on its way through ingestion.
+type activityUpload struct {
+	Filename    string
+	UserID      string
+	ContentHash string
+	// Device identifies the recording device, e.g. by its serial number. It is
+	// empty when the file format carries no device information.
+	Device string
+	// Name, RideType and Description override the derived values when set.
+	Name        string
+	RideType    string
+	Description string
+	// Data and ContentType are the uploaded file, kept as the original of the
+	// activity when a file store is configured.
+	Data        []byte
+	ContentType string
+	// ReprocessID is the activity whose derived data the upload replaces when
+	// an activity is reprocessed from its stored original.
+	ReprocessID int32
+}
+
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// deviceFingerprint identifies a single recording of a device, so the same
+// ride exported twice (e.g. as FIT and TCX) is recognised as one.
+func deviceFingerprint(device string, startedAt time.Time) string {
+	if device == "" || startedAt.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%s@%d", device, startedAt.Unix())
+}
+
+func fitDevice(fileId fit.FileIdMsg) string {
+	if fileId.SerialNumber == 0 {
+		return ""
+	}
+	return fmt.Sprintf("serial:%d", fileId.SerialNumber)
+}
+
+// checkDuplicate applies the duplicate policy to an activity about to be
+// created. It returns the activity to flag the new one as a duplicate of, or a
+// *DuplicateActivityError when the upload must not be imported.
+func (s *activityService) checkDuplicate(ctx context.Context, repos repositories.Repositories, params db.CreateActivityParams) (pgtype.Int4, error) {
+	existingId, err := repos.Activities.FindDuplicateActivity(ctx, db.FindDuplicateActivityParams{
+		UserID:            params.UserID,
+		ContentHash:       params.ContentHash,
+		DeviceFingerprint: params.DeviceFingerprint.String,
+	})
+	switch {
+	case err == nil:
+		return pgtype.Int4{}, &DuplicateActivityError{
+			ExistingID: existingId,
+			Exact:      true,
+			Skipped:    s.duplicatePolicy != DuplicatePolicyReject,
+		}
+	case !errors.Is(err, pgx.ErrNoRows):
+		return pgtype.Int4{}, err
+	}
+
+	if !params.StartedAt.Valid || !params.EndedAt.Valid || !params.StartPosition.Valid {
+		return pgtype.Int4{}, nil
+	}
+
+	overlapping, err := repos.Activities.GetOverlappingActivities(ctx, db.GetOverlappingActivitiesParams{
+		UserID:    params.UserID,
+		StartedAt: params.StartedAt,
+		EndedAt:   params.EndedAt,
+	})
+	if err != nil {
+		return pgtype.Int4{}, err
+	}
+
+	start := params.StartPosition.P
+	for _, candidate := range overlapping {
+		if !candidate.StartPosition.Valid {
+			continue
+		}
+
+		other := candidate.StartPosition.P
+		if utils.Haversine(start.Y, start.X, other.Y, other.X) > likelyDuplicateRadius {
+			continue
+		}
+
+		if s.duplicatePolicy == DuplicatePolicyFlag {
+			return pgtype.Int4{Int32: candidate.ID, Valid: true}, nil
+		}
+
+		return pgtype.Int4{}, &DuplicateActivityError{
+			ExistingID: candidate.ID,
+			Skipped:    s.duplicatePolicy == DuplicatePolicySkip,
+		}
+	}
+
+	return pgtype.Int4{}, nil
+}
+
+// duplicateFromInsertError translates a unique violation on the content hash
+// into a DuplicateActivityError; other errors are returned unchanged.
+func (s *activityService) duplicateFromInsertError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return &DuplicateActivityError{
+			Exact:   true,
+			Skipped: s.duplicatePolicy != DuplicatePolicyReject,
+		}
+	}
+	return err
+}
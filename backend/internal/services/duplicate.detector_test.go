@@ -0,0 +1,34 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tormoder/fit"
+)
+
+func TestParseDuplicatePolicy(t *testing.T) {
+	policy, err := ParseDuplicatePolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, DuplicatePolicySkip, policy)
+
+	policy, err = ParseDuplicatePolicy(" Flag ")
+	require.NoError(t, err)
+	assert.Equal(t, DuplicatePolicyFlag, policy)
+
+	_, err = ParseDuplicatePolicy("merge")
+	assert.Error(t, err)
+}
+
+func TestDeviceFingerprint(t *testing.T) {
+	start := time.Date(2024, 5, 1, 6, 0, 0, 0, time.UTC)
+
+	fileId := fit.NewFileIdMsg()
+	assert.Empty(t, deviceFingerprint(fitDevice(*fileId), start))
+
+	fileId.SerialNumber = 3333
+	assert.Equal(t, "serial:3333@1714543200", deviceFingerprint(fitDevice(*fileId), start))
+	assert.Empty(t, deviceFingerprint("serial:3333", time.Time{}))
+}
@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	// DefaultImportDebounce is how long a watched file must stay unchanged
+	// before it is imported, so files still being copied are not read early.
+	DefaultImportDebounce = 5 * time.Second
+	// DefaultImportRetryDelay is how long a file that failed for an internal
+	// reason, e.g. a database outage, waits before it is imported again.
+	DefaultImportRetryDelay = time.Minute
+	// ImportDoneDir and ImportFailedDir are the subfolders of a watched folder
+	// that imported and rejected files are moved to.
+	ImportDoneDir   = "done"
+	ImportFailedDir = "failed"
+	// ImportLogName is the file in a watched folder that import outcomes are
+	// appended to.
+	ImportLogName = "import.log"
+)
+
+// ImportFolder maps a watched folder onto the user its files belong to.
+type ImportFolder struct {
+	Path   string
+	UserID string
+}
+
+// ParseImportFolders parses a comma separated list of folder=user-id pairs,
+// e.g. "/nas/alice=uuid-1,/nas/bob=uuid-2".
+func ParseImportFolders(value string) ([]ImportFolder, error) {
+	var folders []ImportFolder
+	seen := map[string]bool{}
+
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		separator := strings.LastIndex(pair, "=")
+		if separator <= 0 || separator == len(pair)-1 {
+			return nil, fmt.Errorf("invalid import folder %q: expected folder=user-id", pair)
+		}
+
+		path := filepath.Clean(strings.TrimSpace(pair[:separator]))
+		if seen[path] {
+			return nil, fmt.Errorf("import folder %q is listed twice", path)
+		}
+		seen[path] = true
+
+		folders = append(folders, ImportFolder{Path: path, UserID: strings.TrimSpace(pair[separator+1:])})
+	}
+
+	if len(folders) == 0 {
+		return nil, errors.New("no import folders are configured")
+	}
+	return folders, nil
+}
+
+// FolderImporter ingests activity files dropped into watched folders, e.g. a
+// NAS share synced from a head unit.
+type FolderImporter struct {
+	activities ActivityService
+	folders    map[string]ImportFolder
+	debounce   time.Duration
+	retryDelay time.Duration
+}
+
+type FolderImporterOption func(*FolderImporter)
+
+func NewFolderImporter(activities ActivityService, folders []ImportFolder, options ...FolderImporterOption) *FolderImporter {
+	importer := &FolderImporter{
+		activities: activities,
+		folders:    make(map[string]ImportFolder, len(folders)),
+		debounce:   DefaultImportDebounce,
+		retryDelay: DefaultImportRetryDelay,
+	}
+	for _, folder := range folders {
+		folder.Path = filepath.Clean(folder.Path)
+		importer.folders[folder.Path] = folder
+	}
+
+	for _, option := range options {
+		option(importer)
+	}
+
+	return importer
+}
+
+func WithImportDebounce(debounce time.Duration) FolderImporterOption {
+	return func(i *FolderImporter) {
+		if debounce > 0 {
+			i.debounce = debounce
+		}
+	}
+}
+
+func WithImportRetryDelay(delay time.Duration) FolderImporterOption {
+	return func(i *FolderImporter) {
+		if delay > 0 {
+			i.retryDelay = delay
+		}
+	}
+}
+
+// Run imports the files already in the watched folders, then watches them
+// for new and changed files until ctx is cancelled. Files are imported one at
+// a time once they have not changed for the debounce period.
+func (i *FolderImporter) Run(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to start the folder watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	for path := range i.folders {
+		for _, dir := range []string{ImportDoneDir, ImportFailedDir} {
+			if err := os.MkdirAll(filepath.Join(path, dir), 0o755); err != nil {
+				return fmt.Errorf("failed to prepare import folder %q: %w", path, err)
+			}
+		}
+		if err := watcher.Add(path); err != nil {
+			return fmt.Errorf("failed to watch import folder %q: %w", path, err)
+		}
+		slog.Info("watching import folder", "folder", path, "userID", i.folders[path].UserID)
+	}
+
+	ready := make(chan string)
+	pending := map[string]*time.Timer{}
+	scheduleAfter := func(path string, delay time.Duration) {
+		if timer, ok := pending[path]; ok {
+			timer.Reset(delay)
+			return
+		}
+		pending[path] = time.AfterFunc(delay, func() {
+			select {
+			case ready <- path:
+			case <-ctx.Done():
+			}
+		})
+	}
+	schedule := func(path string) {
+		scheduleAfter(path, i.debounce)
+	}
+	defer func() {
+		for _, timer := range pending {
+			timer.Stop()
+		}
+	}()
+
+	// Files copied while the importer was not running have no events.
+	for path := range i.folders {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return fmt.Errorf("failed to list import folder %q: %w", path, err)
+		}
+		for _, entry := range entries {
+			if entry.Type().IsRegular() && isImportFileName(entry.Name()) {
+				schedule(filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if !event.Has(fsnotify.Create) && !event.Has(fsnotify.Write) {
+				continue
+			}
+			if _, watched := i.folders[filepath.Dir(event.Name)]; watched && isImportFileName(filepath.Base(event.Name)) {
+				schedule(event.Name)
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			slog.Error("folder watcher error", "error", err)
+
+		case path := <-ready:
+			delete(pending, path)
+			if retry := i.importFile(ctx, i.folders[filepath.Dir(path)], path); retry {
+				scheduleAfter(path, i.retryDelay)
+			}
+		}
+	}
+}
+
+// importFile ingests a single file for the folder's user, moves it into the
+// done or failed subfolder and records the outcome in the folder's log. Files
+// that failed for an internal reason are left in place and it reports that
+// they should be tried again.
+func (i *FolderImporter) importFile(ctx context.Context, folder ImportFolder, path string) bool {
+	filename := filepath.Base(path)
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		// Moved away or already imported after a late event.
+		return false
+	}
+
+	var activity *Activity
+	if err == nil {
+		activity, err = i.activities.CreateActivityFromBytes(ctx, ActivityFilePayload{
+			Filename:     filename,
+			ContentType:  "application/octet-stream",
+			Data:         data,
+			LastModified: fileModTime(path),
+		}, folder.UserID)
+	}
+	if ctx.Err() != nil {
+		// Leave the file in place to be imported on the next start.
+		return false
+	}
+
+	result := NewFileResult(filename, activity, err)
+	if result.Reason == FailureInternal {
+		slog.Error("failed to import file, will retry", "file", path, "userID", folder.UserID, "retryIn", i.retryDelay, "error", err)
+		return true
+	}
+
+	target := ImportDoneDir
+	if result.Error != "" {
+		target = ImportFailedDir
+	}
+
+	moved, moveErr := moveImportedFile(path, filepath.Join(folder.Path, target))
+	if moveErr != nil {
+		slog.Error("failed to move imported file", "file", path, "error", moveErr)
+	}
+
+	switch {
+	case result.Error != "":
+		slog.Error("failed to import file", "file", path, "userID", folder.UserID, "reason", result.Reason, "error", result.Error)
+	case result.Skipped:
+		slog.Info("skipped already imported file", "file", path, "userID", folder.UserID, "activityID", result.ActivityID)
+	default:
+		slog.Info("imported file", "file", path, "userID", folder.UserID, "activityID", result.ActivityID)
+	}
+
+	if err := appendImportLog(folder.Path, filepath.Base(moved), result); err != nil {
+		slog.Error("failed to write import log", "folder", folder.Path, "error", err)
+	}
+	return false
+}
+
+// moveImportedFile moves the file into dir, adding a timestamp to its name if
+// a file of the same name was imported before. It returns the new path.
+func moveImportedFile(path, dir string) (string, error) {
+	target := filepath.Join(dir, filepath.Base(path))
+	if _, err := os.Lstat(target); err == nil {
+		ext := filepath.Ext(target)
+		target = fmt.Sprintf("%s-%s%s", strings.TrimSuffix(target, ext), time.Now().UTC().Format("20060102T150405"), ext)
+	}
+
+	if err := os.Rename(path, target); err != nil {
+		return path, err
+	}
+	return target, nil
+}
+
+// appendImportLog records one line per imported file: the time, the outcome,
+// the file name in its done or failed subfolder and the activity or error.
+func appendImportLog(folder, filename string, result FileResult) error {
+	file, err := os.OpenFile(filepath.Join(folder, ImportLogName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	var line string
+	switch {
+	case result.Error != "":
+		line = fmt.Sprintf("failed\t%s\t%s: %s", filename, result.Reason, result.Error)
+	case result.Skipped:
+		line = fmt.Sprintf("skipped\t%s\tduplicate of activity %d", filename, result.ActivityID)
+	default:
+		line = fmt.Sprintf("imported\t%s\tactivity %d", filename, result.ActivityID)
+	}
+
+	_, err = fmt.Fprintf(file, "%s\t%s\n", time.Now().UTC().Format(time.RFC3339), line)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// isImportFileName reports whether a file in a watched folder is an activity
+// file, leaving out hidden files such as partial copies of sync tools.
+func isImportFileName(name string) bool {
+	if strings.HasPrefix(name, ".") {
+		return false
+	}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".fit", ".gpx", ".tcx":
+		return true
+	}
+	return false
+}
+
+func fileModTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// folderIngestion records the files it ingests: "bad.fit" is corrupt,
+// "flaky.fit" fails internally until outage is cleared, everything else
+// becomes activity 42.
+type folderIngestion struct {
+	ActivityService
+
+	mu      sync.Mutex
+	files   []string
+	userIDs []string
+	outage  bool
+}
+
+func (s *folderIngestion) CreateActivityFromBytes(ctx context.Context, file ActivityFilePayload, userId string) (*Activity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.files = append(s.files, file.Filename)
+	s.userIDs = append(s.userIDs, userId)
+	if file.Filename == "bad.fit" {
+		return nil, ErrCorruptFile
+	}
+	if file.Filename == "flaky.fit" && s.outage {
+		return nil, errors.New("connection refused")
+	}
+	return &Activity{ID: 42}, nil
+}
+
+func (s *folderIngestion) ingested() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.files...)
+}
+
+func TestParseImportFolders(t *testing.T) {
+	folders, err := ParseImportFolders(" /nas/alice/=user-1, /nas/bob=user-2 ")
+	require.NoError(t, err)
+	assert.Equal(t, []ImportFolder{{Path: "/nas/alice", UserID: "user-1"}, {Path: "/nas/bob", UserID: "user-2"}}, folders)
+
+	for _, value := range []string{"", "/nas/alice", "=user-1", "/nas/alice=", "/nas/a=user-1,/nas/a/=user-2"} {
+		_, err := ParseImportFolders(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestFolderImporterMovesFilesByOutcome(t *testing.T) {
+	folder := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "existing.fit"), []byte{1}, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "notes.txt"), []byte{1}, 0o644))
+
+	activities := &folderIngestion{}
+	importer := NewFolderImporter(activities, []ImportFolder{{Path: folder, UserID: "user-1"}}, WithImportDebounce(50*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- importer.Run(ctx) }()
+
+	require.Eventually(t, func() bool { return len(activities.ingested()) == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	// Files copied while the importer runs, written in several steps.
+	for _, name := range []string{"bad.fit", "ride.FIT"} {
+		file, err := os.Create(filepath.Join(folder, name))
+		require.NoError(t, err)
+		_, err = file.Write([]byte{1})
+		require.NoError(t, err)
+		_, err = file.Write([]byte{2})
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+	}
+
+	require.Eventually(t, func() bool { return len(activities.ingested()) == 3 }, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+
+	assert.ElementsMatch(t, []string{"existing.fit", "bad.fit", "ride.FIT"}, activities.ingested())
+	assert.Equal(t, []string{"user-1", "user-1", "user-1"}, activities.userIDs)
+
+	assert.FileExists(t, filepath.Join(folder, ImportDoneDir, "existing.fit"))
+	assert.FileExists(t, filepath.Join(folder, ImportDoneDir, "ride.FIT"))
+	assert.FileExists(t, filepath.Join(folder, ImportFailedDir, "bad.fit"))
+	assert.FileExists(t, filepath.Join(folder, "notes.txt"))
+	assert.NoFileExists(t, filepath.Join(folder, "existing.fit"))
+
+	log, err := os.ReadFile(filepath.Join(folder, ImportLogName))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(log)), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, string(log), "imported\texisting.fit\tactivity 42")
+	assert.Contains(t, string(log), "failed\tbad.fit\tcorrupt_file")
+}
+
+func TestMoveImportedFileKeepsEarlierImports(t *testing.T) {
+	folder := t.TempDir()
+	done := filepath.Join(folder, ImportDoneDir)
+	require.NoError(t, os.Mkdir(done, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(done, "ride.fit"), []byte("first"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "ride.fit"), []byte("second"), 0o644))
+
+	moved, err := moveImportedFile(filepath.Join(folder, "ride.fit"), done)
+	require.NoError(t, err)
+	assert.NotEqual(t, filepath.Join(done, "ride.fit"), moved)
+	assert.True(t, strings.HasPrefix(filepath.Base(moved), "ride-"))
+
+	first, err := os.ReadFile(filepath.Join(done, "ride.fit"))
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(first))
+}
+
+func TestFolderImporterRetriesInternalFailures(t *testing.T) {
+	folder := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "flaky.fit"), []byte{1}, 0o644))
+
+	activities := &folderIngestion{outage: true}
+	importer := NewFolderImporter(activities, []ImportFolder{{Path: folder, UserID: "user-1"}},
+		WithImportDebounce(10*time.Millisecond), WithImportRetryDelay(50*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- importer.Run(ctx) }()
+
+	require.Eventually(t, func() bool { return len(activities.ingested()) >= 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.FileExists(t, filepath.Join(folder, "flaky.fit"), "left in place during the outage")
+	assert.NoFileExists(t, filepath.Join(folder, ImportFailedDir, "flaky.fit"))
+
+	activities.mu.Lock()
+	activities.outage = false
+	activities.mu.Unlock()
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(filepath.Join(folder, ImportDoneDir, "flaky.fit"))
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+
+	log, err := os.ReadFile(filepath.Join(folder, ImportLogName))
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(log), "\n"), "only the final outcome is logged")
+	assert.Contains(t, string(log), "imported\tflaky.fit\tactivity 42")
+}
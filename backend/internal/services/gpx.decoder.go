@@ -0,0 +1,145 @@
+package service
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/tormoder/fit"
+)
+
+// gpxFile mirrors the parts of a GPX 1.1 document we ingest. Element names are
+// matched on their local name so both the plain and the Garmin
+// TrackPointExtension namespaces (gpxtpx, ns3, ...) are picked up.
+type gpxFile struct {
+	XMLName  xml.Name    `xml:"gpx"`
+	Metadata gpxMetadata `xml:"metadata"`
+	Tracks   []gpxTrack  `xml:"trk"`
+}
+
+type gpxMetadata struct {
+	Name string    `xml:"name"`
+	Time time.Time `xml:"time"`
+}
+
+type gpxTrack struct {
+	Name     string       `xml:"name"`
+	Type     string       `xml:"type"`
+	Segments []gpxSegment `xml:"trkseg"`
+}
+
+type gpxSegment struct {
+	Points []gpxTrackPoint `xml:"trkpt"`
+}
+
+type gpxTrackPoint struct {
+	Lat        float64             `xml:"lat,attr"`
+	Lon        float64             `xml:"lon,attr"`
+	Elevation  *float64            `xml:"ele"`
+	Time       time.Time           `xml:"time"`
+	Extensions gpxTrackPointExtras `xml:"extensions"`
+}
+
+type gpxTrackPointExtras struct {
+	TrackPoint gpxTrackPointExtension `xml:"TrackPointExtension"`
+	// Power is written directly under extensions by Strava and Wahoo.
+	Power *uint16 `xml:"power"`
+}
+
+type gpxTrackPointExtension struct {
+	AirTemperature *float64 `xml:"atemp"`
+	HeartRate      *uint8   `xml:"hr"`
+	Cadence        *uint8   `xml:"cad"`
+}
+
+func decodeGPX(r io.Reader) (*decodedActivity, error) {
+	var doc gpxFile
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode gpx file: %v", ErrCorruptFile, err)
+	}
+
+	var points []gpxTrackPoint
+	var name string
+	for _, track := range doc.Tracks {
+		if name == "" {
+			name = strings.TrimSpace(track.Name)
+		}
+		for _, segment := range track.Segments {
+			points = append(points, segment.Points...)
+		}
+	}
+
+	if len(points) == 0 {
+		return nil, fmt.Errorf("%w: gpx file contains no track points", ErrNoRecords)
+	}
+
+	if name == "" {
+		name = strings.TrimSpace(doc.Metadata.Name)
+	}
+
+	records := make([]*fit.RecordMsg, 0, len(points))
+
+	for _, point := range points {
+		record := fit.NewRecordMsg()
+		record.Timestamp = point.Time
+		record.PositionLat = fit.NewLatitudeDegrees(point.Lat)
+		record.PositionLong = fit.NewLongitudeDegrees(point.Lon)
+
+		if point.Elevation != nil {
+			record.Altitude = toFitAltitude(*point.Elevation)
+			record.EnhancedAltitude = uint32(record.Altitude)
+		}
+
+		ext := point.Extensions.TrackPoint
+		if ext.HeartRate != nil {
+			record.HeartRate = *ext.HeartRate
+		}
+		if ext.Cadence != nil {
+			record.Cadence = *ext.Cadence
+		}
+		if ext.AirTemperature != nil {
+			record.Temperature = int8(math.Round(*ext.AirTemperature))
+		}
+		if point.Extensions.Power != nil {
+			record.Power = *point.Extensions.Power
+		}
+
+		records = append(records, record)
+	}
+	deriveTrackMotion(records)
+
+	activity := &decodedActivity{
+		Name:            name,
+		StartTime:       points[0].Time,
+		Records:         records,
+		MotionFromTrack: true,
+	}
+
+	if activity.StartTime.IsZero() {
+		activity.StartTime = doc.Metadata.Time
+	}
+
+	last := points[len(points)-1].Time
+	if !points[0].Time.IsZero() && last.After(points[0].Time) {
+		activity.TotalDuration = last.Sub(points[0].Time)
+		activity.TimerDuration = activity.TotalDuration
+	}
+
+	return activity, nil
+}
+
+// toFitAltitude converts meters into the FIT altitude encoding (scale 5,
+// offset 500) used by the records table.
+func toFitAltitude(meters float64) uint16 {
+	scaled := math.Round((meters + 500) * 5)
+	return uint16(math.Max(0, math.Min(scaled, math.MaxUint16-1)))
+}
+
+// toFitSpeed converts meters per second into the FIT speed encoding (mm/s).
+func toFitSpeed(metersPerSecond float64) uint16 {
+	scaled := math.Round(metersPerSecond * 1000)
+	return uint16(math.Max(0, math.Min(scaled, math.MaxUint16-1)))
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleGPX = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="test" xmlns="http://www.topografix.com/GPX/1/1"
+     xmlns:gpxtpx="http://www.garmin.com/xmlschemas/TrackPointExtension/v1">
+  <trk>
+    <name>Morning loop</name>
+    <trkseg>
+      <trkpt lat="55.6380" lon="12.5480">
+        <ele>12.4</ele>
+        <time>2024-05-01T06:00:00Z</time>
+        <extensions>
+          <gpxtpx:TrackPointExtension>
+            <gpxtpx:atemp>14</gpxtpx:atemp>
+            <gpxtpx:hr>120</gpxtpx:hr>
+            <gpxtpx:cad>85</gpxtpx:cad>
+          </gpxtpx:TrackPointExtension>
+        </extensions>
+      </trkpt>
+      <trkpt lat="55.6390" lon="12.5480">
+        <ele>13.0</ele>
+        <time>2024-05-01T06:00:20Z</time>
+        <extensions>
+          <gpxtpx:TrackPointExtension>
+            <gpxtpx:hr>125</gpxtpx:hr>
+            <gpxtpx:cad>88</gpxtpx:cad>
+          </gpxtpx:TrackPointExtension>
+          <power>210</power>
+        </extensions>
+      </trkpt>
+    </trkseg>
+  </trk>
+</gpx>`
+
+func TestDecodeGPX(t *testing.T) {
+	activity, err := decodeGPX(strings.NewReader(sampleGPX))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Morning loop", activity.Name)
+	assert.Equal(t, 20*time.Second, activity.TotalDuration)
+	require.Len(t, activity.Records, 2)
+
+	first, second := activity.Records[0], activity.Records[1]
+	assert.InDelta(t, 55.6380, first.PositionLat.Degrees(), 1e-6)
+	assert.InDelta(t, 12.5480, first.PositionLong.Degrees(), 1e-6)
+	assert.Equal(t, uint8(120), first.HeartRate)
+	assert.Equal(t, uint8(85), first.Cadence)
+	assert.Equal(t, int8(14), first.Temperature)
+	assert.Equal(t, uint16((12.4+500)*5), first.Altitude)
+
+	// ~111 m between the points, covered in 20 s.
+	assert.InDelta(t, 11120, float64(second.Distance), 20)
+	assert.InDelta(t, 5560, float64(second.Speed), 10)
+	assert.Equal(t, uint8(125), second.HeartRate)
+	assert.Equal(t, uint16(0xFFFF), first.Power)
+	assert.Equal(t, uint16(210), second.Power)
+}
+
+func TestDecodeGPXWithoutTrackPoints(t *testing.T) {
+	_, err := decodeGPX(strings.NewReader(`<gpx version="1.1"><trk><trkseg></trkseg></trk></gpx>`))
+	assert.Error(t, err)
+}
+
+func TestDetectActivityFormat(t *testing.T) {
+	fitHeader := []byte{14, 0x10, 0, 0, 0, 0, 0, 0, '.', 'F', 'I', 'T', 0, 0}
+
+	assert.Equal(t, formatFIT, detectActivityFormat("ride.bin", fitHeader))
+	assert.Equal(t, formatGPX, detectActivityFormat("ride", []byte(sampleGPX)))
+	assert.Equal(t, formatGPX, detectActivityFormat("ride.GPX", []byte("garbage")))
+	assert.Equal(t, formatFIT, detectActivityFormat("ride.fit", []byte("garbage")))
+	assert.Equal(t, formatUnknown, detectActivityFormat("ride.txt", []byte("garbage")))
+}
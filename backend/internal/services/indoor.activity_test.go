@@ -0,0 +1,50 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tormoder/fit"
+)
+
+func trainerRecords(start time.Time, speeds ...uint16) []*fit.RecordMsg {
+	records := make([]*fit.RecordMsg, len(speeds))
+	for i, speed := range speeds {
+		records[i] = fit.NewRecordMsg()
+		records[i].Timestamp = start.Add(time.Duration(i) * time.Second)
+		records[i].Speed = speed
+	}
+	return records
+}
+
+func TestProcessRecordsKeepsRecordsWithoutPosition(t *testing.T) {
+	start := time.Date(2024, 1, 10, 18, 0, 0, 0, time.UTC)
+	records := trainerRecords(start, 10000, 10000, 10000)
+	for i, record := range records {
+		record.Distance = uint32(i * 1000)
+	}
+
+	entities, stats, err := (&activityService{}).processRecords(records)
+	require.NoError(t, err)
+	require.Len(t, entities, 3)
+
+	assert.False(t, entities[0].Position.Valid)
+	assert.True(t, stats.Indoor)
+	assert.Equal(t, 0.02, stats.Distance.InexactFloat64())
+	assert.Equal(t, 36.0, stats.AvgSpeed.InexactFloat64())
+}
+
+func TestProcessRecordsWithoutRecords(t *testing.T) {
+	_, _, err := (&activityService{}).processRecords(nil)
+	assert.ErrorIs(t, err, ErrNoRecords)
+}
+
+func TestSensorDistanceFallsBackToSpeed(t *testing.T) {
+	start := time.Date(2024, 1, 10, 18, 0, 0, 0, time.UTC)
+
+	// 10 m/s for 60 seconds.
+	records := trainerRecords(start, repeatWatts(10000, 61)...)
+	assert.InDelta(t, 0.6, sensorDistance(records), 1e-9)
+}
@@ -0,0 +1,335 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/notaduck/backend/internal/db"
+	"github.com/notaduck/backend/internal/repositories"
+)
+
+// JobStatus is the state of an ingestion job.
+type JobStatus string
+
+const (
+	JobQueued  JobStatus = "queued"
+	JobRunning JobStatus = "running"
+	JobDone    JobStatus = "done"
+	JobFailed  JobStatus = "failed"
+)
+
+const (
+	// DefaultIngestionWorkers is the number of jobs ingested concurrently.
+	DefaultIngestionWorkers = 4
+	// jobPollInterval is how often idle workers look for jobs queued by other
+	// server instances.
+	jobPollInterval = 2 * time.Second
+	// staleJobTimeout is how long a job may run before it is assumed to be
+	// abandoned by its worker and queued again.
+	staleJobTimeout = 15 * time.Minute
+	// maxListedJobs caps the number of jobs returned by GetIngestionJobs.
+	maxListedJobs = 100
+	// maxIngestionAttempts is how often a job is claimed before it is failed,
+	// so a file that crashes or hangs its worker is not retried forever.
+	maxIngestionAttempts = 3
+)
+
+// errIngestionAttemptsExhausted is the error of a job failed after
+// maxIngestionAttempts attempts.
+var errIngestionAttemptsExhausted = fmt.Errorf("the file could not be processed after %d attempts", maxIngestionAttempts)
+
+// IngestionJob tracks an uploaded file through asynchronous ingestion. Once
+// done it holds the same outcome as a FileResult.
+type IngestionJob struct {
+	ID         int64         `json:"id"`
+	Filename   string        `json:"filename"`
+	Status     JobStatus     `json:"status"`
+	ActivityID *int32        `json:"activityId,omitempty"`
+	Skipped    bool          `json:"skipped,omitempty"`
+	Reason     FailureReason `json:"reason,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	CreatedAt  time.Time     `json:"createdAt"`
+	StartedAt  *time.Time    `json:"startedAt,omitempty"`
+	FinishedAt *time.Time    `json:"finishedAt,omitempty"`
+}
+
+type IngestionService interface {
+	// Enqueue queues the files for ingestion and returns their jobs without
+	// waiting for them to be processed.
+	Enqueue(ctx context.Context, files []ActivityFilePayload, userID string) ([]IngestionJob, error)
+	// GetIngestionJobs returns the jobs with the given ids, or the user's most
+	// recent jobs when ids is empty.
+	GetIngestionJobs(ctx context.Context, userID string, ids []int64) ([]IngestionJob, error)
+	// Run ingests queued jobs with a pool of workers until ctx is cancelled,
+	// and returns once the jobs in progress were completed or handed back to
+	// the queue.
+	Run(ctx context.Context)
+}
+
+type ingestionService struct {
+	jobs       repositories.IngestionJobRepository
+	activities ActivityService
+	workers    int
+	// staleJobInterval is how often jobs running for longer than
+	// staleJobTimeout are looked for.
+	staleJobInterval time.Duration
+	// wake signals idle workers that a job was queued by this instance.
+	wake chan struct{}
+}
+
+type IngestionServiceOption func(*ingestionService)
+
+func NewIngestionService(jobs repositories.IngestionJobRepository, activities ActivityService, options ...IngestionServiceOption) IngestionService {
+	service := &ingestionService{
+		jobs:       jobs,
+		activities: activities,
+		workers:    DefaultIngestionWorkers,
+
+		staleJobInterval: staleJobTimeout / 2,
+	}
+
+	for _, option := range options {
+		option(service)
+	}
+
+	service.wake = make(chan struct{}, service.workers)
+
+	return service
+}
+
+// ParseIngestionWorkers parses the worker count, defaulting to
+// DefaultIngestionWorkers when empty.
+func ParseIngestionWorkers(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return DefaultIngestionWorkers, nil
+	}
+
+	workers, err := strconv.Atoi(value)
+	if err != nil || workers < 1 {
+		return 0, fmt.Errorf("invalid ingestion worker count %q: expected a positive number", value)
+	}
+	return workers, nil
+}
+
+func WithIngestionWorkers(workers int) IngestionServiceOption {
+	return func(s *ingestionService) {
+		if workers > 0 {
+			s.workers = workers
+		}
+	}
+}
+
+func (s *ingestionService) Enqueue(ctx context.Context, files []ActivityFilePayload, userId string) ([]IngestionJob, error) {
+	jobs := make([]IngestionJob, 0, len(files))
+
+	for _, file := range files {
+		row, err := s.jobs.EnqueueIngestionJob(ctx, db.EnqueueIngestionJobParams{
+			UserID:       userId,
+			Filename:     file.Filename,
+			ContentType:  file.ContentType,
+			LastModified: pgtype.Timestamptz{Time: file.LastModified, Valid: !file.LastModified.IsZero()},
+			Data:         file.Data,
+			ActivityName: file.Name,
+			RideType:     file.RideType,
+			Description:  file.Description,
+		})
+		if err != nil {
+			slog.Error("failed to enqueue ingestion job", "filename", file.Filename, "error", err)
+			return nil, err
+		}
+
+		jobs = append(jobs, IngestionJob{
+			ID:        row.ID,
+			Filename:  row.Filename,
+			Status:    JobStatus(row.Status),
+			CreatedAt: row.CreatedAt.Time,
+		})
+
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+
+	return jobs, nil
+}
+
+func (s *ingestionService) GetIngestionJobs(ctx context.Context, userId string, ids []int64) ([]IngestionJob, error) {
+	if ids == nil {
+		ids = []int64{}
+	}
+
+	rows, err := s.jobs.GetIngestionJobs(ctx, db.GetIngestionJobsParams{
+		UserID:   userId,
+		Ids:      ids,
+		RowLimit: maxListedJobs,
+	})
+	if err != nil {
+		slog.Error("failed to retrieve ingestion jobs", "userID", userId, "error", err)
+		return nil, err
+	}
+
+	return convertIngestionJobs(rows), nil
+}
+
+func (s *ingestionService) Run(ctx context.Context) {
+	slog.Info("starting ingestion workers", "workers", s.workers)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.requeueStaleJobs(ctx)
+	}()
+	for range s.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.work(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+// requeueStaleJobs hands jobs running for longer than staleJobTimeout back to
+// the queue, or fails them once their attempts are used up, at startup and
+// then periodically until ctx is cancelled. This covers jobs whose worker
+// died or hung, here or in another server instance.
+func (s *ingestionService) requeueStaleJobs(ctx context.Context) {
+	ticker := time.NewTicker(s.staleJobInterval)
+	defer ticker.Stop()
+
+	for {
+		requeued, err := s.jobs.RequeueStaleIngestionJobs(ctx, db.RequeueStaleIngestionJobsParams{
+			MaxAttempts:    maxIngestionAttempts,
+			ExhaustedError: errIngestionAttemptsExhausted.Error(),
+			StartedBefore:  pgtype.Timestamptz{Time: time.Now().Add(-staleJobTimeout), Valid: true},
+		})
+		if err != nil && ctx.Err() == nil {
+			slog.Error("failed to requeue stale ingestion jobs", "error", err)
+		} else if requeued > 0 {
+			slog.Info("requeued or failed stale ingestion jobs", "jobs", requeued)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// work processes jobs until the queue is empty, then waits to be woken by a
+// new job or the poll interval.
+func (s *ingestionService) work(ctx context.Context) {
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		processed, err := s.processNext(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.Error("failed to process ingestion job", "error", err)
+		}
+		if processed && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// processNext claims the oldest queued job and ingests it. It reports whether
+// a job was claimed.
+func (s *ingestionService) processNext(ctx context.Context) (bool, error) {
+	job, err := s.jobs.ClaimIngestionJob(ctx, maxIngestionAttempts)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	activity, err := s.activities.CreateActivityFromBytes(ctx, ActivityFilePayload{
+		Filename:     job.Filename,
+		ContentType:  job.ContentType,
+		Data:         job.Data,
+		LastModified: job.LastModified.Time,
+		Name:         job.ActivityName,
+		RideType:     job.RideType,
+		Description:  job.Description,
+	}, job.UserID)
+
+	// A job interrupted by shutdown is handed back to the queue instead of
+	// being failed.
+	if ctx.Err() != nil {
+		return true, s.jobs.RequeueIngestionJob(context.WithoutCancel(ctx), db.RequeueIngestionJobParams{
+			ID:             job.ID,
+			MaxAttempts:    maxIngestionAttempts,
+			ExhaustedError: errIngestionAttemptsExhausted.Error(),
+		})
+	}
+
+	if err != nil {
+		slog.Error("failed to ingest queued file", "jobID", job.ID, "filename", job.Filename, "error", err)
+	}
+
+	result := NewFileResult(job.Filename, activity, err)
+	return true, s.jobs.CompleteIngestionJob(ctx, completeJobParams(job.ID, result))
+}
+
+func completeJobParams(id int64, result FileResult) db.CompleteIngestionJobParams {
+	status := JobDone
+	if result.Reason != "" {
+		status = JobFailed
+	}
+
+	return db.CompleteIngestionJobParams{
+		ID:            id,
+		Status:        string(status),
+		ActivityID:    pgtype.Int4{Int32: result.ActivityID, Valid: result.ActivityID != 0},
+		Skipped:       result.Skipped,
+		FailureReason: string(result.Reason),
+		Error:         result.Error,
+	}
+}
+
+func convertIngestionJobs(rows []db.GetIngestionJobsRow) []IngestionJob {
+	optionalTime := func(value pgtype.Timestamptz) *time.Time {
+		if !value.Valid {
+			return nil
+		}
+		return &value.Time
+	}
+
+	jobs := make([]IngestionJob, len(rows))
+	for i, row := range rows {
+		jobs[i] = IngestionJob{
+			ID:         row.ID,
+			Filename:   row.Filename,
+			Status:     JobStatus(row.Status),
+			ActivityID: optionalInt4(row.ActivityID),
+			Skipped:    row.Skipped,
+			Reason:     FailureReason(row.FailureReason),
+			Error:      row.Error,
+			CreatedAt:  row.CreatedAt.Time,
+			StartedAt:  optionalTime(row.StartedAt),
+			FinishedAt: optionalTime(row.FinishedAt),
+		}
+	}
+	return jobs
+}
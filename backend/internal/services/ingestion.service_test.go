@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/notaduck/backend/internal/db"
+	"github.com/notaduck/backend/internal/repositories"
+)
+
+// memoryJobRepository is an in-memory ingestion queue.
+type memoryJobRepository struct {
+	repositories.IngestionJobRepository
+
+	mu        sync.Mutex
+	queued    []db.ClaimIngestionJobRow
+	running   map[int64]db.ClaimIngestionJobRow
+	attempts  map[int64]int32
+	completed map[int64]db.CompleteIngestionJobParams
+	requeued  []int64
+	// staleSweeps counts the calls to RequeueStaleIngestionJobs.
+	staleSweeps int
+}
+
+func (r *memoryJobRepository) EnqueueIngestionJob(ctx context.Context, params db.EnqueueIngestionJobParams) (db.EnqueueIngestionJobRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := int64(len(r.queued) + len(r.completed) + 1)
+	r.queued = append(r.queued, db.ClaimIngestionJobRow{
+		ID:           id,
+		UserID:       params.UserID,
+		Filename:     params.Filename,
+		ContentType:  params.ContentType,
+		LastModified: params.LastModified,
+		Data:         params.Data,
+		ActivityName: params.ActivityName,
+	})
+	return db.EnqueueIngestionJobRow{ID: id, Filename: params.Filename, Status: string(JobQueued)}, nil
+}
+
+func (r *memoryJobRepository) ClaimIngestionJob(ctx context.Context, maxAttempts int32) (db.ClaimIngestionJobRow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.attempts == nil {
+		r.attempts = map[int64]int32{}
+		r.running = map[int64]db.ClaimIngestionJobRow{}
+	}
+	for i, job := range r.queued {
+		if r.attempts[job.ID] >= maxAttempts {
+			continue
+		}
+		r.queued = append(r.queued[:i:i], r.queued[i+1:]...)
+		r.attempts[job.ID]++
+		r.running[job.ID] = job
+		return job, nil
+	}
+	return db.ClaimIngestionJobRow{}, pgx.ErrNoRows
+}
+
+func (r *memoryJobRepository) CompleteIngestionJob(ctx context.Context, params db.CompleteIngestionJobParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.completed == nil {
+		r.completed = map[int64]db.CompleteIngestionJobParams{}
+	}
+	r.completed[params.ID] = params
+	return nil
+}
+
+func (r *memoryJobRepository) RequeueIngestionJob(ctx context.Context, params db.RequeueIngestionJobParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job := r.running[params.ID]
+	delete(r.running, params.ID)
+	if r.attempts[params.ID] >= params.MaxAttempts {
+		if r.completed == nil {
+			r.completed = map[int64]db.CompleteIngestionJobParams{}
+		}
+		r.completed[params.ID] = db.CompleteIngestionJobParams{
+			ID:            params.ID,
+			Status:        string(JobFailed),
+			FailureReason: string(FailureInternal),
+			Error:         params.ExhaustedError,
+		}
+		return nil
+	}
+	r.requeued = append(r.requeued, params.ID)
+	r.queued = append(r.queued, job)
+	return nil
+}
+
+func (r *memoryJobRepository) RequeueStaleIngestionJobs(ctx context.Context, params db.RequeueStaleIngestionJobsParams) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.staleSweeps++
+	return 0, nil
+}
+
+func (r *memoryJobRepository) sweeps() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.staleSweeps
+}
+
+// stubIngestion ingests files by name: "bad.fit" is corrupt, everything else
+// becomes activity 42.
+type stubIngestion struct {
+	ActivityService
+	names []string
+	files []ActivityFilePayload
+}
+
+func (s *stubIngestion) CreateActivityFromBytes(ctx context.Context, file ActivityFilePayload, userId string) (*Activity, error) {
+	s.names = append(s.names, file.Name)
+	s.files = append(s.files, file)
+	if file.Filename == "bad.fit" {
+		return nil, ErrCorruptFile
+	}
+	return &Activity{ID: 42}, nil
+}
+
+func TestIngestionWorkerProcessesQueuedJobs(t *testing.T) {
+	ctx := context.Background()
+	jobs := &memoryJobRepository{}
+	activities := &stubIngestion{}
+	ingestion := NewIngestionService(jobs, activities).(*ingestionService)
+	lastModified := time.Date(2024, 5, 1, 6, 0, 0, 0, time.UTC)
+
+	queued, err := ingestion.Enqueue(ctx, []ActivityFilePayload{
+		{Filename: "ride.fit", ContentType: "application/vnd.ant.fit", Data: []byte{1}, Name: "Morning ride", LastModified: lastModified},
+		{Filename: "bad.fit", Data: []byte{2}},
+	}, "user")
+	require.NoError(t, err)
+	require.Len(t, queued, 2)
+	assert.Equal(t, JobQueued, queued[0].Status)
+
+	for range queued {
+		processed, err := ingestion.processNext(ctx)
+		require.NoError(t, err)
+		assert.True(t, processed)
+	}
+
+	processed, err := ingestion.processNext(ctx)
+	require.NoError(t, err)
+	assert.False(t, processed)
+
+	done := jobs.completed[queued[0].ID]
+	assert.Equal(t, string(JobDone), done.Status)
+	assert.Equal(t, pgtype.Int4{Int32: 42, Valid: true}, done.ActivityID)
+	assert.Equal(t, "Morning ride", activities.names[0])
+	assert.Equal(t, "application/vnd.ant.fit", activities.files[0].ContentType, "the content type survives the queue")
+	assert.Equal(t, lastModified, activities.files[0].LastModified)
+	assert.True(t, activities.files[1].LastModified.IsZero())
+
+	failed := jobs.completed[queued[1].ID]
+	assert.Equal(t, string(JobFailed), failed.Status)
+	assert.Equal(t, string(FailureCorruptFile), failed.FailureReason)
+	assert.False(t, failed.ActivityID.Valid)
+}
+
+func TestIngestionWorkerRequeuesJobOnShutdown(t *testing.T) {
+	jobs := &memoryJobRepository{}
+	ingestion := NewIngestionService(jobs, &stubIngestion{}).(*ingestionService)
+
+	queued, err := ingestion.Enqueue(context.Background(), []ActivityFilePayload{{Filename: "ride.fit", Data: []byte{1}}}, "user")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	processed, err := ingestion.processNext(ctx)
+	require.NoError(t, err)
+	assert.True(t, processed)
+	assert.Equal(t, []int64{queued[0].ID}, jobs.requeued)
+	assert.Empty(t, jobs.completed)
+}
+
+func TestParseIngestionWorkers(t *testing.T) {
+	workers, err := ParseIngestionWorkers("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultIngestionWorkers, workers)
+
+	workers, err = ParseIngestionWorkers("8")
+	require.NoError(t, err)
+	assert.Equal(t, 8, workers)
+
+	_, err = ParseIngestionWorkers("0")
+	assert.Error(t, err)
+}
+
+func TestIngestionWorkerFailsJobAfterMaxAttempts(t *testing.T) {
+	jobs := &memoryJobRepository{}
+	ingestion := NewIngestionService(jobs, &stubIngestion{}).(*ingestionService)
+
+	queued, err := ingestion.Enqueue(context.Background(), []ActivityFilePayload{{Filename: "ride.fit", Data: []byte{1}}}, "user")
+	require.NoError(t, err)
+
+	// Every attempt is interrupted, as by a worker that keeps going away.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for range maxIngestionAttempts {
+		processed, err := ingestion.processNext(ctx)
+		require.NoError(t, err)
+		assert.True(t, processed)
+	}
+
+	processed, err := ingestion.processNext(context.Background())
+	require.NoError(t, err)
+	assert.False(t, processed, "no attempts left")
+
+	failed := jobs.completed[queued[0].ID]
+	assert.Equal(t, string(JobFailed), failed.Status)
+	assert.Equal(t, string(FailureInternal), failed.FailureReason)
+	assert.Equal(t, errIngestionAttemptsExhausted.Error(), failed.Error)
+	assert.Len(t, jobs.requeued, maxIngestionAttempts-1)
+}
+
+func TestIngestionRunRequeuesStaleJobsPeriodically(t *testing.T) {
+	jobs := &memoryJobRepository{}
+	ingestion := NewIngestionService(jobs, &stubIngestion{}, WithIngestionWorkers(1)).(*ingestionService)
+	ingestion.staleJobInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		ingestion.Run(ctx)
+		close(stopped)
+	}()
+
+	assert.Eventually(t, func() bool { return jobs.sweeps() >= 3 }, time.Second, 5*time.Millisecond,
+		"stale jobs are looked for while the workers run, not only at startup")
+
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after the context was cancelled")
+	}
+}
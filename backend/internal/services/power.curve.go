@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/notaduck/backend/internal/db"
+	"github.com/notaduck/backend/internal/repositories"
+)
+
+// ErrInvalidPowerCurveFilter is returned for a power curve request with an
+// unknown ride type or a date range that ends before it starts.
+var ErrInvalidPowerCurveFilter = errors.New("invalid power curve filter")
+
+// powerCurveDurations are the durations, in seconds, the mean-maximal power
+// is stored for. They are dense at the short end where the curve is steep
+// and include the usual 5 s, 1, 5, 20 and 60 min marks.
+var powerCurveDurations = []int32{
+	1, 2, 3, 5, 10, 15, 20, 30, 45,
+	60, 90, 120, 180, 240, 300, 420, 600, 900, 1200, 1800, 2700,
+	3600, 5400, 7200, 10800, 14400, 18000,
+}
+
+// PowerCurvePoint is the best average power held for Duration. On an
+// envelope curve ActivityID and Date name the ride that set it.
+type PowerCurvePoint struct {
+	Duration   int32      `json:"duration"`
+	Watts      int32      `json:"watts"`
+	ActivityID int32      `json:"activityId,omitempty"`
+	Date       *time.Time `json:"date,omitempty"`
+}
+
+// PowerCurveFilter selects the rides an envelope curve is built from. A zero
+// From includes every ride up to To; an empty RideType includes every type.
+type PowerCurveFilter struct {
+	From     time.Time
+	To       time.Time
+	RideType string
+}
+
+// meanMaximalPower returns the highest average power of the per-second
+// series over each of powerCurveDurations, skipping the durations longer
+// than the series. The curve is empty for an empty series.
+func meanMaximalPower(series []float64) db.CreateActivityPowerCurveParams {
+	var curve db.CreateActivityPowerCurveParams
+	if len(series) == 0 {
+		return curve
+	}
+
+	sums := make([]float64, len(series)+1)
+	for i, watts := range series {
+		sums[i+1] = sums[i] + watts
+	}
+
+	for _, duration := range powerCurveDurations {
+		window := int(duration)
+		if window > len(series) {
+			break
+		}
+
+		var best float64
+		for end := window; end < len(sums); end++ {
+			best = math.Max(best, sums[end]-sums[end-window])
+		}
+
+		curve.Durations = append(curve.Durations, duration)
+		curve.Watts = append(curve.Watts, int32(math.Round(best/float64(window))))
+	}
+
+	return curve
+}
+
+func convertPowerCurve(row db.GetActivityPowerCurveRow) []PowerCurvePoint {
+	points := make([]PowerCurvePoint, 0, len(row.Durations))
+	for i, duration := range row.Durations {
+		if i >= len(row.Watts) {
+			break
+		}
+		points = append(points, PowerCurvePoint{Duration: duration, Watts: row.Watts[i]})
+	}
+	return points
+}
+
+// loadPowerCurve loads the stored curve of an activity, which is empty for
+// rides without power data.
+func loadPowerCurve(ctx context.Context, activities repositories.ActivityRepository, activityId int32) ([]PowerCurvePoint, error) {
+	row, err := activities.GetActivityPowerCurve(ctx, activityId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return convertPowerCurve(row), nil
+}
+
+// GetPowerCurve returns the envelope of the power curves of the user's rides
+// in the filter's date range: for each duration the best power of any ride.
+func (s *activityService) GetPowerCurve(ctx context.Context, userId string, filter PowerCurveFilter) ([]PowerCurvePoint, error) {
+	if filter.RideType != "" && !IsRideType(filter.RideType) {
+		return nil, fmt.Errorf("%w: unknown ride type %q", ErrInvalidPowerCurveFilter, filter.RideType)
+	}
+	if filter.To.IsZero() {
+		filter.To = time.Now()
+	}
+	if filter.From.After(filter.To) {
+		return nil, fmt.Errorf("%w: the range starts after it ends", ErrInvalidPowerCurveFilter)
+	}
+
+	rows, err := s.activityRepo.GetPowerCurveEnvelope(ctx, db.GetPowerCurveEnvelopeParams{
+		UserID:   userId,
+		FromDate: pgtype.Timestamptz{Time: filter.From, Valid: true},
+		ToDate:   pgtype.Timestamptz{Time: filter.To, Valid: true},
+		RideType: pgtype.Text{String: filter.RideType, Valid: filter.RideType != ""},
+	})
+	if err != nil {
+		slog.Error("failed to get power curve", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	points := make([]PowerCurvePoint, len(rows))
+	for i, row := range rows {
+		points[i] = PowerCurvePoint{
+			Duration:   row.Duration,
+			Watts:      row.Watts,
+			ActivityID: row.ActivityID,
+			Date:       &row.DateOfActivity.Time,
+		}
+	}
+	return points, nil
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeanMaximalPower(t *testing.T) {
+	start := time.Date(2024, 5, 1, 6, 0, 0, 0, time.UTC)
+
+	// Ten minutes at 200 W with a 5 second sprint at 800 W and a minute at
+	// 400 W in the middle.
+	watts := repeatWatts(200, 300)
+	watts = append(watts, repeatWatts(800, 5)...)
+	watts = append(watts, repeatWatts(400, 60)...)
+	watts = append(watts, repeatWatts(200, 235)...)
+
+	curve := meanMaximalPower(powerSeries(powerRecords(start, watts...)))
+
+	best := map[int32]int32{}
+	for i, duration := range curve.Durations {
+		best[duration] = curve.Watts[i]
+	}
+	assert.Equal(t, int32(800), best[1])
+	assert.Equal(t, int32(800), best[5])
+	assert.Equal(t, int32(433), best[60], "the sprint and 55 s at 400 W")
+	assert.Equal(t, int32(225), best[600])
+	assert.Equal(t, int32(600), curve.Durations[len(curve.Durations)-1], "no durations beyond the ride")
+
+	for i := 1; i < len(curve.Watts); i++ {
+		assert.LessOrEqual(t, curve.Watts[i], curve.Watts[i-1], "the curve never rises")
+	}
+}
+
+func TestMeanMaximalPowerWithoutPower(t *testing.T) {
+	curve := meanMaximalPower(nil)
+	assert.Empty(t, curve.Durations)
+	assert.Empty(t, curve.Watts)
+}
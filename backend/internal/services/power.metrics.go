@@ -0,0 +1,121 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"github.com/tormoder/fit"
+)
+
+// normalizedPowerWindow is the rolling average window of normalized power.
+const normalizedPowerWindow = 30
+
+// maxPowerGap is the longest gap between two power samples that is filled by
+// holding the previous sample. Longer gaps are pauses and count as a single
+// second, so auto-paused stops do not drag the averages down.
+const maxPowerGap = 5 * time.Second
+
+// powerMetrics summarises the power data of a ride. Max and Avg are in watts;
+// Seconds is the time covered by power samples.
+type powerMetrics struct {
+	Avg              float64
+	Max              uint16
+	Normalized       float64
+	VariabilityIndex float64
+	Seconds          int
+}
+
+// computePowerMetrics derives average, maximum and normalized power from the
+// records of a ride. It reports false when the records carry no power.
+func computePowerMetrics(records []*fit.RecordMsg) (powerMetrics, bool) {
+	series := powerSeries(records)
+	if len(series) == 0 {
+		return powerMetrics{}, false
+	}
+
+	var metrics powerMetrics
+	var sum float64
+	for _, watts := range series {
+		sum += watts
+	}
+	for _, record := range records {
+		if record.Power != 0xFFFF && record.Power > metrics.Max {
+			metrics.Max = record.Power
+		}
+	}
+
+	metrics.Seconds = len(series)
+	metrics.Avg = sum / float64(len(series))
+	metrics.Normalized = normalizedPower(series)
+	if metrics.Normalized == 0 {
+		metrics.Normalized = metrics.Avg
+	}
+	if metrics.Avg > 0 {
+		metrics.VariabilityIndex = metrics.Normalized / metrics.Avg
+	}
+
+	return metrics, true
+}
+
+// intensity returns the intensity factor and training stress score of the
+// ride for the given FTP in watts.
+func (m powerMetrics) intensity(ftp int32) (intensityFactor, tss float64) {
+	if ftp <= 0 {
+		return 0, 0
+	}
+
+	intensityFactor = m.Normalized / float64(ftp)
+	tss = float64(m.Seconds) * m.Normalized * intensityFactor / (float64(ftp) * 3600) * 100
+
+	return intensityFactor, tss
+}
+
+// powerSeries resamples the power of the records to one value per second.
+func powerSeries(records []*fit.RecordMsg) []float64 {
+	var series []float64
+	var previous *fit.RecordMsg
+
+	for _, record := range records {
+		if record.Power == 0xFFFF || record.Timestamp.IsZero() {
+			continue
+		}
+
+		if previous != nil {
+			gap := record.Timestamp.Sub(previous.Timestamp)
+			if gap > maxPowerGap {
+				gap = time.Second
+			}
+			for i := time.Second; i < gap; i += time.Second {
+				series = append(series, float64(previous.Power))
+			}
+		}
+
+		series = append(series, float64(record.Power))
+		previous = record
+	}
+
+	return series
+}
+
+// normalizedPower is the fourth root of the mean of the fourth powers of the
+// 30 second rolling average. It is zero for rides shorter than the window.
+func normalizedPower(series []float64) float64 {
+	if len(series) < normalizedPowerWindow {
+		return 0
+	}
+
+	var window, sum float64
+	var count int
+	for i, watts := range series {
+		window += watts
+		if i >= normalizedPowerWindow {
+			window -= series[i-normalizedPowerWindow]
+		}
+		if i >= normalizedPowerWindow-1 {
+			sum += math.Pow(window/normalizedPowerWindow, 4)
+			count++
+		}
+	}
+
+	return math.Pow(sum/float64(count), 0.25)
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tormoder/fit"
+)
+
+func powerRecords(start time.Time, watts ...uint16) []*fit.RecordMsg {
+	records := make([]*fit.RecordMsg, len(watts))
+	for i, w := range watts {
+		records[i] = fit.NewRecordMsg()
+		records[i].Timestamp = start.Add(time.Duration(i) * time.Second)
+		records[i].Power = w
+	}
+	return records
+}
+
+func repeatWatts(watts uint16, seconds int) []uint16 {
+	series := make([]uint16, seconds)
+	for i := range series {
+		series[i] = watts
+	}
+	return series
+}
+
+func TestComputePowerMetricsWithoutPower(t *testing.T) {
+	start := time.Date(2024, 5, 1, 6, 0, 0, 0, time.UTC)
+
+	_, ok := computePowerMetrics(powerRecords(start, 0xFFFF, 0xFFFF))
+	assert.False(t, ok)
+}
+
+func TestComputePowerMetricsSteadyRide(t *testing.T) {
+	start := time.Date(2024, 5, 1, 6, 0, 0, 0, time.UTC)
+
+	metrics, ok := computePowerMetrics(powerRecords(start, repeatWatts(250, 3600)...))
+	assert.True(t, ok)
+	assert.InDelta(t, 250, metrics.Avg, 1e-9)
+	assert.InDelta(t, 250, metrics.Normalized, 1e-9)
+	assert.InDelta(t, 1, metrics.VariabilityIndex, 1e-9)
+
+	// An hour at FTP is 100 TSS by definition.
+	intensityFactor, tss := metrics.intensity(250)
+	assert.InDelta(t, 1, intensityFactor, 1e-9)
+	assert.InDelta(t, 100, tss, 1e-6)
+}
+
+func TestComputePowerMetricsIntervals(t *testing.T) {
+	start := time.Date(2024, 5, 1, 6, 0, 0, 0, time.UTC)
+
+	var watts []uint16
+	for i := 0; i < 10; i++ {
+		watts = append(watts, repeatWatts(400, 60)...)
+		watts = append(watts, repeatWatts(100, 60)...)
+	}
+
+	metrics, ok := computePowerMetrics(powerRecords(start, watts...))
+	assert.True(t, ok)
+	assert.InDelta(t, 250, metrics.Avg, 1e-9)
+	assert.Equal(t, uint16(400), metrics.Max)
+	assert.Greater(t, metrics.Normalized, metrics.Avg)
+	assert.Greater(t, metrics.VariabilityIndex, 1.0)
+}
+
+func TestPowerSeriesFillsShortGapsAndSkipsPauses(t *testing.T) {
+	start := time.Date(2024, 5, 1, 6, 0, 0, 0, time.UTC)
+
+	records := powerRecords(start, 200, 300)
+	records[1].Timestamp = start.Add(3 * time.Second)
+	paused := fit.NewRecordMsg()
+	paused.Timestamp = start.Add(10 * time.Minute)
+	paused.Power = 150
+	records = append(records, paused)
+
+	assert.Equal(t, []float64{200, 200, 200, 300, 150}, powerSeries(records))
+}
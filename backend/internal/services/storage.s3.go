@@ -0,0 +1,93 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Config locates the bucket of an S3 compatible object store such as AWS S3
+// or MinIO.
+type S3Config struct {
+	// Endpoint is the host and optional port, e.g. s3.amazonaws.com or
+	// localhost:9000.
+	Endpoint  string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	Region    string
+	UseSSL    bool
+}
+
+// s3FileStore keeps files as objects in a single bucket.
+type s3FileStore struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewS3FileStore connects to the object store and creates the bucket when it
+// does not exist yet.
+func NewS3FileStore(ctx context.Context, config S3Config) (FileStore, error) {
+	if config.Endpoint == "" || config.Bucket == "" {
+		return nil, fmt.Errorf("s3 file store requires an endpoint and a bucket")
+	}
+
+	client, err := minio.New(config.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(config.AccessKey, config.SecretKey, ""),
+		Secure: config.UseSSL,
+		Region: config.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 client: %w", err)
+	}
+
+	exists, err := client.BucketExists(ctx, config.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check s3 bucket %q: %w", config.Bucket, err)
+	}
+	if !exists {
+		err := client.MakeBucket(ctx, config.Bucket, minio.MakeBucketOptions{Region: config.Region})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create s3 bucket %q: %w", config.Bucket, err)
+		}
+	}
+
+	return &s3FileStore{client: client, bucket: config.Bucket}, nil
+}
+
+func (s *s3FileStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	return err
+}
+
+func (s *s3FileStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, s3Error(key, err)
+	}
+
+	// GetObject is lazy; Stat surfaces a missing object before the caller
+	// starts reading.
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		return nil, s3Error(key, err)
+	}
+	return object, nil
+}
+
+func (s *s3FileStore) Delete(ctx context.Context, key string) error {
+	return s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+}
+
+func s3Error(key string, err error) error {
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return fmt.Errorf("%w: %s", ErrFileNotFound, key)
+	}
+	return err
+}
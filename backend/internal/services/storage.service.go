@@ -1,4 +1,93 @@
+package service
 
-type StorageService interface {
-	UploadImage(bucketName, filePath string, fileData []byte, contentType string) (string, error)
-}
\ No newline at end of file
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrFileNotFound is returned by a FileStore when no file is stored under a key.
+var ErrFileNotFound = errors.New("file not found")
+
+// FileStore keeps files under slash separated keys, e.g. on the local disk or
+// in an S3 compatible bucket.
+type FileStore interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get returns ErrFileNotFound when no file is stored under key. The caller
+	// closes the returned reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the file stored under key; a missing file is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+const (
+	FileStoreLocal = "local"
+	FileStoreS3    = "s3"
+	// DefaultFileStorePath is the directory of the local file store.
+	DefaultFileStorePath = "data/files"
+)
+
+// FileStoreConfig selects and configures the file store, as read from the
+// environment.
+type FileStoreConfig struct {
+	// Kind is local (default) or s3.
+	Kind string
+	// Path is the root directory of the local file store.
+	Path        string
+	S3Endpoint  string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	S3Region    string
+	// S3UseSSL defaults to true.
+	S3UseSSL string
+}
+
+// NewFileStore opens the file store selected by config.
+func NewFileStore(ctx context.Context, config FileStoreConfig) (FileStore, error) {
+	switch strings.ToLower(strings.TrimSpace(config.Kind)) {
+	case "", FileStoreLocal:
+		root := config.Path
+		if root == "" {
+			root = DefaultFileStorePath
+		}
+		return NewLocalFileStore(root)
+	case FileStoreS3:
+		useSSL := true
+		if value := strings.TrimSpace(config.S3UseSSL); value != "" {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid S3_USE_SSL %q: expected true or false", value)
+			}
+			useSSL = parsed
+		}
+		return NewS3FileStore(ctx, S3Config{
+			Endpoint:  config.S3Endpoint,
+			Bucket:    config.S3Bucket,
+			AccessKey: config.S3AccessKey,
+			SecretKey: config.S3SecretKey,
+			Region:    config.S3Region,
+			UseSSL:    useSSL,
+		})
+	default:
+		return nil, fmt.Errorf("invalid file store %q: expected local or s3", config.Kind)
+	}
+}
+
+var unsafeKeyCharacters = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// originalFileKey is the key of the uploaded original of an activity. The
+// filename is kept, minus any directories and unusual characters, so the
+// stored files remain recognisable.
+func originalFileKey(userId string, activityId int32, filename string) string {
+	name := unsafeKeyCharacters.ReplaceAllString(path.Base(strings.ReplaceAll(filename, "\\", "/")), "_")
+	if name == "" || name == "." || name == ".." {
+		name = "original"
+	}
+	return fmt.Sprintf("originals/%s/%d/%s", userId, activityId, name)
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+
+	"github.com/notaduck/backend/misc/testhelpers"
+)
+
+// testFileStore exercises the FileStore contract shared by all implementations.
+func testFileStore(t *testing.T, store FileStore) {
+	ctx := context.Background()
+	key := originalFileKey("user", 7, "Morning Ride.fit")
+
+	require.NoError(t, store.Put(ctx, key, []byte("fit data"), "application/vnd.ant.fit"))
+
+	content, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	data, err := io.ReadAll(content)
+	require.NoError(t, err)
+	require.NoError(t, content.Close())
+	assert.Equal(t, "fit data", string(data))
+
+	require.NoError(t, store.Delete(ctx, key))
+	require.NoError(t, store.Delete(ctx, key), "deleting a missing file succeeds")
+
+	_, err = store.Get(ctx, key)
+	assert.ErrorIs(t, err, ErrFileNotFound)
+}
+
+func TestLocalFileStore(t *testing.T) {
+	store, err := NewLocalFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	testFileStore(t, store)
+
+	err = store.Put(context.Background(), "../escape.fit", []byte{1}, "")
+	assert.Error(t, err)
+}
+
+func TestS3FileStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping MinIO container test in short mode")
+	}
+	skipWithoutDocker(t)
+
+	ctx := context.Background()
+	container, err := testhelpers.CreateMinioContainer(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = container.Terminate(context.Background()) })
+
+	store, err := NewS3FileStore(ctx, S3Config{
+		Endpoint:  container.Endpoint,
+		Bucket:    "originals",
+		AccessKey: testhelpers.MINIO_USER,
+		SecretKey: testhelpers.MINIO_PASSWORD,
+	})
+	require.NoError(t, err)
+
+	testFileStore(t, store)
+}
+
+// skipWithoutDocker skips the test when no container runtime is reachable;
+// testcontainers panics rather than failing when it finds no Docker host.
+func skipWithoutDocker(t *testing.T) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r != nil {
+			t.Skipf("docker is not available: %v", r)
+		}
+	}()
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+}
+
+func TestOriginalFileKey(t *testing.T) {
+	assert.Equal(t, "originals/user/7/Morning_Ride.fit", originalFileKey("user", 7, "Morning Ride.fit"))
+	assert.Equal(t, "originals/user/7/ride.gpx", originalFileKey("user", 7, `C:\rides\ride.gpx`))
+	assert.Equal(t, "originals/user/7/original", originalFileKey("user", 7, ""))
+}
+
+func TestNewFileStoreRejectsUnknownKind(t *testing.T) {
+	_, err := NewFileStore(context.Background(), FileStoreConfig{Kind: "ftp"})
+	assert.Error(t, err)
+
+	_, err = NewFileStore(context.Background(), FileStoreConfig{Kind: FileStoreS3, S3Endpoint: "localhost:9000", S3Bucket: "b", S3UseSSL: "maybe"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/tormoder/fit"
+)
+
+// tcxFile mirrors the parts of a Garmin Training Center Database (v2) document
+// we ingest. Element names are matched on their local name so the ActivityExtension
+// (ns3, ax, ...) prefixes used by different exporters all decode.
+type tcxFile struct {
+	XMLName    xml.Name      `xml:"TrainingCenterDatabase"`
+	Activities []tcxActivity `xml:"Activities>Activity"`
+}
+
+type tcxActivity struct {
+	Sport   string     `xml:"Sport,attr"`
+	ID      time.Time  `xml:"Id"`
+	Notes   string     `xml:"Notes"`
+	Laps    []tcxLap   `xml:"Lap"`
+	Creator tcxCreator `xml:"Creator"`
+}
+
+type tcxCreator struct {
+	Name      string `xml:"Name"`
+	UnitID    uint32 `xml:"UnitId"`
+	ProductID uint16 `xml:"ProductID"`
+}
+
+type tcxLap struct {
+	StartTime        time.Time       `xml:"StartTime,attr"`
+	TotalTimeSeconds float64         `xml:"TotalTimeSeconds"`
+	DistanceMeters   float64         `xml:"DistanceMeters"`
+	MaximumSpeed     float64         `xml:"MaximumSpeed"`
+	Trackpoints      []tcxTrackpoint `xml:"Track>Trackpoint"`
+}
+
+type tcxTrackpoint struct {
+	Time           time.Time           `xml:"Time"`
+	Position       *tcxPosition        `xml:"Position"`
+	AltitudeMeters *float64            `xml:"AltitudeMeters"`
+	DistanceMeters *float64            `xml:"DistanceMeters"`
+	HeartRateBpm   *tcxHeartRate       `xml:"HeartRateBpm"`
+	Cadence        *uint8              `xml:"Cadence"`
+	Extensions     tcxTrackpointExtras `xml:"Extensions"`
+}
+
+type tcxPosition struct {
+	LatitudeDegrees  float64 `xml:"LatitudeDegrees"`
+	LongitudeDegrees float64 `xml:"LongitudeDegrees"`
+}
+
+type tcxHeartRate struct {
+	Value uint8 `xml:"Value"`
+}
+
+type tcxTrackpointExtras struct {
+	TPX tcxTPX `xml:"TPX"`
+}
+
+type tcxTPX struct {
+	Speed *float64 `xml:"Speed"`
+	Watts *uint16  `xml:"Watts"`
+}
+
+func decodeTCX(r io.Reader) (*decodedActivity, error) {
+	var doc tcxFile
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode tcx file: %v", ErrCorruptFile, err)
+	}
+
+	if len(doc.Activities) == 0 {
+		return nil, fmt.Errorf("%w: tcx file contains no activities", ErrCorruptFile)
+	}
+
+	tcx := doc.Activities[0]
+
+	var (
+		records     []*fit.RecordMsg
+		timer       float64 // seconds
+		distance    float64 // meters
+		maxSpeed    float64 // m/s
+		first, last time.Time
+	)
+
+	for _, lap := range tcx.Laps {
+		timer += lap.TotalTimeSeconds
+		distance += lap.DistanceMeters
+		maxSpeed = math.Max(maxSpeed, lap.MaximumSpeed)
+
+		for _, point := range lap.Trackpoints {
+			records = append(records, tcxTrackpointToRecord(point))
+
+			if first.IsZero() || point.Time.Before(first) {
+				first = point.Time
+			}
+			if point.Time.After(last) {
+				last = point.Time
+			}
+		}
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: tcx file contains no track points", ErrNoRecords)
+	}
+
+	fillTCXSpeeds(records)
+
+	activity := &decodedActivity{
+		Name:          strings.TrimSpace(tcx.Notes),
+		StartTime:     tcx.ID,
+		TimerDuration: time.Duration(timer * float64(time.Second)),
+		Distance:      distance,
+		MaxSpeed:      maxSpeed,
+		Records:       records,
+	}
+
+	// UnitId carries the serial number of Garmin devices, matching the FIT
+	// file_id serial so both exports of a ride share a fingerprint.
+	if tcx.Creator.UnitID != 0 {
+		activity.Device = fmt.Sprintf("serial:%d", tcx.Creator.UnitID)
+	}
+
+	if len(tcx.Laps) > 0 && !tcx.Laps[0].StartTime.IsZero() {
+		activity.StartTime = tcx.Laps[0].StartTime
+	}
+	if activity.StartTime.IsZero() {
+		activity.StartTime = first
+	}
+
+	if !first.IsZero() && last.After(first) {
+		activity.TotalDuration = last.Sub(first)
+	}
+	if activity.TimerDuration == 0 {
+		activity.TimerDuration = activity.TotalDuration
+	}
+
+	return activity, nil
+}
+
+func tcxTrackpointToRecord(point tcxTrackpoint) *fit.RecordMsg {
+	record := fit.NewRecordMsg()
+	record.Timestamp = point.Time
+
+	if point.Position != nil {
+		record.PositionLat = fit.NewLatitudeDegrees(point.Position.LatitudeDegrees)
+		record.PositionLong = fit.NewLongitudeDegrees(point.Position.LongitudeDegrees)
+	}
+	if point.AltitudeMeters != nil {
+		record.Altitude = toFitAltitude(*point.AltitudeMeters)
+		record.EnhancedAltitude = uint32(record.Altitude)
+	}
+	if point.DistanceMeters != nil {
+		record.Distance = uint32(math.Round(*point.DistanceMeters * 100))
+	}
+	if point.HeartRateBpm != nil {
+		record.HeartRate = point.HeartRateBpm.Value
+	}
+	if point.Cadence != nil {
+		record.Cadence = *point.Cadence
+	}
+	if point.Extensions.TPX.Speed != nil {
+		record.Speed = toFitSpeed(*point.Extensions.TPX.Speed)
+	}
+	if point.Extensions.TPX.Watts != nil {
+		record.Power = *point.Extensions.TPX.Watts
+	}
+
+	return record
+}
+
+// fillTCXSpeeds derives a speed for trackpoints that carry a distance but no
+// TPX speed extension, using the distance delta to the previous trackpoint.
+func fillTCXSpeeds(records []*fit.RecordMsg) {
+	invalidSpeed := fit.NewRecordMsg().Speed
+	invalidDistance := fit.NewRecordMsg().Distance
+
+	for i, record := range records {
+		if record.Speed != invalidSpeed {
+			continue
+		}
+
+		record.Speed = 0
+		if i == 0 {
+			continue
+		}
+
+		prev := records[i-1]
+		if record.Distance == invalidDistance || prev.Distance == invalidDistance || record.Distance < prev.Distance {
+			continue
+		}
+
+		if dt := record.Timestamp.Sub(prev.Timestamp).Seconds(); dt > 0 {
+			record.Speed = toFitSpeed(float64(record.Distance-prev.Distance) / 100 / dt)
+		}
+	}
+}
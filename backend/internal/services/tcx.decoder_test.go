@@ -0,0 +1,81 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleTCX = `<?xml version="1.0" encoding="UTF-8"?>
+<TrainingCenterDatabase xmlns="http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2"
+    xmlns:ns3="http://www.garmin.com/xmlschemas/ActivityExtension/v2">
+  <Activities>
+    <Activity Sport="Biking">
+      <Id>2024-05-01T06:00:00Z</Id>
+      <Lap StartTime="2024-05-01T06:00:00Z">
+        <TotalTimeSeconds>1200</TotalTimeSeconds>
+        <DistanceMeters>10000</DistanceMeters>
+        <MaximumSpeed>12.5</MaximumSpeed>
+        <Track>
+          <Trackpoint>
+            <Time>2024-05-01T06:00:00Z</Time>
+            <Position><LatitudeDegrees>55.6380</LatitudeDegrees><LongitudeDegrees>12.5480</LongitudeDegrees></Position>
+            <AltitudeMeters>10</AltitudeMeters>
+            <DistanceMeters>0</DistanceMeters>
+            <HeartRateBpm><Value>110</Value></HeartRateBpm>
+            <Cadence>80</Cadence>
+            <Extensions><ns3:TPX><ns3:Speed>8.5</ns3:Speed><ns3:Watts>210</ns3:Watts></ns3:TPX></Extensions>
+          </Trackpoint>
+          <Trackpoint>
+            <Time>2024-05-01T06:00:10Z</Time>
+            <Position><LatitudeDegrees>55.6390</LatitudeDegrees><LongitudeDegrees>12.5480</LongitudeDegrees></Position>
+            <DistanceMeters>100</DistanceMeters>
+            <HeartRateBpm><Value>115</Value></HeartRateBpm>
+          </Trackpoint>
+        </Track>
+      </Lap>
+      <Lap StartTime="2024-05-01T06:20:00Z">
+        <TotalTimeSeconds>600</TotalTimeSeconds>
+        <DistanceMeters>5000</DistanceMeters>
+        <MaximumSpeed>14</MaximumSpeed>
+        <Track>
+          <Trackpoint>
+            <Time>2024-05-01T06:30:00Z</Time>
+            <Position><LatitudeDegrees>55.6400</LatitudeDegrees><LongitudeDegrees>12.5480</LongitudeDegrees></Position>
+            <DistanceMeters>15000</DistanceMeters>
+          </Trackpoint>
+        </Track>
+      </Lap>
+    </Activity>
+  </Activities>
+</TrainingCenterDatabase>`
+
+func TestDecodeTCX(t *testing.T) {
+	activity, err := decodeTCX(strings.NewReader(sampleTCX))
+	require.NoError(t, err)
+
+	assert.Equal(t, 30*time.Minute, activity.TotalDuration)
+	assert.Equal(t, 30*time.Minute, activity.TimerDuration)
+	assert.Equal(t, 15000.0, activity.Distance)
+	assert.Equal(t, 14.0, activity.MaxSpeed)
+	require.Len(t, activity.Records, 3)
+
+	first, second := activity.Records[0], activity.Records[1]
+	assert.Equal(t, uint8(110), first.HeartRate)
+	assert.Equal(t, uint8(80), first.Cadence)
+	assert.Equal(t, uint16(8500), first.Speed)
+	assert.Equal(t, uint16(210), first.Power)
+	assert.Equal(t, uint16((10+500)*5), first.Altitude)
+
+	// The second trackpoint has no TPX speed, so it is derived from distance.
+	assert.Equal(t, uint32(10000), second.Distance)
+	assert.Equal(t, uint16(10000), second.Speed)
+}
+
+func TestDetectTCXFormat(t *testing.T) {
+	assert.Equal(t, formatTCX, detectActivityFormat("export", []byte(sampleTCX)))
+	assert.Equal(t, formatTCX, detectActivityFormat("ride.tcx", []byte("garbage")))
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"errors"
+	"log/slog"
+)
+
+// FailureReason classifies why an uploaded file could not be ingested.
+type FailureReason string
+
+const (
+	FailureUnsupportedFormat FailureReason = "unsupported_format"
+	FailureCorruptFile       FailureReason = "corrupt_file"
+	FailureNoRecords         FailureReason = "no_records"
+	FailureDuplicate         FailureReason = "duplicate"
+	FailureEmptyFile         FailureReason = "empty_file"
+	FailureInternal          FailureReason = "internal"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported file format: only .fit, .gpx and .tcx files are allowed")
+	ErrCorruptFile       = errors.New("file is corrupt or could not be decoded")
+	ErrNoRecords         = errors.New("file contains no data records")
+	ErrDuplicateActivity = errors.New("activity has already been uploaded")
+	ErrEmptyFile         = errors.New("file has no data")
+	// errInternalFailure is reported in place of unexpected errors, which may
+	// carry database details that should not reach clients.
+	errInternalFailure = errors.New("the file could not be processed, please try again later")
+)
+
+// FailureReasonFor maps an ingestion error onto its FailureReason.
+func FailureReasonFor(err error) FailureReason {
+	switch {
+	case errors.Is(err, ErrUnsupportedFormat):
+		return FailureUnsupportedFormat
+	case errors.Is(err, ErrCorruptFile):
+		return FailureCorruptFile
+	case errors.Is(err, ErrNoRecords):
+		return FailureNoRecords
+	case errors.Is(err, ErrDuplicateActivity):
+		return FailureDuplicate
+	case errors.Is(err, ErrEmptyFile):
+		return FailureEmptyFile
+	default:
+		return FailureInternal
+	}
+}
+
+// FileResult is the outcome of ingesting a single uploaded file. Exactly one
+// of Activity, Skipped or Reason is set.
+type FileResult struct {
+	Filename   string    `json:"filename"`
+	ActivityID int32     `json:"activityId,omitempty"`
+	Activity   *Activity `json:"-"`
+	// Skipped is set for duplicates that were not imported, ActivityID then
+	// refers to the existing activity when known.
+	Skipped bool          `json:"skipped,omitempty"`
+	Reason  FailureReason `json:"reason,omitempty"`
+	Error   string        `json:"error,omitempty"`
+}
+
+// UploadBatchResult reports per-file outcomes of an upload, in upload order.
+type UploadBatchResult struct {
+	Files []FileResult `json:"files"`
+}
+
+// NewFileResult builds the outcome of ingesting filename from the values
+// returned by the ingestion. Internal errors are logged and reported with a
+// generic message.
+func NewFileResult(filename string, activity *Activity, err error) FileResult {
+	var duplicate *DuplicateActivityError
+	if errors.As(err, &duplicate) && duplicate.Skipped {
+		return FileResult{
+			Filename:   filename,
+			ActivityID: duplicate.ExistingID,
+			Skipped:    true,
+		}
+	}
+
+	if err != nil {
+		reason := FailureReasonFor(err)
+		if reason == FailureInternal {
+			slog.Error("failed to ingest file", "filename", filename, "error", err)
+			err = errInternalFailure
+		}
+		return FileResult{
+			Filename: filename,
+			Reason:   reason,
+			Error:    err.Error(),
+		}
+	}
+
+	return FileResult{
+		Filename:   filename,
+		ActivityID: activity.ID,
+		Activity:   activity,
+	}
+}
+
+// Activities returns the activities created by the upload.
+func (r *UploadBatchResult) Activities() []*Activity {
+	activities := make([]*Activity, 0, len(r.Files))
+	for _, file := range r.Files {
+		if file.Activity != nil {
+			activities = append(activities, file.Activity)
+		}
+	}
+	return activities
+}
+
+// Failed returns the number of files that could not be ingested.
+func (r *UploadBatchResult) Failed() int {
+	failed := 0
+	for _, file := range r.Files {
+		if file.Reason != "" {
+			failed++
+		}
+	}
+	return failed
+}
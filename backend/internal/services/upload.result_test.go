@@ -0,0 +1,51 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailureReasonFor(t *testing.T) {
+	assert.Equal(t, FailureUnsupportedFormat, FailureReasonFor(ErrUnsupportedFormat))
+	assert.Equal(t, FailureCorruptFile, FailureReasonFor(fmt.Errorf("%w: bad header", ErrCorruptFile)))
+	assert.Equal(t, FailureNoRecords, FailureReasonFor(fmt.Errorf("ride.gpx: %w", ErrNoRecords)))
+	assert.Equal(t, FailureDuplicate, FailureReasonFor(ErrDuplicateActivity))
+	assert.Equal(t, FailureEmptyFile, FailureReasonFor(ErrEmptyFile))
+	assert.Equal(t, FailureInternal, FailureReasonFor(errors.New("connection reset")))
+}
+
+func TestUploadBatchResult(t *testing.T) {
+	result := &UploadBatchResult{Files: []FileResult{
+		NewFileResult("ride.fit", &Activity{ID: 7}, nil),
+		NewFileResult("notes.txt", nil, ErrUnsupportedFormat),
+	}}
+
+	assert.Equal(t, 1, result.Failed())
+	assert.Len(t, result.Activities(), 1)
+	assert.Equal(t, int32(7), result.Files[0].ActivityID)
+	assert.Equal(t, FailureUnsupportedFormat, result.Files[1].Reason)
+}
+
+func TestNewFileResultForDuplicates(t *testing.T) {
+	skipped := NewFileResult("ride.fit", nil, &DuplicateActivityError{ExistingID: 3, Exact: true, Skipped: true})
+	assert.True(t, skipped.Skipped)
+	assert.Equal(t, int32(3), skipped.ActivityID)
+	assert.Empty(t, skipped.Reason)
+
+	rejected := NewFileResult("ride.fit", nil, &DuplicateActivityError{ExistingID: 3})
+	assert.False(t, rejected.Skipped)
+	assert.Equal(t, FailureDuplicate, rejected.Reason)
+	assert.Equal(t, "activity is likely a duplicate of activity 3", rejected.Error)
+}
+
+func TestNewFileResultHidesInternalErrors(t *testing.T) {
+	result := NewFileResult("ride.fit", nil, errors.New(`ERROR: relation "records" does not exist (SQLSTATE 42P01)`))
+	assert.Equal(t, FailureInternal, result.Reason)
+	assert.Equal(t, errInternalFailure.Error(), result.Error)
+
+	corrupt := NewFileResult("ride.fit", nil, fmt.Errorf("%w: bad header", ErrCorruptFile))
+	assert.Equal(t, "file is corrupt or could not be decoded: bad header", corrupt.Error)
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/notaduck/backend/internal/db"
+)
+
+var ErrInvalidSettings = errors.New("invalid settings")
+
+// UserSettings holds the rider's training parameters. Unset values are nil.
+type UserSettings struct {
+	// FTP is the functional threshold power in watts used for intensity
+	// factor and TSS.
+	FTP *int32 `json:"ftp,omitempty"`
+	// MaxHeartRate and ThresholdHeartRate are in bpm; the latter is the
+	// lactate threshold heart rate.
+	MaxHeartRate       *int32 `json:"maxHeartRate,omitempty"`
+	ThresholdHeartRate *int32 `json:"thresholdHeartRate,omitempty"`
+	// HeartRateZoneMethod selects how heart rate zones are derived: max_hr,
+	// lthr or custom. Time in zone is not computed while it is empty.
+	HeartRateZoneMethod string `json:"heartRateZoneMethod,omitempty"`
+	// HeartRateZoneBounds are the custom bounds in bpm between consecutive
+	// zones, ascending; n bounds define n+1 zones.
+	HeartRateZoneBounds []int32   `json:"heartRateZoneBounds,omitempty"`
+	UpdatedAt           time.Time `json:"updatedAt,omitempty"`
+}
+
+func (s *activityService) GetUserSettings(ctx context.Context, userId string) (*UserSettings, error) {
+	settings, err := s.activityRepo.GetUserSettings(ctx, userId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &UserSettings{}, nil
+	}
+	if err != nil {
+		slog.Error("failed to retrieve user settings", "userId", userId, "error", err)
+		return nil, err
+	}
+
+	return convertUserSettings(settings), nil
+}
+
+func (s *activityService) UpdateUserSettings(ctx context.Context, userId string, settings UserSettings) (*UserSettings, error) {
+	if settings.FTP != nil && (*settings.FTP <= 0 || *settings.FTP > 2000) {
+		return nil, fmt.Errorf("%w: ftp must be between 1 and 2000 watts", ErrInvalidSettings)
+	}
+	if err := validateHeartRateZoneSettings(settings); err != nil {
+		return nil, err
+	}
+
+	params := db.UpsertUserSettingsParams{
+		UserID:              userId,
+		HeartRateZoneMethod: pgtype.Text{String: settings.HeartRateZoneMethod, Valid: settings.HeartRateZoneMethod != ""},
+		HeartRateZoneBounds: settings.HeartRateZoneBounds,
+	}
+	if settings.FTP != nil {
+		params.Ftp = pgtype.Int4{Int32: *settings.FTP, Valid: true}
+	}
+	if settings.MaxHeartRate != nil {
+		params.MaxHeartRate = pgtype.Int4{Int32: *settings.MaxHeartRate, Valid: true}
+	}
+	if settings.ThresholdHeartRate != nil {
+		params.ThresholdHeartRate = pgtype.Int4{Int32: *settings.ThresholdHeartRate, Valid: true}
+	}
+
+	updated, err := s.activityRepo.UpsertUserSettings(ctx, params)
+	if err != nil {
+		slog.Error("failed to update user settings", "userId", userId, "error", err)
+		return nil, err
+	}
+
+	return convertUserSettings(updated), nil
+}
+
+// trainingSettings returns the settings used to rate an activity at
+// ingestion, which are empty when the user has none.
+func (s *activityService) trainingSettings(ctx context.Context, userId string) (*UserSettings, error) {
+	settings, err := s.activityRepo.GetUserSettings(ctx, userId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &UserSettings{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return convertUserSettings(settings), nil
+}
+
+func convertUserSettings(settings db.UserSetting) *UserSettings {
+	result := &UserSettings{
+		FTP:                 optionalInt4(settings.Ftp),
+		MaxHeartRate:        optionalInt4(settings.MaxHeartRate),
+		ThresholdHeartRate:  optionalInt4(settings.ThresholdHeartRate),
+		HeartRateZoneMethod: settings.HeartRateZoneMethod.String,
+		HeartRateZoneBounds: settings.HeartRateZoneBounds,
+		UpdatedAt:           settings.UpdatedAt.Time,
+	}
+	return result
+}
@@ -0,0 +1,42 @@
+DROP VIEW IF EXISTS activity_with_records_view;
+
+DROP INDEX IF EXISTS "idx_activities_user_content_hash";
+DROP INDEX IF EXISTS "idx_activities_user_device_fingerprint";
+DROP INDEX IF EXISTS "idx_activities_user_started_at";
+
+ALTER TABLE activities
+    DROP COLUMN IF EXISTS duplicate_of,
+    DROP COLUMN IF EXISTS start_position,
+    DROP COLUMN IF EXISTS ended_at,
+    DROP COLUMN IF EXISTS started_at,
+    DROP COLUMN IF EXISTS device_fingerprint,
+    DROP COLUMN IF EXISTS content_hash;
+
+CREATE VIEW activity_with_records_view AS
+SELECT
+    a.id,
+    a.created_at,
+    a.user_id,
+    a.distance,
+    a.activity_name,
+    a.avg_speed,
+    a.max_speed,
+    a.ride_type,
+    a.elapsed_time,
+    a.total_time,
+    TO_CHAR(a.elapsed_time::time, 'HH24:MI:SS') AS elapsed_time_char,
+    TO_CHAR(a.total_time::time, 'HH24:MI:SS') AS total_time_char,
+    JSON_AGG(r.* ORDER BY r.time_stamp) AS records
+FROM activities a
+JOIN records r ON r.activity_id = a.id
+GROUP BY
+    a.id,
+    a.created_at,
+    a.user_id,
+    a.distance,
+    a.activity_name,
+    a.avg_speed,
+    a.max_speed,
+    a.ride_type,
+    a.elapsed_time,
+    a.total_time;
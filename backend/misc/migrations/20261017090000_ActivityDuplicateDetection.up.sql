@@ -0,0 +1,44 @@
+ALTER TABLE activities
+    ADD COLUMN IF NOT EXISTS content_hash TEXT,
+    ADD COLUMN IF NOT EXISTS device_fingerprint TEXT,
+    ADD COLUMN IF NOT EXISTS started_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS ended_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS start_position POINT,
+    ADD COLUMN IF NOT EXISTS duplicate_of INTEGER REFERENCES activities (id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_activities_user_content_hash" ON "activities" ("user_id", "content_hash") WHERE content_hash IS NOT NULL;
+CREATE INDEX IF NOT EXISTS "idx_activities_user_device_fingerprint" ON "activities" ("user_id", "device_fingerprint");
+CREATE INDEX IF NOT EXISTS "idx_activities_user_started_at" ON "activities" ("user_id", "started_at", "ended_at");
+
+DROP VIEW IF EXISTS activity_with_records_view;
+
+CREATE VIEW activity_with_records_view AS
+SELECT
+    a.id,
+    a.created_at,
+    a.user_id,
+    a.distance,
+    a.activity_name,
+    a.avg_speed,
+    a.max_speed,
+    a.ride_type,
+    a.elapsed_time,
+    a.total_time,
+    TO_CHAR(a.elapsed_time::time, 'HH24:MI:SS') AS elapsed_time_char,
+    TO_CHAR(a.total_time::time, 'HH24:MI:SS') AS total_time_char,
+    a.duplicate_of,
+    JSON_AGG(r.* ORDER BY r.time_stamp) AS records
+FROM activities a
+JOIN records r ON r.activity_id = a.id
+GROUP BY
+    a.id,
+    a.created_at,
+    a.user_id,
+    a.distance,
+    a.activity_name,
+    a.avg_speed,
+    a.max_speed,
+    a.ride_type,
+    a.elapsed_time,
+    a.total_time,
+    a.duplicate_of;
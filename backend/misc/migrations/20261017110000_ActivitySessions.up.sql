@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS activity_sessions (
+    id SERIAL PRIMARY KEY,
+    activity_id INTEGER NOT NULL REFERENCES activities (id) ON DELETE CASCADE,
+    session_index SMALLINT NOT NULL,
+    sport TEXT NOT NULL,
+    sub_sport TEXT NOT NULL,
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    total_elapsed_time INTERVAL NOT NULL,
+    total_timer_time INTERVAL NOT NULL,
+    distance NUMERIC NOT NULL,
+    UNIQUE (activity_id, session_index)
+);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS laps (
+    id SERIAL PRIMARY KEY,
+    activity_id INTEGER NOT NULL REFERENCES activities (id) ON DELETE CASCADE,
+    lap_index SMALLINT NOT NULL,
+    lap_trigger TEXT NOT NULL,
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    total_elapsed_time INTERVAL NOT NULL,
+    total_timer_time INTERVAL NOT NULL,
+    distance NUMERIC NOT NULL,
+    avg_speed NUMERIC NOT NULL,
+    max_speed NUMERIC NOT NULL,
+    avg_heart_rate SMALLINT,
+    max_heart_rate SMALLINT,
+    avg_power INTEGER,
+    max_power INTEGER,
+    UNIQUE (activity_id, lap_index)
+);
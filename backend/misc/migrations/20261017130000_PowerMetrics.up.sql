@@ -0,0 +1,68 @@
+ALTER TABLE records
+    ADD COLUMN IF NOT EXISTS power INTEGER;
+
+-- Power metrics are NULL for rides recorded without a power meter. Intensity
+-- factor and TSS are computed against the FTP the rider had set at ingestion.
+ALTER TABLE activities
+    ADD COLUMN IF NOT EXISTS avg_power INTEGER,
+    ADD COLUMN IF NOT EXISTS max_power INTEGER,
+    ADD COLUMN IF NOT EXISTS normalized_power INTEGER,
+    ADD COLUMN IF NOT EXISTS variability_index DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS intensity_factor DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS training_stress_score DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS ftp INTEGER;
+
+CREATE TABLE IF NOT EXISTS user_settings (
+    user_id UUID PRIMARY KEY REFERENCES auth.users,
+    ftp INTEGER,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+DROP VIEW IF EXISTS activity_with_records_view;
+
+CREATE VIEW activity_with_records_view AS
+SELECT
+    a.id,
+    a.created_at,
+    a.user_id,
+    a.distance,
+    a.activity_name,
+    a.avg_speed,
+    a.max_speed,
+    a.ride_type,
+    a.elapsed_time,
+    a.total_time,
+    TO_CHAR(a.elapsed_time::time, 'HH24:MI:SS') AS elapsed_time_char,
+    TO_CHAR(a.total_time::time, 'HH24:MI:SS') AS total_time_char,
+    a.duplicate_of,
+    a.description,
+    a.avg_power,
+    a.max_power,
+    a.normalized_power,
+    a.variability_index,
+    a.intensity_factor,
+    a.training_stress_score,
+    a.ftp,
+    JSON_AGG(r.* ORDER BY r.time_stamp) AS records
+FROM activities a
+JOIN records r ON r.activity_id = a.id
+GROUP BY
+    a.id,
+    a.created_at,
+    a.user_id,
+    a.distance,
+    a.activity_name,
+    a.avg_speed,
+    a.max_speed,
+    a.ride_type,
+    a.elapsed_time,
+    a.total_time,
+    a.duplicate_of,
+    a.description,
+    a.avg_power,
+    a.max_power,
+    a.normalized_power,
+    a.variability_index,
+    a.intensity_factor,
+    a.training_stress_score,
+    a.ftp;
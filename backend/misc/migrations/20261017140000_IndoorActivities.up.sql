@@ -0,0 +1,58 @@
+-- Indoor activities (trainer rides, virtual rides) have no GPS positions, so
+-- their records are kept without one and the view no longer requires records.
+ALTER TABLE activities
+    ADD COLUMN IF NOT EXISTS indoor BOOLEAN NOT NULL DEFAULT FALSE;
+
+DROP VIEW IF EXISTS activity_with_records_view;
+
+CREATE VIEW activity_with_records_view AS
+SELECT
+    a.id,
+    a.created_at,
+    a.user_id,
+    a.distance,
+    a.activity_name,
+    a.avg_speed,
+    a.max_speed,
+    a.ride_type,
+    a.elapsed_time,
+    a.total_time,
+    TO_CHAR(a.elapsed_time::time, 'HH24:MI:SS') AS elapsed_time_char,
+    TO_CHAR(a.total_time::time, 'HH24:MI:SS') AS total_time_char,
+    a.duplicate_of,
+    a.description,
+    a.avg_power,
+    a.max_power,
+    a.normalized_power,
+    a.variability_index,
+    a.intensity_factor,
+    a.training_stress_score,
+    a.ftp,
+    a.indoor,
+    COALESCE(
+        JSON_AGG(r.* ORDER BY r.time_stamp) FILTER (WHERE r.id IS NOT NULL),
+        '[]'
+    ) AS records
+FROM activities a
+LEFT JOIN records r ON r.activity_id = a.id
+GROUP BY
+    a.id,
+    a.created_at,
+    a.user_id,
+    a.distance,
+    a.activity_name,
+    a.avg_speed,
+    a.max_speed,
+    a.ride_type,
+    a.elapsed_time,
+    a.total_time,
+    a.duplicate_of,
+    a.description,
+    a.avg_power,
+    a.max_power,
+    a.normalized_power,
+    a.variability_index,
+    a.intensity_factor,
+    a.training_stress_score,
+    a.ftp,
+    a.indoor;
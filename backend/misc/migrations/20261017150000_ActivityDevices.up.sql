@@ -0,0 +1,24 @@
+-- One row per device that recorded an activity: the head unit that created
+-- the file (creator) and every connected sensor reported in device_info.
+CREATE TABLE IF NOT EXISTS activity_devices (
+    id SERIAL PRIMARY KEY,
+    activity_id INTEGER NOT NULL REFERENCES activities (id) ON DELETE CASCADE,
+    device_index SMALLINT NOT NULL,
+    creator BOOLEAN NOT NULL DEFAULT FALSE,
+    manufacturer TEXT NOT NULL DEFAULT '',
+    product INTEGER,
+    product_name TEXT NOT NULL DEFAULT '',
+    serial_number BIGINT,
+    software_version TEXT NOT NULL DEFAULT '',
+    hardware_version SMALLINT,
+    device_type TEXT NOT NULL DEFAULT '',
+    source_type TEXT NOT NULL DEFAULT '',
+    ant_device_number INTEGER,
+    battery_voltage DOUBLE PRECISION,
+    battery_status TEXT NOT NULL DEFAULT '',
+    UNIQUE (activity_id, device_index)
+);
+
+CREATE INDEX IF NOT EXISTS idx_activity_devices_serial_number
+    ON activity_devices (serial_number)
+    WHERE serial_number IS NOT NULL;
@@ -0,0 +1,77 @@
+-- Moving time excludes the periods the rider was stopped, taken from the FIT
+-- timer events or, without them, from records below the auto-pause speed.
+-- Existing activities fall back to their timer time.
+ALTER TABLE activities
+    ADD COLUMN IF NOT EXISTS moving_time INTERVAL NOT NULL DEFAULT '0';
+
+UPDATE activities SET moving_time = elapsed_time;
+
+CREATE TABLE IF NOT EXISTS activity_pauses (
+    id SERIAL PRIMARY KEY,
+    activity_id INTEGER NOT NULL REFERENCES activities (id) ON DELETE CASCADE,
+    started_at TIMESTAMPTZ NOT NULL,
+    ended_at TIMESTAMPTZ NOT NULL,
+    -- source is 'timer' for pauses of the device timer and 'auto' for stops
+    -- detected from the recorded speed.
+    source TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_activity_pauses_activity_id
+    ON activity_pauses (activity_id);
+
+DROP VIEW IF EXISTS activity_with_records_view;
+
+CREATE VIEW activity_with_records_view AS
+SELECT
+    a.id,
+    a.created_at,
+    a.user_id,
+    a.distance,
+    a.activity_name,
+    a.avg_speed,
+    a.max_speed,
+    a.ride_type,
+    a.elapsed_time,
+    a.total_time,
+    TO_CHAR(a.elapsed_time::time, 'HH24:MI:SS') AS elapsed_time_char,
+    TO_CHAR(a.total_time::time, 'HH24:MI:SS') AS total_time_char,
+    a.moving_time,
+    TO_CHAR(a.moving_time::time, 'HH24:MI:SS') AS moving_time_char,
+    a.duplicate_of,
+    a.description,
+    a.avg_power,
+    a.max_power,
+    a.normalized_power,
+    a.variability_index,
+    a.intensity_factor,
+    a.training_stress_score,
+    a.ftp,
+    a.indoor,
+    COALESCE(
+        JSON_AGG(r.* ORDER BY r.time_stamp) FILTER (WHERE r.id IS NOT NULL),
+        '[]'
+    ) AS records
+FROM activities a
+LEFT JOIN records r ON r.activity_id = a.id
+GROUP BY
+    a.id,
+    a.created_at,
+    a.user_id,
+    a.distance,
+    a.activity_name,
+    a.avg_speed,
+    a.max_speed,
+    a.ride_type,
+    a.elapsed_time,
+    a.total_time,
+    a.duplicate_of,
+    a.description,
+    a.avg_power,
+    a.max_power,
+    a.normalized_power,
+    a.variability_index,
+    a.intensity_factor,
+    a.training_stress_score,
+    a.ftp,
+    a.indoor,
+    a.moving_time;
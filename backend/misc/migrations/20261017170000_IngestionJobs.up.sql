@@ -0,0 +1,31 @@
+-- Uploaded files are queued here and ingested by the worker pool. Workers
+-- claim queued jobs with FOR UPDATE SKIP LOCKED so several server instances
+-- can share the queue. The file content is dropped once the job finishes.
+CREATE TABLE IF NOT EXISTS ingestion_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES auth.users (id) ON DELETE CASCADE,
+    filename TEXT NOT NULL,
+    content_type TEXT NOT NULL DEFAULT '',
+    last_modified TIMESTAMPTZ,
+    data BYTEA,
+    activity_name TEXT NOT NULL DEFAULT '',
+    ride_type TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'queued'
+        CHECK (status IN ('queued', 'running', 'done', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    activity_id INTEGER REFERENCES activities (id) ON DELETE SET NULL,
+    skipped BOOLEAN NOT NULL DEFAULT FALSE,
+    failure_reason TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_ingestion_jobs_queued
+    ON ingestion_jobs (id)
+    WHERE status = 'queued';
+
+CREATE INDEX IF NOT EXISTS idx_ingestion_jobs_user_id
+    ON ingestion_jobs (user_id, id DESC);
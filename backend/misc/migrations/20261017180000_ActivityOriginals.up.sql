@@ -0,0 +1,10 @@
+-- The original uploaded file of every activity, kept in the file store so it
+-- can be downloaded again and metrics can be derived from it later.
+CREATE TABLE IF NOT EXISTS activity_originals (
+    activity_id INTEGER PRIMARY KEY REFERENCES activities (id) ON DELETE CASCADE,
+    storage_key TEXT NOT NULL,
+    filename TEXT NOT NULL,
+    content_type TEXT NOT NULL DEFAULT '',
+    size BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
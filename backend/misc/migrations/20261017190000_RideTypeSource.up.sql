@@ -0,0 +1,68 @@
+-- ride_type is detected from the FIT sport and sub-sport or, failing that,
+-- from the recorded track. ride_type_source tells a detected type from one set
+-- by the user, which reprocessing must not overwrite. Every activity used to be
+-- created as road, so any other type was chosen by the user.
+ALTER TABLE activities
+    ADD COLUMN IF NOT EXISTS ride_type_source TEXT NOT NULL DEFAULT 'detected'
+        CHECK (ride_type_source IN ('detected', 'user'));
+
+UPDATE activities SET ride_type_source = 'user' WHERE ride_type <> 'road';
+
+DROP VIEW IF EXISTS activity_with_records_view;
+
+CREATE VIEW activity_with_records_view AS
+SELECT
+    a.id,
+    a.created_at,
+    a.user_id,
+    a.distance,
+    a.activity_name,
+    a.avg_speed,
+    a.max_speed,
+    a.ride_type,
+    a.elapsed_time,
+    a.total_time,
+    TO_CHAR(a.elapsed_time::time, 'HH24:MI:SS') AS elapsed_time_char,
+    TO_CHAR(a.total_time::time, 'HH24:MI:SS') AS total_time_char,
+    a.moving_time,
+    TO_CHAR(a.moving_time::time, 'HH24:MI:SS') AS moving_time_char,
+    a.duplicate_of,
+    a.description,
+    a.avg_power,
+    a.max_power,
+    a.normalized_power,
+    a.variability_index,
+    a.intensity_factor,
+    a.training_stress_score,
+    a.ftp,
+    a.indoor,
+    a.ride_type_source,
+    COALESCE(
+        JSON_AGG(r.* ORDER BY r.time_stamp) FILTER (WHERE r.id IS NOT NULL),
+        '[]'
+    ) AS records
+FROM activities a
+LEFT JOIN records r ON r.activity_id = a.id
+GROUP BY
+    a.id,
+    a.created_at,
+    a.user_id,
+    a.distance,
+    a.activity_name,
+    a.avg_speed,
+    a.max_speed,
+    a.ride_type,
+    a.elapsed_time,
+    a.total_time,
+    a.duplicate_of,
+    a.description,
+    a.avg_power,
+    a.max_power,
+    a.normalized_power,
+    a.variability_index,
+    a.intensity_factor,
+    a.training_stress_score,
+    a.ftp,
+    a.indoor,
+    a.moving_time,
+    a.ride_type_source;
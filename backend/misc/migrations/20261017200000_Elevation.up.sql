@@ -0,0 +1,76 @@
+-- Elevation gain and loss in m are summed from the smoothed altitude, ignoring
+-- changes below the hysteresis threshold. All four are NULL for activities
+-- without altitude data and for those ingested before they were derived;
+-- reprocessing fills them in.
+ALTER TABLE activities
+    ADD COLUMN IF NOT EXISTS elevation_gain DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS elevation_loss DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS min_altitude DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS max_altitude DOUBLE PRECISION;
+
+DROP VIEW IF EXISTS activity_with_records_view;
+
+CREATE VIEW activity_with_records_view AS
+SELECT
+    a.id,
+    a.created_at,
+    a.user_id,
+    a.distance,
+    a.activity_name,
+    a.avg_speed,
+    a.max_speed,
+    a.ride_type,
+    a.elapsed_time,
+    a.total_time,
+    TO_CHAR(a.elapsed_time::time, 'HH24:MI:SS') AS elapsed_time_char,
+    TO_CHAR(a.total_time::time, 'HH24:MI:SS') AS total_time_char,
+    a.moving_time,
+    TO_CHAR(a.moving_time::time, 'HH24:MI:SS') AS moving_time_char,
+    a.duplicate_of,
+    a.description,
+    a.avg_power,
+    a.max_power,
+    a.normalized_power,
+    a.variability_index,
+    a.intensity_factor,
+    a.training_stress_score,
+    a.ftp,
+    a.indoor,
+    a.ride_type_source,
+    a.elevation_gain,
+    a.elevation_loss,
+    a.min_altitude,
+    a.max_altitude,
+    COALESCE(
+        JSON_AGG(r.* ORDER BY r.time_stamp) FILTER (WHERE r.id IS NOT NULL),
+        '[]'
+    ) AS records
+FROM activities a
+LEFT JOIN records r ON r.activity_id = a.id
+GROUP BY
+    a.id,
+    a.created_at,
+    a.user_id,
+    a.distance,
+    a.activity_name,
+    a.avg_speed,
+    a.max_speed,
+    a.ride_type,
+    a.elapsed_time,
+    a.total_time,
+    a.duplicate_of,
+    a.description,
+    a.avg_power,
+    a.max_power,
+    a.normalized_power,
+    a.variability_index,
+    a.intensity_factor,
+    a.training_stress_score,
+    a.ftp,
+    a.indoor,
+    a.moving_time,
+    a.ride_type_source,
+    a.elevation_gain,
+    a.elevation_loss,
+    a.min_altitude,
+    a.max_altitude;
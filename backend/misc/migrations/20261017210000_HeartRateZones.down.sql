@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS activity_heart_rate_zones;
+
+ALTER TABLE user_settings
+    DROP COLUMN IF EXISTS max_heart_rate,
+    DROP COLUMN IF EXISTS threshold_heart_rate,
+    DROP COLUMN IF EXISTS heart_rate_zone_method,
+    DROP COLUMN IF EXISTS heart_rate_zone_bounds;
@@ -0,0 +1,21 @@
+-- Heart rate zones are configured per user from a percentage of the maximum
+-- heart rate, from the lactate threshold heart rate, or as custom bounds in
+-- bpm between consecutive zones.
+ALTER TABLE user_settings
+    ADD COLUMN IF NOT EXISTS max_heart_rate INTEGER,
+    ADD COLUMN IF NOT EXISTS threshold_heart_rate INTEGER,
+    ADD COLUMN IF NOT EXISTS heart_rate_zone_method TEXT
+        CHECK (heart_rate_zone_method IN ('max_hr', 'lthr', 'custom')),
+    ADD COLUMN IF NOT EXISTS heart_rate_zone_bounds INTEGER[];
+
+-- The time spent in each zone, computed at ingestion. The bounds are kept
+-- with the activity so later changes to the zones do not rewrite history.
+CREATE TABLE IF NOT EXISTS activity_heart_rate_zones (
+    activity_id INTEGER NOT NULL REFERENCES activities (id) ON DELETE CASCADE,
+    zone INTEGER NOT NULL,
+    min_heart_rate INTEGER NOT NULL,
+    -- max_heart_rate is NULL for the open-ended top zone.
+    max_heart_rate INTEGER,
+    seconds INTEGER NOT NULL,
+    PRIMARY KEY (activity_id, zone)
+);
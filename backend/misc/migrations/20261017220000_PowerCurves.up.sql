@@ -0,0 +1,7 @@
+-- The mean-maximal power curve of each ride with power data: watts[i] is the
+-- best average power held for durations[i] seconds.
+CREATE TABLE IF NOT EXISTS activity_power_curves (
+    activity_id INTEGER PRIMARY KEY REFERENCES activities (id) ON DELETE CASCADE,
+    durations INTEGER[] NOT NULL,
+    watts INTEGER[] NOT NULL
+);
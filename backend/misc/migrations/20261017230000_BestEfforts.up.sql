@@ -0,0 +1,14 @@
+-- The fastest effort over each standard distance within a ride, in elapsed
+-- seconds. personal_record marks efforts faster than any earlier ride of the
+-- user when the ride was ingested.
+CREATE TABLE IF NOT EXISTS activity_best_efforts (
+    activity_id INTEGER NOT NULL REFERENCES activities (id) ON DELETE CASCADE,
+    -- distance is in m.
+    distance INTEGER NOT NULL,
+    seconds INTEGER NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    personal_record BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (activity_id, distance)
+);
+
+CREATE INDEX IF NOT EXISTS activity_best_efforts_distance_idx ON activity_best_efforts (distance, seconds);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS daily_training_load;
+
+ALTER TABLE activities
+    DROP COLUMN IF EXISTS trimp;
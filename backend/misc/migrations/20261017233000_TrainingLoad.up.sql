@@ -0,0 +1,19 @@
+-- TRIMP is the heart rate training load of a ride: minutes in each heart rate
+-- zone weighted by the zone number (Edwards). It stands in for TSS on rides
+-- without power and is NULL for rides without heart rate zones.
+ALTER TABLE activities
+    ADD COLUMN IF NOT EXISTS trimp DOUBLE PRECISION;
+
+-- The training load of each user per UTC day from their first ride to their
+-- latest: the summed load of the day's rides with the chronic (42 day) and
+-- acute (7 day) training load and the training stress balance. Rows from the
+-- day of a stored or reprocessed ride onwards are rebuilt with it.
+CREATE TABLE IF NOT EXISTS daily_training_load (
+    user_id UUID NOT NULL REFERENCES auth.users (id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    load DOUBLE PRECISION NOT NULL,
+    ctl DOUBLE PRECISION NOT NULL,
+    atl DOUBLE PRECISION NOT NULL,
+    tsb DOUBLE PRECISION NOT NULL,
+    PRIMARY KEY (user_id, day)
+);
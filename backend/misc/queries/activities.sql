@@ -24,10 +24,14 @@ SELECT
     activity_name,
     distance,
     ride_type,
+    indoor,
+    elevation_gain,
     elapsed_time::interval AS elapsed_time,
     total_time::interval AS total_time,
+    moving_time,
     TO_CHAR(elapsed_time::time, 'HH24:MI:SS') AS elapsed_time_char,
-    TO_CHAR(total_time::time, 'HH24:MI:SS') AS total_time_char
+    TO_CHAR(total_time::time, 'HH24:MI:SS') AS total_time_char,
+    TO_CHAR(moving_time::time, 'HH24:MI:SS') AS moving_time_char
 FROM activities
 WHERE user_id = $1
 ORDER BY date_of_activity DESC;
@@ -46,9 +50,28 @@ SELECT
     total_time,
     elapsed_time_char,
     total_time_char,
+    moving_time,
+    moving_time_char,
+    duplicate_of,
+    description,
+    avg_power,
+    max_power,
+    normalized_power,
+    variability_index,
+    intensity_factor,
+    training_stress_score,
+    ftp,
+    indoor,
+    ride_type_source,
+    elevation_gain,
+    elevation_loss,
+    min_altitude,
+    max_altitude,
+    corrected_points,
+    filled_points,
     records
 FROM activity_with_records_view
-WHERE id = $1;
+WHERE id = $1 AND user_id = $2;
 
 -- name: CreateActivity :one
 INSERT INTO activities (
@@ -60,8 +83,31 @@ INSERT INTO activities (
     ride_type,
     elapsed_time,
     total_time,
-    date_of_activity
-
+    date_of_activity,
+    content_hash,
+    device_fingerprint,
+    started_at,
+    ended_at,
+    start_position,
+    duplicate_of,
+    description,
+    avg_power,
+    max_power,
+    normalized_power,
+    variability_index,
+    intensity_factor,
+    training_stress_score,
+    ftp,
+    indoor,
+    moving_time,
+    ride_type_source,
+    elevation_gain,
+    elevation_loss,
+    min_altitude,
+    max_altitude,
+    trimp,
+    corrected_points,
+    filled_points
 ) VALUES (
     $1, 
     $2,
@@ -71,10 +117,57 @@ INSERT INTO activities (
     $6,
     $7,
     $8,
-    $9
+    $9,
+    $10,
+    $11,
+    $12,
+    $13,
+    $14,
+    $15,
+    $16,
+    $17,
+    $18,
+    $19,
+    $20,
+    $21,
+    $22,
+    $23,
+    $24,
+    $25,
+    $26,
+    $27,
+    $28,
+    $29,
+    $30,
+    $31,
+    $32,
+    $33
 )
 RETURNING id; 
 
+-- name: FindDuplicateActivity :one
+-- Exact duplicates share the file content or come from the same device
+-- recording started at the same time.
+SELECT id
+FROM activities
+WHERE user_id = sqlc.arg('user_id')
+    AND (
+        content_hash = sqlc.arg('content_hash')
+        OR (sqlc.arg('device_fingerprint')::text <> '' AND device_fingerprint = sqlc.arg('device_fingerprint')::text)
+    )
+ORDER BY id
+LIMIT 1;
+
+-- name: GetOverlappingActivities :many
+SELECT
+    id,
+    start_position
+FROM activities
+WHERE user_id = sqlc.arg('user_id')
+    AND started_at < sqlc.arg('ended_at')
+    AND ended_at > sqlc.arg('started_at')
+ORDER BY started_at;
+
 -- name: GetActivityStats :one
 SELECT 
     -- Current month total
@@ -107,7 +200,13 @@ SELECT
             NULLIF(SUM(CASE WHEN DATE_TRUNC('week', created_at) = DATE_TRUNC('week', CURRENT_DATE - INTERVAL '1 WEEK') THEN distance ELSE 0 END), 0) * 100, 
             0
         ), 2
-    ) AS percentage_change_week
+    ) AS percentage_change_week,
+
+    -- Elevation gain totals
+    ROUND(SUM(CASE WHEN TO_CHAR(created_at, 'YYYY-MM') = TO_CHAR(CURRENT_DATE, 'YYYY-MM') THEN COALESCE(elevation_gain, 0) ELSE 0 END)::numeric, 0) AS elevation_gain_for_current_month,
+    ROUND(SUM(CASE WHEN TO_CHAR(created_at, 'YYYY-MM') = TO_CHAR(CURRENT_DATE - INTERVAL '1 MONTH', 'YYYY-MM') THEN COALESCE(elevation_gain, 0) ELSE 0 END)::numeric, 0) AS elevation_gain_for_last_month,
+    ROUND(SUM(CASE WHEN DATE_TRUNC('week', created_at) = DATE_TRUNC('week', CURRENT_DATE) THEN COALESCE(elevation_gain, 0) ELSE 0 END)::numeric, 0) AS elevation_gain_for_current_week,
+    ROUND(SUM(CASE WHEN DATE_TRUNC('week', created_at) = DATE_TRUNC('week', CURRENT_DATE - INTERVAL '1 WEEK') THEN COALESCE(elevation_gain, 0) ELSE 0 END)::numeric, 0) AS elevation_gain_for_last_week
 FROM activities
 WHERE user_id = $1;
 
@@ -138,7 +237,11 @@ WITH updated_activity AS (
     UPDATE activities 
     SET 
         activity_name = COALESCE(sqlc.narg('activity_name'), activity_name),
-        ride_type = COALESCE(sqlc.narg('ride_type'), ride_type)
+        ride_type = COALESCE(sqlc.narg('ride_type'), ride_type),
+        ride_type_source = CASE
+            WHEN sqlc.narg('ride_type')::text IS NULL THEN ride_type_source
+            ELSE 'user'
+        END
     WHERE 
         activities.id = sqlc.arg('id') 
         AND activities.user_id = sqlc.arg('user_id')
@@ -147,3 +250,11 @@ WITH updated_activity AS (
 SELECT *
 FROM activity_with_records_view awrv
 WHERE awrv.id = (SELECT updated_activity.id FROM updated_activity);
+
+-- name: DeleteActivity :one
+-- Deletes the activity with its child rows and returns when it started, from
+-- which the training load and personal records are refreshed.
+DELETE FROM activities
+WHERE id = sqlc.arg('id')
+    AND user_id = sqlc.arg('user_id')
+RETURNING date_of_activity;
@@ -0,0 +1,66 @@
+-- name: CreateActivityBestEfforts :copyfrom
+INSERT INTO activity_best_efforts (
+    activity_id,
+    distance,
+    seconds,
+    started_at,
+    personal_record
+) VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetActivityBestEfforts :many
+SELECT
+    distance,
+    seconds,
+    started_at,
+    personal_record
+FROM activity_best_efforts
+WHERE activity_id = $1
+ORDER BY distance;
+
+-- name: GetPreviousBestEfforts :many
+-- The user's fastest time over each distance in the rides before the given
+-- date, leaving out the activity itself.
+SELECT
+    e.distance,
+    MIN(e.seconds)::integer AS seconds
+FROM activity_best_efforts e
+JOIN activities a ON a.id = e.activity_id
+WHERE a.user_id = sqlc.arg(user_id)
+    AND a.id <> sqlc.arg(activity_id)
+    AND a.date_of_activity < sqlc.arg(before)
+GROUP BY e.distance;
+
+-- name: GetPersonalRecords :many
+-- The user's fastest effort over each distance with the ride that holds it.
+-- Ties go to the earliest ride.
+SELECT DISTINCT ON (e.distance)
+    e.distance,
+    e.seconds,
+    e.started_at,
+    a.id AS activity_id,
+    a.activity_name,
+    a.date_of_activity
+FROM activity_best_efforts e
+JOIN activities a ON a.id = e.activity_id
+WHERE a.user_id = $1
+ORDER BY e.distance, e.seconds, a.date_of_activity;
+
+-- name: RefreshPersonalRecords :exec
+-- Flags the best efforts of the user's rides after the given date again, so
+-- they follow a ride whose efforts were added or changed before them. An
+-- effort is a record when no earlier ride was as fast over the distance.
+UPDATE activity_best_efforts e
+SET personal_record = NOT EXISTS (
+    SELECT 1
+    FROM activity_best_efforts earlier
+    JOIN activities ea ON ea.id = earlier.activity_id
+    WHERE ea.user_id = a.user_id
+        AND ea.id <> a.id
+        AND ea.date_of_activity < a.date_of_activity
+        AND earlier.distance = e.distance
+        AND earlier.seconds <= e.seconds
+)
+FROM activities a
+WHERE a.id = e.activity_id
+    AND a.user_id = sqlc.arg(user_id)
+    AND a.date_of_activity > sqlc.arg(after);
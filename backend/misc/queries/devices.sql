@@ -0,0 +1,36 @@
+-- name: CreateActivityDevices :copyfrom
+INSERT INTO activity_devices (
+    activity_id,
+    device_index,
+    creator,
+    manufacturer,
+    product,
+    product_name,
+    serial_number,
+    software_version,
+    hardware_version,
+    device_type,
+    source_type,
+    ant_device_number,
+    battery_voltage,
+    battery_status
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14);
+
+-- name: GetActivityDevices :many
+SELECT
+    device_index,
+    creator,
+    manufacturer,
+    product,
+    product_name,
+    serial_number,
+    software_version,
+    hardware_version,
+    device_type,
+    source_type,
+    ant_device_number,
+    battery_voltage,
+    battery_status
+FROM activity_devices
+WHERE activity_id = $1
+ORDER BY device_index;
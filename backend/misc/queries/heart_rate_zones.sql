@@ -0,0 +1,32 @@
+-- name: CreateActivityHeartRateZones :copyfrom
+INSERT INTO activity_heart_rate_zones (
+    activity_id,
+    zone,
+    min_heart_rate,
+    max_heart_rate,
+    seconds
+) VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetActivityHeartRateZones :many
+SELECT
+    zone,
+    min_heart_rate,
+    max_heart_rate,
+    seconds
+FROM activity_heart_rate_zones
+WHERE activity_id = $1
+ORDER BY zone;
+
+-- name: GetWeeklyHeartRateZones :many
+-- Time in each zone summed per week of the ride, from since onwards. Weeks
+-- start on Monday in UTC.
+SELECT
+    (DATE_TRUNC('week', a.date_of_activity AT TIME ZONE 'UTC') AT TIME ZONE 'UTC')::timestamptz AS week_start,
+    z.zone,
+    SUM(z.seconds)::bigint AS seconds
+FROM activity_heart_rate_zones z
+JOIN activities a ON a.id = z.activity_id
+WHERE a.user_id = sqlc.arg(user_id)
+    AND a.date_of_activity >= sqlc.arg(since)
+GROUP BY week_start, z.zone
+ORDER BY week_start, z.zone;
@@ -0,0 +1,90 @@
+-- name: EnqueueIngestionJob :one
+INSERT INTO ingestion_jobs (
+    user_id,
+    filename,
+    content_type,
+    last_modified,
+    data,
+    activity_name,
+    ride_type,
+    description
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, filename, status, created_at;
+
+-- name: ClaimIngestionJob :one
+-- Marks the oldest queued job with attempts left as running. Jobs locked by
+-- another worker are skipped rather than waited for.
+UPDATE ingestion_jobs
+SET
+    status = 'running',
+    attempts = attempts + 1,
+    started_at = NOW()
+WHERE id = (
+    SELECT queued.id
+    FROM ingestion_jobs AS queued
+    WHERE queued.status = 'queued'
+        AND queued.attempts < sqlc.arg('max_attempts')
+    ORDER BY id
+    FOR UPDATE SKIP LOCKED
+    LIMIT 1
+)
+RETURNING id, user_id, filename, content_type, last_modified, data, activity_name, ride_type, description;
+
+-- name: CompleteIngestionJob :exec
+UPDATE ingestion_jobs
+SET
+    status = sqlc.arg('status'),
+    activity_id = sqlc.narg('activity_id'),
+    skipped = sqlc.arg('skipped'),
+    failure_reason = sqlc.arg('failure_reason'),
+    error = sqlc.arg('error'),
+    data = NULL,
+    finished_at = NOW()
+WHERE id = sqlc.arg('id');
+
+-- name: RequeueIngestionJob :exec
+-- Hands a running job back to the queue, or fails it as internal once it
+-- has used up its attempts.
+UPDATE ingestion_jobs
+SET
+    status = CASE WHEN attempts < sqlc.arg('max_attempts') THEN 'queued' ELSE 'failed' END,
+    failure_reason = CASE WHEN attempts < sqlc.arg('max_attempts') THEN failure_reason ELSE 'internal' END,
+    error = CASE WHEN attempts < sqlc.arg('max_attempts') THEN error ELSE sqlc.arg('exhausted_error') END,
+    data = CASE WHEN attempts < sqlc.arg('max_attempts') THEN data END,
+    started_at = NULL,
+    finished_at = CASE WHEN attempts < sqlc.arg('max_attempts') THEN NULL ELSE NOW() END
+WHERE id = sqlc.arg('id') AND status = 'running';
+
+-- name: RequeueStaleIngestionJobs :execrows
+-- Returns jobs left running by a worker that went away, e.g. a crashed
+-- server, to the queue. Jobs that have used up their attempts, e.g. because
+-- they crash the worker every time, are failed as internal instead.
+UPDATE ingestion_jobs
+SET
+    status = CASE WHEN attempts < sqlc.arg('max_attempts') THEN 'queued' ELSE 'failed' END,
+    failure_reason = CASE WHEN attempts < sqlc.arg('max_attempts') THEN failure_reason ELSE 'internal' END,
+    error = CASE WHEN attempts < sqlc.arg('max_attempts') THEN error ELSE sqlc.arg('exhausted_error') END,
+    data = CASE WHEN attempts < sqlc.arg('max_attempts') THEN data END,
+    started_at = NULL,
+    finished_at = CASE WHEN attempts < sqlc.arg('max_attempts') THEN NULL ELSE NOW() END
+WHERE status = 'running'
+    AND started_at < sqlc.arg('started_before');
+
+-- name: GetIngestionJobs :many
+-- Lists the jobs of a user, newest first, optionally restricted to ids.
+SELECT
+    id,
+    filename,
+    status,
+    activity_id,
+    skipped,
+    failure_reason,
+    error,
+    created_at,
+    started_at,
+    finished_at
+FROM ingestion_jobs
+WHERE user_id = sqlc.arg('user_id')
+    AND (cardinality(sqlc.arg('ids')::bigint[]) = 0 OR id = ANY(sqlc.arg('ids')::bigint[]))
+ORDER BY id DESC
+LIMIT sqlc.arg('row_limit');
@@ -0,0 +1,37 @@
+-- name: CreateLaps :copyfrom
+INSERT INTO laps (
+    activity_id,
+    lap_index,
+    lap_trigger,
+    start_time,
+    end_time,
+    total_elapsed_time,
+    total_timer_time,
+    distance,
+    avg_speed,
+    max_speed,
+    avg_heart_rate,
+    max_heart_rate,
+    avg_power,
+    max_power
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14);
+
+-- name: GetActivityLaps :many
+SELECT
+    l.lap_index,
+    l.lap_trigger,
+    l.start_time,
+    l.end_time,
+    l.total_elapsed_time,
+    l.total_timer_time,
+    l.distance,
+    l.avg_speed,
+    l.max_speed,
+    l.avg_heart_rate,
+    l.max_heart_rate,
+    l.avg_power,
+    l.max_power
+FROM laps l
+JOIN activities a ON a.id = l.activity_id
+WHERE l.activity_id = $1 AND a.user_id = $2
+ORDER BY l.lap_index;
@@ -0,0 +1,20 @@
+-- name: CreateActivityOriginal :exec
+INSERT INTO activity_originals (
+    activity_id,
+    storage_key,
+    filename,
+    content_type,
+    size
+) VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetActivityOriginal :one
+SELECT
+    o.activity_id,
+    o.storage_key,
+    o.filename,
+    o.content_type,
+    o.size,
+    o.created_at
+FROM activity_originals o
+JOIN activities a ON a.id = o.activity_id
+WHERE o.activity_id = $1 AND a.user_id = $2;
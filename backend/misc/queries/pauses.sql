@@ -0,0 +1,16 @@
+-- name: CreateActivityPauses :copyfrom
+INSERT INTO activity_pauses (
+    activity_id,
+    started_at,
+    ended_at,
+    source
+) VALUES ($1, $2, $3, $4);
+
+-- name: GetActivityPauses :many
+SELECT
+    started_at,
+    ended_at,
+    source
+FROM activity_pauses
+WHERE activity_id = $1
+ORDER BY started_at;
@@ -0,0 +1,30 @@
+-- name: CreateActivityPowerCurve :exec
+INSERT INTO activity_power_curves (
+    activity_id,
+    durations,
+    watts
+) VALUES ($1, $2, $3);
+
+-- name: GetActivityPowerCurve :one
+SELECT
+    durations,
+    watts
+FROM activity_power_curves
+WHERE activity_id = $1;
+
+-- name: GetPowerCurveEnvelope :many
+-- The best power for every duration over the user's rides in the date range,
+-- with the ride that holds it. Ties go to the earliest ride.
+SELECT DISTINCT ON (p.durations[i])
+    p.durations[i]::integer AS duration,
+    p.watts[i]::integer AS watts,
+    a.id AS activity_id,
+    a.date_of_activity
+FROM activities a
+JOIN activity_power_curves p ON p.activity_id = a.id
+CROSS JOIN LATERAL GENERATE_SUBSCRIPTS(p.durations, 1) AS i
+WHERE a.user_id = sqlc.arg(user_id)
+    AND a.date_of_activity >= sqlc.arg(from_date)
+    AND a.date_of_activity < sqlc.arg(to_date)
+    AND (sqlc.narg(ride_type)::text IS NULL OR a.ride_type = sqlc.narg(ride_type))
+ORDER BY p.durations[i], p.watts[i] DESC, a.date_of_activity;
@@ -10,10 +10,10 @@ INSERT INTO records (
     temperature, 
     gps_accuracy, 
     enhanced_altitude, 
-    activity_id
-    
+    activity_id,
+    power
 ) 
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);
 
 -- name: GetRecords :many
 SELECT *
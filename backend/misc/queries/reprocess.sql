@@ -0,0 +1,77 @@
+-- name: GetReprocessableActivities :many
+-- Activities with a stored original, optionally narrowed to one activity or
+-- one user.
+SELECT
+    o.activity_id,
+    a.user_id,
+    o.storage_key,
+    o.filename,
+    o.content_type
+FROM activity_originals o
+JOIN activities a ON a.id = o.activity_id
+WHERE (sqlc.narg(activity_id)::integer IS NULL OR o.activity_id = sqlc.narg(activity_id))
+  AND (sqlc.narg(user_id)::text IS NULL OR a.user_id::text = sqlc.narg(user_id))
+ORDER BY o.activity_id;
+
+-- name: GetActivityDate :one
+-- The stored start of the activity, read before reprocessing may move it.
+SELECT date_of_activity
+FROM activities
+WHERE id = $1;
+
+-- name: ReplaceActivityMetrics :exec
+-- Overwrites the values derived from the activity file. Fields the user can
+-- edit (activity_name, description, a ride_type set by the user) and
+-- duplicate_of are kept.
+UPDATE activities SET
+    ride_type = CASE
+        WHEN ride_type_source = 'detected' THEN sqlc.arg(ride_type)
+        ELSE ride_type
+    END,
+    distance = sqlc.arg(distance),
+    avg_speed = sqlc.arg(avg_speed),
+    max_speed = sqlc.arg(max_speed),
+    elapsed_time = sqlc.arg(elapsed_time),
+    total_time = sqlc.arg(total_time),
+    date_of_activity = sqlc.arg(date_of_activity),
+    content_hash = sqlc.arg(content_hash),
+    device_fingerprint = sqlc.arg(device_fingerprint),
+    started_at = sqlc.arg(started_at),
+    ended_at = sqlc.arg(ended_at),
+    start_position = sqlc.arg(start_position),
+    avg_power = sqlc.arg(avg_power),
+    max_power = sqlc.arg(max_power),
+    normalized_power = sqlc.arg(normalized_power),
+    variability_index = sqlc.arg(variability_index),
+    intensity_factor = sqlc.arg(intensity_factor),
+    training_stress_score = sqlc.arg(training_stress_score),
+    ftp = sqlc.arg(ftp),
+    indoor = sqlc.arg(indoor),
+    moving_time = sqlc.arg(moving_time),
+    elevation_gain = sqlc.arg(elevation_gain),
+    elevation_loss = sqlc.arg(elevation_loss),
+    min_altitude = sqlc.arg(min_altitude),
+    max_altitude = sqlc.arg(max_altitude),
+    trimp = sqlc.arg(trimp),
+    corrected_points = sqlc.arg(corrected_points),
+    filled_points = sqlc.arg(filled_points)
+WHERE id = sqlc.arg(id);
+
+-- name: ClearActivityDetails :exec
+-- Removes the rows derived from the activity file so they can be stored again.
+WITH deleted_records AS (
+    DELETE FROM records r WHERE r.activity_id = sqlc.arg(activity_id)::integer
+), deleted_sessions AS (
+    DELETE FROM activity_sessions s WHERE s.activity_id = sqlc.arg(activity_id)::integer
+), deleted_laps AS (
+    DELETE FROM laps l WHERE l.activity_id = sqlc.arg(activity_id)::integer
+), deleted_devices AS (
+    DELETE FROM activity_devices d WHERE d.activity_id = sqlc.arg(activity_id)::integer
+), deleted_heart_rate_zones AS (
+    DELETE FROM activity_heart_rate_zones z WHERE z.activity_id = sqlc.arg(activity_id)::integer
+), deleted_power_curves AS (
+    DELETE FROM activity_power_curves c WHERE c.activity_id = sqlc.arg(activity_id)::integer
+), deleted_best_efforts AS (
+    DELETE FROM activity_best_efforts e WHERE e.activity_id = sqlc.arg(activity_id)::integer
+)
+DELETE FROM activity_pauses p WHERE p.activity_id = sqlc.arg(activity_id)::integer;
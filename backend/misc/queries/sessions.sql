@@ -0,0 +1,26 @@
+-- name: CreateActivitySessions :copyfrom
+INSERT INTO activity_sessions (
+    activity_id,
+    session_index,
+    sport,
+    sub_sport,
+    start_time,
+    end_time,
+    total_elapsed_time,
+    total_timer_time,
+    distance
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: GetActivitySessions :many
+SELECT
+    session_index,
+    sport,
+    sub_sport,
+    start_time,
+    end_time,
+    total_elapsed_time,
+    total_timer_time,
+    distance
+FROM activity_sessions
+WHERE activity_id = $1
+ORDER BY session_index;
@@ -0,0 +1,44 @@
+-- name: GetUserSettings :one
+SELECT
+    user_id,
+    ftp,
+    updated_at,
+    max_heart_rate,
+    threshold_heart_rate,
+    heart_rate_zone_method,
+    heart_rate_zone_bounds
+FROM user_settings
+WHERE user_id = $1;
+
+-- name: UpsertUserSettings :one
+INSERT INTO user_settings (
+    user_id,
+    ftp,
+    max_heart_rate,
+    threshold_heart_rate,
+    heart_rate_zone_method,
+    heart_rate_zone_bounds
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
+)
+ON CONFLICT (user_id) DO UPDATE
+SET
+    ftp = EXCLUDED.ftp,
+    max_heart_rate = EXCLUDED.max_heart_rate,
+    threshold_heart_rate = EXCLUDED.threshold_heart_rate,
+    heart_rate_zone_method = EXCLUDED.heart_rate_zone_method,
+    heart_rate_zone_bounds = EXCLUDED.heart_rate_zone_bounds,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING
+    user_id,
+    ftp,
+    updated_at,
+    max_heart_rate,
+    threshold_heart_rate,
+    heart_rate_zone_method,
+    heart_rate_zone_bounds;
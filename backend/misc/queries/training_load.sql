@@ -0,0 +1,57 @@
+-- name: LockTrainingLoad :exec
+-- Serialises rebuilds of a user's training load until the transaction ends.
+SELECT pg_advisory_xact_lock(hashtext(sqlc.arg(user_id)::text));
+
+-- name: GetTrainingLoadBefore :one
+-- The user's latest stored day before the given one.
+SELECT
+    day,
+    ctl,
+    atl
+FROM daily_training_load
+WHERE user_id = sqlc.arg(user_id)
+    AND day < sqlc.arg(before)::date
+ORDER BY day DESC
+LIMIT 1;
+
+-- name: GetDailyActivityLoads :many
+-- The summed load of the user's rides per UTC day from since onwards: TSS
+-- where there is power, otherwise TRIMP. Rides imported as duplicates of
+-- another are left out.
+SELECT
+    (a.date_of_activity AT TIME ZONE 'UTC')::date AS day,
+    SUM(COALESCE(a.training_stress_score, a.trimp, 0))::double precision AS load
+FROM activities a
+WHERE a.user_id = sqlc.arg(user_id)
+    AND a.duplicate_of IS NULL
+    AND (a.date_of_activity AT TIME ZONE 'UTC')::date >= sqlc.arg(since)::date
+GROUP BY day
+ORDER BY day;
+
+-- name: DeleteTrainingLoadFrom :exec
+DELETE FROM daily_training_load
+WHERE user_id = sqlc.arg(user_id)
+    AND day >= sqlc.arg(since)::date;
+
+-- name: CreateTrainingLoad :copyfrom
+INSERT INTO daily_training_load (
+    user_id,
+    day,
+    load,
+    ctl,
+    atl,
+    tsb
+) VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetTrainingLoad :many
+SELECT
+    day,
+    load,
+    ctl,
+    atl,
+    tsb
+FROM daily_training_load
+WHERE user_id = sqlc.arg(user_id)
+    AND day >= sqlc.arg(from_day)::date
+    AND day <= sqlc.arg(to_day)::date
+ORDER BY day;
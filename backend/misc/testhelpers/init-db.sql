@@ -128,6 +128,7 @@ DROP TABLE IF EXISTS public.activities;
 CREATE TABLE public.activities (
 	id serial4 NOT NULL,
 	created_at timestamptz DEFAULT CURRENT_TIMESTAMP NULL,
+	date_of_activity timestamptz DEFAULT CURRENT_TIMESTAMP NOT NULL,
 	user_id uuid NOT NULL,
 	distance numeric NULL,
 	activity_name varchar(255) NOT NULL,
@@ -136,8 +137,34 @@ CREATE TABLE public.activities (
 	ride_type text NOT NULL DEFAULT 'road',
 	elapsed_time interval NOT NULL,
 	total_time interval NOT NULL,
-	CONSTRAINT activities_pkey PRIMARY KEY (id)
+	content_hash text NULL,
+	device_fingerprint text NULL,
+	started_at timestamptz NULL,
+	ended_at timestamptz NULL,
+	start_position point NULL,
+	duplicate_of int4 NULL,
+	description text DEFAULT '' NOT NULL,
+	avg_power int4 NULL,
+	max_power int4 NULL,
+	normalized_power int4 NULL,
+	variability_index float8 NULL,
+	intensity_factor float8 NULL,
+	training_stress_score float8 NULL,
+	ftp int4 NULL,
+	indoor bool DEFAULT false NOT NULL,
+	moving_time interval DEFAULT '0' NOT NULL,
+	ride_type_source text DEFAULT 'detected' NOT NULL,
+	elevation_gain float8 NULL,
+	elevation_loss float8 NULL,
+	min_altitude float8 NULL,
+	max_altitude float8 NULL,
+	trimp float8 NULL,
+	corrected_points int4 DEFAULT 0 NOT NULL,
+	filled_points int4 DEFAULT 0 NOT NULL,
+	CONSTRAINT activities_pkey PRIMARY KEY (id),
+	CONSTRAINT activities_ride_type_source_check CHECK (ride_type_source IN ('detected', 'user'))
 );
+CREATE UNIQUE INDEX idx_activities_user_content_hash ON public.activities USING btree (user_id, content_hash) WHERE content_hash IS NOT NULL;
 -- CREATE INDEX idx_id_user_id ON pubklic.activities USING btree (id, user_id);
 
 INSERT INTO public.activities (created_at,user_id,distance,activity_name,avg_speed,max_speed,ride_type,elapsed_time,total_time) VALUES
@@ -169,6 +196,7 @@ CREATE TABLE public.records (
 	gps_accuracy int2 NULL,
 	enhanced_altitude int4 NULL,
 	activity_id int4 NULL,
+	power int4 NULL,
 	CONSTRAINT records_pkey PRIMARY KEY (id)
 );
 
@@ -613,6 +641,163 @@ INSERT INTO public.records (time_stamp,"position",altitude,heart_rate,cadence,di
 	 (NULL,'(12.546840934082866,55.637255972251296)',2499,170,255,307147,9635,10,255,2499,1),
 	 (NULL,'(12.546727946028113,55.63719796948135)',2504,171,255,308136,9679,10,255,2504,1);
 
+CREATE TABLE public.user_settings (
+	user_id uuid NOT NULL,
+	ftp int4 NULL,
+	updated_at timestamptz DEFAULT CURRENT_TIMESTAMP NOT NULL,
+	max_heart_rate int4 NULL,
+	threshold_heart_rate int4 NULL,
+	heart_rate_zone_method text NULL,
+	heart_rate_zone_bounds int4[] NULL,
+	CONSTRAINT user_settings_heart_rate_zone_method_check CHECK (heart_rate_zone_method IN ('max_hr', 'lthr', 'custom')),
+	CONSTRAINT user_settings_pkey PRIMARY KEY (user_id),
+	CONSTRAINT user_settings_user_id_fkey FOREIGN KEY (user_id) REFERENCES auth.users(id)
+);
+
+CREATE TABLE public.activity_sessions (
+	id serial4 NOT NULL,
+	activity_id int4 NOT NULL,
+	session_index int2 NOT NULL,
+	sport text NOT NULL,
+	sub_sport text NOT NULL,
+	start_time timestamptz NOT NULL,
+	end_time timestamptz NOT NULL,
+	total_elapsed_time interval NOT NULL,
+	total_timer_time interval NOT NULL,
+	distance numeric NOT NULL,
+	CONSTRAINT activity_sessions_pkey PRIMARY KEY (id),
+	CONSTRAINT activity_sessions_activity_id_session_index_key UNIQUE (activity_id, session_index),
+	CONSTRAINT activity_sessions_activity_id_fkey FOREIGN KEY (activity_id) REFERENCES public.activities(id) ON DELETE CASCADE
+);
+
+CREATE TABLE public.laps (
+	id serial4 NOT NULL,
+	activity_id int4 NOT NULL,
+	lap_index int2 NOT NULL,
+	lap_trigger text NOT NULL,
+	start_time timestamptz NOT NULL,
+	end_time timestamptz NOT NULL,
+	total_elapsed_time interval NOT NULL,
+	total_timer_time interval NOT NULL,
+	distance numeric NOT NULL,
+	avg_speed numeric NOT NULL,
+	max_speed numeric NOT NULL,
+	avg_heart_rate int2 NULL,
+	max_heart_rate int2 NULL,
+	avg_power int4 NULL,
+	max_power int4 NULL,
+	CONSTRAINT laps_pkey PRIMARY KEY (id),
+	CONSTRAINT laps_activity_id_lap_index_key UNIQUE (activity_id, lap_index),
+	CONSTRAINT laps_activity_id_fkey FOREIGN KEY (activity_id) REFERENCES public.activities(id) ON DELETE CASCADE
+);
+
+CREATE TABLE public.activity_devices (
+	id serial4 NOT NULL,
+	activity_id int4 NOT NULL,
+	device_index int2 NOT NULL,
+	creator bool DEFAULT false NOT NULL,
+	manufacturer text DEFAULT '' NOT NULL,
+	product int4 NULL,
+	product_name text DEFAULT '' NOT NULL,
+	serial_number int8 NULL,
+	software_version text DEFAULT '' NOT NULL,
+	hardware_version int2 NULL,
+	device_type text DEFAULT '' NOT NULL,
+	source_type text DEFAULT '' NOT NULL,
+	ant_device_number int4 NULL,
+	battery_voltage float8 NULL,
+	battery_status text DEFAULT '' NOT NULL,
+	CONSTRAINT activity_devices_pkey PRIMARY KEY (id),
+	CONSTRAINT activity_devices_activity_id_device_index_key UNIQUE (activity_id, device_index),
+	CONSTRAINT activity_devices_activity_id_fkey FOREIGN KEY (activity_id) REFERENCES public.activities(id) ON DELETE CASCADE
+);
+
+CREATE TABLE public.activity_pauses (
+	id serial4 NOT NULL,
+	activity_id int4 NOT NULL,
+	started_at timestamptz NOT NULL,
+	ended_at timestamptz NOT NULL,
+	"source" text NOT NULL,
+	CONSTRAINT activity_pauses_pkey PRIMARY KEY (id),
+	CONSTRAINT activity_pauses_activity_id_fkey FOREIGN KEY (activity_id) REFERENCES public.activities(id) ON DELETE CASCADE
+);
+
+CREATE TABLE public.ingestion_jobs (
+	id bigserial NOT NULL,
+	user_id uuid NOT NULL,
+	filename text NOT NULL,
+	content_type text DEFAULT '' NOT NULL,
+	last_modified timestamptz NULL,
+	"data" bytea NULL,
+	activity_name text DEFAULT '' NOT NULL,
+	ride_type text DEFAULT '' NOT NULL,
+	description text DEFAULT '' NOT NULL,
+	status text DEFAULT 'queued' NOT NULL,
+	attempts int4 DEFAULT 0 NOT NULL,
+	activity_id int4 NULL,
+	skipped bool DEFAULT false NOT NULL,
+	failure_reason text DEFAULT '' NOT NULL,
+	error text DEFAULT '' NOT NULL,
+	created_at timestamptz DEFAULT now() NOT NULL,
+	started_at timestamptz NULL,
+	finished_at timestamptz NULL,
+	CONSTRAINT ingestion_jobs_pkey PRIMARY KEY (id),
+	CONSTRAINT ingestion_jobs_status_check CHECK (status IN ('queued', 'running', 'done', 'failed')),
+	CONSTRAINT ingestion_jobs_user_id_fkey FOREIGN KEY (user_id) REFERENCES auth.users(id) ON DELETE CASCADE,
+	CONSTRAINT ingestion_jobs_activity_id_fkey FOREIGN KEY (activity_id) REFERENCES public.activities(id) ON DELETE SET NULL
+);
+
+CREATE TABLE public.activity_originals (
+	activity_id int4 NOT NULL,
+	storage_key text NOT NULL,
+	filename text NOT NULL,
+	content_type text DEFAULT '' NOT NULL,
+	"size" int8 NOT NULL,
+	created_at timestamptz DEFAULT now() NOT NULL,
+	CONSTRAINT activity_originals_pkey PRIMARY KEY (activity_id),
+	CONSTRAINT activity_originals_activity_id_fkey FOREIGN KEY (activity_id) REFERENCES public.activities(id) ON DELETE CASCADE
+);
+
+CREATE TABLE public.activity_heart_rate_zones (
+	activity_id int4 NOT NULL,
+	"zone" int4 NOT NULL,
+	min_heart_rate int4 NOT NULL,
+	max_heart_rate int4 NULL,
+	seconds int4 NOT NULL,
+	CONSTRAINT activity_heart_rate_zones_pkey PRIMARY KEY (activity_id, zone),
+	CONSTRAINT activity_heart_rate_zones_activity_id_fkey FOREIGN KEY (activity_id) REFERENCES public.activities(id) ON DELETE CASCADE
+);
+
+CREATE TABLE public.activity_power_curves (
+	activity_id int4 NOT NULL,
+	durations int4[] NOT NULL,
+	watts int4[] NOT NULL,
+	CONSTRAINT activity_power_curves_pkey PRIMARY KEY (activity_id),
+	CONSTRAINT activity_power_curves_activity_id_fkey FOREIGN KEY (activity_id) REFERENCES public.activities(id) ON DELETE CASCADE
+);
+
+CREATE TABLE public.activity_best_efforts (
+	activity_id int4 NOT NULL,
+	distance int4 NOT NULL,
+	seconds int4 NOT NULL,
+	started_at timestamptz NOT NULL,
+	personal_record bool DEFAULT false NOT NULL,
+	CONSTRAINT activity_best_efforts_pkey PRIMARY KEY (activity_id, distance),
+	CONSTRAINT activity_best_efforts_activity_id_fkey FOREIGN KEY (activity_id) REFERENCES public.activities(id) ON DELETE CASCADE
+);
+CREATE INDEX activity_best_efforts_distance_idx ON public.activity_best_efforts USING btree (distance, seconds);
+
+CREATE TABLE public.daily_training_load (
+	user_id uuid NOT NULL,
+	"day" date NOT NULL,
+	"load" float8 NOT NULL,
+	ctl float8 NOT NULL,
+	atl float8 NOT NULL,
+	tsb float8 NOT NULL,
+	CONSTRAINT daily_training_load_pkey PRIMARY KEY (user_id, day),
+	CONSTRAINT daily_training_load_user_id_fkey FOREIGN KEY (user_id) REFERENCES auth.users(id) ON DELETE CASCADE
+);
+
 CREATE VIEW activity_with_records_view AS 
 SELECT 
     a.id,
@@ -627,9 +812,31 @@ SELECT
     a.total_time,
     TO_CHAR(a.elapsed_time::time, 'HH24:MI:SS') AS elapsed_time_char,
     TO_CHAR(a.total_time::time, 'HH24:MI:SS') AS total_time_char,
-    JSON_AGG(r.* ORDER BY r.time_stamp) AS records
+    a.moving_time,
+    TO_CHAR(a.moving_time::time, 'HH24:MI:SS') AS moving_time_char,
+    a.duplicate_of,
+    a.description,
+    a.avg_power,
+    a.max_power,
+    a.normalized_power,
+    a.variability_index,
+    a.intensity_factor,
+    a.training_stress_score,
+    a.ftp,
+    a.indoor,
+    a.ride_type_source,
+    a.elevation_gain,
+    a.elevation_loss,
+    a.min_altitude,
+    a.max_altitude,
+    a.corrected_points,
+    a.filled_points,
+    COALESCE(
+        JSON_AGG(r.* ORDER BY r.time_stamp) FILTER (WHERE r.id IS NOT NULL),
+        '[]'
+    ) AS records
 FROM activities a
-JOIN records r ON r.activity_id = a.id
+LEFT JOIN records r ON r.activity_id = a.id
 GROUP BY 
     a.id, 
     a.created_at,
@@ -640,4 +847,22 @@ GROUP BY
     a.max_speed,
     a.ride_type,
     a.elapsed_time,
-    a.total_time;
+    a.total_time,
+    a.duplicate_of,
+    a.description,
+    a.avg_power,
+    a.max_power,
+    a.normalized_power,
+    a.variability_index,
+    a.intensity_factor,
+    a.training_stress_score,
+    a.ftp,
+    a.indoor,
+    a.moving_time,
+    a.ride_type_source,
+    a.elevation_gain,
+    a.elevation_loss,
+    a.min_altitude,
+    a.max_altitude,
+    a.corrected_points,
+    a.filled_points;
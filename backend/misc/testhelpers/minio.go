@@ -0,0 +1,51 @@
+package testhelpers
+
+import (
+	"context"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+type MinioContainer struct {
+	testcontainers.Container
+	// Endpoint is the host:port of the S3 API.
+	Endpoint string
+}
+
+var (
+	MINIO_USER     = "minioadmin"
+	MINIO_PASSWORD = "minioadmin"
+)
+
+func CreateMinioContainer(ctx context.Context) (*MinioContainer, error) {
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "minio/minio:RELEASE.2024-10-13T13-34-11Z",
+			Cmd:          []string{"server", "/data"},
+			ExposedPorts: []string{"9000/tcp"},
+			Env: map[string]string{
+				"MINIO_ROOT_USER":     MINIO_USER,
+				"MINIO_ROOT_PASSWORD": MINIO_PASSWORD,
+			},
+			WaitingFor: wait.ForHTTP("/minio/health/live").WithPort("9000/tcp").
+				WithStartupTimeout(30 * time.Second),
+		},
+		Started: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := container.PortEndpoint(ctx, "9000/tcp", "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &MinioContainer{
+		Container: container,
+		Endpoint:  endpoint,
+	}, nil
+}
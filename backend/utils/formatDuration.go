@@ -0,0 +1,13 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+// FormatDuration renders d as HH:MM:SS, matching the TO_CHAR formatting of
+// activity durations. Hours are not wrapped at 24.
+func FormatDuration(d time.Duration) string {
+	seconds := int64(d.Round(time.Second) / time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+}
@@ -0,0 +1,103 @@
+import { useMemo } from "react";
+import {
+  CartesianGrid,
+  Legend,
+  Line,
+  LineChart,
+  ResponsiveContainer,
+  Tooltip,
+  XAxis,
+  YAxis,
+} from "recharts";
+import { useQuery } from "@connectrpc/connect-query";
+
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardHeader,
+  CardTitle,
+} from "@/components/ui/card";
+import { getTrainingLoad } from "@/gen/activity/v1/activity-ActivityService_connectquery";
+
+const SERIES = [
+  { key: "ctl", label: "Fitness (CTL)", color: "#2563eb" },
+  { key: "atl", label: "Fatigue (ATL)", color: "#e11d48" },
+  { key: "tsb", label: "Form (TSB)", color: "#f59e0b" },
+] as const;
+
+/**
+ * Renders the performance management chart: fitness, fatigue and form over
+ * the last year.
+ */
+export const TrainingLoadChart = () => {
+  const { data } = useQuery(getTrainingLoad, {});
+
+  const points = useMemo(
+    () =>
+      (data?.days ?? []).map((day) => ({
+        date: Number(day.date?.seconds ?? 0) * 1000,
+        ctl: day.ctl,
+        atl: day.atl,
+        tsb: day.tsb,
+      })),
+    [data],
+  );
+  const hasLoad = points.some((point) => point.ctl > 0 || point.atl > 0);
+
+  return (
+    <Card>
+      <CardHeader className="space-y-1 border-b px-6 py-4">
+        <CardTitle className="text-lg">Training load</CardTitle>
+        <CardDescription>
+          Fitness and fatigue from the TSS of each ride, or TRIMP without a
+          power meter. Form is fitness minus fatigue.
+        </CardDescription>
+      </CardHeader>
+      <CardContent className="px-6 py-6">
+        {hasLoad ? (
+          <ResponsiveContainer width="100%" height={280}>
+            <LineChart data={points} margin={{ top: 8, right: 24, left: 0 }}>
+              <CartesianGrid strokeDasharray="3 3" stroke="#e2e8f0" />
+              <XAxis
+                dataKey="date"
+                type="number"
+                scale="time"
+                domain={["dataMin", "dataMax"]}
+                tickFormatter={(value) =>
+                  new Date(value).toLocaleDateString(undefined, {
+                    month: "short",
+                  })
+                }
+                tickMargin={12}
+              />
+              <YAxis width={40} />
+              <Tooltip
+                labelFormatter={(value) =>
+                  new Date(Number(value)).toLocaleDateString()
+                }
+                formatter={(value) => Number(value).toFixed(1)}
+              />
+              <Legend />
+              {SERIES.map((series) => (
+                <Line
+                  key={series.key}
+                  dataKey={series.key}
+                  name={series.label}
+                  stroke={series.color}
+                  dot={false}
+                  strokeWidth={2}
+                />
+              ))}
+            </LineChart>
+          </ResponsiveContainer>
+        ) : (
+          <p className="text-sm text-muted-foreground">
+            Upload rides with power or heart rate data to see your training
+            load.
+          </p>
+        )}
+      </CardContent>
+    </Card>
+  );
+};
@@ -1,5 +1,7 @@
 /** Maximum number of samples rendered in the speed chart to keep performance reasonable. */
 export const MAX_METRIC_POINTS = 1_000;
+/** Furthest in metres the route drawn on the map may stray from the recorded track. */
+export const ROUTE_TOLERANCE_METERS = 5;
 /** Maximum number of heart-rate samples rendered in the heart-rate chart. */
 export const MAX_HEART_RATE_POINTS = 600;
 /** Maximum number of cadence samples rendered in the cadence chart. */
@@ -32,7 +32,8 @@ const formatRecordedOn = (isoString: string | undefined): string => {
 };
 
 /**
- * Down-samples raw activity records into chart-friendly metric points.
+ * Down-samples activity records into chart-friendly metric points. Records
+ * requested at a resolution already arrive averaged down by the server.
  */
 const buildMetricsPoints = (activity?: GetActivityResponse): MetricPoint[] => {
   const records = activity?.records ?? [];
@@ -102,9 +103,23 @@ const buildSampleLookup = (
 };
 
 /**
- * Generates the polyline and centre point used to initialise the map viewport.
+ * Generates the polyline and centre point used to initialise the map viewport,
+ * preferring the simplified route from the server over the raw records.
  */
 const buildRouteInfo = (activity?: GetActivityResponse): RouteInfo => {
+  const simplifiedRoute = activity?.route ?? [];
+  if (simplifiedRoute.length) {
+    return {
+      route: simplifiedRoute.map((point) => [point.x, point.y]),
+      centerLat:
+        simplifiedRoute.reduce((acc, point) => acc + point.y, 0) /
+        simplifiedRoute.length,
+      centerLng:
+        simplifiedRoute.reduce((acc, point) => acc + point.x, 0) /
+        simplifiedRoute.length,
+    };
+  }
+
   const coordinateRecords = (activity?.records ?? []).filter(
     (record): record is NonNullable<GetActivityResponse["records"]>[number] & {
       coordinates: NonNullable<GetActivityResponse["records"][number]["coordinates"]>;
@@ -204,7 +219,12 @@ export const useActivityDerivedData = (
   const avgSpeedLabel = formatSpeedLabel(activity?.avgSpeed);
   const maxSpeedLabel = formatSpeedLabel(activity?.maxSpeed);
   const elapsedTimeLabel = activity?.elapsedTime ?? UNKNOWN_VALUE;
+  const movingTimeLabel = activity?.movingTime || elapsedTimeLabel;
   const totalTimeLabel = activity?.totalTime ?? UNKNOWN_VALUE;
+  const elevationGainLabel =
+    activity?.elevationGain != null
+      ? `${Math.round(activity.elevationGain)} m`
+      : UNKNOWN_VALUE;
 
   const heartRecords = useMemo(
     () => (activity?.records ?? []).filter((record) => record.heartRate != null),
@@ -303,7 +323,9 @@ export const useActivityDerivedData = (
     avgSpeedLabel,
     maxSpeedLabel,
     elapsedTimeLabel,
+    movingTimeLabel,
     totalTimeLabel,
+    elevationGainLabel,
     averageHeartRateValue,
     averageHeartRateLabel,
     maxHeartRateValue,
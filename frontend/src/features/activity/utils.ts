@@ -38,3 +38,16 @@ export const createDistanceTicks = (points: MetricPoint[]): number[] => {
 
 export const formatNullableLabel = (value: string | null | undefined) =>
   value ?? UNKNOWN_VALUE;
+
+/**
+ * Formats a number of seconds as H:MM:SS, or M:SS below an hour.
+ */
+export const formatSeconds = (totalSeconds: number) => {
+  const hours = Math.floor(totalSeconds / 3600);
+  const minutes = Math.floor((totalSeconds % 3600) / 60);
+  const seconds = totalSeconds % 60;
+  const pad = (value: number) => value.toString().padStart(2, "0");
+  return hours > 0
+    ? `${hours}:${pad(minutes)}:${pad(seconds)}`
+    : `${minutes}:${pad(seconds)}`;
+};
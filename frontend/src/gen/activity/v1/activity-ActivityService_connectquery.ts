@@ -18,14 +18,79 @@ export const getActivities = ActivityService.method.getActivities;
  */
 export const getActivity = ActivityService.method.getActivity;
 
+/**
+ * Fetch the laps of an activity.
+ *
+ * @generated from rpc activity.v1.ActivityService.GetActivityLaps
+ */
+export const getActivityLaps = ActivityService.method.getActivityLaps;
+
 /**
  * @generated from rpc activity.v1.ActivityService.UpdateActivity
  */
 export const updateActivity = ActivityService.method.updateActivity;
 
+/**
+ * Delete an activity, refreshing the training load and personal records
+ *
+ * @generated from rpc activity.v1.ActivityService.DeleteActivity
+ */
+export const deleteActivity = ActivityService.method.deleteActivity;
+
+/**
+ * Download the original uploaded file of an activity
+ *
+ * @generated from rpc activity.v1.ActivityService.GetOriginalFile
+ */
+export const getOriginalFile = ActivityService.method.getOriginalFile;
+
 /**
  * Upload fit files using a unary request (for clients without streaming support)
  *
  * @generated from rpc activity.v1.ActivityService.UploadActivitiesUnary
  */
 export const uploadActivitiesUnary = ActivityService.method.uploadActivitiesUnary;
+
+/**
+ * Stage a chunk of an export archive for ImportArchive
+ *
+ * @generated from rpc activity.v1.ActivityService.UploadArchiveChunk
+ */
+export const uploadArchiveChunk = ActivityService.method.uploadArchiveChunk;
+
+/**
+ * @generated from rpc activity.v1.ActivityService.GetIngestionJobs
+ */
+export const getIngestionJobs = ActivityService.method.getIngestionJobs;
+
+/**
+ * @generated from rpc activity.v1.ActivityService.GetUserSettings
+ */
+export const getUserSettings = ActivityService.method.getUserSettings;
+
+/**
+ * @generated from rpc activity.v1.ActivityService.UpdateUserSettings
+ */
+export const updateUserSettings = ActivityService.method.updateUserSettings;
+
+/**
+ * Fetch the user's best power for each duration over a date range
+ *
+ * @generated from rpc activity.v1.ActivityService.GetPowerCurve
+ */
+export const getPowerCurve = ActivityService.method.getPowerCurve;
+
+/**
+ * Fetch the user's fastest effort over each best effort distance
+ *
+ * @generated from rpc activity.v1.ActivityService.GetPersonalRecords
+ */
+export const getPersonalRecords = ActivityService.method.getPersonalRecords;
+
+/**
+ * Fetch the user's daily fitness, fatigue and form for a performance
+ * management chart
+ *
+ * @generated from rpc activity.v1.ActivityService.GetTrainingLoad
+ */
+export const getTrainingLoad = ActivityService.method.getTrainingLoad;
@@ -2,8 +2,8 @@
 // @generated from file activity/v1/activity.proto (package activity.v1, syntax proto3)
 /* eslint-disable */
 
-import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
-import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { GenEnum, GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
 import type { Timestamp } from "@bufbuild/protobuf/wkt";
 import { file_google_protobuf_timestamp, file_google_protobuf_wrappers } from "@bufbuild/protobuf/wkt";
 import type { Message } from "@bufbuild/protobuf";
@@ -12,7 +12,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file activity/v1/activity.proto.
  */
 export const file_activity_v1_activity: GenFile = /*@__PURE__*/
-  fileDesc("ChphY3Rpdml0eS92MS9hY3Rpdml0eS5wcm90bxILYWN0aXZpdHkudjEiHQoFUG9pbnQSCQoBeBgBIAEoARIJCgF5GAIgASgBIrMBCgZSZWNvcmQSCgoCaWQYASABKAUSJwoLY29vcmRpbmF0ZXMYAiABKAsyEi5hY3Rpdml0eS52MS5Qb2ludBINCgVzcGVlZBgDIAEoARIuCgp0aW1lX3N0YW1wGAQgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIQCghkaXN0YW5jZRgFIAEoBRISCgpoZWFydF9yYXRlGAYgASgFEg8KB2NhZGVuY2UYByABKAUiwQIKE0dldEFjdGl2aXR5UmVzcG9uc2USCgoCaWQYASABKAUSEgoKY3JlYXRlZF9hdBgCIAEoCRIQCghkaXN0YW5jZRgDIAEoARIVCg1hY3Rpdml0eV9uYW1lGAQgASgJEhEKCWF2Z19zcGVlZBgFIAEoARIRCgltYXhfc3BlZWQYBiABKAESFAoMZWxhcHNlZF90aW1lGAcgASgJEhIKCnRvdGFsX3RpbWUYCCABKAkSJAoHcmVjb3JkcxgJIAMoCzITLmFjdGl2aXR5LnYxLlJlY29yZBIWCg5hdmdfaGVhcnRfcmF0ZRgKIAEoARIWCg5tYXhfaGVhcnRfcmF0ZRgLIAEoARITCgthdmdfY2FkZW5jZRgMIAEoARITCgttYXhfY2FkZW5jZRgNIAEoARIRCglyaWRlX3R5cGUYDiABKAkixgEKD0FjdGl2aXR5U3VtbWFyeRIKCgJpZBgBIAEoBRIuCgpjcmVhdGVkX2F0GAIgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIQCghkaXN0YW5jZRgDIAEoARIVCg1hY3Rpdml0eV9uYW1lGAQgASgJEhEKCWF2Z19zcGVlZBgFIAEoARIRCgltYXhfc3BlZWQYBiABKAESFAoMZWxhcHNlZF90aW1lGAcgASgJEhIKCnRvdGFsX3RpbWUYCCABKAkiTgoXVXBsb2FkQWN0aXZpdGllc1JlcXVlc3QSFAoKZmlsZV9jaHVuaxgBIAEoDEgAEhIKCG1ldGFkYXRhGAIgASgJSABCCQoHcGF5bG9hZCIqChhVcGxvYWRBY3Rpdml0aWVzUmVzcG9uc2USDgoGc3RhdHVzGAEgASgJImgKGVVwbG9hZEFjdGl2aXRpZXNVbmFyeUZpbGUSDAoEZGF0YRgBIAEoDBIQCghmaWxlbmFtZRgCIAEoCRIUCgxjb250ZW50X3R5cGUYAyABKAkSFQoNbGFzdF9tb2RpZmllZBgEIAEoAyJVChxVcGxvYWRBY3Rpdml0aWVzVW5hcnlSZXF1ZXN0EjUKBWZpbGVzGAEgAygLMiYuYWN0aXZpdHkudjEuVXBsb2FkQWN0aXZpdGllc1VuYXJ5RmlsZSJJChVHZXRBY3Rpdml0aWVzUmVzcG9uc2USMAoKYWN0aXZpdGllcxgBIAMoCzIcLmFjdGl2aXR5LnYxLkFjdGl2aXR5U3VtbWFyeSIWChRHZXRBY3Rpdml0aWVzUmVxdWVzdCIpChJHZXRBY3Rpdml0eVJlcXVlc3QSEwoLYWN0aXZpdHlfaWQYASABKAUikgEKFVVwZGF0ZUFjdGl2aXR5UmVxdWVzdBITCgthY3Rpdml0eV9pZBgBIAEoBRIzCg1hY3Rpdml0eV9uYW1lGAIgASgLMhwuZ29vZ2xlLnByb3RvYnVmLlN0cmluZ1ZhbHVlEi8KCXJpZGVfdHlwZRgDIAEoCzIcLmdvb2dsZS5wcm90b2J1Zi5TdHJpbmdWYWx1ZTLnAwoPQWN0aXZpdHlTZXJ2aWNlElgKDUdldEFjdGl2aXRpZXMSIS5hY3Rpdml0eS52MS5HZXRBY3Rpdml0aWVzUmVxdWVzdBoiLmFjdGl2aXR5LnYxLkdldEFjdGl2aXRpZXNSZXNwb25zZSIAElIKC0dldEFjdGl2aXR5Eh8uYWN0aXZpdHkudjEuR2V0QWN0aXZpdHlSZXF1ZXN0GiAuYWN0aXZpdHkudjEuR2V0QWN0aXZpdHlSZXNwb25zZSIAElgKDlVwZGF0ZUFjdGl2aXR5EiIuYWN0aXZpdHkudjEuVXBkYXRlQWN0aXZpdHlSZXF1ZXN0GiAuYWN0aXZpdHkudjEuR2V0QWN0aXZpdHlSZXNwb25zZSIAEmEKEFVwbG9hZEFjdGl2aXRpZXMSJC5hY3Rpdml0eS52MS5VcGxvYWRBY3Rpdml0aWVzUmVxdWVzdBolLmFjdGl2aXR5LnYxLlVwbG9hZEFjdGl2aXRpZXNSZXNwb25zZSgBEmkKFVVwbG9hZEFjdGl2aXRpZXNVbmFyeRIpLmFjdGl2aXR5LnYxLlVwbG9hZEFjdGl2aXRpZXNVbmFyeVJlcXVlc3QaJS5hY3Rpdml0eS52MS5VcGxvYWRBY3Rpdml0aWVzUmVzcG9uc2VCOFo2Z2l0aHViLmNvbS9ub3RhZHVjay9iYWNrZW5kL2dlbi9hY3Rpdml0eS92MTthY3Rpdml0eXYxYgZwcm90bzM", [file_google_protobuf_timestamp, file_google_protobuf_wrappers]);
+  fileDesc("ChphY3Rpdml0eS92MS9hY3Rpdml0eS5wcm90bxILYWN0aXZpdHkudjEiHQoFUG9pbnQSCQoBeBgBIAEoARIJCgF5GAIgASgBIsIBCgZSZWNvcmQSCgoCaWQYASABKAUSJwoLY29vcmRpbmF0ZXMYAiABKAsyEi5hY3Rpdml0eS52MS5Qb2ludBINCgVzcGVlZBgDIAEoARIuCgp0aW1lX3N0YW1wGAQgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIQCghkaXN0YW5jZRgFIAEoBRISCgpoZWFydF9yYXRlGAYgASgFEg8KB2NhZGVuY2UYByABKAUSDQoFcG93ZXIYCCABKAUingsKE0dldEFjdGl2aXR5UmVzcG9uc2USCgoCaWQYASABKAUSEgoKY3JlYXRlZF9hdBgCIAEoCRIQCghkaXN0YW5jZRgDIAEoARIVCg1hY3Rpdml0eV9uYW1lGAQgASgJEhEKCWF2Z19zcGVlZBgFIAEoARIRCgltYXhfc3BlZWQYBiABKAESFAoMZWxhcHNlZF90aW1lGAcgASgJEhIKCnRvdGFsX3RpbWUYCCABKAkSJAoHcmVjb3JkcxgJIAMoCzITLmFjdGl2aXR5LnYxLlJlY29yZBIWCg5hdmdfaGVhcnRfcmF0ZRgKIAEoARIWCg5tYXhfaGVhcnRfcmF0ZRgLIAEoARITCgthdmdfY2FkZW5jZRgMIAEoARITCgttYXhfY2FkZW5jZRgNIAEoARIRCglyaWRlX3R5cGUYDiABKAkSFAoMZHVwbGljYXRlX29mGA8gASgFEhMKC2Rlc2NyaXB0aW9uGBAgASgJEi4KCHNlc3Npb25zGBEgAygLMhwuYWN0aXZpdHkudjEuQWN0aXZpdHlTZXNzaW9uEh4KBGxhcHMYEiADKAsyEC5hY3Rpdml0eS52MS5MYXASLgoJYXZnX3Bvd2VyGBMgASgLMhsuZ29vZ2xlLnByb3RvYnVmLkludDMyVmFsdWUSLgoJbWF4X3Bvd2VyGBQgASgLMhsuZ29vZ2xlLnByb3RvYnVmLkludDMyVmFsdWUSNQoQbm9ybWFsaXplZF9wb3dlchgVIAEoCzIbLmdvb2dsZS5wcm90b2J1Zi5JbnQzMlZhbHVlEjcKEXZhcmlhYmlsaXR5X2luZGV4GBYgASgLMhwuZ29vZ2xlLnByb3RvYnVmLkRvdWJsZVZhbHVlEjYKEGludGVuc2l0eV9mYWN0b3IYFyABKAsyHC5nb29nbGUucHJvdG9idWYuRG91YmxlVmFsdWUSOwoVdHJhaW5pbmdfc3RyZXNzX3Njb3JlGBggASgLMhwuZ29vZ2xlLnByb3RvYnVmLkRvdWJsZVZhbHVlEigKA2Z0cBgZIAEoCzIbLmdvb2dsZS5wcm90b2J1Zi5JbnQzMlZhbHVlEg4KBmluZG9vchgaIAEoCBIsCgdkZXZpY2VzGBsgAygLMhsuYWN0aXZpdHkudjEuQWN0aXZpdHlEZXZpY2USEwoLbW92aW5nX3RpbWUYHCABKAkSKgoGcGF1c2VzGB0gAygLMhouYWN0aXZpdHkudjEuQWN0aXZpdHlQYXVzZRIaChJyaWRlX3R5cGVfZGV0ZWN0ZWQYHiABKAgSNAoOZWxldmF0aW9uX2dhaW4YHyABKAsyHC5nb29nbGUucHJvdG9idWYuRG91YmxlVmFsdWUSNAoOZWxldmF0aW9uX2xvc3MYICABKAsyHC5nb29nbGUucHJvdG9idWYuRG91YmxlVmFsdWUSMgoMbWluX2FsdGl0dWRlGCEgASgLMhwuZ29vZ2xlLnByb3RvYnVmLkRvdWJsZVZhbHVlEjIKDG1heF9hbHRpdHVkZRgiIAEoCzIcLmdvb2dsZS5wcm90b2J1Zi5Eb3VibGVWYWx1ZRI1ChBoZWFydF9yYXRlX3pvbmVzGCMgASgLMhsuYWN0aXZpdHkudjEuSGVhcnRSYXRlWm9uZXMSMQoLcG93ZXJfY3VydmUYJCADKAsyHC5hY3Rpdml0eS52MS5Qb3dlckN1cnZlUG9pbnQSLQoMYmVzdF9lZmZvcnRzGCUgAygLMhcuYWN0aXZpdHkudjEuQmVzdEVmZm9ydBIYChBjb3JyZWN0ZWRfcG9pbnRzGCYgASgFEiEKBXJvdXRlGCcgAygLMhIuYWN0aXZpdHkudjEuUG9pbnQSFQoNZW5jb2RlZF9yb3V0ZRgoIAEoCRIVCg1maWxsZWRfcG9pbnRzGCkgASgFIngKCkJlc3RFZmZvcnQSEAoIZGlzdGFuY2UYASABKAUSDwoHc2Vjb25kcxgCIAEoBRIuCgpzdGFydGVkX2F0GAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIXCg9wZXJzb25hbF9yZWNvcmQYBCABKAgicQoPUG93ZXJDdXJ2ZVBvaW50EhAKCGR1cmF0aW9uGAEgASgFEg0KBXdhdHRzGAIgASgFEhMKC2FjdGl2aXR5X2lkGAMgASgFEigKBGRhdGUYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIjsKDkhlYXJ0UmF0ZVpvbmVzEikKBXpvbmVzGAEgAygLMhouYWN0aXZpdHkudjEuSGVhcnRSYXRlWm9uZSJ7Cg1IZWFydFJhdGVab25lEgwKBHpvbmUYASABKAUSFgoObWluX2hlYXJ0X3JhdGUYAiABKAUSMwoObWF4X2hlYXJ0X3JhdGUYAyABKAsyGy5nb29nbGUucHJvdG9idWYuSW50MzJWYWx1ZRIPCgdzZWNvbmRzGAQgASgFIpMBCg1BY3Rpdml0eVBhdXNlEi4KCnN0YXJ0ZWRfYXQYASABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEiwKCGVuZGVkX2F0GAIgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIUCgxlbGFwc2VkX3RpbWUYAyABKAkSDgoGc291cmNlGAQgASgJIsADCg5BY3Rpdml0eURldmljZRINCgVpbmRleBgBIAEoBRIPCgdjcmVhdG9yGAIgASgIEhQKDG1hbnVmYWN0dXJlchgDIAEoCRIsCgdwcm9kdWN0GAQgASgLMhsuZ29vZ2xlLnByb3RvYnVmLkludDMyVmFsdWUSFAoMcHJvZHVjdF9uYW1lGAUgASgJEjIKDXNlcmlhbF9udW1iZXIYBiABKAsyGy5nb29nbGUucHJvdG9idWYuSW50NjRWYWx1ZRIYChBzb2Z0d2FyZV92ZXJzaW9uGAcgASgJEjUKEGhhcmR3YXJlX3ZlcnNpb24YCCABKAsyGy5nb29nbGUucHJvdG9idWYuSW50MzJWYWx1ZRITCgtkZXZpY2VfdHlwZRgJIAEoCRITCgtzb3VyY2VfdHlwZRgKIAEoCRI2ChFhbnRfZGV2aWNlX251bWJlchgLIAEoCzIbLmdvb2dsZS5wcm90b2J1Zi5JbnQzMlZhbHVlEjUKD2JhdHRlcnlfdm9sdGFnZRgMIAEoCzIcLmdvb2dsZS5wcm90b2J1Zi5Eb3VibGVWYWx1ZRIWCg5iYXR0ZXJ5X3N0YXR1cxgNIAEoCSLcAQoPQWN0aXZpdHlTZXNzaW9uEg0KBWluZGV4GAEgASgFEg0KBXNwb3J0GAIgASgJEhEKCXN1Yl9zcG9ydBgDIAEoCRIuCgpzdGFydF90aW1lGAQgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIsCghlbmRfdGltZRgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASFAoMZWxhcHNlZF90aW1lGAYgASgJEhIKCnRpbWVyX3RpbWUYByABKAkSEAoIZGlzdGFuY2UYCCABKAEiuwIKA0xhcBINCgVpbmRleBgBIAEoBRIPCgd0cmlnZ2VyGAIgASgJEi4KCnN0YXJ0X3RpbWUYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEiwKCGVuZF90aW1lGAQgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIUCgxlbGFwc2VkX3RpbWUYBSABKAkSEgoKdGltZXJfdGltZRgGIAEoCRIQCghkaXN0YW5jZRgHIAEoARIRCglhdmdfc3BlZWQYCCABKAESEQoJbWF4X3NwZWVkGAkgASgBEhYKDmF2Z19oZWFydF9yYXRlGAogASgFEhYKDm1heF9oZWFydF9yYXRlGAsgASgFEhEKCWF2Z19wb3dlchgMIAEoBRIRCgltYXhfcG93ZXIYDSABKAUioQIKD0FjdGl2aXR5U3VtbWFyeRIKCgJpZBgBIAEoBRIuCgpjcmVhdGVkX2F0GAIgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIQCghkaXN0YW5jZRgDIAEoARIVCg1hY3Rpdml0eV9uYW1lGAQgASgJEhEKCWF2Z19zcGVlZBgFIAEoARIRCgltYXhfc3BlZWQYBiABKAESFAoMZWxhcHNlZF90aW1lGAcgASgJEhIKCnRvdGFsX3RpbWUYCCABKAkSDgoGaW5kb29yGAkgASgIEhMKC21vdmluZ190aW1lGAogASgJEjQKDmVsZXZhdGlvbl9nYWluGAsgASgLMhwuZ29vZ2xlLnByb3RvYnVmLkRvdWJsZVZhbHVlIoQBChdVcGxvYWRBY3Rpdml0aWVzUmVxdWVzdBIUCgpmaWxlX2NodW5rGAEgASgMSAASEgoIbWV0YWRhdGEYAiABKAlIABI0CgtmaWxlX2hlYWRlchgDIAEoCzIdLmFjdGl2aXR5LnYxLlVwbG9hZEZpbGVIZWFkZXJIAEIJCgdwYXlsb2FkIm8KEFVwbG9hZEZpbGVIZWFkZXISEAoIZmlsZW5hbWUYASABKAkSDAoEc2l6ZRgCIAEoAxIUCgxjb250ZW50X3R5cGUYAyABKAkSDgoGc2hhMjU2GAQgASgJEhUKDWxhc3RfbW9kaWZpZWQYBSABKAMikwEKEFVwbG9hZEZpbGVSZXN1bHQSEAoIZmlsZW5hbWUYASABKAkSEwoLYWN0aXZpdHlfaWQYAiABKAUSDQoFZXJyb3IYAyABKAkSOAoOZmFpbHVyZV9yZWFzb24YBCABKA4yIC5hY3Rpdml0eS52MS5VcGxvYWRGYWlsdXJlUmVhc29uEg8KB3NraXBwZWQYBSABKAgimQEKGFVwbG9hZEFjdGl2aXRpZXNSZXNwb25zZRIOCgZzdGF0dXMYASABKAkSFAoMYWN0aXZpdHlfaWRzGAIgAygFEi4KB3Jlc3VsdHMYAyADKAsyHS5hY3Rpdml0eS52MS5VcGxvYWRGaWxlUmVzdWx0EicKBGpvYnMYBCADKAsyGS5hY3Rpdml0eS52MS5Jbmdlc3Rpb25Kb2Ii3QIKDEluZ2VzdGlvbkpvYhIKCgJpZBgBIAEoAxIQCghmaWxlbmFtZRgCIAEoCRIvCgZzdGF0dXMYAyABKA4yHy5hY3Rpdml0eS52MS5Jbmdlc3Rpb25Kb2JTdGF0dXMSEwoLYWN0aXZpdHlfaWQYBCABKAUSDwoHc2tpcHBlZBgFIAEoCBI4Cg5mYWlsdXJlX3JlYXNvbhgGIAEoDjIgLmFjdGl2aXR5LnYxLlVwbG9hZEZhaWx1cmVSZWFzb24SDQoFZXJyb3IYByABKAkSLgoKY3JlYXRlZF9hdBgIIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLgoKc3RhcnRlZF9hdBgJIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLwoLZmluaXNoZWRfYXQYCiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIioKF0dldEluZ2VzdGlvbkpvYnNSZXF1ZXN0Eg8KB2pvYl9pZHMYASADKAMiQwoYR2V0SW5nZXN0aW9uSm9ic1Jlc3BvbnNlEicKBGpvYnMYASADKAsyGS5hY3Rpdml0eS52MS5Jbmdlc3Rpb25Kb2IiaAoZVXBsb2FkQWN0aXZpdGllc1VuYXJ5RmlsZRIMCgRkYXRhGAEgASgMEhAKCGZpbGVuYW1lGAIgASgJEhQKDGNvbnRlbnRfdHlwZRgDIAEoCRIVCg1sYXN0X21vZGlmaWVkGAQgASgDIlUKHFVwbG9hZEFjdGl2aXRpZXNVbmFyeVJlcXVlc3QSNQoFZmlsZXMYASADKAsyJi5hY3Rpdml0eS52MS5VcGxvYWRBY3Rpdml0aWVzVW5hcnlGaWxlIk0KGVVwbG9hZEFyY2hpdmVDaHVua1JlcXVlc3QSEQoJdXBsb2FkX2lkGAEgASgJEg4KBm9mZnNldBgCIAEoAxINCgVjaHVuaxgDIAEoDCI9ChpVcGxvYWRBcmNoaXZlQ2h1bmtSZXNwb25zZRIRCgl1cGxvYWRfaWQYASABKAkSDAoEc2l6ZRgCIAEoAyJKChRJbXBvcnRBcmNoaXZlUmVxdWVzdBIQCghmaWxlbmFtZRgBIAEoCRIRCgl1cGxvYWRfaWQYAyABKAlKBAgCEANSB2FyY2hpdmUiaAoVSW1wb3J0QXJjaGl2ZVByb2dyZXNzEhEKCXByb2Nlc3NlZBgBIAEoBRINCgV0b3RhbBgCIAEoBRItCgZyZXN1bHQYAyABKAsyHS5hY3Rpdml0eS52MS5VcGxvYWRGaWxlUmVzdWx0Ik8KGlJlcHJvY2Vzc0FjdGl2aXRpZXNSZXF1ZXN0EhMKC2FjdGl2aXR5X2lkGAEgASgFEg8KB3VzZXJfaWQYAiABKAkSCwoDYWxsGAMgASgIIm4KG1JlcHJvY2Vzc0FjdGl2aXRpZXNQcm9ncmVzcxIRCglwcm9jZXNzZWQYASABKAUSDQoFdG90YWwYAiABKAUSLQoGcmVzdWx0GAMgASgLMh0uYWN0aXZpdHkudjEuVXBsb2FkRmlsZVJlc3VsdCJJChVHZXRBY3Rpdml0aWVzUmVzcG9uc2USMAoKYWN0aXZpdGllcxgBIAMoCzIcLmFjdGl2aXR5LnYxLkFjdGl2aXR5U3VtbWFyeSIWChRHZXRBY3Rpdml0aWVzUmVxdWVzdCJQChJHZXRBY3Rpdml0eVJlcXVlc3QSEwoLYWN0aXZpdHlfaWQYASABKAUSEQoJdG9sZXJhbmNlGAIgASgBEhIKCm1heF9wb2ludHMYAyABKAUiLQoWR2V0QWN0aXZpdHlMYXBzUmVxdWVzdBITCgthY3Rpdml0eV9pZBgBIAEoBSI5ChdHZXRBY3Rpdml0eUxhcHNSZXNwb25zZRIeCgRsYXBzGAEgAygLMhAuYWN0aXZpdHkudjEuTGFwIi0KFkdldE9yaWdpbmFsRmlsZVJlcXVlc3QSEwoLYWN0aXZpdHlfaWQYASABKAUiTwoXR2V0T3JpZ2luYWxGaWxlUmVzcG9uc2USEAoIZmlsZW5hbWUYASABKAkSFAoMY29udGVudF90eXBlGAIgASgJEgwKBGRhdGEYAyABKAwikgEKFVVwZGF0ZUFjdGl2aXR5UmVxdWVzdBITCgthY3Rpdml0eV9pZBgBIAEoBRIzCg1hY3Rpdml0eV9uYW1lGAIgASgLMhwuZ29vZ2xlLnByb3RvYnVmLlN0cmluZ1ZhbHVlEi8KCXJpZGVfdHlwZRgDIAEoCzIcLmdvb2dsZS5wcm90b2J1Zi5TdHJpbmdWYWx1ZSIsChVEZWxldGVBY3Rpdml0eVJlcXVlc3QSEwoLYWN0aXZpdHlfaWQYASABKAUiGAoWRGVsZXRlQWN0aXZpdHlSZXNwb25zZSIYChZHZXRVc2VyU2V0dGluZ3NSZXF1ZXN0IugBCgxVc2VyU2V0dGluZ3MSKAoDZnRwGAEgASgLMhsuZ29vZ2xlLnByb3RvYnVmLkludDMyVmFsdWUSMwoObWF4X2hlYXJ0X3JhdGUYAiABKAsyGy5nb29nbGUucHJvdG9idWYuSW50MzJWYWx1ZRI5ChR0aHJlc2hvbGRfaGVhcnRfcmF0ZRgDIAEoCzIbLmdvb2dsZS5wcm90b2J1Zi5JbnQzMlZhbHVlEh4KFmhlYXJ0X3JhdGVfem9uZV9tZXRob2QYBCABKAkSHgoWaGVhcnRfcmF0ZV96b25lX2JvdW5kcxgFIAMoBSJIChlVcGRhdGVVc2VyU2V0dGluZ3NSZXF1ZXN0EisKCHNldHRpbmdzGAEgASgLMhkuYWN0aXZpdHkudjEuVXNlclNldHRpbmdzInsKFEdldFBvd2VyQ3VydmVSZXF1ZXN0EigKBGZyb20YASABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEiYKAnRvGAIgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIRCglyaWRlX3R5cGUYAyABKAkiRQoVR2V0UG93ZXJDdXJ2ZVJlc3BvbnNlEiwKBnBvaW50cxgBIAMoCzIcLmFjdGl2aXR5LnYxLlBvd2VyQ3VydmVQb2ludCIbChlHZXRQZXJzb25hbFJlY29yZHNSZXF1ZXN0IrkBCg5QZXJzb25hbFJlY29yZBIQCghkaXN0YW5jZRgBIAEoBRIPCgdzZWNvbmRzGAIgASgFEi4KCnN0YXJ0ZWRfYXQYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhMKC2FjdGl2aXR5X2lkGAQgASgFEhUKDWFjdGl2aXR5X25hbWUYBSABKAkSKAoEZGF0ZRgGIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiSgoaR2V0UGVyc29uYWxSZWNvcmRzUmVzcG9uc2USLAoHcmVjb3JkcxgBIAMoCzIbLmFjdGl2aXR5LnYxLlBlcnNvbmFsUmVjb3JkImoKFkdldFRyYWluaW5nTG9hZFJlcXVlc3QSKAoEZnJvbRgBIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASJgoCdG8YAiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wInAKD1RyYWluaW5nTG9hZERheRIoCgRkYXRlGAEgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIMCgRsb2FkGAIgASgBEgsKA2N0bBgDIAEoARILCgNhdGwYBCABKAESCwoDdHNiGAUgASgBIkUKF0dldFRyYWluaW5nTG9hZFJlc3BvbnNlEioKBGRheXMYASADKAsyHC5hY3Rpdml0eS52MS5UcmFpbmluZ0xvYWREYXkqpwIKE1VwbG9hZEZhaWx1cmVSZWFzb24SJQohVVBMT0FEX0ZBSUxVUkVfUkVBU09OX1VOU1BFQ0lGSUVEEAASLAooVVBMT0FEX0ZBSUxVUkVfUkVBU09OX1VOU1VQUE9SVEVEX0ZPUk1BVBABEiYKIlVQTE9BRF9GQUlMVVJFX1JFQVNPTl9DT1JSVVBUX0ZJTEUQAhIkCiBVUExPQURfRkFJTFVSRV9SRUFTT05fTk9fUkVDT1JEUxADEiMKH1VQTE9BRF9GQUlMVVJFX1JFQVNPTl9EVVBMSUNBVEUQBBIkCiBVUExPQURfRkFJTFVSRV9SRUFTT05fRU1QVFlfRklMRRAFEiIKHlVQTE9BRF9GQUlMVVJFX1JFQVNPTl9JTlRFUk5BTBAGKr0BChJJbmdlc3Rpb25Kb2JTdGF0dXMSJAogSU5HRVNUSU9OX0pPQl9TVEFUVVNfVU5TUEVDSUZJRUQQABIfChtJTkdFU1RJT05fSk9CX1NUQVRVU19RVUVVRUQQARIgChxJTkdFU1RJT05fSk9CX1NUQVRVU19SVU5OSU5HEAISHQoZSU5HRVNUSU9OX0pPQl9TVEFUVVNfRE9ORRADEh8KG0lOR0VTVElPTl9KT0JfU1RBVFVTX0ZBSUxFRBAEMukMCg9BY3Rpdml0eVNlcnZpY2USWAoNR2V0QWN0aXZpdGllcxIhLmFjdGl2aXR5LnYxLkdldEFjdGl2aXRpZXNSZXF1ZXN0GiIuYWN0aXZpdHkudjEuR2V0QWN0aXZpdGllc1Jlc3BvbnNlIgASUgoLR2V0QWN0aXZpdHkSHy5hY3Rpdml0eS52MS5HZXRBY3Rpdml0eVJlcXVlc3QaIC5hY3Rpdml0eS52MS5HZXRBY3Rpdml0eVJlc3BvbnNlIgASXgoPR2V0QWN0aXZpdHlMYXBzEiMuYWN0aXZpdHkudjEuR2V0QWN0aXZpdHlMYXBzUmVxdWVzdBokLmFjdGl2aXR5LnYxLkdldEFjdGl2aXR5TGFwc1Jlc3BvbnNlIgASWAoOVXBkYXRlQWN0aXZpdHkSIi5hY3Rpdml0eS52MS5VcGRhdGVBY3Rpdml0eVJlcXVlc3QaIC5hY3Rpdml0eS52MS5HZXRBY3Rpdml0eVJlc3BvbnNlIgASWwoORGVsZXRlQWN0aXZpdHkSIi5hY3Rpdml0eS52MS5EZWxldGVBY3Rpdml0eVJlcXVlc3QaIy5hY3Rpdml0eS52MS5EZWxldGVBY3Rpdml0eVJlc3BvbnNlIgASXgoPR2V0T3JpZ2luYWxGaWxlEiMuYWN0aXZpdHkudjEuR2V0T3JpZ2luYWxGaWxlUmVxdWVzdBokLmFjdGl2aXR5LnYxLkdldE9yaWdpbmFsRmlsZVJlc3BvbnNlIgASYQoQVXBsb2FkQWN0aXZpdGllcxIkLmFjdGl2aXR5LnYxLlVwbG9hZEFjdGl2aXRpZXNSZXF1ZXN0GiUuYWN0aXZpdHkudjEuVXBsb2FkQWN0aXZpdGllc1Jlc3BvbnNlKAESaQoVVXBsb2FkQWN0aXZpdGllc1VuYXJ5EikuYWN0aXZpdHkudjEuVXBsb2FkQWN0aXZpdGllc1VuYXJ5UmVxdWVzdBolLmFjdGl2aXR5LnYxLlVwbG9hZEFjdGl2aXRpZXNSZXNwb25zZRJnChJVcGxvYWRBcmNoaXZlQ2h1bmsSJi5hY3Rpdml0eS52MS5VcGxvYWRBcmNoaXZlQ2h1bmtSZXF1ZXN0GicuYWN0aXZpdHkudjEuVXBsb2FkQXJjaGl2ZUNodW5rUmVzcG9uc2UiABJYCg1JbXBvcnRBcmNoaXZlEiEuYWN0aXZpdHkudjEuSW1wb3J0QXJjaGl2ZVJlcXVlc3QaIi5hY3Rpdml0eS52MS5JbXBvcnRBcmNoaXZlUHJvZ3Jlc3MwARJqChNSZXByb2Nlc3NBY3Rpdml0aWVzEicuYWN0aXZpdHkudjEuUmVwcm9jZXNzQWN0aXZpdGllc1JlcXVlc3QaKC5hY3Rpdml0eS52MS5SZXByb2Nlc3NBY3Rpdml0aWVzUHJvZ3Jlc3MwARJhChBHZXRJbmdlc3Rpb25Kb2JzEiQuYWN0aXZpdHkudjEuR2V0SW5nZXN0aW9uSm9ic1JlcXVlc3QaJS5hY3Rpdml0eS52MS5HZXRJbmdlc3Rpb25Kb2JzUmVzcG9uc2UiABJTCg9HZXRVc2VyU2V0dGluZ3MSIy5hY3Rpdml0eS52MS5HZXRVc2VyU2V0dGluZ3NSZXF1ZXN0GhkuYWN0aXZpdHkudjEuVXNlclNldHRpbmdzIgASWQoSVXBkYXRlVXNlclNldHRpbmdzEiYuYWN0aXZpdHkudjEuVXBkYXRlVXNlclNldHRpbmdzUmVxdWVzdBoZLmFjdGl2aXR5LnYxLlVzZXJTZXR0aW5ncyIAElgKDUdldFBvd2VyQ3VydmUSIS5hY3Rpdml0eS52MS5HZXRQb3dlckN1cnZlUmVxdWVzdBoiLmFjdGl2aXR5LnYxLkdldFBvd2VyQ3VydmVSZXNwb25zZSIAEmcKEkdldFBlcnNvbmFsUmVjb3JkcxImLmFjdGl2aXR5LnYxLkdldFBlcnNvbmFsUmVjb3Jkc1JlcXVlc3QaJy5hY3Rpdml0eS52MS5HZXRQZXJzb25hbFJlY29yZHNSZXNwb25zZSIAEl4KD0dldFRyYWluaW5nTG9hZBIjLmFjdGl2aXR5LnYxLkdldFRyYWluaW5nTG9hZFJlcXVlc3QaJC5hY3Rpdml0eS52MS5HZXRUcmFpbmluZ0xvYWRSZXNwb25zZSIAQjhaNmdpdGh1Yi5jb20vbm90YWR1Y2svYmFja2VuZC9nZW4vYWN0aXZpdHkvdjE7YWN0aXZpdHl2MWIGcHJvdG8z", [file_google_protobuf_timestamp, file_google_protobuf_wrappers]);
 
 /**
  * Point represents a coordinate point.
@@ -50,6 +50,8 @@ export type Record = Message<"activity.v1.Record"> & {
   id: number;
 
   /**
+   * Unset for records without a GPS position
+   *
    * @generated from field: activity.v1.Point coordinates = 2;
    */
   coordinates?: Point;
@@ -78,6 +80,13 @@ export type Record = Message<"activity.v1.Record"> & {
    * @generated from field: int32 cadence = 7;
    */
   cadence: number;
+
+  /**
+   * Watts, 0 when not recorded
+   *
+   * @generated from field: int32 power = 8;
+   */
+  power: number;
 };
 
 /**
@@ -162,290 +171,1802 @@ export type GetActivityResponse = Message<"activity.v1.GetActivityResponse"> & {
    * @generated from field: string ride_type = 14;
    */
   rideType: string;
-};
 
-/**
- * Describes the message activity.v1.GetActivityResponse.
- * Use `create(GetActivityResponseSchema)` to create a new message.
- */
-export const GetActivityResponseSchema: GenMessage<GetActivityResponse> = /*@__PURE__*/
-  messageDesc(file_activity_v1_activity, 2);
+  /**
+   * Set when imported as a likely duplicate of that activity
+   *
+   * @generated from field: int32 duplicate_of = 15;
+   */
+  duplicateOf: number;
 
-/**
- * ActivitySummary provides a summarized view of an activity.
- *
- * @generated from message activity.v1.ActivitySummary
- */
-export type ActivitySummary = Message<"activity.v1.ActivitySummary"> & {
   /**
-   * @generated from field: int32 id = 1;
+   * @generated from field: string description = 16;
    */
-  id: number;
+  description: string;
 
   /**
-   * @generated from field: google.protobuf.Timestamp created_at = 2;
+   * @generated from field: repeated activity.v1.ActivitySession sessions = 17;
    */
-  createdAt?: Timestamp;
+  sessions: ActivitySession[];
 
   /**
-   * @generated from field: double distance = 3;
+   * @generated from field: repeated activity.v1.Lap laps = 18;
    */
-  distance: number;
+  laps: Lap[];
 
   /**
-   * @generated from field: string activity_name = 4;
+   * Power metrics in watts, unset for rides without power data. Intensity
+   * factor and TSS are rated against the FTP set when the ride was ingested.
+   *
+   * @generated from field: google.protobuf.Int32Value avg_power = 19;
    */
-  activityName: string;
+  avgPower?: number;
 
   /**
-   * @generated from field: double avg_speed = 5;
+   * @generated from field: google.protobuf.Int32Value max_power = 20;
    */
-  avgSpeed: number;
+  maxPower?: number;
 
   /**
-   * @generated from field: double max_speed = 6;
+   * @generated from field: google.protobuf.Int32Value normalized_power = 21;
    */
-  maxSpeed: number;
+  normalizedPower?: number;
 
   /**
-   * @generated from field: string elapsed_time = 7;
+   * @generated from field: google.protobuf.DoubleValue variability_index = 22;
    */
-  elapsedTime: string;
+  variabilityIndex?: number;
 
   /**
-   * @generated from field: string total_time = 8;
+   * @generated from field: google.protobuf.DoubleValue intensity_factor = 23;
    */
-  totalTime: string;
-};
+  intensityFactor?: number;
 
-/**
- * Describes the message activity.v1.ActivitySummary.
- * Use `create(ActivitySummarySchema)` to create a new message.
- */
-export const ActivitySummarySchema: GenMessage<ActivitySummary> = /*@__PURE__*/
-  messageDesc(file_activity_v1_activity, 3);
+  /**
+   * @generated from field: google.protobuf.DoubleValue training_stress_score = 24;
+   */
+  trainingStressScore?: number;
 
-/**
- * Request message for streaming uploads
- *
- * @generated from message activity.v1.UploadActivitiesRequest
- */
-export type UploadActivitiesRequest = Message<"activity.v1.UploadActivitiesRequest"> & {
   /**
-   * @generated from oneof activity.v1.UploadActivitiesRequest.payload
+   * @generated from field: google.protobuf.Int32Value ftp = 25;
    */
-  payload: {
-    /**
-     * Chunk of file data
-     *
-     * @generated from field: bytes file_chunk = 1;
-     */
-    value: Uint8Array;
-    case: "fileChunk";
-  } | {
-    /**
-     * Optional metadata (e.g., user ID, filename)
-     *
-     * @generated from field: string metadata = 2;
-     */
-    value: string;
-    case: "metadata";
-  } | { case: undefined; value?: undefined };
-};
+  ftp?: number;
 
-/**
- * Describes the message activity.v1.UploadActivitiesRequest.
- * Use `create(UploadActivitiesRequestSchema)` to create a new message.
- */
-export const UploadActivitiesRequestSchema: GenMessage<UploadActivitiesRequest> = /*@__PURE__*/
-  messageDesc(file_activity_v1_activity, 4);
+  /**
+   * Recorded without GPS, e.g. on a trainer
+   *
+   * @generated from field: bool indoor = 26;
+   */
+  indoor: boolean;
+
+  /**
+   * @generated from field: repeated activity.v1.ActivityDevice devices = 27;
+   */
+  devices: ActivityDevice[];
 
-/**
- * Response message after upload
- *
- * @generated from message activity.v1.UploadActivitiesResponse
- */
-export type UploadActivitiesResponse = Message<"activity.v1.UploadActivitiesResponse"> & {
   /**
-   * Status of the upload (e.g., "success" or error message)
+   * Excludes the pauses below
    *
-   * @generated from field: string status = 1;
+   * @generated from field: string moving_time = 28;
    */
-  status: string;
-};
+  movingTime: string;
 
-/**
- * Describes the message activity.v1.UploadActivitiesResponse.
- * Use `create(UploadActivitiesResponseSchema)` to create a new message.
- */
-export const UploadActivitiesResponseSchema: GenMessage<UploadActivitiesResponse> = /*@__PURE__*/
-  messageDesc(file_activity_v1_activity, 5);
+  /**
+   * @generated from field: repeated activity.v1.ActivityPause pauses = 29;
+   */
+  pauses: ActivityPause[];
 
-/**
- * @generated from message activity.v1.UploadActivitiesUnaryFile
- */
-export type UploadActivitiesUnaryFile = Message<"activity.v1.UploadActivitiesUnaryFile"> & {
   /**
-   * @generated from field: bytes data = 1;
+   * False once the user has chosen the ride type
+   *
+   * @generated from field: bool ride_type_detected = 30;
    */
-  data: Uint8Array;
+  rideTypeDetected: boolean;
 
   /**
-   * @generated from field: string filename = 2;
+   * Elevation in metres; unset for rides without altitude data.
+   *
+   * @generated from field: google.protobuf.DoubleValue elevation_gain = 31;
    */
-  filename: string;
+  elevationGain?: number;
 
   /**
-   * @generated from field: string content_type = 3;
+   * @generated from field: google.protobuf.DoubleValue elevation_loss = 32;
    */
-  contentType: string;
+  elevationLoss?: number;
 
   /**
-   * Unix milliseconds
+   * @generated from field: google.protobuf.DoubleValue min_altitude = 33;
+   */
+  minAltitude?: number;
+
+  /**
+   * @generated from field: google.protobuf.DoubleValue max_altitude = 34;
+   */
+  maxAltitude?: number;
+
+  /**
+   * Unset for rides without heart rate data or ingested before the user
+   * configured heart rate zones.
    *
-   * @generated from field: int64 last_modified = 4;
+   * @generated from field: activity.v1.HeartRateZones heart_rate_zones = 35;
    */
-  lastModified: bigint;
-};
+  heartRateZones?: HeartRateZones;
 
-/**
- * Describes the message activity.v1.UploadActivitiesUnaryFile.
- * Use `create(UploadActivitiesUnaryFileSchema)` to create a new message.
- */
-export const UploadActivitiesUnaryFileSchema: GenMessage<UploadActivitiesUnaryFile> = /*@__PURE__*/
-  messageDesc(file_activity_v1_activity, 6);
+  /**
+   * Mean-maximal power by duration, empty for rides without power data.
+   *
+   * @generated from field: repeated activity.v1.PowerCurvePoint power_curve = 36;
+   */
+  powerCurve: PowerCurvePoint[];
 
-/**
- * @generated from message activity.v1.UploadActivitiesUnaryRequest
- */
-export type UploadActivitiesUnaryRequest = Message<"activity.v1.UploadActivitiesUnaryRequest"> & {
   /**
-   * @generated from field: repeated activity.v1.UploadActivitiesUnaryFile files = 1;
+   * The fastest effort over each distance the ride covers, flagged when it
+   * was a personal record.
+   *
+   * @generated from field: repeated activity.v1.BestEffort best_efforts = 37;
    */
-  files: UploadActivitiesUnaryFile[];
+  bestEfforts: BestEffort[];
+
+  /**
+   * GPS spikes moved or dropped by the track cleaning at ingestion.
+   *
+   * @generated from field: int32 corrected_points = 38;
+   */
+  correctedPoints: number;
+
+  /**
+   * The route simplified to the requested tolerance, and the same in the
+   * encoded polyline format with five decimals.
+   *
+   * @generated from field: repeated activity.v1.Point route = 39;
+   */
+  route: Point[];
+
+  /**
+   * @generated from field: string encoded_route = 40;
+   */
+  encodedRoute: string;
+
+  /**
+   * Missing GPS fixes interpolated by the track cleaning at ingestion.
+   *
+   * @generated from field: int32 filled_points = 41;
+   */
+  filledPoints: number;
 };
 
 /**
- * Describes the message activity.v1.UploadActivitiesUnaryRequest.
- * Use `create(UploadActivitiesUnaryRequestSchema)` to create a new message.
+ * Describes the message activity.v1.GetActivityResponse.
+ * Use `create(GetActivityResponseSchema)` to create a new message.
  */
-export const UploadActivitiesUnaryRequestSchema: GenMessage<UploadActivitiesUnaryRequest> = /*@__PURE__*/
-  messageDesc(file_activity_v1_activity, 7);
+export const GetActivityResponseSchema: GenMessage<GetActivityResponse> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 2);
 
 /**
- * GetActivitiesResponse contains a list of activity summaries.
+ * BestEffort is the fastest stretch of a ride over a distance, timed in
+ * elapsed seconds.
  *
- * @generated from message activity.v1.GetActivitiesResponse
+ * @generated from message activity.v1.BestEffort
  */
-export type GetActivitiesResponse = Message<"activity.v1.GetActivitiesResponse"> & {
+export type BestEffort = Message<"activity.v1.BestEffort"> & {
   /**
-   * @generated from field: repeated activity.v1.ActivitySummary activities = 1;
+   * Metres
+   *
+   * @generated from field: int32 distance = 1;
    */
-  activities: ActivitySummary[];
+  distance: number;
+
+  /**
+   * @generated from field: int32 seconds = 2;
+   */
+  seconds: number;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp started_at = 3;
+   */
+  startedAt?: Timestamp;
+
+  /**
+   * Faster than any earlier ride when ingested
+   *
+   * @generated from field: bool personal_record = 4;
+   */
+  personalRecord: boolean;
 };
 
 /**
- * Describes the message activity.v1.GetActivitiesResponse.
- * Use `create(GetActivitiesResponseSchema)` to create a new message.
+ * Describes the message activity.v1.BestEffort.
+ * Use `create(BestEffortSchema)` to create a new message.
  */
-export const GetActivitiesResponseSchema: GenMessage<GetActivitiesResponse> = /*@__PURE__*/
-  messageDesc(file_activity_v1_activity, 8);
+export const BestEffortSchema: GenMessage<BestEffort> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 3);
 
 /**
- * GetActivitiesRequest is an empty request message for fetching all activities.
- * If you prefer to keep using the standard Empty message, you can alias it, but
- * defining it explicitly is clearer.
+ * PowerCurvePoint is the best average power held for a duration. On an
+ * envelope curve it also names the ride that set it.
  *
- * @generated from message activity.v1.GetActivitiesRequest
+ * @generated from message activity.v1.PowerCurvePoint
  */
-export type GetActivitiesRequest = Message<"activity.v1.GetActivitiesRequest"> & {
+export type PowerCurvePoint = Message<"activity.v1.PowerCurvePoint"> & {
+  /**
+   * Seconds
+   *
+   * @generated from field: int32 duration = 1;
+   */
+  duration: number;
+
+  /**
+   * @generated from field: int32 watts = 2;
+   */
+  watts: number;
+
+  /**
+   * @generated from field: int32 activity_id = 3;
+   */
+  activityId: number;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp date = 4;
+   */
+  date?: Timestamp;
 };
 
 /**
- * Describes the message activity.v1.GetActivitiesRequest.
- * Use `create(GetActivitiesRequestSchema)` to create a new message.
+ * Describes the message activity.v1.PowerCurvePoint.
+ * Use `create(PowerCurvePointSchema)` to create a new message.
  */
-export const GetActivitiesRequestSchema: GenMessage<GetActivitiesRequest> = /*@__PURE__*/
-  messageDesc(file_activity_v1_activity, 9);
+export const PowerCurvePointSchema: GenMessage<PowerCurvePoint> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 4);
 
 /**
- * GetActivityRequest specifies the ID of the activity to retrieve.
+ * HeartRateZones is the time an activity spent in each heart rate zone, with
+ * the zone bounds in effect when it was ingested.
  *
- * @generated from message activity.v1.GetActivityRequest
+ * @generated from message activity.v1.HeartRateZones
  */
-export type GetActivityRequest = Message<"activity.v1.GetActivityRequest"> & {
+export type HeartRateZones = Message<"activity.v1.HeartRateZones"> & {
   /**
-   * @generated from field: int32 activity_id = 1;
+   * @generated from field: repeated activity.v1.HeartRateZone zones = 1;
    */
-  activityId: number;
+  zones: HeartRateZone[];
 };
 
 /**
- * Describes the message activity.v1.GetActivityRequest.
- * Use `create(GetActivityRequestSchema)` to create a new message.
+ * Describes the message activity.v1.HeartRateZones.
+ * Use `create(HeartRateZonesSchema)` to create a new message.
  */
-export const GetActivityRequestSchema: GenMessage<GetActivityRequest> = /*@__PURE__*/
-  messageDesc(file_activity_v1_activity, 10);
+export const HeartRateZonesSchema: GenMessage<HeartRateZones> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 5);
 
 /**
- * @generated from message activity.v1.UpdateActivityRequest
+ * @generated from message activity.v1.HeartRateZone
  */
-export type UpdateActivityRequest = Message<"activity.v1.UpdateActivityRequest"> & {
+export type HeartRateZone = Message<"activity.v1.HeartRateZone"> & {
   /**
-   * @generated from field: int32 activity_id = 1;
+   * Numbered from 1
+   *
+   * @generated from field: int32 zone = 1;
    */
-  activityId: number;
+  zone: number;
 
   /**
-   * @generated from field: google.protobuf.StringValue activity_name = 2;
+   * @generated from field: int32 min_heart_rate = 2;
    */
-  activityName?: string;
+  minHeartRate: number;
 
   /**
-   * @generated from field: google.protobuf.StringValue ride_type = 3;
+   * Unset for the top zone
+   *
+   * @generated from field: google.protobuf.Int32Value max_heart_rate = 3;
    */
-  rideType?: string;
+  maxHeartRate?: number;
+
+  /**
+   * @generated from field: int32 seconds = 4;
+   */
+  seconds: number;
 };
 
 /**
- * Describes the message activity.v1.UpdateActivityRequest.
- * Use `create(UpdateActivityRequestSchema)` to create a new message.
+ * Describes the message activity.v1.HeartRateZone.
+ * Use `create(HeartRateZoneSchema)` to create a new message.
  */
-export const UpdateActivityRequestSchema: GenMessage<UpdateActivityRequest> = /*@__PURE__*/
-  messageDesc(file_activity_v1_activity, 11);
+export const HeartRateZoneSchema: GenMessage<HeartRateZone> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 6);
 
 /**
- * @generated from service activity.v1.ActivityService
+ * ActivityPause is a period in which the rider was stopped. The source is
+ * "timer" for pauses of the device timer and "auto" for stops detected from
+ * the recorded speed.
+ *
+ * @generated from message activity.v1.ActivityPause
  */
-export const ActivityService: GenService<{
+export type ActivityPause = Message<"activity.v1.ActivityPause"> & {
   /**
-   * Fetch all activities without records.
-   *
-   * @generated from rpc activity.v1.ActivityService.GetActivities
+   * @generated from field: google.protobuf.Timestamp started_at = 1;
    */
-  getActivities: {
-    methodKind: "unary";
-    input: typeof GetActivitiesRequestSchema;
-    output: typeof GetActivitiesResponseSchema;
-  },
+  startedAt?: Timestamp;
+
   /**
-   * Fetch a single activity by ID with records.
-   *
-   * @generated from rpc activity.v1.ActivityService.GetActivity
+   * @generated from field: google.protobuf.Timestamp ended_at = 2;
    */
-  getActivity: {
-    methodKind: "unary";
-    input: typeof GetActivityRequestSchema;
-    output: typeof GetActivityResponseSchema;
-  },
+  endedAt?: Timestamp;
+
   /**
-   * @generated from rpc activity.v1.ActivityService.UpdateActivity
+   * @generated from field: string elapsed_time = 3;
    */
-  updateActivity: {
+  elapsedTime: string;
+
+  /**
+   * @generated from field: string source = 4;
+   */
+  source: string;
+};
+
+/**
+ * Describes the message activity.v1.ActivityPause.
+ * Use `create(ActivityPauseSchema)` to create a new message.
+ */
+export const ActivityPauseSchema: GenMessage<ActivityPause> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 7);
+
+/**
+ * ActivityDevice is the head unit (creator) or a sensor that recorded an
+ * activity. Names are snake_case FIT names, e.g. garmin, edge530, bike_power;
+ * unknown values are empty.
+ *
+ * @generated from message activity.v1.ActivityDevice
+ */
+export type ActivityDevice = Message<"activity.v1.ActivityDevice"> & {
+  /**
+   * @generated from field: int32 index = 1;
+   */
+  index: number;
+
+  /**
+   * @generated from field: bool creator = 2;
+   */
+  creator: boolean;
+
+  /**
+   * @generated from field: string manufacturer = 3;
+   */
+  manufacturer: string;
+
+  /**
+   * @generated from field: google.protobuf.Int32Value product = 4;
+   */
+  product?: number;
+
+  /**
+   * @generated from field: string product_name = 5;
+   */
+  productName: string;
+
+  /**
+   * @generated from field: google.protobuf.Int64Value serial_number = 6;
+   */
+  serialNumber?: bigint;
+
+  /**
+   * @generated from field: string software_version = 7;
+   */
+  softwareVersion: string;
+
+  /**
+   * @generated from field: google.protobuf.Int32Value hardware_version = 8;
+   */
+  hardwareVersion?: number;
+
+  /**
+   * @generated from field: string device_type = 9;
+   */
+  deviceType: string;
+
+  /**
+   * e.g. antplus, bluetooth_low_energy, local
+   *
+   * @generated from field: string source_type = 10;
+   */
+  sourceType: string;
+
+  /**
+   * @generated from field: google.protobuf.Int32Value ant_device_number = 11;
+   */
+  antDeviceNumber?: number;
+
+  /**
+   * @generated from field: google.protobuf.DoubleValue battery_voltage = 12;
+   */
+  batteryVoltage?: number;
+
+  /**
+   * @generated from field: string battery_status = 13;
+   */
+  batteryStatus: string;
+};
+
+/**
+ * Describes the message activity.v1.ActivityDevice.
+ * Use `create(ActivityDeviceSchema)` to create a new message.
+ */
+export const ActivityDeviceSchema: GenMessage<ActivityDevice> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 8);
+
+/**
+ * ActivitySession is one FIT session of an activity, e.g. a leg of a
+ * multi-sport file.
+ *
+ * @generated from message activity.v1.ActivitySession
+ */
+export type ActivitySession = Message<"activity.v1.ActivitySession"> & {
+  /**
+   * @generated from field: int32 index = 1;
+   */
+  index: number;
+
+  /**
+   * @generated from field: string sport = 2;
+   */
+  sport: string;
+
+  /**
+   * @generated from field: string sub_sport = 3;
+   */
+  subSport: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp start_time = 4;
+   */
+  startTime?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp end_time = 5;
+   */
+  endTime?: Timestamp;
+
+  /**
+   * @generated from field: string elapsed_time = 6;
+   */
+  elapsedTime: string;
+
+  /**
+   * @generated from field: string timer_time = 7;
+   */
+  timerTime: string;
+
+  /**
+   * @generated from field: double distance = 8;
+   */
+  distance: number;
+};
+
+/**
+ * Describes the message activity.v1.ActivitySession.
+ * Use `create(ActivitySessionSchema)` to create a new message.
+ */
+export const ActivitySessionSchema: GenMessage<ActivitySession> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 9);
+
+/**
+ * Lap is a manual or automatic lap recorded by the device. Heart rate and
+ * power are 0 when the device did not record them.
+ *
+ * @generated from message activity.v1.Lap
+ */
+export type Lap = Message<"activity.v1.Lap"> & {
+  /**
+   * @generated from field: int32 index = 1;
+   */
+  index: number;
+
+  /**
+   * e.g. manual, distance, time, session_end
+   *
+   * @generated from field: string trigger = 2;
+   */
+  trigger: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp start_time = 3;
+   */
+  startTime?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp end_time = 4;
+   */
+  endTime?: Timestamp;
+
+  /**
+   * @generated from field: string elapsed_time = 5;
+   */
+  elapsedTime: string;
+
+  /**
+   * @generated from field: string timer_time = 6;
+   */
+  timerTime: string;
+
+  /**
+   * @generated from field: double distance = 7;
+   */
+  distance: number;
+
+  /**
+   * @generated from field: double avg_speed = 8;
+   */
+  avgSpeed: number;
+
+  /**
+   * @generated from field: double max_speed = 9;
+   */
+  maxSpeed: number;
+
+  /**
+   * @generated from field: int32 avg_heart_rate = 10;
+   */
+  avgHeartRate: number;
+
+  /**
+   * @generated from field: int32 max_heart_rate = 11;
+   */
+  maxHeartRate: number;
+
+  /**
+   * @generated from field: int32 avg_power = 12;
+   */
+  avgPower: number;
+
+  /**
+   * @generated from field: int32 max_power = 13;
+   */
+  maxPower: number;
+};
+
+/**
+ * Describes the message activity.v1.Lap.
+ * Use `create(LapSchema)` to create a new message.
+ */
+export const LapSchema: GenMessage<Lap> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 10);
+
+/**
+ * ActivitySummary provides a summarized view of an activity.
+ *
+ * @generated from message activity.v1.ActivitySummary
+ */
+export type ActivitySummary = Message<"activity.v1.ActivitySummary"> & {
+  /**
+   * @generated from field: int32 id = 1;
+   */
+  id: number;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 2;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * @generated from field: double distance = 3;
+   */
+  distance: number;
+
+  /**
+   * @generated from field: string activity_name = 4;
+   */
+  activityName: string;
+
+  /**
+   * @generated from field: double avg_speed = 5;
+   */
+  avgSpeed: number;
+
+  /**
+   * @generated from field: double max_speed = 6;
+   */
+  maxSpeed: number;
+
+  /**
+   * @generated from field: string elapsed_time = 7;
+   */
+  elapsedTime: string;
+
+  /**
+   * @generated from field: string total_time = 8;
+   */
+  totalTime: string;
+
+  /**
+   * @generated from field: bool indoor = 9;
+   */
+  indoor: boolean;
+
+  /**
+   * @generated from field: string moving_time = 10;
+   */
+  movingTime: string;
+
+  /**
+   * @generated from field: google.protobuf.DoubleValue elevation_gain = 11;
+   */
+  elevationGain?: number;
+};
+
+/**
+ * Describes the message activity.v1.ActivitySummary.
+ * Use `create(ActivitySummarySchema)` to create a new message.
+ */
+export const ActivitySummarySchema: GenMessage<ActivitySummary> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 11);
+
+/**
+ * Request message for streaming uploads.
+ *
+ * A stream carries one or more files. Every file starts with a file_header
+ * message followed by the file_chunk messages holding its bytes; the next
+ * file_header (or the end of the stream) completes the current file.
+ *
+ * @generated from message activity.v1.UploadActivitiesRequest
+ */
+export type UploadActivitiesRequest = Message<"activity.v1.UploadActivitiesRequest"> & {
+  /**
+   * @generated from oneof activity.v1.UploadActivitiesRequest.payload
+   */
+  payload: {
+    /**
+     * Chunk of file data
+     *
+     * @generated from field: bytes file_chunk = 1;
+     */
+    value: Uint8Array;
+    case: "fileChunk";
+  } | {
+    /**
+     * Optional metadata (e.g., user ID, filename)
+     *
+     * @generated from field: string metadata = 2;
+     */
+    value: string;
+    case: "metadata";
+  } | {
+    /**
+     * Starts a new file in the stream
+     *
+     * @generated from field: activity.v1.UploadFileHeader file_header = 3;
+     */
+    value: UploadFileHeader;
+    case: "fileHeader";
+  } | { case: undefined; value?: undefined };
+};
+
+/**
+ * Describes the message activity.v1.UploadActivitiesRequest.
+ * Use `create(UploadActivitiesRequestSchema)` to create a new message.
+ */
+export const UploadActivitiesRequestSchema: GenMessage<UploadActivitiesRequest> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 12);
+
+/**
+ * UploadFileHeader describes the file whose chunks follow it in the stream.
+ *
+ * @generated from message activity.v1.UploadFileHeader
+ */
+export type UploadFileHeader = Message<"activity.v1.UploadFileHeader"> & {
+  /**
+   * @generated from field: string filename = 1;
+   */
+  filename: string;
+
+  /**
+   * Size in bytes, used to validate the received data
+   *
+   * @generated from field: int64 size = 2;
+   */
+  size: bigint;
+
+  /**
+   * @generated from field: string content_type = 3;
+   */
+  contentType: string;
+
+  /**
+   * Optional hex encoded SHA-256 checksum of the file
+   *
+   * @generated from field: string sha256 = 4;
+   */
+  sha256: string;
+
+  /**
+   * Unix milliseconds
+   *
+   * @generated from field: int64 last_modified = 5;
+   */
+  lastModified: bigint;
+};
+
+/**
+ * Describes the message activity.v1.UploadFileHeader.
+ * Use `create(UploadFileHeaderSchema)` to create a new message.
+ */
+export const UploadFileHeaderSchema: GenMessage<UploadFileHeader> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 13);
+
+/**
+ * UploadFileResult reports the outcome for a single uploaded file.
+ *
+ * @generated from message activity.v1.UploadFileResult
+ */
+export type UploadFileResult = Message<"activity.v1.UploadFileResult"> & {
+  /**
+   * @generated from field: string filename = 1;
+   */
+  filename: string;
+
+  /**
+   * Set when the activity was created
+   *
+   * @generated from field: int32 activity_id = 2;
+   */
+  activityId: number;
+
+  /**
+   * Set when the file could not be ingested
+   *
+   * @generated from field: string error = 3;
+   */
+  error: string;
+
+  /**
+   * @generated from field: activity.v1.UploadFailureReason failure_reason = 4;
+   */
+  failureReason: UploadFailureReason;
+
+  /**
+   * Duplicate that was not imported, activity_id is the existing activity
+   *
+   * @generated from field: bool skipped = 5;
+   */
+  skipped: boolean;
+};
+
+/**
+ * Describes the message activity.v1.UploadFileResult.
+ * Use `create(UploadFileResultSchema)` to create a new message.
+ */
+export const UploadFileResultSchema: GenMessage<UploadFileResult> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 14);
+
+/**
+ * Response message after upload
+ *
+ * Uploads are ingested asynchronously: every accepted file is queued as a job
+ * whose progress is reported by GetIngestionJobs. Results only hold the files
+ * rejected before they could be queued, e.g. incomplete streamed files.
+ *
+ * @generated from message activity.v1.UploadActivitiesResponse
+ */
+export type UploadActivitiesResponse = Message<"activity.v1.UploadActivitiesResponse"> & {
+  /**
+   * "queued" when files were queued, otherwise "failed"
+   *
+   * @generated from field: string status = 1;
+   */
+  status: string;
+
+  /**
+   * IDs of the activities that were created
+   *
+   * @generated from field: repeated int32 activity_ids = 2;
+   */
+  activityIds: number[];
+
+  /**
+   * Per-file outcome in upload order
+   *
+   * @generated from field: repeated activity.v1.UploadFileResult results = 3;
+   */
+  results: UploadFileResult[];
+
+  /**
+   * Jobs queued for the accepted files
+   *
+   * @generated from field: repeated activity.v1.IngestionJob jobs = 4;
+   */
+  jobs: IngestionJob[];
+};
+
+/**
+ * Describes the message activity.v1.UploadActivitiesResponse.
+ * Use `create(UploadActivitiesResponseSchema)` to create a new message.
+ */
+export const UploadActivitiesResponseSchema: GenMessage<UploadActivitiesResponse> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 15);
+
+/**
+ * IngestionJob tracks an uploaded file through asynchronous ingestion. Once
+ * done or failed it holds the same outcome as an UploadFileResult.
+ *
+ * @generated from message activity.v1.IngestionJob
+ */
+export type IngestionJob = Message<"activity.v1.IngestionJob"> & {
+  /**
+   * @generated from field: int64 id = 1;
+   */
+  id: bigint;
+
+  /**
+   * @generated from field: string filename = 2;
+   */
+  filename: string;
+
+  /**
+   * @generated from field: activity.v1.IngestionJobStatus status = 3;
+   */
+  status: IngestionJobStatus;
+
+  /**
+   * Created activity, or the existing one for skipped duplicates
+   *
+   * @generated from field: int32 activity_id = 4;
+   */
+  activityId: number;
+
+  /**
+   * @generated from field: bool skipped = 5;
+   */
+  skipped: boolean;
+
+  /**
+   * @generated from field: activity.v1.UploadFailureReason failure_reason = 6;
+   */
+  failureReason: UploadFailureReason;
+
+  /**
+   * @generated from field: string error = 7;
+   */
+  error: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 8;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp started_at = 9;
+   */
+  startedAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp finished_at = 10;
+   */
+  finishedAt?: Timestamp;
+};
+
+/**
+ * Describes the message activity.v1.IngestionJob.
+ * Use `create(IngestionJobSchema)` to create a new message.
+ */
+export const IngestionJobSchema: GenMessage<IngestionJob> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 16);
+
+/**
+ * @generated from message activity.v1.GetIngestionJobsRequest
+ */
+export type GetIngestionJobsRequest = Message<"activity.v1.GetIngestionJobsRequest"> & {
+  /**
+   * Empty lists the most recent jobs
+   *
+   * @generated from field: repeated int64 job_ids = 1;
+   */
+  jobIds: bigint[];
+};
+
+/**
+ * Describes the message activity.v1.GetIngestionJobsRequest.
+ * Use `create(GetIngestionJobsRequestSchema)` to create a new message.
+ */
+export const GetIngestionJobsRequestSchema: GenMessage<GetIngestionJobsRequest> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 17);
+
+/**
+ * @generated from message activity.v1.GetIngestionJobsResponse
+ */
+export type GetIngestionJobsResponse = Message<"activity.v1.GetIngestionJobsResponse"> & {
+  /**
+   * @generated from field: repeated activity.v1.IngestionJob jobs = 1;
+   */
+  jobs: IngestionJob[];
+};
+
+/**
+ * Describes the message activity.v1.GetIngestionJobsResponse.
+ * Use `create(GetIngestionJobsResponseSchema)` to create a new message.
+ */
+export const GetIngestionJobsResponseSchema: GenMessage<GetIngestionJobsResponse> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 18);
+
+/**
+ * @generated from message activity.v1.UploadActivitiesUnaryFile
+ */
+export type UploadActivitiesUnaryFile = Message<"activity.v1.UploadActivitiesUnaryFile"> & {
+  /**
+   * @generated from field: bytes data = 1;
+   */
+  data: Uint8Array;
+
+  /**
+   * @generated from field: string filename = 2;
+   */
+  filename: string;
+
+  /**
+   * @generated from field: string content_type = 3;
+   */
+  contentType: string;
+
+  /**
+   * Unix milliseconds
+   *
+   * @generated from field: int64 last_modified = 4;
+   */
+  lastModified: bigint;
+};
+
+/**
+ * Describes the message activity.v1.UploadActivitiesUnaryFile.
+ * Use `create(UploadActivitiesUnaryFileSchema)` to create a new message.
+ */
+export const UploadActivitiesUnaryFileSchema: GenMessage<UploadActivitiesUnaryFile> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 19);
+
+/**
+ * @generated from message activity.v1.UploadActivitiesUnaryRequest
+ */
+export type UploadActivitiesUnaryRequest = Message<"activity.v1.UploadActivitiesUnaryRequest"> & {
+  /**
+   * @generated from field: repeated activity.v1.UploadActivitiesUnaryFile files = 1;
+   */
+  files: UploadActivitiesUnaryFile[];
+};
+
+/**
+ * Describes the message activity.v1.UploadActivitiesUnaryRequest.
+ * Use `create(UploadActivitiesUnaryRequestSchema)` to create a new message.
+ */
+export const UploadActivitiesUnaryRequestSchema: GenMessage<UploadActivitiesUnaryRequest> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 20);
+
+/**
+ * UploadArchiveChunkRequest appends a chunk to an export archive staged for
+ * ImportArchive. Exports are often several GB, far over the message size
+ * limits, so they are sent in chunks of a few MB.
+ *
+ * @generated from message activity.v1.UploadArchiveChunkRequest
+ */
+export type UploadArchiveChunkRequest = Message<"activity.v1.UploadArchiveChunkRequest"> & {
+  /**
+   * Empty on the first chunk, which starts a new upload
+   *
+   * @generated from field: string upload_id = 1;
+   */
+  uploadId: string;
+
+  /**
+   * Position of the chunk in the archive
+   *
+   * @generated from field: int64 offset = 2;
+   */
+  offset: bigint;
+
+  /**
+   * @generated from field: bytes chunk = 3;
+   */
+  chunk: Uint8Array;
+};
+
+/**
+ * Describes the message activity.v1.UploadArchiveChunkRequest.
+ * Use `create(UploadArchiveChunkRequestSchema)` to create a new message.
+ */
+export const UploadArchiveChunkRequestSchema: GenMessage<UploadArchiveChunkRequest> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 21);
+
+/**
+ * @generated from message activity.v1.UploadArchiveChunkResponse
+ */
+export type UploadArchiveChunkResponse = Message<"activity.v1.UploadArchiveChunkResponse"> & {
+  /**
+   * @generated from field: string upload_id = 1;
+   */
+  uploadId: string;
+
+  /**
+   * Bytes staged so far
+   *
+   * @generated from field: int64 size = 2;
+   */
+  size: bigint;
+};
+
+/**
+ * Describes the message activity.v1.UploadArchiveChunkResponse.
+ * Use `create(UploadArchiveChunkResponseSchema)` to create a new message.
+ */
+export const UploadArchiveChunkResponseSchema: GenMessage<UploadArchiveChunkResponse> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 22);
+
+/**
+ * ImportArchiveRequest refers to a Strava or Garmin account export ZIP staged
+ * with UploadArchiveChunk. The staged archive is removed once imported.
+ *
+ * @generated from message activity.v1.ImportArchiveRequest
+ */
+export type ImportArchiveRequest = Message<"activity.v1.ImportArchiveRequest"> & {
+  /**
+   * @generated from field: string filename = 1;
+   */
+  filename: string;
+
+  /**
+   * @generated from field: string upload_id = 3;
+   */
+  uploadId: string;
+};
+
+/**
+ * Describes the message activity.v1.ImportArchiveRequest.
+ * Use `create(ImportArchiveRequestSchema)` to create a new message.
+ */
+export const ImportArchiveRequestSchema: GenMessage<ImportArchiveRequest> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 23);
+
+/**
+ * ImportArchiveProgress is streamed after each activity file of the archive.
+ *
+ * @generated from message activity.v1.ImportArchiveProgress
+ */
+export type ImportArchiveProgress = Message<"activity.v1.ImportArchiveProgress"> & {
+  /**
+   * Files handled so far, including this one
+   *
+   * @generated from field: int32 processed = 1;
+   */
+  processed: number;
+
+  /**
+   * Activity files found in the archive
+   *
+   * @generated from field: int32 total = 2;
+   */
+  total: number;
+
+  /**
+   * @generated from field: activity.v1.UploadFileResult result = 3;
+   */
+  result?: UploadFileResult;
+};
+
+/**
+ * Describes the message activity.v1.ImportArchiveProgress.
+ * Use `create(ImportArchiveProgressSchema)` to create a new message.
+ */
+export const ImportArchiveProgressSchema: GenMessage<ImportArchiveProgress> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 24);
+
+/**
+ * Selects the activities to reprocess from their stored originals. Exactly
+ * one of activity_id, user_id or all must be set.
+ *
+ * @generated from message activity.v1.ReprocessActivitiesRequest
+ */
+export type ReprocessActivitiesRequest = Message<"activity.v1.ReprocessActivitiesRequest"> & {
+  /**
+   * @generated from field: int32 activity_id = 1;
+   */
+  activityId: number;
+
+  /**
+   * @generated from field: string user_id = 2;
+   */
+  userId: string;
+
+  /**
+   * @generated from field: bool all = 3;
+   */
+  all: boolean;
+};
+
+/**
+ * Describes the message activity.v1.ReprocessActivitiesRequest.
+ * Use `create(ReprocessActivitiesRequestSchema)` to create a new message.
+ */
+export const ReprocessActivitiesRequestSchema: GenMessage<ReprocessActivitiesRequest> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 25);
+
+/**
+ * Reported after each reprocessed activity
+ *
+ * @generated from message activity.v1.ReprocessActivitiesProgress
+ */
+export type ReprocessActivitiesProgress = Message<"activity.v1.ReprocessActivitiesProgress"> & {
+  /**
+   * @generated from field: int32 processed = 1;
+   */
+  processed: number;
+
+  /**
+   * @generated from field: int32 total = 2;
+   */
+  total: number;
+
+  /**
+   * @generated from field: activity.v1.UploadFileResult result = 3;
+   */
+  result?: UploadFileResult;
+};
+
+/**
+ * Describes the message activity.v1.ReprocessActivitiesProgress.
+ * Use `create(ReprocessActivitiesProgressSchema)` to create a new message.
+ */
+export const ReprocessActivitiesProgressSchema: GenMessage<ReprocessActivitiesProgress> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 26);
+
+/**
+ * GetActivitiesResponse contains a list of activity summaries.
+ *
+ * @generated from message activity.v1.GetActivitiesResponse
+ */
+export type GetActivitiesResponse = Message<"activity.v1.GetActivitiesResponse"> & {
+  /**
+   * @generated from field: repeated activity.v1.ActivitySummary activities = 1;
+   */
+  activities: ActivitySummary[];
+};
+
+/**
+ * Describes the message activity.v1.GetActivitiesResponse.
+ * Use `create(GetActivitiesResponseSchema)` to create a new message.
+ */
+export const GetActivitiesResponseSchema: GenMessage<GetActivitiesResponse> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 27);
+
+/**
+ * GetActivitiesRequest is an empty request message for fetching all activities.
+ * If you prefer to keep using the standard Empty message, you can alias it, but
+ * defining it explicitly is clearer.
+ *
+ * @generated from message activity.v1.GetActivitiesRequest
+ */
+export type GetActivitiesRequest = Message<"activity.v1.GetActivitiesRequest"> & {
+};
+
+/**
+ * Describes the message activity.v1.GetActivitiesRequest.
+ * Use `create(GetActivitiesRequestSchema)` to create a new message.
+ */
+export const GetActivitiesRequestSchema: GenMessage<GetActivitiesRequest> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 28);
+
+/**
+ * GetActivityRequest specifies the ID of the activity to retrieve and,
+ * optionally, the resolution to return it at.
+ *
+ * @generated from message activity.v1.GetActivityRequest
+ */
+export type GetActivityRequest = Message<"activity.v1.GetActivityRequest"> & {
+  /**
+   * @generated from field: int32 activity_id = 1;
+   */
+  activityId: number;
+
+  /**
+   * Furthest in metres the simplified route may stray from the recorded
+   * positions; 0 leaves route and encoded_route unset.
+   *
+   * @generated from field: double tolerance = 2;
+   */
+  tolerance: number;
+
+  /**
+   * Number of records to average the streams down to; 0 returns every record.
+   *
+   * @generated from field: int32 max_points = 3;
+   */
+  maxPoints: number;
+};
+
+/**
+ * Describes the message activity.v1.GetActivityRequest.
+ * Use `create(GetActivityRequestSchema)` to create a new message.
+ */
+export const GetActivityRequestSchema: GenMessage<GetActivityRequest> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 29);
+
+/**
+ * @generated from message activity.v1.GetActivityLapsRequest
+ */
+export type GetActivityLapsRequest = Message<"activity.v1.GetActivityLapsRequest"> & {
+  /**
+   * @generated from field: int32 activity_id = 1;
+   */
+  activityId: number;
+};
+
+/**
+ * Describes the message activity.v1.GetActivityLapsRequest.
+ * Use `create(GetActivityLapsRequestSchema)` to create a new message.
+ */
+export const GetActivityLapsRequestSchema: GenMessage<GetActivityLapsRequest> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 30);
+
+/**
+ * @generated from message activity.v1.GetActivityLapsResponse
+ */
+export type GetActivityLapsResponse = Message<"activity.v1.GetActivityLapsResponse"> & {
+  /**
+   * @generated from field: repeated activity.v1.Lap laps = 1;
+   */
+  laps: Lap[];
+};
+
+/**
+ * Describes the message activity.v1.GetActivityLapsResponse.
+ * Use `create(GetActivityLapsResponseSchema)` to create a new message.
+ */
+export const GetActivityLapsResponseSchema: GenMessage<GetActivityLapsResponse> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 31);
+
+/**
+ * @generated from message activity.v1.GetOriginalFileRequest
+ */
+export type GetOriginalFileRequest = Message<"activity.v1.GetOriginalFileRequest"> & {
+  /**
+   * @generated from field: int32 activity_id = 1;
+   */
+  activityId: number;
+};
+
+/**
+ * Describes the message activity.v1.GetOriginalFileRequest.
+ * Use `create(GetOriginalFileRequestSchema)` to create a new message.
+ */
+export const GetOriginalFileRequestSchema: GenMessage<GetOriginalFileRequest> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 32);
+
+/**
+ * The file an activity was created from, as it was uploaded
+ *
+ * @generated from message activity.v1.GetOriginalFileResponse
+ */
+export type GetOriginalFileResponse = Message<"activity.v1.GetOriginalFileResponse"> & {
+  /**
+   * @generated from field: string filename = 1;
+   */
+  filename: string;
+
+  /**
+   * @generated from field: string content_type = 2;
+   */
+  contentType: string;
+
+  /**
+   * @generated from field: bytes data = 3;
+   */
+  data: Uint8Array;
+};
+
+/**
+ * Describes the message activity.v1.GetOriginalFileResponse.
+ * Use `create(GetOriginalFileResponseSchema)` to create a new message.
+ */
+export const GetOriginalFileResponseSchema: GenMessage<GetOriginalFileResponse> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 33);
+
+/**
+ * @generated from message activity.v1.UpdateActivityRequest
+ */
+export type UpdateActivityRequest = Message<"activity.v1.UpdateActivityRequest"> & {
+  /**
+   * @generated from field: int32 activity_id = 1;
+   */
+  activityId: number;
+
+  /**
+   * @generated from field: google.protobuf.StringValue activity_name = 2;
+   */
+  activityName?: string;
+
+  /**
+   * @generated from field: google.protobuf.StringValue ride_type = 3;
+   */
+  rideType?: string;
+};
+
+/**
+ * Describes the message activity.v1.UpdateActivityRequest.
+ * Use `create(UpdateActivityRequestSchema)` to create a new message.
+ */
+export const UpdateActivityRequestSchema: GenMessage<UpdateActivityRequest> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 34);
+
+/**
+ * @generated from message activity.v1.DeleteActivityRequest
+ */
+export type DeleteActivityRequest = Message<"activity.v1.DeleteActivityRequest"> & {
+  /**
+   * @generated from field: int32 activity_id = 1;
+   */
+  activityId: number;
+};
+
+/**
+ * Describes the message activity.v1.DeleteActivityRequest.
+ * Use `create(DeleteActivityRequestSchema)` to create a new message.
+ */
+export const DeleteActivityRequestSchema: GenMessage<DeleteActivityRequest> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 35);
+
+/**
+ * @generated from message activity.v1.DeleteActivityResponse
+ */
+export type DeleteActivityResponse = Message<"activity.v1.DeleteActivityResponse"> & {
+};
+
+/**
+ * Describes the message activity.v1.DeleteActivityResponse.
+ * Use `create(DeleteActivityResponseSchema)` to create a new message.
+ */
+export const DeleteActivityResponseSchema: GenMessage<DeleteActivityResponse> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 36);
+
+/**
+ * @generated from message activity.v1.GetUserSettingsRequest
+ */
+export type GetUserSettingsRequest = Message<"activity.v1.GetUserSettingsRequest"> & {
+};
+
+/**
+ * Describes the message activity.v1.GetUserSettingsRequest.
+ * Use `create(GetUserSettingsRequestSchema)` to create a new message.
+ */
+export const GetUserSettingsRequestSchema: GenMessage<GetUserSettingsRequest> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 37);
+
+/**
+ * UserSettings holds the rider's training parameters.
+ *
+ * @generated from message activity.v1.UserSettings
+ */
+export type UserSettings = Message<"activity.v1.UserSettings"> & {
+  /**
+   * Functional threshold power in watts
+   *
+   * @generated from field: google.protobuf.Int32Value ftp = 1;
+   */
+  ftp?: number;
+
+  /**
+   * @generated from field: google.protobuf.Int32Value max_heart_rate = 2;
+   */
+  maxHeartRate?: number;
+
+  /**
+   * Lactate threshold heart rate
+   *
+   * @generated from field: google.protobuf.Int32Value threshold_heart_rate = 3;
+   */
+  thresholdHeartRate?: number;
+
+  /**
+   * How heart rate zones are derived: "max_hr", "lthr" or "custom". Time in
+   * zone is not computed while it is empty.
+   *
+   * @generated from field: string heart_rate_zone_method = 4;
+   */
+  heartRateZoneMethod: string;
+
+  /**
+   * Custom bounds in bpm between consecutive zones, ascending.
+   *
+   * @generated from field: repeated int32 heart_rate_zone_bounds = 5;
+   */
+  heartRateZoneBounds: number[];
+};
+
+/**
+ * Describes the message activity.v1.UserSettings.
+ * Use `create(UserSettingsSchema)` to create a new message.
+ */
+export const UserSettingsSchema: GenMessage<UserSettings> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 38);
+
+/**
+ * @generated from message activity.v1.UpdateUserSettingsRequest
+ */
+export type UpdateUserSettingsRequest = Message<"activity.v1.UpdateUserSettingsRequest"> & {
+  /**
+   * @generated from field: activity.v1.UserSettings settings = 1;
+   */
+  settings?: UserSettings;
+};
+
+/**
+ * Describes the message activity.v1.UpdateUserSettingsRequest.
+ * Use `create(UpdateUserSettingsRequestSchema)` to create a new message.
+ */
+export const UpdateUserSettingsRequestSchema: GenMessage<UpdateUserSettingsRequest> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 39);
+
+/**
+ * GetPowerCurveRequest selects the rides of the envelope curve. An unset from
+ * includes all rides, an unset to ends the range now and an empty ride_type
+ * includes every ride type.
+ *
+ * @generated from message activity.v1.GetPowerCurveRequest
+ */
+export type GetPowerCurveRequest = Message<"activity.v1.GetPowerCurveRequest"> & {
+  /**
+   * @generated from field: google.protobuf.Timestamp from = 1;
+   */
+  from?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp to = 2;
+   */
+  to?: Timestamp;
+
+  /**
+   * @generated from field: string ride_type = 3;
+   */
+  rideType: string;
+};
+
+/**
+ * Describes the message activity.v1.GetPowerCurveRequest.
+ * Use `create(GetPowerCurveRequestSchema)` to create a new message.
+ */
+export const GetPowerCurveRequestSchema: GenMessage<GetPowerCurveRequest> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 40);
+
+/**
+ * GetPowerCurveResponse holds the best power for each duration over the
+ * selected rides, ordered by duration.
+ *
+ * @generated from message activity.v1.GetPowerCurveResponse
+ */
+export type GetPowerCurveResponse = Message<"activity.v1.GetPowerCurveResponse"> & {
+  /**
+   * @generated from field: repeated activity.v1.PowerCurvePoint points = 1;
+   */
+  points: PowerCurvePoint[];
+};
+
+/**
+ * Describes the message activity.v1.GetPowerCurveResponse.
+ * Use `create(GetPowerCurveResponseSchema)` to create a new message.
+ */
+export const GetPowerCurveResponseSchema: GenMessage<GetPowerCurveResponse> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 41);
+
+/**
+ * @generated from message activity.v1.GetPersonalRecordsRequest
+ */
+export type GetPersonalRecordsRequest = Message<"activity.v1.GetPersonalRecordsRequest"> & {
+};
+
+/**
+ * Describes the message activity.v1.GetPersonalRecordsRequest.
+ * Use `create(GetPersonalRecordsRequestSchema)` to create a new message.
+ */
+export const GetPersonalRecordsRequestSchema: GenMessage<GetPersonalRecordsRequest> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 42);
+
+/**
+ * PersonalRecord is the user's fastest effort over a distance.
+ *
+ * @generated from message activity.v1.PersonalRecord
+ */
+export type PersonalRecord = Message<"activity.v1.PersonalRecord"> & {
+  /**
+   * Metres
+   *
+   * @generated from field: int32 distance = 1;
+   */
+  distance: number;
+
+  /**
+   * @generated from field: int32 seconds = 2;
+   */
+  seconds: number;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp started_at = 3;
+   */
+  startedAt?: Timestamp;
+
+  /**
+   * @generated from field: int32 activity_id = 4;
+   */
+  activityId: number;
+
+  /**
+   * @generated from field: string activity_name = 5;
+   */
+  activityName: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp date = 6;
+   */
+  date?: Timestamp;
+};
+
+/**
+ * Describes the message activity.v1.PersonalRecord.
+ * Use `create(PersonalRecordSchema)` to create a new message.
+ */
+export const PersonalRecordSchema: GenMessage<PersonalRecord> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 43);
+
+/**
+ * GetPersonalRecordsResponse holds a record for each distance the user has
+ * covered, ordered by distance.
+ *
+ * @generated from message activity.v1.GetPersonalRecordsResponse
+ */
+export type GetPersonalRecordsResponse = Message<"activity.v1.GetPersonalRecordsResponse"> & {
+  /**
+   * @generated from field: repeated activity.v1.PersonalRecord records = 1;
+   */
+  records: PersonalRecord[];
+};
+
+/**
+ * Describes the message activity.v1.GetPersonalRecordsResponse.
+ * Use `create(GetPersonalRecordsResponseSchema)` to create a new message.
+ */
+export const GetPersonalRecordsResponseSchema: GenMessage<GetPersonalRecordsResponse> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 44);
+
+/**
+ * GetTrainingLoadRequest selects the UTC days of the series. An unset to is
+ * today and an unset from the year up to to.
+ *
+ * @generated from message activity.v1.GetTrainingLoadRequest
+ */
+export type GetTrainingLoadRequest = Message<"activity.v1.GetTrainingLoadRequest"> & {
+  /**
+   * @generated from field: google.protobuf.Timestamp from = 1;
+   */
+  from?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp to = 2;
+   */
+  to?: Timestamp;
+};
+
+/**
+ * Describes the message activity.v1.GetTrainingLoadRequest.
+ * Use `create(GetTrainingLoadRequestSchema)` to create a new message.
+ */
+export const GetTrainingLoadRequestSchema: GenMessage<GetTrainingLoadRequest> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 45);
+
+/**
+ * TrainingLoadDay is the training load of a UTC day. Load is the summed TSS
+ * of the day's rides, or TRIMP for rides without power.
+ *
+ * @generated from message activity.v1.TrainingLoadDay
+ */
+export type TrainingLoadDay = Message<"activity.v1.TrainingLoadDay"> & {
+  /**
+   * @generated from field: google.protobuf.Timestamp date = 1;
+   */
+  date?: Timestamp;
+
+  /**
+   * @generated from field: double load = 2;
+   */
+  load: number;
+
+  /**
+   * Chronic training load (fitness), 42 day average
+   *
+   * @generated from field: double ctl = 3;
+   */
+  ctl: number;
+
+  /**
+   * Acute training load (fatigue), 7 day average
+   *
+   * @generated from field: double atl = 4;
+   */
+  atl: number;
+
+  /**
+   * Training stress balance (form), CTL - ATL of the day before
+   *
+   * @generated from field: double tsb = 5;
+   */
+  tsb: number;
+};
+
+/**
+ * Describes the message activity.v1.TrainingLoadDay.
+ * Use `create(TrainingLoadDaySchema)` to create a new message.
+ */
+export const TrainingLoadDaySchema: GenMessage<TrainingLoadDay> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 46);
+
+/**
+ * GetTrainingLoadResponse holds a day for every day of the range, in order.
+ *
+ * @generated from message activity.v1.GetTrainingLoadResponse
+ */
+export type GetTrainingLoadResponse = Message<"activity.v1.GetTrainingLoadResponse"> & {
+  /**
+   * @generated from field: repeated activity.v1.TrainingLoadDay days = 1;
+   */
+  days: TrainingLoadDay[];
+};
+
+/**
+ * Describes the message activity.v1.GetTrainingLoadResponse.
+ * Use `create(GetTrainingLoadResponseSchema)` to create a new message.
+ */
+export const GetTrainingLoadResponseSchema: GenMessage<GetTrainingLoadResponse> = /*@__PURE__*/
+  messageDesc(file_activity_v1_activity, 47);
+
+/**
+ * UploadFailureReason classifies why a file could not be ingested.
+ *
+ * @generated from enum activity.v1.UploadFailureReason
+ */
+export enum UploadFailureReason {
+  /**
+   * @generated from enum value: UPLOAD_FAILURE_REASON_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: UPLOAD_FAILURE_REASON_UNSUPPORTED_FORMAT = 1;
+   */
+  UNSUPPORTED_FORMAT = 1,
+
+  /**
+   * @generated from enum value: UPLOAD_FAILURE_REASON_CORRUPT_FILE = 2;
+   */
+  CORRUPT_FILE = 2,
+
+  /**
+   * The file holds no data records
+   *
+   * @generated from enum value: UPLOAD_FAILURE_REASON_NO_RECORDS = 3;
+   */
+  NO_RECORDS = 3,
+
+  /**
+   * @generated from enum value: UPLOAD_FAILURE_REASON_DUPLICATE = 4;
+   */
+  DUPLICATE = 4,
+
+  /**
+   * @generated from enum value: UPLOAD_FAILURE_REASON_EMPTY_FILE = 5;
+   */
+  EMPTY_FILE = 5,
+
+  /**
+   * @generated from enum value: UPLOAD_FAILURE_REASON_INTERNAL = 6;
+   */
+  INTERNAL = 6,
+}
+
+/**
+ * Describes the enum activity.v1.UploadFailureReason.
+ */
+export const UploadFailureReasonSchema: GenEnum<UploadFailureReason> = /*@__PURE__*/
+  enumDesc(file_activity_v1_activity, 0);
+
+/**
+ * @generated from enum activity.v1.IngestionJobStatus
+ */
+export enum IngestionJobStatus {
+  /**
+   * @generated from enum value: INGESTION_JOB_STATUS_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: INGESTION_JOB_STATUS_QUEUED = 1;
+   */
+  QUEUED = 1,
+
+  /**
+   * @generated from enum value: INGESTION_JOB_STATUS_RUNNING = 2;
+   */
+  RUNNING = 2,
+
+  /**
+   * @generated from enum value: INGESTION_JOB_STATUS_DONE = 3;
+   */
+  DONE = 3,
+
+  /**
+   * @generated from enum value: INGESTION_JOB_STATUS_FAILED = 4;
+   */
+  FAILED = 4,
+}
+
+/**
+ * Describes the enum activity.v1.IngestionJobStatus.
+ */
+export const IngestionJobStatusSchema: GenEnum<IngestionJobStatus> = /*@__PURE__*/
+  enumDesc(file_activity_v1_activity, 1);
+
+/**
+ * @generated from service activity.v1.ActivityService
+ */
+export const ActivityService: GenService<{
+  /**
+   * Fetch all activities without records.
+   *
+   * @generated from rpc activity.v1.ActivityService.GetActivities
+   */
+  getActivities: {
+    methodKind: "unary";
+    input: typeof GetActivitiesRequestSchema;
+    output: typeof GetActivitiesResponseSchema;
+  },
+  /**
+   * Fetch a single activity by ID with records.
+   *
+   * @generated from rpc activity.v1.ActivityService.GetActivity
+   */
+  getActivity: {
+    methodKind: "unary";
+    input: typeof GetActivityRequestSchema;
+    output: typeof GetActivityResponseSchema;
+  },
+  /**
+   * Fetch the laps of an activity.
+   *
+   * @generated from rpc activity.v1.ActivityService.GetActivityLaps
+   */
+  getActivityLaps: {
+    methodKind: "unary";
+    input: typeof GetActivityLapsRequestSchema;
+    output: typeof GetActivityLapsResponseSchema;
+  },
+  /**
+   * @generated from rpc activity.v1.ActivityService.UpdateActivity
+   */
+  updateActivity: {
     methodKind: "unary";
     input: typeof UpdateActivityRequestSchema;
     output: typeof GetActivityResponseSchema;
   },
+  /**
+   * Delete an activity, refreshing the training load and personal records
+   *
+   * @generated from rpc activity.v1.ActivityService.DeleteActivity
+   */
+  deleteActivity: {
+    methodKind: "unary";
+    input: typeof DeleteActivityRequestSchema;
+    output: typeof DeleteActivityResponseSchema;
+  },
+  /**
+   * Download the original uploaded file of an activity
+   *
+   * @generated from rpc activity.v1.ActivityService.GetOriginalFile
+   */
+  getOriginalFile: {
+    methodKind: "unary";
+    input: typeof GetOriginalFileRequestSchema;
+    output: typeof GetOriginalFileResponseSchema;
+  },
   /**
    * Upload multiple fit files
    *
@@ -466,6 +1987,91 @@ export const ActivityService: GenService<{
     input: typeof UploadActivitiesUnaryRequestSchema;
     output: typeof UploadActivitiesResponseSchema;
   },
+  /**
+   * Stage a chunk of an export archive for ImportArchive
+   *
+   * @generated from rpc activity.v1.ActivityService.UploadArchiveChunk
+   */
+  uploadArchiveChunk: {
+    methodKind: "unary";
+    input: typeof UploadArchiveChunkRequestSchema;
+    output: typeof UploadArchiveChunkResponseSchema;
+  },
+  /**
+   * Import a staged Strava or Garmin export archive, streaming per-file progress
+   *
+   * @generated from rpc activity.v1.ActivityService.ImportArchive
+   */
+  importArchive: {
+    methodKind: "server_streaming";
+    input: typeof ImportArchiveRequestSchema;
+    output: typeof ImportArchiveProgressSchema;
+  },
+  /**
+   * Admin only: derive activity data again from the stored original files
+   *
+   * @generated from rpc activity.v1.ActivityService.ReprocessActivities
+   */
+  reprocessActivities: {
+    methodKind: "server_streaming";
+    input: typeof ReprocessActivitiesRequestSchema;
+    output: typeof ReprocessActivitiesProgressSchema;
+  },
+  /**
+   * @generated from rpc activity.v1.ActivityService.GetIngestionJobs
+   */
+  getIngestionJobs: {
+    methodKind: "unary";
+    input: typeof GetIngestionJobsRequestSchema;
+    output: typeof GetIngestionJobsResponseSchema;
+  },
+  /**
+   * @generated from rpc activity.v1.ActivityService.GetUserSettings
+   */
+  getUserSettings: {
+    methodKind: "unary";
+    input: typeof GetUserSettingsRequestSchema;
+    output: typeof UserSettingsSchema;
+  },
+  /**
+   * @generated from rpc activity.v1.ActivityService.UpdateUserSettings
+   */
+  updateUserSettings: {
+    methodKind: "unary";
+    input: typeof UpdateUserSettingsRequestSchema;
+    output: typeof UserSettingsSchema;
+  },
+  /**
+   * Fetch the user's best power for each duration over a date range
+   *
+   * @generated from rpc activity.v1.ActivityService.GetPowerCurve
+   */
+  getPowerCurve: {
+    methodKind: "unary";
+    input: typeof GetPowerCurveRequestSchema;
+    output: typeof GetPowerCurveResponseSchema;
+  },
+  /**
+   * Fetch the user's fastest effort over each best effort distance
+   *
+   * @generated from rpc activity.v1.ActivityService.GetPersonalRecords
+   */
+  getPersonalRecords: {
+    methodKind: "unary";
+    input: typeof GetPersonalRecordsRequestSchema;
+    output: typeof GetPersonalRecordsResponseSchema;
+  },
+  /**
+   * Fetch the user's daily fitness, fatigue and form for a performance
+   * management chart
+   *
+   * @generated from rpc activity.v1.ActivityService.GetTrainingLoad
+   */
+  getTrainingLoad: {
+    methodKind: "unary";
+    input: typeof GetTrainingLoadRequestSchema;
+    output: typeof GetTrainingLoadResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_activity_v1_activity, 0);
 
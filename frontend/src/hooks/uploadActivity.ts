@@ -5,23 +5,76 @@ import { create } from "@bufbuild/protobuf";
 
 import {
   ActivityService,
+  GetIngestionJobsRequestSchema,
+  ImportArchiveRequestSchema,
+  IngestionJob,
+  IngestionJobStatus,
   UploadActivitiesUnaryFile,
   UploadActivitiesUnaryFileSchema,
   UploadActivitiesUnaryRequestSchema,
+  UploadArchiveChunkRequestSchema,
+  UploadFailureReason,
+  UploadFileResult,
+  UploadFileResultSchema,
 } from "@/gen/activity/v1/activity_pb";
 import { transport } from "@/main";
 
+export type FailedUpload = {
+  filename: string;
+  reason: string;
+};
+
+export type ImportProgress = {
+  processed: number;
+  total: number;
+};
+
 type UploadStatus = {
   isUploading: boolean;
   error: string | null;
   success: boolean;
+  failedFiles: FailedUpload[];
+  importProgress: ImportProgress | null;
 };
 
+const failureReasonLabels: Partial<Record<UploadFailureReason, string>> = {
+  [UploadFailureReason.UNSUPPORTED_FORMAT]: "Unsupported file format",
+  [UploadFailureReason.CORRUPT_FILE]: "File is corrupt",
+  [UploadFailureReason.NO_RECORDS]: "No recorded data",
+  [UploadFailureReason.DUPLICATE]: "Already uploaded",
+  [UploadFailureReason.EMPTY_FILE]: "File is empty",
+};
+
+const isArchive = (file: File) => file.name.toLowerCase().endsWith(".zip");
+
+const jobPollInterval = 1000;
+
+// Export archives are uploaded in chunks well below the message size limits.
+const archiveChunkSize = 8 * 1024 * 1024;
+
+const isFinished = (job: IngestionJob) =>
+  job.status === IngestionJobStatus.DONE ||
+  job.status === IngestionJobStatus.FAILED;
+
+const jobResult = (job: IngestionJob): UploadFileResult =>
+  create(UploadFileResultSchema, {
+    filename: job.filename,
+    activityId: job.activityId,
+    error: job.error,
+    failureReason: job.failureReason,
+    skipped: job.skipped,
+  });
+
+const sleep = (ms: number) =>
+  new Promise((resolve) => setTimeout(resolve, ms));
+
 export const useUploadActivities = () => {
   const [status, setStatus] = useState<UploadStatus>({
     isUploading: false,
     error: null,
     success: false,
+    failedFiles: [],
+    importProgress: null,
   });
 
   const client = useMemo(() => createClient(ActivityService, transport), []);
@@ -33,15 +86,69 @@ export const useUploadActivities = () => {
           isUploading: false,
           error: "No files selected",
           success: false,
+          failedFiles: [],
+          importProgress: null,
         });
         return false;
       }
 
-      setStatus({ isUploading: true, error: null, success: false });
+      setStatus({
+        isUploading: true,
+        error: null,
+        success: false,
+        failedFiles: [],
+        importProgress: null,
+      });
 
       try {
+        const archives = Array.from(files).filter(isArchive);
+        const activityFiles = Array.from(files).filter(
+          (file) => !isArchive(file),
+        );
+        const results: UploadFileResult[] = [];
+
+        // Export archives are staged in chunks, as they are often several GB,
+        // then imported one at a time, streaming per-file progress.
+        for (const archive of archives) {
+          let uploadId = "";
+          for (
+            let offset = 0;
+            offset === 0 || offset < archive.size;
+            offset += archiveChunkSize
+          ) {
+            const chunk = archive.slice(offset, offset + archiveChunkSize);
+            ({ uploadId } = await client.uploadArchiveChunk(
+              create(UploadArchiveChunkRequestSchema, {
+                uploadId,
+                offset: BigInt(offset),
+                chunk: new Uint8Array(await chunk.arrayBuffer()),
+              }),
+            ));
+          }
+
+          const stream = client.importArchive(
+            create(ImportArchiveRequestSchema, {
+              filename: archive.name,
+              uploadId,
+            }),
+          );
+
+          for await (const progress of stream) {
+            if (progress.result) {
+              results.push(progress.result);
+            }
+            setStatus((current) => ({
+              ...current,
+              importProgress: {
+                processed: progress.processed,
+                total: progress.total,
+              },
+            }));
+          }
+        }
+
         const payloads: UploadActivitiesUnaryFile[] = await Promise.all(
-          Array.from(files).map(async (file) => {
+          activityFiles.map(async (file) => {
             const buffer = await file.arrayBuffer();
             return create(UploadActivitiesUnaryFileSchema, {
               data: new Uint8Array(buffer),
@@ -52,16 +159,54 @@ export const useUploadActivities = () => {
           }),
         );
 
-        const response = await client.uploadActivitiesUnary(
-          create(UploadActivitiesUnaryRequestSchema, {
-            files: payloads,
-          }),
-        );
+        if (payloads.length > 0) {
+          const response = await client.uploadActivitiesUnary(
+            create(UploadActivitiesUnaryRequestSchema, {
+              files: payloads,
+            }),
+          );
+
+          console.debug("Upload queued:", response.status);
+          results.push(...response.results);
+
+          // Uploaded files are ingested in the background; poll their jobs
+          // until every one of them is done or failed.
+          const jobIds = response.jobs.map((job) => job.id);
+          let jobs = response.jobs;
+          while (jobIds.length > 0 && !jobs.every(isFinished)) {
+            setStatus((current) => ({
+              ...current,
+              importProgress: {
+                processed: jobs.filter(isFinished).length,
+                total: jobIds.length,
+              },
+            }));
+            await sleep(jobPollInterval);
+            ({ jobs } = await client.getIngestionJobs(
+              create(GetIngestionJobsRequestSchema, { jobIds }),
+            ));
+          }
+          results.push(...jobs.map(jobResult));
+        }
 
-        console.debug("Upload success:", response.status);
+        const failedFiles: FailedUpload[] = results
+          .filter((result) => result.error !== "" || result.skipped)
+          .map((result) => ({
+            filename: result.filename,
+            reason: result.skipped
+              ? "Already uploaded, skipped"
+              : (failureReasonLabels[result.failureReason] ?? result.error),
+          }));
+        const success = results.some((result) => result.error === "");
 
-        setStatus({ isUploading: false, error: null, success: true });
-        return true;
+        setStatus({
+          isUploading: false,
+          error: success ? null : "None of the files could be imported",
+          success,
+          failedFiles,
+          importProgress: null,
+        });
+        return results.every((result) => result.error === "");
       } catch (error) {
         console.error("Upload failed:", error);
         setStatus({
@@ -71,6 +216,8 @@ export const useUploadActivities = () => {
               ? error.message
               : "Failed to upload activities",
           success: false,
+          failedFiles: [],
+          importProgress: null,
         });
         return false;
       }
@@ -0,0 +1,31 @@
+import { useMutation } from "@tanstack/react-query";
+import { createClient } from "@connectrpc/connect";
+
+import { ActivityService } from "@/gen/activity/v1/activity_pb";
+import { transport } from "@/main";
+
+/**
+ * Downloads the file an activity was originally uploaded from.
+ */
+export const useDownloadOriginal = () => {
+  const client = createClient(ActivityService, transport);
+
+  return useMutation<void, Error, number>({
+    mutationKey: ["download", "original"],
+    mutationFn: async (activityId: number) => {
+      const response = await client.getOriginalFile({ activityId });
+
+      const blob = new Blob([response.data], {
+        type: response.contentType || "application/octet-stream",
+      });
+      const url = URL.createObjectURL(blob);
+
+      const link = document.createElement("a");
+      link.href = url;
+      link.download = response.filename || `activity-${activityId}`;
+      link.click();
+
+      URL.revokeObjectURL(url);
+    },
+  });
+};
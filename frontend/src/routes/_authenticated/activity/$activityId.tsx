@@ -1,7 +1,7 @@
 import { Badge } from "@/components/ui/badge";
 import { Button } from "@/components/ui/button";
 import { Input } from "@/components/ui/input";
-import { Pencil2Icon } from "@radix-ui/react-icons";
+import { DownloadIcon, Pencil2Icon } from "@radix-ui/react-icons";
 import { SupabaseClient } from "@supabase/supabase-js";
 import { createFileRoute } from "@tanstack/react-router";
 import clsx from "clsx";
@@ -54,6 +54,8 @@ import {
 import {
   MAX_CADENCE_POINTS,
   MAX_HEART_RATE_POINTS,
+  MAX_METRIC_POINTS,
+  ROUTE_TOLERANCE_METERS,
   UNKNOWN_VALUE,
 } from "@/features/activity/constants";
 import type {
@@ -62,8 +64,13 @@ import type {
   MetricPoint,
   StatItem,
 } from "@/features/activity/types";
-import { createDistanceTicks, formatDistance } from "@/features/activity/utils";
+import {
+  createDistanceTicks,
+  formatDistance,
+  formatSeconds,
+} from "@/features/activity/utils";
 import { useActivityDerivedData } from "@/features/activity/hooks/useActivityDerivedData";
+import { useDownloadOriginal } from "@/hooks/useDownloadOriginal";
 
 const LazyMap = lazy(() => import("../../../components/map/lazyMap"));
 
@@ -72,6 +79,16 @@ const rideTypeOptions = [
   { value: "gravel", label: "Gravel", icon: Mountain },
   { value: "mtb", label: "MTB", icon: TreePine },
   { value: "tt", label: "TT", icon: Wind },
+  { value: "indoor", label: "Indoor", icon: Home },
+];
+
+// The durations, in seconds, of the best powers shown for a ride.
+const POWER_CURVE_MARKS = [
+  { duration: 5, label: "5 s" },
+  { duration: 60, label: "1 min" },
+  { duration: 300, label: "5 min" },
+  { duration: 1200, label: "20 min" },
+  { duration: 3600, label: "60 min" },
 ];
 
 import {
@@ -80,6 +97,7 @@ import {
   Clock,
   Gauge,
   HeartPulse,
+  Home,
   MapPin,
   Mountain,
   Timer,
@@ -116,9 +134,14 @@ function Activity() {
 
   // const { updateActivity } = useActivity();
   const updateActivityMutation = useMutation(updateActivity);
+  const downloadOriginal = useDownloadOriginal();
 
+  // The server simplifies the route and averages the records down, so the
+  // page does not download every sample of long rides.
   const { data: activity } = useQuery(getActivity, {
     activityId: activityId,
+    tolerance: ROUTE_TOLERANCE_METERS,
+    maxPoints: MAX_METRIC_POINTS,
   });
 
   const {
@@ -130,8 +153,9 @@ function Activity() {
     distanceLabel,
     avgSpeedLabel,
     maxSpeedLabel,
-    elapsedTimeLabel,
+    movingTimeLabel,
     totalTimeLabel,
+    elevationGainLabel,
     averageHeartRateValue,
     averageHeartRateLabel,
     maxHeartRateLabel,
@@ -180,8 +204,9 @@ function Activity() {
 
   const rideTypeLabel = useMemo(() => {
     const match = rideTypeOptions.find((option) => option.value === rideType);
-    return match?.label ?? rideType;
-  }, [rideType]);
+    const label = match?.label ?? rideType;
+    return activity?.rideTypeDetected ? `${label} (detected)` : label;
+  }, [rideType, activity?.rideTypeDetected]);
 
   const detailItemsWithRideType = useMemo(
     () => [
@@ -221,6 +246,18 @@ function Activity() {
     }
     return sampleByRecordId.get(activeRecordId) ?? null;
   }, [activeRecordId, sampleByRecordId]);
+  const heartRateZones = activity?.heartRateZones?.zones ?? [];
+  const heartRateZoneSeconds = Math.max(
+    1,
+    heartRateZones.reduce((total, zone) => total + zone.seconds, 0),
+  );
+  const bestEfforts = activity?.bestEfforts ?? [];
+  const bestPowers = POWER_CURVE_MARKS.flatMap(({ duration, label }) => {
+    const point = activity?.powerCurve.find(
+      (candidate) => candidate.duration === duration,
+    );
+    return point ? [{ duration, label, watts: point.watts }] : [];
+  });
   type HeroMetric = StatItem;
   const heroStats: HeroMetric[] = useMemo(
     () => [
@@ -238,11 +275,17 @@ function Activity() {
         icon: Clock,
       },
       {
-        label: "Ride time",
-        value: elapsedTimeLabel,
-        helper: "Moving time while recording",
+        label: "Moving time",
+        value: movingTimeLabel,
+        helper: "Excludes stops and pauses",
         icon: Timer,
       },
+      {
+        label: "Elevation gain",
+        value: elevationGainLabel,
+        helper: "Total climbing",
+        icon: Mountain,
+      },
       {
         label: "Max cadence",
         value: maxCadenceLabel,
@@ -289,10 +332,11 @@ function Activity() {
       averageHeartRateValue,
       avgSpeedLabel,
       distanceLabel,
-      elapsedTimeLabel,
+      elevationGainLabel,
       maxCadenceLabel,
       maxHeartRateLabel,
       maxSpeedLabel,
+      movingTimeLabel,
       totalTimeLabel,
     ]
   );
@@ -409,6 +453,18 @@ function Activity() {
                     >
                       <Pencil2Icon className="h-4 w-4" />
                     </Button>
+                    <Button
+                      type="button"
+                      size="sm"
+                      variant="secondary"
+                      className="bg-white/20 text-white hover:bg-white/30"
+                      disabled={downloadOriginal.isPending}
+                      title="Download the original uploaded file"
+                      onClick={() => downloadOriginal.mutate(Number(activityId))}
+                    >
+                      <DownloadIcon className="mr-2 h-4 w-4" />
+                      Original file
+                    </Button>
                   </div>
                 )}
                 <p className="max-w-2xl text-sm text-slate-200/80 sm:text-base">
@@ -457,7 +513,14 @@ function Activity() {
                       </div>
                       {label === "Distance" && (
                         <div className="mt-1 space-y-2 text-xs text-slate-200/70">
-                          <p className="uppercase tracking-wider">Ride type</p>
+                          <p className="uppercase tracking-wider">
+                            Ride type
+                            {activity?.rideTypeDetected && (
+                              <span className="ml-2 normal-case tracking-normal text-slate-200/50">
+                                detected automatically
+                              </span>
+                            )}
+                          </p>
                           <div className="flex flex-wrap gap-2">
                             {rideTypeOptions.map((option) => {
                               const OptionIcon = option.icon;
@@ -499,6 +562,76 @@ function Activity() {
                 }
               )}
             </div>
+            {heartRateZones.length > 0 && (
+              <div className="space-y-2 rounded-2xl border border-white/10 bg-white/5 px-4 py-3 text-xs text-slate-100">
+                <p className="uppercase tracking-wider text-slate-200/70">
+                  Time in heart rate zones
+                </p>
+                {heartRateZones.map((zone) => (
+                  <div key={zone.zone} className="flex items-center gap-3">
+                    <span className="w-32 shrink-0 text-slate-200/80">
+                      Z{zone.zone}{" "}
+                      {zone.maxHeartRate != null
+                        ? `${zone.minHeartRate}–${zone.maxHeartRate}`
+                        : `${zone.minHeartRate}+`}{" "}
+                      bpm
+                    </span>
+                    <div className="h-2 flex-1 overflow-hidden rounded-full bg-white/10">
+                      <div
+                        className="h-full rounded-full bg-rose-400"
+                        style={{
+                          width: `${(zone.seconds / heartRateZoneSeconds) * 100}%`,
+                        }}
+                      />
+                    </div>
+                    <span className="w-16 shrink-0 text-right font-semibold text-white">
+                      {formatSeconds(zone.seconds)}
+                    </span>
+                  </div>
+                ))}
+              </div>
+            )}
+            {bestEfforts.length > 0 && (
+              <div className="space-y-2 rounded-2xl border border-white/10 bg-white/5 px-4 py-3 text-xs text-slate-100">
+                <p className="uppercase tracking-wider text-slate-200/70">
+                  Best efforts
+                </p>
+                <div className="flex flex-wrap gap-x-6 gap-y-2">
+                  {bestEfforts.map((effort) => (
+                    <div key={effort.distance}>
+                      <span className="text-slate-200/80">
+                        {effort.distance / 1000} km
+                      </span>{" "}
+                      <span className="font-semibold text-white">
+                        {formatSeconds(effort.seconds)}
+                      </span>
+                      {effort.personalRecord && (
+                        <span className="ml-1.5 rounded-full bg-amber-400/90 px-1.5 py-0.5 text-[10px] font-bold text-slate-900">
+                          PR
+                        </span>
+                      )}
+                    </div>
+                  ))}
+                </div>
+              </div>
+            )}
+            {bestPowers.length > 0 && (
+              <div className="space-y-2 rounded-2xl border border-white/10 bg-white/5 px-4 py-3 text-xs text-slate-100">
+                <p className="uppercase tracking-wider text-slate-200/70">
+                  Best power
+                </p>
+                <div className="flex flex-wrap gap-x-6 gap-y-2">
+                  {bestPowers.map((best) => (
+                    <div key={best.duration}>
+                      <span className="text-slate-200/80">{best.label}</span>{" "}
+                      <span className="font-semibold text-white">
+                        {best.watts} W
+                      </span>
+                    </div>
+                  ))}
+                </div>
+              </div>
+            )}
           </div>
         </section>
       </FormProvider>
@@ -536,10 +669,14 @@ function Activity() {
             ) : (
               <div className="flex h-[360px] flex-col items-center justify-center gap-2 rounded-2xl border border-dashed border-muted-foreground/30 bg-muted/40 text-center">
                 <p className="text-sm font-medium text-foreground">
-                  No route coordinates available
+                  {activity?.indoor
+                    ? "Indoor activity"
+                    : "No route coordinates available"}
                 </p>
                 <p className="text-xs text-muted-foreground">
-                  Upload an activity with GPS data to view the map.
+                  {activity?.indoor
+                    ? "This ride was recorded without GPS, so there is no route to show."
+                    : "Upload an activity with GPS data to view the map."}
                 </p>
               </div>
             )}
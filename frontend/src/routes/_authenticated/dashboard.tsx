@@ -45,6 +45,7 @@ import {
 } from "@/components/ui/form"
 import { Dropzone } from "@/components/ui/dropzone"
 import { Progress } from "@/components/ui/progress"
+import { TrainingLoadChart } from "@/components/charts/trainingLoadChart"
 import { getActivities } from "@/gen/activity/v1/activity-ActivityService_connectquery"
 import { useQuery } from "@connectrpc/connect-query"
 import { useUploadActivities } from "@/hooks/uploadActivity"
@@ -117,8 +118,13 @@ function Dashboard() {
     }
   }
 
-  const allowedMimeTypes = ["application/fits"]
-  const allowedExtensions = ["fit", "fits"]
+  const allowedMimeTypes = [
+    "application/fits",
+    "application/gpx+xml",
+    "application/vnd.garmin.tcx+xml",
+    "application/zip",
+  ]
+  const allowedExtensions = ["fit", "fits", "gpx", "tcx", "zip"]
   const allowedExtensionsLabel = allowedExtensions
     .map((extension) => `.${extension}`)
     .join(", ")
@@ -227,7 +233,8 @@ function Dashboard() {
                     Drop FIT files to begin
                   </p>
                   <p className="text-xs text-slate-200/70">
-                    Supports multi-file uploads up to 25MB each.
+                    Supports multi-file uploads up to 25MB each, or a
+                    Strava/Garmin export .zip.
                   </p>
                 </div>
                 <FormField
@@ -261,7 +268,9 @@ function Dashboard() {
                 {status.isUploading && (
                   <div className="flex items-center gap-2 rounded-md border border-sky-300/40 bg-sky-500/20 px-3 py-2 text-xs text-sky-100">
                     <TrendingUp className="h-3.5 w-3.5" />
-                    Uploading activities…
+                    {status.importProgress
+                      ? `Importing… ${status.importProgress.processed} of ${status.importProgress.total} files`
+                      : "Uploading activities…"}
                   </div>
                 )}
                 {status.error && (
@@ -276,6 +285,24 @@ function Dashboard() {
                     Upload complete
                   </div>
                 )}
+                {status.failedFiles.length > 0 && (
+                  <ul className="space-y-1 rounded-md border border-amber-300/40 bg-amber-500/20 px-3 py-2 text-xs text-amber-100">
+                    {status.failedFiles.map((failed) => (
+                      <li
+                        key={failed.filename}
+                        className="flex items-center gap-2"
+                      >
+                        <File className="h-3.5 w-3.5 shrink-0" />
+                        <span className="truncate font-medium">
+                          {failed.filename}
+                        </span>
+                        <span className="text-amber-100/80">
+                          {failed.reason}
+                        </span>
+                      </li>
+                    ))}
+                  </ul>
+                )}
               </form>
             </FormProvider>
           </div>
@@ -360,6 +387,8 @@ function Dashboard() {
         </Card>
       </section>
 
+      <TrainingLoadChart />
+
       <section className="space-y-4">
         <div className="flex flex-col gap-3 md:flex-row md:items-end md:justify-between">
           <div className="space-y-1">